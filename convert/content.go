@@ -0,0 +1,173 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package convert
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/nerdzeu/nerdz-core/db"
+	"github.com/nerdzeu/nerdz-core/db/igor"
+	"github.com/nerdzeu/nerdz-core/proto"
+)
+
+// MessageToProto converts a db.Message (an element of the home) into a *proto.Message
+func MessageToProto(message *db.Message) *proto.Message {
+	if message == nil {
+		return nil
+	}
+
+	if message.Type == new(db.UserPost).NumericType() {
+		return &proto.Message{Post: &proto.Message_UserPost{UserPost: UserPostToProto(message.Post.UserPost())}}
+	}
+	return &proto.Message{Post: &proto.Message_ProjectPost{ProjectPost: ProjectPostToProto(message.Post.ProjectPost())}}
+}
+
+// ContentToProto converts a db.Content into a *proto.Content.
+// Returns an error if the dynamic type of content has no transfer object
+func ContentToProto(content db.Content) (*proto.Content, error) {
+	switch content := content.(type) {
+	case nil:
+		return nil, nil
+	case *db.UserPost:
+		return &proto.Content{Content: &proto.Content_UserPost{UserPost: UserPostToProto(content)}}, nil
+	case *db.ProjectPost:
+		return &proto.Content{Content: &proto.Content_ProjectPost{ProjectPost: ProjectPostToProto(content)}}, nil
+	case *db.UserPostComment:
+		return &proto.Content{Content: &proto.Content_UserPostComment{UserPostComment: UserPostCommentToProto(content)}}, nil
+	case *db.ProjectPostComment:
+		return &proto.Content{Content: &proto.Content_ProjectPostComment{ProjectPostComment: ProjectPostCommentToProto(content)}}, nil
+	case *db.PM:
+		return &proto.Content{Content: &proto.Content_Pm{Pm: PMToProto(content)}}, nil
+	}
+
+	return nil, fmt.Errorf("invalid content type: %s", reflect.TypeOf(content))
+}
+
+// ContentFromProto converts a *proto.Content into a db.Content.
+// Returns an error if content is empty
+func ContentFromProto(content *proto.Content) (db.Content, error) {
+	if content == nil {
+		return nil, nil
+	}
+
+	switch content := content.Content.(type) {
+	case *proto.Content_UserPost:
+		return UserPostFromProto(content.UserPost), nil
+	case *proto.Content_ProjectPost:
+		return ProjectPostFromProto(content.ProjectPost), nil
+	case *proto.Content_UserPostComment:
+		return UserPostCommentFromProto(content.UserPostComment), nil
+	case *proto.Content_ProjectPostComment:
+		return ProjectPostCommentFromProto(content.ProjectPostComment), nil
+	case *proto.Content_Pm:
+		return PMFromProto(content.Pm), nil
+	}
+
+	return nil, fmt.Errorf("empty content")
+}
+
+// tables returns the names of the tables of models
+func tables(models ...igor.DBModel) []string {
+	names := make([]string, len(models))
+	for i, model := range models {
+		names[i] = model.TableName()
+	}
+	return names
+}
+
+// cursors parses the older and newer tokens, that must point to elements of the tables of models
+func cursors(ctx context.Context, older, newer string, models ...igor.DBModel) (*db.Cursor, *db.Cursor, error) {
+	olderCursor, err := db.ParseCursor(ctx, older, tables(models...)...)
+	if err != nil {
+		return nil, nil, err
+	}
+	newerCursor, err := db.ParseCursor(ctx, newer, tables(models...)...)
+	return olderCursor, newerCursor, err
+}
+
+// PostlistOptionsFromProto converts a *proto.PostlistOptions into a db.PostlistOptions,
+// for a list of posts of the types of models.
+// A nil options is mapped to the zero value of db.PostlistOptions.
+// Returns an error if a cursor is invalid or doesn't point to a post of the list
+func PostlistOptionsFromProto(ctx context.Context, options *proto.PostlistOptions, models ...igor.DBModel) (db.PostlistOptions, error) {
+	if options == nil {
+		return db.PostlistOptions{}, nil
+	}
+
+	older, newer, err := cursors(ctx, options.Older, options.Newer, models...)
+	if err != nil {
+		return db.PostlistOptions{}, err
+	}
+
+	return db.PostlistOptions{
+		Following: options.Following,
+		Followers: options.Followers,
+		Language:  LanguageFromProto(options.Language),
+		N:         db.AtMostPosts(uint64(options.N)),
+		Older:     older,
+		Newer:     newer}, nil
+}
+
+// commentModel returns the model of the comments of post
+func commentModel(post db.ExistingPost) igor.DBModel {
+	if _, ok := post.(*db.ProjectPost); ok {
+		return db.ProjectPostComment{}
+	}
+	return db.UserPostComment{}
+}
+
+// CommentlistOptionsFromProto converts a *proto.CommentlistOptions into a db.CommentlistOptions,
+// for a list of comments of post.
+// A nil options is mapped to the zero value of db.CommentlistOptions.
+// Returns an error if a cursor is invalid or doesn't point to a comment of the same type
+func CommentlistOptionsFromProto(ctx context.Context, options *proto.CommentlistOptions, post db.ExistingPost) (db.CommentlistOptions, error) {
+	if options == nil {
+		return db.CommentlistOptions{}, nil
+	}
+
+	older, newer, err := cursors(ctx, options.Older, options.Newer, commentModel(post))
+	if err != nil {
+		return db.CommentlistOptions{}, err
+	}
+
+	return db.CommentlistOptions{
+		N:     db.AtMostComments(uint64(options.N)),
+		Older: older,
+		Newer: newer}, nil
+}
+
+// PmsOptionsFromProto converts a *proto.PmsOptions into a db.PmsOptions.
+// A nil options is mapped to the zero value of db.PmsOptions.
+// Returns an error if a cursor is invalid or doesn't point to a pm
+func PmsOptionsFromProto(ctx context.Context, options *proto.PmsOptions) (db.PmsOptions, error) {
+	if options == nil {
+		return db.PmsOptions{}, nil
+	}
+
+	older, newer, err := cursors(ctx, options.Older, options.Newer, db.PM{})
+	if err != nil {
+		return db.PmsOptions{}, err
+	}
+
+	return db.PmsOptions{
+		N:     db.AtMostPms(uint64(options.N)),
+		Older: older,
+		Newer: newer}, nil
+}
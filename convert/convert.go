@@ -0,0 +1,118 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package convert converts the types of the db package into their Protocol Buffers
+// transfer objects, and vice versa.
+//
+// Every XToProto function accepts a nil pointer and returns nil in that case.
+// The same holds for every XFromProto function.
+package convert
+
+import (
+	"net/mail"
+	"net/url"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/nerdzeu/nerdz-core/proto"
+)
+
+var (
+	languageToProto = map[string]proto.Language{
+		"en": proto.Language_ENGLISH,
+		"it": proto.Language_ITALIAN,
+		"hr": proto.Language_CROATIAN,
+		"de": proto.Language_GERMAN,
+		"pt": proto.Language_PORTUGUESE,
+		"ro": proto.Language_ROMANIAN,
+	}
+
+	languageFromProto = map[proto.Language]string{
+		proto.Language_ENGLISH:    "en",
+		proto.Language_ITALIAN:    "it",
+		proto.Language_CROATIAN:   "hr",
+		proto.Language_GERMAN:     "de",
+		proto.Language_PORTUGUESE: "pt",
+		proto.Language_ROMANIAN:   "ro",
+	}
+)
+
+// LanguageToProto returns the proto.Language of the 2 characters language identifier lang.
+// Unknown languages are mapped to proto.Language_INVALID
+func LanguageToProto(lang string) proto.Language {
+	return languageToProto[lang]
+}
+
+// LanguageFromProto returns the 2 characters identifier of lang.
+// proto.Language_INVALID is mapped to the empty string
+func LanguageFromProto(lang proto.Language) string {
+	return languageFromProto[lang]
+}
+
+// timeToProto converts t into a Timestamp. The zero time is mapped to nil
+func timeToProto(t time.Time) *timestamp.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	// Errors should never occur, since every time stored in the db is in the range of a Timestamp
+	ts, _ := ptypes.TimestampProto(t)
+	return ts
+}
+
+// timeFromProto converts ts into a time.Time. A nil Timestamp is mapped to the zero time
+func timeFromProto(ts *timestamp.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	t, _ := ptypes.Timestamp(ts)
+	return t
+}
+
+// urlToProto returns the string representation of u, or the empty string if u is nil
+func urlToProto(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	return u.String()
+}
+
+// urlFromProto parses s. The empty string, as well as an invalid URL, is mapped to nil
+func urlFromProto(s string) *url.URL {
+	if s == "" {
+		return nil
+	}
+	u, _ := url.Parse(s)
+	return u
+}
+
+// addressToProto returns the string representation of address, or the empty string if address is nil
+func addressToProto(address *mail.Address) string {
+	if address == nil {
+		return ""
+	}
+	return address.String()
+}
+
+// addressFromProto parses s. The empty string, as well as an invalid address, is mapped to nil
+func addressFromProto(s string) *mail.Address {
+	if s == "" {
+		return nil
+	}
+	address, _ := mail.ParseAddress(s)
+	return address
+}
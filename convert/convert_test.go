@@ -0,0 +1,449 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package convert_test
+
+import (
+	"database/sql"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	proto1 "github.com/golang/protobuf/proto"
+	"github.com/nerdzeu/nerdz-core/convert"
+	"github.com/nerdzeu/nerdz-core/db"
+	"github.com/nerdzeu/nerdz-core/db/igor"
+	"github.com/nerdzeu/nerdz-core/proto"
+)
+
+var (
+	now      = time.Date(2017, time.March, 16, 18, 4, 28, 0, time.UTC)
+	birthday = time.Date(1993, time.June, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func mustParse(t *testing.T, rawurl string) *url.URL {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		t.Fatalf("unable to parse %s: %s", rawurl, err)
+	}
+	return u
+}
+
+// wire marshals msg and unmarshals the result into out, to be sure the
+// transfer object survives the serialization
+func wire(t *testing.T, msg, out proto1.Message) {
+	data, err := proto1.Marshal(msg)
+	if err != nil {
+		t.Fatalf("Marshal should work, but got: %s", err)
+	}
+	if err = proto1.Unmarshal(data, out); err != nil {
+		t.Fatalf("Unmarshal should work, but got: %s", err)
+	}
+}
+
+func testUser() *db.User {
+	return &db.User{
+		Counter:          1,
+		Last:             now,
+		NotifyStory:      igor.JSON{"last": "post"},
+		Private:          true,
+		Lang:             "it",
+		Username:         "admin",
+		Email:            "admin@admin.net",
+		Name:             "Paolo",
+		Surname:          "Galeone",
+		Gender:           true,
+		BirthDate:        birthday,
+		BoardLang:        "en",
+		Timezone:         "Europe/Rome",
+		Viewonline:       true,
+		RegistrationTime: now,
+		Profile: db.Profile{
+			Counter:        1,
+			Website:        "https://www.nerdz.eu",
+			Quotes:         "quote",
+			Biography:      "bio",
+			Github:         "https://github.com/galeone",
+			Template:       1,
+			MobileTemplate: 2,
+			Dateformat:     "d/m/Y, H:i",
+			Push:           true,
+			Pushregtime:    now,
+			Closed:         true}}
+}
+
+func TestLanguage(t *testing.T) {
+	for lang := range db.Languages {
+		if got := convert.LanguageFromProto(convert.LanguageToProto(lang)); got != lang {
+			t.Errorf("Expected language %s, but got %s", lang, got)
+		}
+	}
+
+	if got := convert.LanguageToProto("xx"); got != proto.Language_INVALID {
+		t.Errorf("Expected INVALID for an unknown language, but got %s", got)
+	}
+}
+
+func TestUser(t *testing.T) {
+	user := testUser()
+	var transferred proto.User
+	wire(t, convert.UserToProto(user), &transferred)
+
+	back, err := convert.UserFromProto(&transferred)
+	if err != nil {
+		t.Fatalf("UserFromProto should work, but got: %s", err)
+	}
+
+	if !reflect.DeepEqual(user, back) {
+		t.Errorf("Round trip failed.\nExpected: %+v\nGot: %+v", user, back)
+	}
+
+	user.Password = "adminadmin"
+	if convert.UserToProto(user).String() != convert.UserToProto(back).String() {
+		t.Error("The password should never be transferred")
+	}
+
+	if _, err = convert.UserFromProto(&proto.User{NotifyStory: "{"}); err == nil {
+		t.Error("An invalid notify story should be rejected")
+	}
+}
+
+func TestInfos(t *testing.T) {
+	yahoo, _ := mail.ParseAddress("Paolo Galeone <paolo@yahoo.com>")
+
+	personal := &db.PersonalInfo{
+		IsOnline:  true,
+		Nation:    "it",
+		Timezone:  "Europe/Rome",
+		Username:  "admin",
+		Name:      "Paolo",
+		Surname:   "Galeone",
+		Gender:    true,
+		Birthday:  birthday,
+		Gravatar:  mustParse(t, "https://www.gravatar.com/avatar/1"),
+		Interests: []string{"go", "postgres"},
+		Quotes:    []string{"a", "b"},
+		Biography: "bio"}
+
+	var transferredPersonal proto.PersonalInfo
+	wire(t, convert.PersonalInfoToProto(personal), &transferredPersonal)
+	if back := convert.PersonalInfoFromProto(&transferredPersonal); !reflect.DeepEqual(personal, back) {
+		t.Errorf("Round trip failed.\nExpected: %+v\nGot: %+v", personal, back)
+	}
+
+	contact := &db.ContactInfo{
+		Website: mustParse(t, "https://www.nerdz.eu"),
+		GitHub:  mustParse(t, "https://github.com/galeone"),
+		Skype:   "skype",
+		Jabber:  "jabber",
+		Yahoo:   yahoo,
+		Twitter: mustParse(t, "https://twitter.com/paolo_galeone"),
+		Steam:   "steam"}
+
+	var transferredContact proto.ContactInfo
+	wire(t, convert.ContactInfoToProto(contact), &transferredContact)
+	if back := convert.ContactInfoFromProto(&transferredContact); !reflect.DeepEqual(contact, back) {
+		t.Errorf("Round trip failed.\nExpected: %+v\nGot: %+v", contact, back)
+	}
+
+	board := &db.BoardInfo{
+		Language:   "en",
+		IsClosed:   true,
+		Private:    true,
+		Whitelist:  []*db.User{testUser()},
+		UserScript: mustParse(t, "https://www.nerdz.eu/script.js")}
+
+	var transferredBoard proto.BoardInfo
+	wire(t, convert.BoardInfoToProto(board), &transferredBoard)
+	backBoard, err := convert.BoardInfoFromProto(&transferredBoard)
+	if err != nil {
+		t.Fatalf("BoardInfoFromProto should work, but got: %s", err)
+	}
+	if !reflect.DeepEqual(board, backBoard) {
+		t.Errorf("Round trip failed.\nExpected: %+v\nGot: %+v", board, backBoard)
+	}
+
+	info := &db.Info{
+		ID: 3,
+		Owner: &db.Info{
+			ID:       1,
+			Name:     "Paolo",
+			Username: "admin",
+			Image:    mustParse(t, "https://www.gravatar.com/avatar/1"),
+			Type:     db.UserBoardID},
+		Name:    "project",
+		Website: mustParse(t, "https://www.nerdz.eu"),
+		Closed:  true,
+		Type:    db.ProjectBoardID}
+
+	var transferredInfo proto.Info
+	wire(t, convert.InfoToProto(info), &transferredInfo)
+	if back := convert.InfoFromProto(&transferredInfo); !reflect.DeepEqual(info, back) {
+		t.Errorf("Round trip failed.\nExpected: %+v\nGot: %+v", info, back)
+	}
+}
+
+func TestProject(t *testing.T) {
+	project := &db.Project{
+		Counter:      3,
+		Description:  "description",
+		Name:         "project",
+		Private:      true,
+		Photo:        sql.NullString{String: "https://www.nerdz.eu/photo.png", Valid: true},
+		Goal:         "goal",
+		Visible:      true,
+		Open:         true,
+		CreationTime: now}
+
+	var transferred proto.Project
+	wire(t, convert.ProjectToProto(project), &transferred)
+	if back := convert.ProjectFromProto(&transferred); !reflect.DeepEqual(project, back) {
+		t.Errorf("Round trip failed.\nExpected: %+v\nGot: %+v", project, back)
+	}
+
+	info := &db.ProjectInfo{
+		ID:               3,
+		Owner:            testUser(),
+		Members:          []*db.User{testUser()},
+		NumericMembers:   []uint64{1},
+		NumericFollowers: []uint64{1, 2},
+		Description:      "description",
+		Name:             "project",
+		Photo:            mustParse(t, "https://www.nerdz.eu/photo.png"),
+		Goal:             "goal",
+		Visible:          true,
+		Open:             true}
+
+	var transferredInfo proto.ProjectInfo
+	wire(t, convert.ProjectInfoToProto(info), &transferredInfo)
+	back, err := convert.ProjectInfoFromProto(&transferredInfo)
+	if err != nil {
+		t.Fatalf("ProjectInfoFromProto should work, but got: %s", err)
+	}
+	if !reflect.DeepEqual(info, back) {
+		t.Errorf("Round trip failed.\nExpected: %+v\nGot: %+v", info, back)
+	}
+}
+
+func TestPosts(t *testing.T) {
+	post := db.Post{Hpid: 6, From: 1, To: 2, Pid: 3, Message: "hi", Time: now, Lang: "en", News: true, Closed: true}
+
+	userPost := &db.UserPost{Post: post}
+	var transferredUserPost proto.UserPost
+	wire(t, convert.UserPostToProto(userPost), &transferredUserPost)
+	if back := convert.UserPostFromProto(&transferredUserPost); !reflect.DeepEqual(userPost, back) {
+		t.Errorf("Round trip failed.\nExpected: %+v\nGot: %+v", userPost, back)
+	}
+
+	projectPost := &db.ProjectPost{Post: post}
+	var transferredProjectPost proto.ProjectPost
+	wire(t, convert.ProjectPostToProto(projectPost), &transferredProjectPost)
+	if back := convert.ProjectPostFromProto(&transferredProjectPost); !reflect.DeepEqual(projectPost, back) {
+		t.Errorf("Round trip failed.\nExpected: %+v\nGot: %+v", projectPost, back)
+	}
+
+	userComment := &db.UserPostComment{Hcid: 1, Hpid: 6, From: 1, To: 2, Message: "hi", Lang: "it", Time: now, Editable: true}
+	var transferredUserComment proto.UserPostComment
+	wire(t, convert.UserPostCommentToProto(userComment), &transferredUserComment)
+	if back := convert.UserPostCommentFromProto(&transferredUserComment); !reflect.DeepEqual(userComment, back) {
+		t.Errorf("Round trip failed.\nExpected: %+v\nGot: %+v", userComment, back)
+	}
+
+	projectComment := &db.ProjectPostComment{Hcid: 1, Hpid: 3, From: 1, To: 2, Message: "hi", Lang: "de", Time: now}
+	var transferredProjectComment proto.ProjectPostComment
+	wire(t, convert.ProjectPostCommentToProto(projectComment), &transferredProjectComment)
+	if back := convert.ProjectPostCommentFromProto(&transferredProjectComment); !reflect.DeepEqual(projectComment, back) {
+		t.Errorf("Round trip failed.\nExpected: %+v\nGot: %+v", projectComment, back)
+	}
+}
+
+func TestPMs(t *testing.T) {
+	pm := &db.PM{Pmid: 1, From: 1, To: 2, Message: "hi", Lang: "hr", ToRead: true, Time: now}
+	var transferredPM proto.PM
+	wire(t, convert.PMToProto(pm), &transferredPM)
+	if back := convert.PMFromProto(&transferredPM); !reflect.DeepEqual(pm, back) {
+		t.Errorf("Round trip failed.\nExpected: %+v\nGot: %+v", pm, back)
+	}
+
+	conversation := &db.Conversation{From: 1, To: 2, LastMessage: "hi", Time: now, ToRead: true}
+	var transferredConversation proto.Conversation
+	wire(t, convert.ConversationToProto(conversation), &transferredConversation)
+	if back := convert.ConversationFromProto(&transferredConversation); !reflect.DeepEqual(conversation, back) {
+		t.Errorf("Round trip failed.\nExpected: %+v\nGot: %+v", conversation, back)
+	}
+}
+
+func TestContent(t *testing.T) {
+	post := db.Post{Hpid: 6, From: 1, To: 2, Pid: 3, Message: "hi", Time: now, Lang: "en"}
+	contents := []db.Content{
+		&db.UserPost{Post: post},
+		&db.ProjectPost{Post: post},
+		&db.UserPostComment{Hcid: 1, Hpid: 6, From: 1, To: 2, Message: "hi", Lang: "it", Time: now},
+		&db.ProjectPostComment{Hcid: 1, Hpid: 3, From: 1, To: 2, Message: "hi", Lang: "de", Time: now},
+		&db.PM{Pmid: 1, From: 1, To: 2, Message: "hi", Lang: "hr", Time: now},
+	}
+
+	for _, content := range contents {
+		converted, err := convert.ContentToProto(content)
+		if err != nil {
+			t.Fatalf("ContentToProto(%T): %s", content, err)
+		}
+
+		var transferred proto.Content
+		wire(t, converted, &transferred)
+		back, err := convert.ContentFromProto(&transferred)
+		if err != nil {
+			t.Fatalf("ContentFromProto(%T): %s", content, err)
+		}
+		if !reflect.DeepEqual(content, back) {
+			t.Errorf("Round trip failed.\nExpected: %+v\nGot: %+v", content, back)
+		}
+	}
+
+	if _, err := convert.ContentFromProto(&proto.Content{}); err == nil {
+		t.Error("ContentFromProto should fail on empty content")
+	}
+
+	message := &db.Message{Post: post, Type: new(db.UserPost).NumericType()}
+	if convert.MessageToProto(message).GetUserPost() == nil {
+		t.Error("MessageToProto should convert a message of type user post into a UserPost")
+	}
+
+	message.Type = new(db.ProjectPost).NumericType()
+	if convert.MessageToProto(message).GetProjectPost() == nil {
+		t.Error("MessageToProto should convert a message of type project post into a ProjectPost")
+	}
+}
+
+func TestRelations(t *testing.T) {
+	userPostVote := &db.UserPostVote{Counter: 1, Hpid: 6, From: 1, To: 2, Vote: -1, Time: now}
+	var transferredUserPostVote proto.UserPostVote
+	wire(t, convert.UserPostVoteToProto(userPostVote), &transferredUserPostVote)
+	if back := convert.UserPostVoteFromProto(&transferredUserPostVote); !reflect.DeepEqual(userPostVote, back) {
+		t.Errorf("Round trip failed.\nExpected: %+v\nGot: %+v", userPostVote, back)
+	}
+
+	projectPostVote := &db.ProjectPostVote{Counter: 1, Hpid: 3, From: 1, To: 2, Vote: 1, Time: now}
+	var transferredProjectPostVote proto.ProjectPostVote
+	wire(t, convert.ProjectPostVoteToProto(projectPostVote), &transferredProjectPostVote)
+	if back := convert.ProjectPostVoteFromProto(&transferredProjectPostVote); !reflect.DeepEqual(projectPostVote, back) {
+		t.Errorf("Round trip failed.\nExpected: %+v\nGot: %+v", projectPostVote, back)
+	}
+
+	userCommentVote := &db.UserPostCommentVote{Counter: 1, Hcid: 1, From: 1, Vote: 1}
+	var transferredUserCommentVote proto.UserPostCommentVote
+	wire(t, convert.UserPostCommentVoteToProto(userCommentVote), &transferredUserCommentVote)
+	if back := convert.UserPostCommentVoteFromProto(&transferredUserCommentVote); !reflect.DeepEqual(userCommentVote, back) {
+		t.Errorf("Round trip failed.\nExpected: %+v\nGot: %+v", userCommentVote, back)
+	}
+
+	projectCommentVote := &db.ProjectPostCommentVote{Counter: 1, Hcid: 1, From: 1, To: 2, Vote: -1, Time: now}
+	var transferredProjectCommentVote proto.ProjectPostCommentVote
+	wire(t, convert.ProjectPostCommentVoteToProto(projectCommentVote), &transferredProjectCommentVote)
+	if back := convert.ProjectPostCommentVoteFromProto(&transferredProjectCommentVote); !reflect.DeepEqual(projectCommentVote, back) {
+		t.Errorf("Round trip failed.\nExpected: %+v\nGot: %+v", projectCommentVote, back)
+	}
+
+	userBookmark := &db.UserPostBookmark{Counter: 1, Hpid: 6, From: 1, Time: now}
+	var transferredUserBookmark proto.UserPostBookmark
+	wire(t, convert.UserPostBookmarkToProto(userBookmark), &transferredUserBookmark)
+	if back := convert.UserPostBookmarkFromProto(&transferredUserBookmark); !reflect.DeepEqual(userBookmark, back) {
+		t.Errorf("Round trip failed.\nExpected: %+v\nGot: %+v", userBookmark, back)
+	}
+
+	projectBookmark := &db.ProjectPostBookmark{Counter: 1, Hpid: 3, From: 1, Time: now}
+	var transferredProjectBookmark proto.ProjectPostBookmark
+	wire(t, convert.ProjectPostBookmarkToProto(projectBookmark), &transferredProjectBookmark)
+	if back := convert.ProjectPostBookmarkFromProto(&transferredProjectBookmark); !reflect.DeepEqual(projectBookmark, back) {
+		t.Errorf("Round trip failed.\nExpected: %+v\nGot: %+v", projectBookmark, back)
+	}
+
+	userLurk := &db.UserPostLurk{Counter: 1, Hpid: 6, From: 1, To: 2, Time: now}
+	var transferredUserLurk proto.UserPostLurk
+	wire(t, convert.UserPostLurkToProto(userLurk), &transferredUserLurk)
+	if back := convert.UserPostLurkFromProto(&transferredUserLurk); !reflect.DeepEqual(userLurk, back) {
+		t.Errorf("Round trip failed.\nExpected: %+v\nGot: %+v", userLurk, back)
+	}
+
+	projectLurk := &db.ProjectPostLurk{Counter: 1, Hpid: 3, From: 1, To: 2, Time: now}
+	var transferredProjectLurk proto.ProjectPostLurk
+	wire(t, convert.ProjectPostLurkToProto(projectLurk), &transferredProjectLurk)
+	if back := convert.ProjectPostLurkFromProto(&transferredProjectLurk); !reflect.DeepEqual(projectLurk, back) {
+		t.Errorf("Round trip failed.\nExpected: %+v\nGot: %+v", projectLurk, back)
+	}
+
+	userLock := &db.UserPostLock{Counter: 1, User: 1, Hpid: 6, Time: now}
+	var transferredUserLock proto.UserPostLock
+	wire(t, convert.UserPostLockToProto(userLock), &transferredUserLock)
+	if back := convert.UserPostLockFromProto(&transferredUserLock); !reflect.DeepEqual(userLock, back) {
+		t.Errorf("Round trip failed.\nExpected: %+v\nGot: %+v", userLock, back)
+	}
+
+	userUserLock := &db.UserPostUserLock{Counter: 1, From: 1, To: 2, Hpid: 6, Time: now}
+	var transferredUserUserLock proto.UserPostUserLock
+	wire(t, convert.UserPostUserLockToProto(userUserLock), &transferredUserUserLock)
+	if back := convert.UserPostUserLockFromProto(&transferredUserUserLock); !reflect.DeepEqual(userUserLock, back) {
+		t.Errorf("Round trip failed.\nExpected: %+v\nGot: %+v", userUserLock, back)
+	}
+
+	projectLock := &db.ProjectPostLock{Counter: 1, User: 1, Hpid: 3, Time: now}
+	var transferredProjectLock proto.ProjectPostLock
+	wire(t, convert.ProjectPostLockToProto(projectLock), &transferredProjectLock)
+	if back := convert.ProjectPostLockFromProto(&transferredProjectLock); !reflect.DeepEqual(projectLock, back) {
+		t.Errorf("Round trip failed.\nExpected: %+v\nGot: %+v", projectLock, back)
+	}
+
+	projectUserLock := &db.ProjectPostUserLock{Counter: 1, From: 1, To: 2, Hpid: 3, Time: now}
+	var transferredProjectUserLock proto.ProjectPostUserLock
+	wire(t, convert.ProjectPostUserLockToProto(projectUserLock), &transferredProjectUserLock)
+	if back := convert.ProjectPostUserLockFromProto(&transferredProjectUserLock); !reflect.DeepEqual(projectUserLock, back) {
+		t.Errorf("Round trip failed.\nExpected: %+v\nGot: %+v", projectUserLock, back)
+	}
+}
+
+func TestEvent(t *testing.T) {
+	event := convert.EventToProto(&db.Event{Type: db.EventComment, From: 1, Board: 2, Project: true, Hpid: 3, Hcid: 4, Time: time.Now()})
+	if event.Type != proto.EventType_NEW_COMMENT || event.BoardType != proto.BoardType_PROJECT || event.Board != 2 || event.Time == nil {
+		t.Errorf("Unexpected event: %+v", event)
+	}
+	if event.Content == nil || event.Content.Type != proto.ContentType_PROJECT_POST_COMMENT || event.Content.Id != 4 {
+		t.Errorf("The content of the event should be the comment, but got %+v", event.Content)
+	}
+
+	if follow := convert.EventToProto(&db.Event{Type: db.EventFollow, From: 1, To: 2, Board: 2}); follow.Content != nil {
+		t.Errorf("A new follower should have no content, but got %+v", follow.Content)
+	}
+}
+
+func TestNotification(t *testing.T) {
+	notification := convert.NotificationToProto(&db.Notification{Type: db.NotificationMention, From: 1, Board: 2, Project: true, Hpid: 3, Time: time.Now()})
+	if notification.Type != proto.NotificationType_NOTIFICATION_MENTION || notification.BoardType != proto.BoardType_PROJECT || notification.Board != 2 {
+		t.Errorf("Unexpected notification: %+v", notification)
+	}
+	if notification.Post == nil || notification.Post.Type != proto.ContentType_PROJECT_POST || notification.Post.Id != 3 {
+		t.Errorf("The post of the notification should be the project post, but got %+v", notification.Post)
+	}
+}
+
+func TestNil(t *testing.T) {
+	if convert.UserToProto(nil) != nil || convert.InfoFromProto(nil) != nil || convert.PMToProto(nil) != nil || convert.EventToProto(nil) != nil ||
+		convert.NotificationToProto(nil) != nil {
+		t.Error("nil values should be converted to nil")
+	}
+}
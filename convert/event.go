@@ -0,0 +1,55 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package convert
+
+import (
+	"github.com/nerdzeu/nerdz-core/db"
+	"github.com/nerdzeu/nerdz-core/proto"
+)
+
+// EventToProto converts a db.Event into a *proto.Event
+func EventToProto(event *db.Event) *proto.Event {
+	if event == nil {
+		return nil
+	}
+
+	ret := &proto.Event{
+		Type:  proto.EventType(event.Type),
+		From:  event.From,
+		Board: event.Board,
+		Vote:  int32(event.Vote),
+		Time:  timeToProto(event.Time)}
+
+	if event.Project {
+		ret.BoardType = proto.BoardType_PROJECT
+	}
+
+	switch {
+	case event.Pmid != 0:
+		ret.Content = &proto.ContentID{Type: proto.ContentType_PRIVATE_MESSAGE, Id: event.Pmid}
+	case event.Hcid != 0 && event.Project:
+		ret.Content = &proto.ContentID{Type: proto.ContentType_PROJECT_POST_COMMENT, Id: event.Hcid}
+	case event.Hcid != 0:
+		ret.Content = &proto.ContentID{Type: proto.ContentType_USER_POST_COMMENT, Id: event.Hcid}
+	case event.Hpid != 0 && event.Project:
+		ret.Content = &proto.ContentID{Type: proto.ContentType_PROJECT_POST, Id: event.Hpid}
+	case event.Hpid != 0:
+		ret.Content = &proto.ContentID{Type: proto.ContentType_USER_POST, Id: event.Hpid}
+	}
+	return ret
+}
@@ -0,0 +1,63 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package convert
+
+import (
+	"context"
+
+	"github.com/nerdzeu/nerdz-core/db"
+	"github.com/nerdzeu/nerdz-core/proto"
+)
+
+// MentionToProto converts a *db.Mention into a *proto.Mention
+func MentionToProto(mention *db.Mention) *proto.Mention {
+	if mention == nil {
+		return nil
+	}
+
+	ret := &proto.Mention{
+		Id:   mention.ID,
+		From: mention.From,
+		To:   mention.To,
+		Post: &proto.ContentID{Type: proto.ContentType_USER_POST, Id: mention.UHpid},
+		Time: timeToProto(mention.Time)}
+
+	if mention.GHpid != 0 {
+		ret.Post = &proto.ContentID{Type: proto.ContentType_PROJECT_POST, Id: mention.GHpid}
+	}
+	return ret
+}
+
+// MentionsOptionsFromProto converts a *proto.MentionsOptions into a db.MentionsOptions.
+// A nil options is mapped to the zero value of db.MentionsOptions.
+// Returns an error if a cursor is invalid or doesn't point to a mention
+func MentionsOptionsFromProto(ctx context.Context, options *proto.MentionsOptions) (db.MentionsOptions, error) {
+	if options == nil {
+		return db.MentionsOptions{}, nil
+	}
+
+	older, newer, err := cursors(ctx, options.Older, options.Newer, db.Mention{})
+	if err != nil {
+		return db.MentionsOptions{}, err
+	}
+
+	return db.MentionsOptions{
+		N:     db.AtMostMentions(uint64(options.N)),
+		Older: older,
+		Newer: newer}, nil
+}
@@ -0,0 +1,80 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package convert
+
+import (
+	"context"
+
+	"github.com/nerdzeu/nerdz-core/db"
+	"github.com/nerdzeu/nerdz-core/proto"
+)
+
+// NotificationToProto converts a db.Notification into a *proto.Notification
+func NotificationToProto(notification *db.Notification) *proto.Notification {
+	if notification == nil {
+		return nil
+	}
+
+	ret := &proto.Notification{
+		Type:  proto.NotificationType(notification.Type),
+		From:  notification.From,
+		Board: notification.Board,
+		Time:  timeToProto(notification.Time)}
+
+	if notification.Project {
+		ret.BoardType = proto.BoardType_PROJECT
+	}
+
+	if notification.Hpid != 0 {
+		ret.Post = &proto.ContentID{Type: proto.ContentType_USER_POST, Id: notification.Hpid}
+		if notification.Project {
+			ret.Post.Type = proto.ContentType_PROJECT_POST
+		}
+	}
+	return ret
+}
+
+// NotificationTypesFromProto converts a slice of proto.NotificationType into a slice of db.NotificationType
+func NotificationTypesFromProto(types []proto.NotificationType) []db.NotificationType {
+	var ret []db.NotificationType
+	for _, notificationType := range types {
+		ret = append(ret, db.NotificationType(notificationType))
+	}
+	return ret
+}
+
+// NotificationsOptionsFromProto converts a *proto.NotificationsOptions into a db.NotificationsOptions.
+// A nil options is mapped to the zero value of db.NotificationsOptions.
+// Returns an error if a cursor is invalid or doesn't point to a notification
+func NotificationsOptionsFromProto(ctx context.Context, options *proto.NotificationsOptions) (db.NotificationsOptions, error) {
+	if options == nil {
+		return db.NotificationsOptions{}, nil
+	}
+
+	older, newer, err := cursors(ctx, options.Older, options.Newer, db.NotificationModels()...)
+	if err != nil {
+		return db.NotificationsOptions{}, err
+	}
+
+	return db.NotificationsOptions{
+		N:     db.AtMostNotifications(uint64(options.N)),
+		Older: older,
+		Newer: newer,
+		Types: NotificationTypesFromProto(options.Types),
+		Read:  options.Read}, nil
+}
@@ -0,0 +1,60 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package convert
+
+import (
+	"github.com/nerdzeu/nerdz-core/db"
+	"github.com/nerdzeu/nerdz-core/proto"
+)
+
+// OAuth2ClientToProto converts a db.OAuth2Client into a *proto.Application, without its secret
+func OAuth2ClientToProto(client *db.OAuth2Client) *proto.Application {
+	if client == nil {
+		return nil
+	}
+
+	return &proto.Application{
+		Id:          client.ID,
+		Name:        client.Name,
+		RedirectUri: client.RedirectURI,
+		UserId:      client.UserID,
+		Public:      client.Public}
+}
+
+// OAuth2ClientFromProto converts a *proto.Application into a db.OAuth2Client, without its secret
+func OAuth2ClientFromProto(client *proto.Application) *db.OAuth2Client {
+	if client == nil {
+		return nil
+	}
+
+	return &db.OAuth2Client{
+		ID:          client.Id,
+		Name:        client.Name,
+		RedirectURI: client.RedirectUri,
+		UserID:      client.UserId,
+		Public:      client.Public}
+}
+
+// OAuth2ClientsToProto converts a slice of *db.OAuth2Client into a slice of *proto.Application
+func OAuth2ClientsToProto(clients []*db.OAuth2Client) []*proto.Application {
+	var ret []*proto.Application
+	for _, client := range clients {
+		ret = append(ret, OAuth2ClientToProto(client))
+	}
+	return ret
+}
@@ -0,0 +1,83 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package convert
+
+import (
+	"github.com/nerdzeu/nerdz-core/db"
+	"github.com/nerdzeu/nerdz-core/proto"
+)
+
+// PMToProto converts a db.PM into a *proto.PM
+func PMToProto(pm *db.PM) *proto.PM {
+	if pm == nil {
+		return nil
+	}
+
+	return &proto.PM{
+		Pmid:    pm.Pmid,
+		From:    pm.From,
+		To:      pm.To,
+		Message: pm.Message,
+		Lang:    LanguageToProto(pm.Lang),
+		ToRead:  pm.ToRead,
+		Time:    timeToProto(pm.Time)}
+}
+
+// PMFromProto converts a *proto.PM into a db.PM
+func PMFromProto(pm *proto.PM) *db.PM {
+	if pm == nil {
+		return nil
+	}
+
+	return &db.PM{
+		Pmid:    pm.Pmid,
+		From:    pm.From,
+		To:      pm.To,
+		Message: pm.Message,
+		Lang:    LanguageFromProto(pm.Lang),
+		ToRead:  pm.ToRead,
+		Time:    timeFromProto(pm.Time)}
+}
+
+// ConversationToProto converts a db.Conversation into a *proto.Conversation
+func ConversationToProto(conversation *db.Conversation) *proto.Conversation {
+	if conversation == nil {
+		return nil
+	}
+
+	return &proto.Conversation{
+		From:        conversation.From,
+		To:          conversation.To,
+		LastMessage: conversation.LastMessage,
+		Time:        timeToProto(conversation.Time),
+		ToRead:      conversation.ToRead}
+}
+
+// ConversationFromProto converts a *proto.Conversation into a db.Conversation
+func ConversationFromProto(conversation *proto.Conversation) *db.Conversation {
+	if conversation == nil {
+		return nil
+	}
+
+	return &db.Conversation{
+		From:        conversation.From,
+		To:          conversation.To,
+		LastMessage: conversation.LastMessage,
+		Time:        timeFromProto(conversation.Time),
+		ToRead:      conversation.ToRead}
+}
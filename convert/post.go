@@ -0,0 +1,163 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package convert
+
+import (
+	"github.com/nerdzeu/nerdz-core/db"
+	"github.com/nerdzeu/nerdz-core/proto"
+)
+
+// UserPostToProto converts a db.UserPost into a *proto.UserPost
+func UserPostToProto(post *db.UserPost) *proto.UserPost {
+	if post == nil {
+		return nil
+	}
+
+	return &proto.UserPost{
+		Hpid:    post.Hpid,
+		From:    post.From,
+		To:      post.To,
+		Pid:     post.Pid,
+		Message: post.Message,
+		Time:    timeToProto(post.Time),
+		Lang:    LanguageToProto(post.Lang),
+		News:    post.News,
+		Closed:  post.Closed}
+}
+
+// UserPostFromProto converts a *proto.UserPost into a db.UserPost
+func UserPostFromProto(post *proto.UserPost) *db.UserPost {
+	if post == nil {
+		return nil
+	}
+
+	return &db.UserPost{Post: db.Post{
+		Hpid:    post.Hpid,
+		From:    post.From,
+		To:      post.To,
+		Pid:     post.Pid,
+		Message: post.Message,
+		Time:    timeFromProto(post.Time),
+		Lang:    LanguageFromProto(post.Lang),
+		News:    post.News,
+		Closed:  post.Closed}}
+}
+
+// ProjectPostToProto converts a db.ProjectPost into a *proto.ProjectPost
+func ProjectPostToProto(post *db.ProjectPost) *proto.ProjectPost {
+	if post == nil {
+		return nil
+	}
+
+	return &proto.ProjectPost{
+		Hpid:    post.Hpid,
+		From:    post.From,
+		To:      post.To,
+		Pid:     post.Pid,
+		Message: post.Message,
+		Time:    timeToProto(post.Time),
+		Lang:    LanguageToProto(post.Lang),
+		News:    post.News,
+		Closed:  post.Closed}
+}
+
+// ProjectPostFromProto converts a *proto.ProjectPost into a db.ProjectPost
+func ProjectPostFromProto(post *proto.ProjectPost) *db.ProjectPost {
+	if post == nil {
+		return nil
+	}
+
+	return &db.ProjectPost{Post: db.Post{
+		Hpid:    post.Hpid,
+		From:    post.From,
+		To:      post.To,
+		Pid:     post.Pid,
+		Message: post.Message,
+		Time:    timeFromProto(post.Time),
+		Lang:    LanguageFromProto(post.Lang),
+		News:    post.News,
+		Closed:  post.Closed}}
+}
+
+// UserPostCommentToProto converts a db.UserPostComment into a *proto.UserPostComment
+func UserPostCommentToProto(comment *db.UserPostComment) *proto.UserPostComment {
+	if comment == nil {
+		return nil
+	}
+
+	return &proto.UserPostComment{
+		Hcid:     comment.Hcid,
+		Hpid:     comment.Hpid,
+		From:     comment.From,
+		To:       comment.To,
+		Message:  comment.Message,
+		Lang:     LanguageToProto(comment.Lang),
+		Time:     timeToProto(comment.Time),
+		Editable: comment.Editable}
+}
+
+// UserPostCommentFromProto converts a *proto.UserPostComment into a db.UserPostComment
+func UserPostCommentFromProto(comment *proto.UserPostComment) *db.UserPostComment {
+	if comment == nil {
+		return nil
+	}
+
+	return &db.UserPostComment{
+		Hcid:     comment.Hcid,
+		Hpid:     comment.Hpid,
+		From:     comment.From,
+		To:       comment.To,
+		Message:  comment.Message,
+		Lang:     LanguageFromProto(comment.Lang),
+		Time:     timeFromProto(comment.Time),
+		Editable: comment.Editable}
+}
+
+// ProjectPostCommentToProto converts a db.ProjectPostComment into a *proto.ProjectPostComment
+func ProjectPostCommentToProto(comment *db.ProjectPostComment) *proto.ProjectPostComment {
+	if comment == nil {
+		return nil
+	}
+
+	return &proto.ProjectPostComment{
+		Hcid:     comment.Hcid,
+		Hpid:     comment.Hpid,
+		From:     comment.From,
+		To:       comment.To,
+		Message:  comment.Message,
+		Lang:     LanguageToProto(comment.Lang),
+		Time:     timeToProto(comment.Time),
+		Editable: comment.Editable}
+}
+
+// ProjectPostCommentFromProto converts a *proto.ProjectPostComment into a db.ProjectPostComment
+func ProjectPostCommentFromProto(comment *proto.ProjectPostComment) *db.ProjectPostComment {
+	if comment == nil {
+		return nil
+	}
+
+	return &db.ProjectPostComment{
+		Hcid:     comment.Hcid,
+		Hpid:     comment.Hpid,
+		From:     comment.From,
+		To:       comment.To,
+		Message:  comment.Message,
+		Lang:     LanguageFromProto(comment.Lang),
+		Time:     timeFromProto(comment.Time),
+		Editable: comment.Editable}
+}
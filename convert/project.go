@@ -0,0 +1,134 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package convert
+
+import (
+	"database/sql"
+
+	"github.com/nerdzeu/nerdz-core/db"
+	"github.com/nerdzeu/nerdz-core/proto"
+)
+
+// ProjectToProto converts a db.Project into a *proto.Project
+func ProjectToProto(project *db.Project) *proto.Project {
+	if project == nil {
+		return nil
+	}
+
+	return &proto.Project{
+		Counter:      project.Counter,
+		Description:  project.Description,
+		Name:         project.Name,
+		Private:      project.Private,
+		Photo:        project.Photo.String,
+		Website:      project.Website.String,
+		Goal:         project.Goal,
+		Visible:      project.Visible,
+		Open:         project.Open,
+		CreationTime: timeToProto(project.CreationTime)}
+}
+
+// ProjectFromProto converts a *proto.Project into a db.Project.
+// Empty Photo and Website are mapped to NULL values
+func ProjectFromProto(project *proto.Project) *db.Project {
+	if project == nil {
+		return nil
+	}
+
+	return &db.Project{
+		Counter:      project.Counter,
+		Description:  project.Description,
+		Name:         project.Name,
+		Private:      project.Private,
+		Photo:        sql.NullString{String: project.Photo, Valid: project.Photo != ""},
+		Website:      sql.NullString{String: project.Website, Valid: project.Website != ""},
+		Goal:         project.Goal,
+		Visible:      project.Visible,
+		Open:         project.Open,
+		CreationTime: timeFromProto(project.CreationTime)}
+}
+
+// ProjectInfoToProto converts a db.ProjectInfo into a *proto.ProjectInfo
+func ProjectInfoToProto(info *db.ProjectInfo) *proto.ProjectInfo {
+	if info == nil {
+		return nil
+	}
+
+	return &proto.ProjectInfo{
+		Id:               info.ID,
+		Owner:            UserToProto(info.Owner),
+		Members:          UsersToProto(info.Members),
+		NumericMembers:   info.NumericMembers,
+		Followers:        UsersToProto(info.Followers),
+		NumericFollowers: info.NumericFollowers,
+		Description:      info.Description,
+		Name:             info.Name,
+		Photo:            urlToProto(info.Photo),
+		Website:          urlToProto(info.Website),
+		Goal:             info.Goal,
+		Visible:          info.Visible,
+		Private:          info.Private,
+		Open:             info.Open}
+}
+
+// ProjectInfoFromProto converts a *proto.ProjectInfo into a db.ProjectInfo
+func ProjectInfoFromProto(info *proto.ProjectInfo) (*db.ProjectInfo, error) {
+	if info == nil {
+		return nil, nil
+	}
+
+	owner, err := UserFromProto(info.Owner)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := UsersFromProto(info.Members)
+	if err != nil {
+		return nil, err
+	}
+
+	followers, err := UsersFromProto(info.Followers)
+	if err != nil {
+		return nil, err
+	}
+
+	return &db.ProjectInfo{
+		ID:               info.Id,
+		Owner:            owner,
+		Members:          members,
+		NumericMembers:   info.NumericMembers,
+		Followers:        followers,
+		NumericFollowers: info.NumericFollowers,
+		Description:      info.Description,
+		Name:             info.Name,
+		Photo:            urlFromProto(info.Photo),
+		Website:          urlFromProto(info.Website),
+		Goal:             info.Goal,
+		Visible:          info.Visible,
+		Private:          info.Private,
+		Open:             info.Open}, nil
+}
+
+// ProjectsToProto converts a slice of *db.Project into a slice of *proto.Project
+func ProjectsToProto(projects []*db.Project) []*proto.Project {
+	var ret []*proto.Project
+	for _, project := range projects {
+		ret = append(ret, ProjectToProto(project))
+	}
+	return ret
+}
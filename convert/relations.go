@@ -0,0 +1,363 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package convert
+
+import (
+	"github.com/nerdzeu/nerdz-core/db"
+	"github.com/nerdzeu/nerdz-core/proto"
+)
+
+// Votes
+
+// UserPostVoteToProto converts a db.UserPostVote into a *proto.UserPostVote
+func UserPostVoteToProto(vote *db.UserPostVote) *proto.UserPostVote {
+	if vote == nil {
+		return nil
+	}
+
+	return &proto.UserPostVote{
+		Counter: vote.Counter,
+		Hpid:    vote.Hpid,
+		From:    vote.From,
+		To:      vote.To,
+		Vote:    int32(vote.Vote),
+		Time:    timeToProto(vote.Time)}
+}
+
+// UserPostVoteFromProto converts a *proto.UserPostVote into a db.UserPostVote
+func UserPostVoteFromProto(vote *proto.UserPostVote) *db.UserPostVote {
+	if vote == nil {
+		return nil
+	}
+
+	return &db.UserPostVote{
+		Counter: vote.Counter,
+		Hpid:    vote.Hpid,
+		From:    vote.From,
+		To:      vote.To,
+		Vote:    int8(vote.Vote),
+		Time:    timeFromProto(vote.Time)}
+}
+
+// ProjectPostVoteToProto converts a db.ProjectPostVote into a *proto.ProjectPostVote
+func ProjectPostVoteToProto(vote *db.ProjectPostVote) *proto.ProjectPostVote {
+	if vote == nil {
+		return nil
+	}
+
+	return &proto.ProjectPostVote{
+		Counter: vote.Counter,
+		Hpid:    vote.Hpid,
+		From:    vote.From,
+		To:      vote.To,
+		Vote:    int32(vote.Vote),
+		Time:    timeToProto(vote.Time)}
+}
+
+// ProjectPostVoteFromProto converts a *proto.ProjectPostVote into a db.ProjectPostVote
+func ProjectPostVoteFromProto(vote *proto.ProjectPostVote) *db.ProjectPostVote {
+	if vote == nil {
+		return nil
+	}
+
+	return &db.ProjectPostVote{
+		Counter: vote.Counter,
+		Hpid:    vote.Hpid,
+		From:    vote.From,
+		To:      vote.To,
+		Vote:    int8(vote.Vote),
+		Time:    timeFromProto(vote.Time)}
+}
+
+// UserPostCommentVoteToProto converts a db.UserPostCommentVote into a *proto.UserPostCommentVote
+func UserPostCommentVoteToProto(vote *db.UserPostCommentVote) *proto.UserPostCommentVote {
+	if vote == nil {
+		return nil
+	}
+
+	return &proto.UserPostCommentVote{
+		Counter: vote.Counter,
+		Hcid:    vote.Hcid,
+		From:    vote.From,
+		Vote:    int32(vote.Vote)}
+}
+
+// UserPostCommentVoteFromProto converts a *proto.UserPostCommentVote into a db.UserPostCommentVote
+func UserPostCommentVoteFromProto(vote *proto.UserPostCommentVote) *db.UserPostCommentVote {
+	if vote == nil {
+		return nil
+	}
+
+	return &db.UserPostCommentVote{
+		Counter: vote.Counter,
+		Hcid:    vote.Hcid,
+		From:    vote.From,
+		Vote:    int8(vote.Vote)}
+}
+
+// ProjectPostCommentVoteToProto converts a db.ProjectPostCommentVote into a *proto.ProjectPostCommentVote
+func ProjectPostCommentVoteToProto(vote *db.ProjectPostCommentVote) *proto.ProjectPostCommentVote {
+	if vote == nil {
+		return nil
+	}
+
+	return &proto.ProjectPostCommentVote{
+		Counter: vote.Counter,
+		Hcid:    vote.Hcid,
+		From:    vote.From,
+		To:      vote.To,
+		Vote:    int32(vote.Vote),
+		Time:    timeToProto(vote.Time)}
+}
+
+// ProjectPostCommentVoteFromProto converts a *proto.ProjectPostCommentVote into a db.ProjectPostCommentVote
+func ProjectPostCommentVoteFromProto(vote *proto.ProjectPostCommentVote) *db.ProjectPostCommentVote {
+	if vote == nil {
+		return nil
+	}
+
+	return &db.ProjectPostCommentVote{
+		Counter: vote.Counter,
+		Hcid:    vote.Hcid,
+		From:    vote.From,
+		To:      vote.To,
+		Vote:    int8(vote.Vote),
+		Time:    timeFromProto(vote.Time)}
+}
+
+// Bookmarks
+
+// UserPostBookmarkToProto converts a db.UserPostBookmark into a *proto.UserPostBookmark
+func UserPostBookmarkToProto(bookmark *db.UserPostBookmark) *proto.UserPostBookmark {
+	if bookmark == nil {
+		return nil
+	}
+
+	return &proto.UserPostBookmark{
+		Counter: bookmark.Counter,
+		Hpid:    bookmark.Hpid,
+		From:    bookmark.From,
+		Time:    timeToProto(bookmark.Time)}
+}
+
+// UserPostBookmarkFromProto converts a *proto.UserPostBookmark into a db.UserPostBookmark
+func UserPostBookmarkFromProto(bookmark *proto.UserPostBookmark) *db.UserPostBookmark {
+	if bookmark == nil {
+		return nil
+	}
+
+	return &db.UserPostBookmark{
+		Counter: bookmark.Counter,
+		Hpid:    bookmark.Hpid,
+		From:    bookmark.From,
+		Time:    timeFromProto(bookmark.Time)}
+}
+
+// ProjectPostBookmarkToProto converts a db.ProjectPostBookmark into a *proto.ProjectPostBookmark
+func ProjectPostBookmarkToProto(bookmark *db.ProjectPostBookmark) *proto.ProjectPostBookmark {
+	if bookmark == nil {
+		return nil
+	}
+
+	return &proto.ProjectPostBookmark{
+		Counter: bookmark.Counter,
+		Hpid:    bookmark.Hpid,
+		From:    bookmark.From,
+		Time:    timeToProto(bookmark.Time)}
+}
+
+// ProjectPostBookmarkFromProto converts a *proto.ProjectPostBookmark into a db.ProjectPostBookmark
+func ProjectPostBookmarkFromProto(bookmark *proto.ProjectPostBookmark) *db.ProjectPostBookmark {
+	if bookmark == nil {
+		return nil
+	}
+
+	return &db.ProjectPostBookmark{
+		Counter: bookmark.Counter,
+		Hpid:    bookmark.Hpid,
+		From:    bookmark.From,
+		Time:    timeFromProto(bookmark.Time)}
+}
+
+// Lurks
+
+// UserPostLurkToProto converts a db.UserPostLurk into a *proto.UserPostLurk
+func UserPostLurkToProto(lurk *db.UserPostLurk) *proto.UserPostLurk {
+	if lurk == nil {
+		return nil
+	}
+
+	return &proto.UserPostLurk{
+		Counter: lurk.Counter,
+		Hpid:    lurk.Hpid,
+		From:    lurk.From,
+		To:      lurk.To,
+		Time:    timeToProto(lurk.Time)}
+}
+
+// UserPostLurkFromProto converts a *proto.UserPostLurk into a db.UserPostLurk
+func UserPostLurkFromProto(lurk *proto.UserPostLurk) *db.UserPostLurk {
+	if lurk == nil {
+		return nil
+	}
+
+	return &db.UserPostLurk{
+		Counter: lurk.Counter,
+		Hpid:    lurk.Hpid,
+		From:    lurk.From,
+		To:      lurk.To,
+		Time:    timeFromProto(lurk.Time)}
+}
+
+// ProjectPostLurkToProto converts a db.ProjectPostLurk into a *proto.ProjectPostLurk
+func ProjectPostLurkToProto(lurk *db.ProjectPostLurk) *proto.ProjectPostLurk {
+	if lurk == nil {
+		return nil
+	}
+
+	return &proto.ProjectPostLurk{
+		Counter: lurk.Counter,
+		Hpid:    lurk.Hpid,
+		From:    lurk.From,
+		To:      lurk.To,
+		Time:    timeToProto(lurk.Time)}
+}
+
+// ProjectPostLurkFromProto converts a *proto.ProjectPostLurk into a db.ProjectPostLurk
+func ProjectPostLurkFromProto(lurk *proto.ProjectPostLurk) *db.ProjectPostLurk {
+	if lurk == nil {
+		return nil
+	}
+
+	return &db.ProjectPostLurk{
+		Counter: lurk.Counter,
+		Hpid:    lurk.Hpid,
+		From:    lurk.From,
+		To:      lurk.To,
+		Time:    timeFromProto(lurk.Time)}
+}
+
+// Locks
+
+// UserPostLockToProto converts a db.UserPostLock into a *proto.UserPostLock
+func UserPostLockToProto(lock *db.UserPostLock) *proto.UserPostLock {
+	if lock == nil {
+		return nil
+	}
+
+	return &proto.UserPostLock{
+		Counter: lock.Counter,
+		User:    lock.User,
+		Hpid:    lock.Hpid,
+		Time:    timeToProto(lock.Time)}
+}
+
+// UserPostLockFromProto converts a *proto.UserPostLock into a db.UserPostLock
+func UserPostLockFromProto(lock *proto.UserPostLock) *db.UserPostLock {
+	if lock == nil {
+		return nil
+	}
+
+	return &db.UserPostLock{
+		Counter: lock.Counter,
+		User:    lock.User,
+		Hpid:    lock.Hpid,
+		Time:    timeFromProto(lock.Time)}
+}
+
+// UserPostUserLockToProto converts a db.UserPostUserLock into a *proto.UserPostUserLock
+func UserPostUserLockToProto(lock *db.UserPostUserLock) *proto.UserPostUserLock {
+	if lock == nil {
+		return nil
+	}
+
+	return &proto.UserPostUserLock{
+		Counter: lock.Counter,
+		From:    lock.From,
+		To:      lock.To,
+		Hpid:    lock.Hpid,
+		Time:    timeToProto(lock.Time)}
+}
+
+// UserPostUserLockFromProto converts a *proto.UserPostUserLock into a db.UserPostUserLock
+func UserPostUserLockFromProto(lock *proto.UserPostUserLock) *db.UserPostUserLock {
+	if lock == nil {
+		return nil
+	}
+
+	return &db.UserPostUserLock{
+		Counter: lock.Counter,
+		From:    lock.From,
+		To:      lock.To,
+		Hpid:    lock.Hpid,
+		Time:    timeFromProto(lock.Time)}
+}
+
+// ProjectPostLockToProto converts a db.ProjectPostLock into a *proto.ProjectPostLock
+func ProjectPostLockToProto(lock *db.ProjectPostLock) *proto.ProjectPostLock {
+	if lock == nil {
+		return nil
+	}
+
+	return &proto.ProjectPostLock{
+		Counter: lock.Counter,
+		User:    lock.User,
+		Hpid:    lock.Hpid,
+		Time:    timeToProto(lock.Time)}
+}
+
+// ProjectPostLockFromProto converts a *proto.ProjectPostLock into a db.ProjectPostLock
+func ProjectPostLockFromProto(lock *proto.ProjectPostLock) *db.ProjectPostLock {
+	if lock == nil {
+		return nil
+	}
+
+	return &db.ProjectPostLock{
+		Counter: lock.Counter,
+		User:    lock.User,
+		Hpid:    lock.Hpid,
+		Time:    timeFromProto(lock.Time)}
+}
+
+// ProjectPostUserLockToProto converts a db.ProjectPostUserLock into a *proto.ProjectPostUserLock
+func ProjectPostUserLockToProto(lock *db.ProjectPostUserLock) *proto.ProjectPostUserLock {
+	if lock == nil {
+		return nil
+	}
+
+	return &proto.ProjectPostUserLock{
+		Counter: lock.Counter,
+		From:    lock.From,
+		To:      lock.To,
+		Hpid:    lock.Hpid,
+		Time:    timeToProto(lock.Time)}
+}
+
+// ProjectPostUserLockFromProto converts a *proto.ProjectPostUserLock into a db.ProjectPostUserLock
+func ProjectPostUserLockFromProto(lock *proto.ProjectPostUserLock) *db.ProjectPostUserLock {
+	if lock == nil {
+		return nil
+	}
+
+	return &db.ProjectPostUserLock{
+		Counter: lock.Counter,
+		From:    lock.From,
+		To:      lock.To,
+		Hpid:    lock.Hpid,
+		Time:    timeFromProto(lock.Time)}
+}
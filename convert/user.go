@@ -0,0 +1,327 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package convert
+
+import (
+	"encoding/json"
+
+	"github.com/nerdzeu/nerdz-core/db"
+	"github.com/nerdzeu/nerdz-core/db/igor"
+	"github.com/nerdzeu/nerdz-core/proto"
+)
+
+// ProfileToProto converts a db.Profile into a *proto.Profile
+func ProfileToProto(profile *db.Profile) *proto.Profile {
+	if profile == nil {
+		return nil
+	}
+
+	return &proto.Profile{
+		Counter:        profile.Counter,
+		Website:        profile.Website,
+		Quotes:         profile.Quotes,
+		Biography:      profile.Biography,
+		Github:         profile.Github,
+		Skype:          profile.Skype,
+		Jabber:         profile.Jabber,
+		Yahoo:          profile.Yahoo,
+		Userscript:     profile.Userscript,
+		Template:       uint32(profile.Template),
+		MobileTemplate: uint32(profile.MobileTemplate),
+		Dateformat:     profile.Dateformat,
+		Facebook:       profile.Facebook,
+		Twitter:        profile.Twitter,
+		Steam:          profile.Steam,
+		Push:           profile.Push,
+		Pushregtime:    timeToProto(profile.Pushregtime),
+		Closed:         profile.Closed}
+}
+
+// ProfileFromProto converts a *proto.Profile into a db.Profile
+func ProfileFromProto(profile *proto.Profile) *db.Profile {
+	if profile == nil {
+		return nil
+	}
+
+	return &db.Profile{
+		Counter:        profile.Counter,
+		Website:        profile.Website,
+		Quotes:         profile.Quotes,
+		Biography:      profile.Biography,
+		Github:         profile.Github,
+		Skype:          profile.Skype,
+		Jabber:         profile.Jabber,
+		Yahoo:          profile.Yahoo,
+		Userscript:     profile.Userscript,
+		Template:       uint8(profile.Template),
+		MobileTemplate: uint8(profile.MobileTemplate),
+		Dateformat:     profile.Dateformat,
+		Facebook:       profile.Facebook,
+		Twitter:        profile.Twitter,
+		Steam:          profile.Steam,
+		Push:           profile.Push,
+		Pushregtime:    timeFromProto(profile.Pushregtime),
+		Closed:         profile.Closed}
+}
+
+// UserToProto converts a db.User into a *proto.User.
+// Password, RemoteAddr and HTTPUserAgent are not converted
+func UserToProto(user *db.User) *proto.User {
+	if user == nil {
+		return nil
+	}
+
+	var notifyStory string
+	if len(user.NotifyStory) > 0 {
+		// Errors should never occur, since NotifyStory has been unmarshaled from JSON
+		story, _ := json.Marshal(user.NotifyStory)
+		notifyStory = string(story)
+	}
+
+	return &proto.User{
+		Counter:          user.Counter,
+		Last:             timeToProto(user.Last),
+		NotifyStory:      notifyStory,
+		Private:          user.Private,
+		Lang:             LanguageToProto(user.Lang),
+		Username:         user.Username,
+		Email:            user.Email,
+		Name:             user.Name,
+		Surname:          user.Surname,
+		Gender:           user.Gender,
+		BirthDate:        timeToProto(user.BirthDate),
+		BoardLang:        LanguageToProto(user.BoardLang),
+		Timezone:         user.Timezone,
+		Viewonline:       user.Viewonline,
+		RegistrationTime: timeToProto(user.RegistrationTime),
+		Profile:          ProfileToProto(&user.Profile)}
+}
+
+// UserFromProto converts a *proto.User into a db.User.
+// Returns an error if NotifyStory is not valid JSON
+func UserFromProto(user *proto.User) (*db.User, error) {
+	if user == nil {
+		return nil, nil
+	}
+
+	var notifyStory igor.JSON
+	if user.NotifyStory != "" {
+		if err := json.Unmarshal([]byte(user.NotifyStory), &notifyStory); err != nil {
+			return nil, err
+		}
+	}
+
+	ret := &db.User{
+		Counter:          user.Counter,
+		Last:             timeFromProto(user.Last),
+		NotifyStory:      notifyStory,
+		Private:          user.Private,
+		Lang:             LanguageFromProto(user.Lang),
+		Username:         user.Username,
+		Email:            user.Email,
+		Name:             user.Name,
+		Surname:          user.Surname,
+		Gender:           user.Gender,
+		BirthDate:        timeFromProto(user.BirthDate),
+		BoardLang:        LanguageFromProto(user.BoardLang),
+		Timezone:         user.Timezone,
+		Viewonline:       user.Viewonline,
+		RegistrationTime: timeFromProto(user.RegistrationTime)}
+
+	if user.Profile != nil {
+		ret.Profile = *ProfileFromProto(user.Profile)
+	}
+
+	return ret, nil
+}
+
+// UsersToProto converts a slice of *db.User into a slice of *proto.User
+func UsersToProto(users []*db.User) []*proto.User {
+	var ret []*proto.User
+	for _, user := range users {
+		ret = append(ret, UserToProto(user))
+	}
+	return ret
+}
+
+// UsersFromProto converts a slice of *proto.User into a slice of *db.User
+func UsersFromProto(users []*proto.User) ([]*db.User, error) {
+	var ret []*db.User
+	for _, user := range users {
+		converted, err := UserFromProto(user)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, converted)
+	}
+	return ret, nil
+}
+
+// PersonalInfoToProto converts a db.PersonalInfo into a *proto.PersonalInfo
+func PersonalInfoToProto(info *db.PersonalInfo) *proto.PersonalInfo {
+	if info == nil {
+		return nil
+	}
+
+	return &proto.PersonalInfo{
+		IsOnline:  info.IsOnline,
+		Nation:    info.Nation,
+		Timezone:  info.Timezone,
+		Username:  info.Username,
+		Name:      info.Name,
+		Surname:   info.Surname,
+		Gender:    info.Gender,
+		Birthday:  timeToProto(info.Birthday),
+		Gravatar:  urlToProto(info.Gravatar),
+		Interests: info.Interests,
+		Quotes:    info.Quotes,
+		Biography: info.Biography}
+}
+
+// PersonalInfoFromProto converts a *proto.PersonalInfo into a db.PersonalInfo
+func PersonalInfoFromProto(info *proto.PersonalInfo) *db.PersonalInfo {
+	if info == nil {
+		return nil
+	}
+
+	return &db.PersonalInfo{
+		IsOnline:  info.IsOnline,
+		Nation:    info.Nation,
+		Timezone:  info.Timezone,
+		Username:  info.Username,
+		Name:      info.Name,
+		Surname:   info.Surname,
+		Gender:    info.Gender,
+		Birthday:  timeFromProto(info.Birthday),
+		Gravatar:  urlFromProto(info.Gravatar),
+		Interests: info.Interests,
+		Quotes:    info.Quotes,
+		Biography: info.Biography}
+}
+
+// ContactInfoToProto converts a db.ContactInfo into a *proto.ContactInfo
+func ContactInfoToProto(info *db.ContactInfo) *proto.ContactInfo {
+	if info == nil {
+		return nil
+	}
+
+	return &proto.ContactInfo{
+		Website:  urlToProto(info.Website),
+		Github:   urlToProto(info.GitHub),
+		Skype:    info.Skype,
+		Jabber:   info.Jabber,
+		Yahoo:    addressToProto(info.Yahoo),
+		Facebook: urlToProto(info.Facebook),
+		Twitter:  urlToProto(info.Twitter),
+		Steam:    info.Steam}
+}
+
+// ContactInfoFromProto converts a *proto.ContactInfo into a db.ContactInfo
+func ContactInfoFromProto(info *proto.ContactInfo) *db.ContactInfo {
+	if info == nil {
+		return nil
+	}
+
+	return &db.ContactInfo{
+		Website:  urlFromProto(info.Website),
+		GitHub:   urlFromProto(info.Github),
+		Skype:    info.Skype,
+		Jabber:   info.Jabber,
+		Yahoo:    addressFromProto(info.Yahoo),
+		Facebook: urlFromProto(info.Facebook),
+		Twitter:  urlFromProto(info.Twitter),
+		Steam:    info.Steam}
+}
+
+// BoardInfoToProto converts a db.BoardInfo into a *proto.BoardInfo
+func BoardInfoToProto(info *db.BoardInfo) *proto.BoardInfo {
+	if info == nil {
+		return nil
+	}
+
+	return &proto.BoardInfo{
+		Language:   LanguageToProto(info.Language),
+		IsClosed:   info.IsClosed,
+		Private:    info.Private,
+		Whitelist:  UsersToProto(info.Whitelist),
+		UserScript: urlToProto(info.UserScript)}
+}
+
+// BoardInfoFromProto converts a *proto.BoardInfo into a db.BoardInfo
+func BoardInfoFromProto(info *proto.BoardInfo) (*db.BoardInfo, error) {
+	if info == nil {
+		return nil, nil
+	}
+
+	whitelist, err := UsersFromProto(info.Whitelist)
+	if err != nil {
+		return nil, err
+	}
+
+	return &db.BoardInfo{
+		Language:   LanguageFromProto(info.Language),
+		IsClosed:   info.IsClosed,
+		Private:    info.Private,
+		Whitelist:  whitelist,
+		UserScript: urlFromProto(info.UserScript)}, nil
+}
+
+// InfoToProto converts a db.Info into a *proto.Info
+func InfoToProto(info *db.Info) *proto.Info {
+	if info == nil {
+		return nil
+	}
+
+	boardType := proto.BoardType_USER
+	if info.Type == db.ProjectBoardID {
+		boardType = proto.BoardType_PROJECT
+	}
+
+	return &proto.Info{
+		Id:       info.ID,
+		Owner:    InfoToProto(info.Owner),
+		Name:     info.Name,
+		Username: info.Username,
+		Website:  urlToProto(info.Website),
+		Image:    urlToProto(info.Image),
+		Closed:   info.Closed,
+		Type:     boardType}
+}
+
+// InfoFromProto converts a *proto.Info into a db.Info
+func InfoFromProto(info *proto.Info) *db.Info {
+	if info == nil {
+		return nil
+	}
+
+	ret := &db.Info{
+		ID:       info.Id,
+		Owner:    InfoFromProto(info.Owner),
+		Name:     info.Name,
+		Username: info.Username,
+		Website:  urlFromProto(info.Website),
+		Image:    urlFromProto(info.Image),
+		Closed:   info.Closed,
+		Type:     db.UserBoardID}
+
+	if info.Type == proto.BoardType_PROJECT {
+		ret.Type = db.ProjectBoardID
+	}
+
+	return ret
+}
@@ -0,0 +1,110 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net"
+
+	"github.com/lib/pq"
+	"github.com/nerdzeu/nerdz-core/db/health"
+	"github.com/nerdzeu/nerdz-core/db/igor"
+)
+
+// probeKey is the key of the probe flag in a context
+type probeKey struct{}
+
+// probe returns a copy of ctx whose connections bypass the circuit breaker,
+// so that the health checks can tell when the database is back
+func probe(ctx context.Context) context.Context {
+	return context.WithValue(ctx, probeKey{}, true)
+}
+
+// isProbe returns true if ctx is used by a health check
+func isProbe(ctx context.Context) bool {
+	probing, _ := ctx.Value(probeKey{}).(bool)
+	return probing
+}
+
+// breakerConnector opens the connections to host while its breaker is closed,
+// and reports the outcome of every connection to the breaker
+type breakerConnector struct {
+	driver.Connector
+	host    string
+	breaker *health.Breaker
+}
+
+func (c *breakerConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	if isProbe(ctx) {
+		return c.Connector.Connect(ctx)
+	}
+
+	if !c.breaker.Allow() {
+		return nil, NewError(ErrUnavailable, "the database %s is unavailable: %v", c.host, c.breaker.Err())
+	}
+
+	conn, err := c.Connector.Connect(ctx)
+	switch {
+	case err == nil:
+		c.breaker.Success()
+	case ctx.Err() == nil:
+		c.breaker.Failure(err)
+	}
+	return conn, err
+}
+
+// check executes a trivial query on database, bypassing its breaker
+func check(database *igor.Database) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		_, err := database.DB().ExecContext(probe(ctx), "SELECT 1")
+		return err
+	}
+}
+
+// transient returns true if err is caused by a connection that failed, or by a database that is restarting:
+// the idempotent operations that fail with such an error can be retried
+func transient(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	if pqErr.Code.Class() == "08" { // connection exception
+		return true
+	}
+	switch pqErr.Code.Name() {
+	case "admin_shutdown", "crash_shutdown", "cannot_connect_now", "too_many_connections":
+		return true
+	}
+	return false
+}
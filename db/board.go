@@ -17,7 +17,12 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 
 package db
 
-import "github.com/galeone/igor"
+import (
+	"context"
+	"strings"
+
+	"github.com/nerdzeu/nerdz-core/db/igor"
+)
 
 const (
 	// MinPosts represents the minimum posts number that can be required in a postList
@@ -32,41 +37,39 @@ const (
 )
 
 // PostlistOptions is used to specify the options for a list of posts.
-// The 4 fields are documented and can be combined.
+// The fields are documented and can be combined.
 //
 // If Following = Followers = true -> show posts FROM user that I follow that follow me back (friends)
-// If Older != 0 && Newer != 0 -> find posts BETWEEN this 2 posts
+// If Older != nil && Newer != nil -> find posts BETWEEN this 2 cursors
 //
 // For example:
-// - user.UserHome(&PostlistOptions{Followed: true, Language: "en"})
+// - user.UserHome(ctx, &PostlistOptions{Followed: true, Language: "en"})
 // returns at most the last 20 posts from the english speaking users that I follow.
-// - user.UserHome(&PostlistOptions{Followed: true, Following: true, Language: "it", Older: 90, Newer: 50, N: 10})
-// returns at most 10 posts, from user's friends, speaking italian, between the posts with hpid 90 and 50
+// - user.UserHome(ctx, &PostlistOptions{Followed: true, Following: true, Language: "it", Older: older, Newer: newer, N: 10})
+// returns at most 10 posts, from user's friends, speaking italian, between the posts pointed by older and newer
 type PostlistOptions struct {
-	Model      igor.DBModel // igor.DBModel used to apply filter (like language) to avoid conflics while doing joins
-	Following  bool         // true -> show posts only FROM following
-	Followers  bool         // true -> show posts only FROM followers
-	Language   string       // if Language is a valid 2 characters identifier, show posts from users (users selected enabling/disabling following & folowers) speaking that Language
-	N          uint8        // number of posts to return
-	Older      uint64       // if specified, tells to the function using this struct to return N posts OLDER (created before) than the post with the specified "Older" ID
-	OlderModel igor.DBModel // igor.DBModel required when the older identifier is fetched from a view
-	Newer      uint64       // if specified, tells to the function using this struct to return N posts NEWER (created after) the post with the specified "Newer" ID
-	NewerModel igor.DBModel // igor.DBModel required when the newer identifier is fetched from a view
+	Model     igor.DBModel // igor.DBModel used to apply filter (like language) to avoid conflics while doing joins
+	Following bool         // true -> show posts only FROM following
+	Followers bool         // true -> show posts only FROM followers
+	Language  string       // if Language is a valid 2 characters identifier, show posts from users (users selected enabling/disabling following & folowers) speaking that Language
+	N         uint8        // number of posts to return
+	Older     *Cursor      // if specified, tells to the function using this struct to return N posts OLDER (that follow in the list) than the post pointed by the cursor
+	Newer     *Cursor      // if specified, tells to the function using this struct to return the N posts NEWER (that precede in the list) than the post pointed by the cursor, nearest first
 }
 
 // CommentlistOptions is used to specify the options for a list of comments
 type CommentlistOptions struct {
-	N     uint8  // number of comments to return
-	Older uint64 // if specified, tells to the function that is using this struct to return N comments OLDER (created before) than the comment with the spefified "Older" ID
-	Newer uint64 // if specified, tells to the function that is using this struct to return N comments NEWER (created after) than the comment with the spefified "Newer" ID
+	N     uint8   // number of comments to return
+	Older *Cursor // if specified, tells to the function that is using this struct to return N comments OLDER (created before) than the comment pointed by the cursor
+	Newer *Cursor // if specified, tells to the function that is using this struct to return the N comments NEWER (created after) than the comment pointed by the cursor, nearest first
 }
 
 // Board is the interface that wraps the methods common to every board.
 // Every board has its own Informations and Postlist
 type Board interface {
-	Info() *Info
+	Info(context.Context) *Info
 	// The return value type of Postlist must be changed by type assertion.
-	Postlist(PostlistOptions) *[]ExistingPost
+	Postlist(context.Context, PostlistOptions) *[]ExistingPost
 }
 
 // postlistQueryBuilder returns the same pointer passed as first argument, with new specified options setted
@@ -94,42 +97,72 @@ func postlistQueryBuilder(query *igor.Database, options PostlistOptions, user ..
 		query = query.Where(options.Model.TableName()+".lang = ?", options.Language)
 	}
 
-	messageTpl := Message{}.TableName()
-
-	if options.Older != 0 && options.Newer != 0 {
-		if options.Model.TableName() == messageTpl {
-			query = query.Where(
-				`"time" BETWEEN
-				(SELECT "time" FROM `+options.NewerModel.TableName()+` WHERE hpid = ?)
-				AND
-				(SELECT "time" FROM `+options.OlderModel.TableName()+` WHERE hpid = ?)
-				AND hpid NOT IN (?)`, options.Newer, options.Older, []uint64{options.Newer, options.Older})
-		} else {
-			query = query.Where("hpid BETWEEN ? AND ?", options.Newer, options.Older)
-		}
-	} else if options.Older != 0 {
-		if options.Model.TableName() == messageTpl {
-			query = query.Where(
-				`"time" <= (
-					SELECT "time" FROM `+options.OlderModel.TableName()+` WHERE hpid = ?
-				) AND hpid <> ?`, options.Older, options.Older)
-		} else {
-			query = query.Where("hpid < ?", options.Older)
-		}
-	} else if options.Newer != 0 {
-		if options.Model.TableName() == messageTpl {
-			query = query.Where(
-				`"time" >= (
-					SELECT "time" FROM `+options.NewerModel.TableName()+` WHERE hpid = ?
-				) AND hpid <> ?`, options.Newer, options.Newer)
-		} else {
-			query = query.Where("hpid > ?", options.Newer)
-		}
+	// the posts of a mixed list are sorted by time, and then by type because the hpids of different types can collide
+	keyset := []string{"hpid"}
+	if options.Model.TableName() == (Message{}).TableName() {
+		keyset = []string{`"time"`, `"type"`, "hpid"}
+	}
+
+	return keysetQueryBuilder(query, keyset, options.Older, options.Newer)
+}
+
+// keysetQueryBuilder returns the same pointer passed as first argument, sorted in descending order of the columns
+// of keyset, and with the rows between the older and newer cursors (if any).
+// When only newer is specified the query is sorted in ascending order, to select the rows nearest to it:
+// the caller must reverse them (see newerOnly)
+func keysetQueryBuilder(query *igor.Database, keyset []string, older, newer *Cursor) *igor.Database {
+	direction := " DESC"
+	if newerOnly(older, newer) {
+		direction = " ASC"
 	}
+	query = query.Order(strings.Join(keyset, direction+", ") + direction)
 
+	columns := "(" + strings.Join(keyset, ", ") + ")"
+	if older != nil {
+		query = query.Where(columns+" < ("+placeholders(len(keyset))+")", keysetValues(keyset, older)...)
+	}
+	if newer != nil {
+		query = query.Where(columns+" > ("+placeholders(len(keyset))+")", keysetValues(keyset, newer)...)
+	}
 	return query
 }
 
+// keysetValues returns the values of the columns of keyset in the element pointed by cursor
+func keysetValues(keyset []string, cursor *Cursor) []interface{} {
+	if len(keyset) == 1 {
+		return []interface{}{cursor.ID}
+	}
+	return []interface{}{cursor.Time, cursor.Type(), cursor.ID}
+}
+
+// placeholders returns n comma separated placeholders
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+// newerOnly returns true if the list is selected only by a newer cursor,
+// and thus the storage returns its rows in ascending order
+func newerOnly(older, newer *Cursor) bool {
+	return older == nil && newer != nil
+}
+
+// homeConditions selects the rows of the messages view that a user, the only parameter, can see in the home:
+// the posts not sent by nor on the board of the users in blist (the blacklist of the user, a CTE of the query),
+// and the ones on the visible projects and on the projects the user is a member or the owner of
+const homeConditions = `messages."from" NOT IN (SELECT * FROM blist) AND
+	CASE messages.type
+	WHEN 1 THEN messages."to" NOT IN (SELECT * FROM blist)
+	ELSE ( -- groups conditions
+		TRUE IN (SELECT visible FROM groups g WHERE g.counter = messages."to")
+		OR
+		(? IN (
+			SELECT "from" FROM groups_members gm WHERE gm."to" = messages."to"
+			UNION ALL
+			SELECT "from" FROM groups_owners go WHERE go."to" = messages."to")
+		)
+	)
+	END`
+
 // projectPostlistConditions returns the same pointer passed as first argumet with the project conditions setted
 func projectPostlistConditions(query *igor.Database, user *User) *igor.Database {
 	var projectPost ProjectPost
@@ -147,18 +180,8 @@ func projectPostlistConditions(query *igor.Database, user *User) *igor.Database
 	return query.Where("( visible IS TRUE OR "+owners+`.from = ? OR ( ? IN (SELECT "from" FROM `+members+` WHERE "to" = `+projectPosts+`.to) ) )`, user.Counter, user.Counter)
 }
 
-// postlistQueryBuilder returns the same pointer passed as first argument, with new specified options setted
+// commentlistQueryBuilder returns the same pointer passed as first argument, with new specified options setted
 func commentlistQueryBuilder(query *igor.Database, options CommentlistOptions) *igor.Database {
-	query = query.Limit(int(AtMostComments(uint64(options.N)))).Order("hcid DESC")
-
-	if options.Older != 0 && options.Newer != 0 {
-		query = query.Where("hcid BETWEEN ? AND ?", options.Newer, options.Older)
-	} else if options.Older != 0 {
-		query = query.Where("hcid < ?", options.Older)
-	} else if options.Newer != 0 {
-		query = query.Where("hcid > ?", options.Newer)
-	}
-
-	return query
-
+	query = query.Limit(int(AtMostComments(uint64(options.N))))
+	return keysetQueryBuilder(query, []string{"hcid"}, options.Older, options.Newer)
 }
@@ -16,3 +16,220 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 */
 
 package db
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/nerdzeu/nerdz-core/db/health"
+	"github.com/nerdzeu/nerdz-core/db/igor"
+	"github.com/nerdzeu/nerdz-core/db/querylog"
+	"github.com/spf13/viper"
+)
+
+// Config describes the connection to a NERDZ database
+type Config struct {
+	User     string
+	Password string
+	Name     string
+	Host     string
+	Port     int
+	SSLMode  string
+	// Replicas are the read replicas of the database, as host or host:port (the port defaults to Port).
+	// They serve the reads, unless the context requires to read its own writes (see ReadYourWrites)
+	Replicas []string
+
+	// MaxOpenConns is the maximum number of open connections to each database. If 0, there is no limit
+	MaxOpenConns int
+	// MaxIdleConns is the maximum number of idle connections to each database. If 0, the default of database/sql is used
+	MaxIdleConns int
+	// ConnMaxLifetime is the maximum amount of time a connection may be reused. If 0, the connections are reused forever
+	ConnMaxLifetime time.Duration
+
+	// HealthInterval is the interval between the health checks of each database. If 0, there are no health checks
+	HealthInterval time.Duration
+	// BreakerThreshold is the number of consecutive failed connections (or health checks) after which
+	// a database is considered down: until it's back, its operations fail immediately with ErrUnavailable.
+	// If 0, a database is never considered down
+	BreakerThreshold int
+	// BreakerCooldown is the time after which a database that is down is tried again
+	BreakerCooldown time.Duration
+	// RetryAttempts is the maximum number of attempts of a read that fails because of a transient error.
+	// The writes are never retried
+	RetryAttempts int
+	// RetryBackoff is the delay before the first retry, that doubles after every retry up to RetryMaxBackoff
+	RetryBackoff    time.Duration
+	RetryMaxBackoff time.Duration
+
+	// CursorSecret is the key used to sign the pagination cursors. It's required, and must be
+	// the same for every process that shares the database, so that the cursors survive restarts and replicas
+	CursorSecret string
+
+	// LogLevel is the level of the query logger: debug (every query), warn (slow and failed queries),
+	// error (failed queries) or off. Defaults to warn
+	LogLevel string
+	// LogOutput is where the queries are logged: stdout (default), stderr or the path of a file
+	LogOutput string
+	// SlowQuery is the duration after which a query is slow. If 0, no query is slow
+	SlowQuery time.Duration
+	// Redact lists the columns, tables and functions, in addition to the ones holding passwords, secrets and tokens,
+	// that make a statement sensitive: all its arguments are redacted from the log.
+	// In NERDZ_DB_LOG_REDACT, the entries are space separated
+	Redact []string
+}
+
+// ConfigFromViper returns the Config loaded in viper.
+// This packages namespaces each one of its keys with 'db.', and each key
+// can be overridden by an environment variable prefixed with NERDZ_ (e.g. NERDZ_DB_HOST for db.host)
+func ConfigFromViper() Config {
+	setDefaults()
+	bindEnv()
+
+	return Config{
+		User:     viper.GetString(unameKey),
+		Password: viper.GetString(passKey),
+		Name:     viper.GetString(dbKey),
+		Host:     viper.GetString(hostKey),
+		Port:     viper.GetInt(portKey),
+		SSLMode:  viper.GetString(sslKey),
+		Replicas: viper.GetStringSlice(replicasKey),
+
+		MaxOpenConns:    viper.GetInt(maxOpenKey),
+		MaxIdleConns:    viper.GetInt(maxIdleKey),
+		ConnMaxLifetime: viper.GetDuration(maxLifetimeKey),
+
+		HealthInterval:   viper.GetDuration(healthIntervalKey),
+		BreakerThreshold: viper.GetInt(breakerThresholdKey),
+		BreakerCooldown:  viper.GetDuration(breakerCooldownKey),
+		RetryAttempts:    viper.GetInt(retryAttemptsKey),
+		RetryBackoff:     viper.GetDuration(retryBackoffKey),
+		RetryMaxBackoff:  viper.GetDuration(retryMaxBackoffKey),
+
+		CursorSecret: viper.GetString(cursorSecretKey),
+
+		LogLevel:  viper.GetString(logLevelKey),
+		LogOutput: viper.GetString(logOutputKey),
+		SlowQuery: viper.GetDuration(slowQueryKey),
+		Redact:    viper.GetStringSlice(redactKey)}
+}
+
+// connectionString returns the connection string of the database described by config
+func (config Config) connectionString() (string, error) {
+	if config.User == "" {
+		return "", invalidArgument("empty database username")
+	}
+
+	if config.Name == "" {
+		return "", invalidArgument("Empty db name")
+	}
+
+	var ret bytes.Buffer
+	ret.WriteString("user=" + config.User + " dbname=" + config.Name + " host=" + config.Host)
+
+	if config.Password != "" {
+		ret.WriteString(" password=" + config.Password)
+	}
+
+	ret.WriteString(" sslmode=" + config.SSLMode)
+
+	ret.WriteString(" port=" + strconv.Itoa(config.Port))
+
+	return ret.String(), nil
+}
+
+// replicas returns the configurations of the read replicas
+func (config Config) replicas() ([]Config, error) {
+	replicas := make([]Config, len(config.Replicas))
+	for i, address := range config.Replicas {
+		replicas[i] = config
+		replicas[i].Host = address
+		if host, port, err := net.SplitHostPort(address); err == nil {
+			replicas[i].Host = host
+			if replicas[i].Port, err = strconv.Atoi(port); err != nil {
+				return nil, invalidArgument("invalid port of the replica %s", address)
+			}
+		}
+	}
+	return replicas, nil
+}
+
+// connect opens the connection to the database described by config, whose connections
+// are opened by logger while the returned breaker is closed. The pool is limited as config describes
+func (config Config) connect(logger *querylog.Logger) (*igor.Database, *health.Breaker, error) {
+	connectionString, err := config.connectionString()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	breaker := health.NewBreaker(config.BreakerThreshold, config.BreakerCooldown)
+	connector := &breakerConnector{
+		Connector: logger.Connector(pqDriver{}, connectionString),
+		host:      config.Host,
+		breaker:   breaker}
+
+	database, err := igor.ConnectWith(connector, connectionString)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pool := database.DB()
+	pool.SetMaxOpenConns(config.MaxOpenConns)
+	pool.SetMaxIdleConns(config.idleConns())
+	pool.SetConnMaxLifetime(config.ConnMaxLifetime)
+	return database, breaker, nil
+}
+
+// idleConns returns the maximum number of idle connections to each database
+func (config Config) idleConns() int {
+	if config.MaxIdleConns == 0 {
+		return 2 // the default of database/sql
+	}
+	return config.MaxIdleConns
+}
+
+// backoff returns how the reads that fail because of a transient error are retried
+func (config Config) backoff() health.Backoff {
+	return health.Backoff{
+		Attempts: config.RetryAttempts,
+		Initial:  config.RetryBackoff,
+		Max:      config.RetryMaxBackoff}
+}
+
+// logger returns the query logger described by config, and the file it writes to, if any
+func (config Config) logger() (*querylog.Logger, io.Closer, error) {
+	level := querylog.Warn
+	if config.LogLevel != "" {
+		var err error
+		if level, err = querylog.ParseLevel(config.LogLevel); err != nil {
+			return nil, nil, invalidArgument("%s", err)
+		}
+	}
+
+	var output io.Writer
+	var file io.Closer
+	switch config.LogOutput {
+	case "", "stdout":
+		output = os.Stdout
+	case "stderr":
+		output = os.Stderr
+	default:
+		if level == querylog.Off {
+			break
+		}
+		f, err := os.OpenFile(config.LogOutput, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, nil, err
+		}
+		output, file = f, f
+	}
+
+	return querylog.New(querylog.Config{
+		Level:         level,
+		Output:        output,
+		SlowThreshold: config.SlowQuery,
+		Redact:        config.Redact}), file, nil
+}
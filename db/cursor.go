@@ -0,0 +1,158 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/nerdzeu/nerdz-core/db/igor"
+)
+
+// Cursor is the position of an element in a list of posts, comments, pms or mentions.
+// The elements older or newer than a cursor are the ones that follow or precede it
+// in the order of the list: the posts of a mixed list (the home) are sorted by time,
+// the other lists by ID.
+type Cursor struct {
+	Table string    // table of the element, that tells its kind
+	Time  time.Time // creation time of the element
+	ID    uint64    // hpid, hcid, pmid or id of the element
+}
+
+// cursorPayload is the signed content of a token
+type cursorPayload struct {
+	Table string `json:"t"`
+	Time  int64  `json:"s"`
+	ID    uint64 `json:"i"`
+}
+
+// NewCursor returns the cursor of element, that can be a post, a message of the home,
+// a comment, a pm or a mention. Returns nil for any other element
+func NewCursor(element igor.DBModel) *Cursor {
+	switch element := element.(type) {
+	case *UserPost:
+		return &Cursor{Table: element.TableName(), Time: element.Time, ID: element.Hpid}
+	case *ProjectPost:
+		return &Cursor{Table: element.TableName(), Time: element.Time, ID: element.Hpid}
+	case *Message:
+		if element.Type == new(UserPost).NumericType() {
+			return NewCursor(element.Post.UserPost())
+		}
+		return NewCursor(element.Post.ProjectPost())
+	case *UserPostComment:
+		return &Cursor{Table: element.TableName(), Time: element.Time, ID: element.Hcid}
+	case *ProjectPostComment:
+		return &Cursor{Table: element.TableName(), Time: element.Time, ID: element.Hcid}
+	case *PM:
+		return &Cursor{Table: element.TableName(), Time: element.Time, ID: element.Pmid}
+	case *Mention:
+		return &Cursor{Table: element.TableName(), Time: element.Time, ID: element.ID}
+	}
+	return nil
+}
+
+// Token returns the opaque token of the cursor, signed with the key of the Store of ctx.
+// Returns ErrNoStore if ctx carries no Store, since the token can't be signed
+func (c *Cursor) Token(ctx context.Context) (string, error) {
+	payload, err := json.Marshal(cursorPayload{Table: c.Table, Time: c.Time.UnixNano(), ID: c.ID})
+	if err != nil {
+		return "", err
+	}
+	signature := sign(ctx, payload)
+	if signature == nil {
+		return "", ErrNoStore
+	}
+	return base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// ParseCursor returns the cursor of token, that must have been signed by the Store of ctx
+// and must point to an element stored in one of tables.
+// An empty token is mapped to a nil cursor
+func ParseCursor(ctx context.Context, token string, tables ...string) (*Cursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return nil, invalidArgument("malformed cursor")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, invalidArgument("malformed cursor")
+	}
+	expected := sign(ctx, payload)
+	if expected == nil {
+		return nil, ErrNoStore
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(signature, expected) {
+		return nil, invalidArgument("invalid cursor signature")
+	}
+
+	var decoded cursorPayload
+	if err = json.Unmarshal(payload, &decoded); err != nil {
+		return nil, invalidArgument("malformed cursor")
+	}
+
+	for _, table := range tables {
+		if decoded.Table == table {
+			return &Cursor{Table: decoded.Table, Time: time.Unix(0, decoded.Time).UTC(), ID: decoded.ID}, nil
+		}
+	}
+	return nil, invalidArgument("the cursor does not belong to this list")
+}
+
+// Type returns the numeric type of the post the cursor points to,
+// used to sort the posts of a mixed list created at the same time
+func (c *Cursor) Type() uint8 {
+	if c.Table == (ProjectPost{}).TableName() {
+		return new(ProjectPost).NumericType()
+	}
+	return new(UserPost).NumericType()
+}
+
+// sign returns the signature of payload, computed with the cursor key of the Store of ctx.
+// Returns nil if ctx carries no Store
+func sign(ctx context.Context, payload []byte) []byte {
+	store := StoreFromContext(ctx)
+	if store == nil {
+		return nil
+	}
+
+	mac := hmac.New(sha256.New, store.cursorKey)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// randomKey returns a random key to sign the cursors of the stores created with NewStore
+func randomKey() []byte {
+	key := make([]byte, sha256.Size)
+	if _, err := rand.Read(key); err != nil {
+		panic(err)
+	}
+	return key
+}
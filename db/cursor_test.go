@@ -0,0 +1,85 @@
+//go:build postgres
+// +build postgres
+
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/nerdzeu/nerdz-core/db"
+	"github.com/nerdzeu/nerdz-core/db/memory"
+)
+
+func TestCursor(t *testing.T) {
+	postlist := *me.Postlist(ctx, db.PostlistOptions{N: 6})
+	if len(postlist) != 6 {
+		t.Fatalf("Expected 6 posts, but got: %d", len(postlist))
+	}
+
+	// Page through the postlist, 2 posts at a time, passing back the tokens
+	table := db.UserPost{}.TableName()
+	options := db.PostlistOptions{N: 2}
+	for i := 0; i < 3; i++ {
+		page := *me.Postlist(ctx, options)
+		if len(page) != 2 {
+			t.Fatalf("Page %d: expected 2 posts, but got: %d", i, len(page))
+		}
+		for j, post := range page {
+			if expected := postlist[2*i+j].(*db.UserPost); post.(*db.UserPost).Hpid != expected.Hpid {
+				t.Errorf("Page %d: expected post %d, but got post %d", i, expected.Hpid, post.(*db.UserPost).Hpid)
+			}
+		}
+
+		token, err := db.NewCursor(page[1]).Token(ctx)
+		if err != nil {
+			t.Fatalf("No error should happen when signing a token, but got: %s", err)
+		}
+		older, err := db.ParseCursor(ctx, token, table)
+		if err != nil {
+			t.Fatalf("No error should happen when parsing a token, but got: %s", err)
+		}
+		options.Older = older
+	}
+
+	token, err := db.NewCursor(postlist[0]).Token(ctx)
+	if err != nil {
+		t.Fatalf("No error should happen when signing a token, but got: %s", err)
+	}
+	if _, err := db.ParseCursor(ctx, token, db.PM{}.TableName()); !errors.Is(err, db.ErrInvalidArgument) {
+		t.Errorf("A cursor of another list should be rejected with ErrInvalidArgument, but got: %v", err)
+	}
+
+	tampered := strings.Replace(token, token[:4], "AAAA", 1)
+	if _, err := db.ParseCursor(ctx, tampered, table); !errors.Is(err, db.ErrInvalidArgument) {
+		t.Errorf("A tampered token should be rejected with ErrInvalidArgument, but got: %v", err)
+	}
+
+	otherCtx := db.NewStore(memory.New()).Context(context.Background())
+	if _, err := db.ParseCursor(otherCtx, token, table); !errors.Is(err, db.ErrInvalidArgument) {
+		t.Errorf("A token signed by another store should be rejected with ErrInvalidArgument, but got: %v", err)
+	}
+
+	if _, err := db.ParseCursor(context.Background(), token, table); err != db.ErrNoStore {
+		t.Errorf("Parsing a token without a store should fail with ErrNoStore, but got: %v", err)
+	}
+}
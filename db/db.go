@@ -18,37 +18,33 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package db
 
 import (
-	"bytes"
-	"errors"
-	"log"
-	"os"
-	"strconv"
+	"context"
 	"strings"
 
-	"github.com/galeone/igor"
+	"github.com/nerdzeu/nerdz-core/db/igor"
 	"github.com/spf13/viper"
 )
 
-var dbInst *igor.Database
+// defaultStore is the Store used when the context of a call carries none
+var defaultStore *Store
 
-// Init initialises the internal Database instance.
-// Using the package before calling Init will cause the application to panic.
+// Init initialises the default Store, using the configuration loaded in viper.
+// It's used by every function and method of the package whose context doesn't carry a Store
+// (see Store.Context): without Init, they fail with ErrNoStore
 func Init() error {
-	connectionString, err := connectionString()
+	store, err := Open(ConfigFromViper())
 	if err != nil {
 		return err
 	}
-
-	if dbInst, err = igor.Connect(connectionString); err != nil {
-		return err
-	}
-
-	logger := log.New(os.Stdout, "query-logger: ", log.LUTC)
-	dbInst.Log(logger)
-
+	defaultStore = store
 	return nil
 }
 
+// DefaultStore returns the default Store initialised by Init, nil if Init has not been called
+func DefaultStore() *Store {
+	return defaultStore
+}
+
 // bindEnv parses and loads into viper config env variables, if present
 func bindEnv() {
 	viper.SetEnvPrefix("nerdz")
@@ -60,46 +56,66 @@ func bindEnv() {
 	viper.BindEnv(passKey)
 	viper.BindEnv(portKey)
 	viper.BindEnv(sslKey)
+	viper.BindEnv(replicasKey)
+	viper.BindEnv(maxOpenKey)
+	viper.BindEnv(maxIdleKey)
+	viper.BindEnv(maxLifetimeKey)
+	viper.BindEnv(healthIntervalKey)
+	viper.BindEnv(breakerThresholdKey)
+	viper.BindEnv(breakerCooldownKey)
+	viper.BindEnv(retryAttemptsKey)
+	viper.BindEnv(retryBackoffKey)
+	viper.BindEnv(retryMaxBackoffKey)
+	viper.BindEnv(cursorSecretKey)
+	viper.BindEnv(logLevelKey)
+	viper.BindEnv(logOutputKey)
+	viper.BindEnv(slowQueryKey)
+	viper.BindEnv(redactKey)
 }
 
-// connectionString uses viper to access the database configuration, to create a global db instance.
-func connectionString() (string, error) {
-	setDefaults()
-	bindEnv()
-
-	username := viper.GetString(unameKey)
-	if username == "" {
-		return "", errors.New("empty database username")
-	}
-
-	name := viper.GetString(dbKey)
-	if name == "" {
-		return "", errors.New("Empty db name")
+// storage is used by this package to access the Storage of the Store of ctx.
+// If ctx carries no Store and Init has not been called, every operation of the returned Storage fails with ErrNoStore
+func storage(ctx context.Context) Storage {
+	store := StoreFromContext(ctx)
+	if store == nil {
+		return noStorage{}
 	}
 
-	var ret bytes.Buffer
-	ret.WriteString("user=" + username + " dbname=" + name + " host=" + viper.GetString(hostKey))
-
-	passwd := viper.GetString(passKey)
+	return store.storage
+}
 
-	if passwd != "" {
-		ret.WriteString(" password=" + passwd)
+// Transaction executes f as a unit of work of the Store of ctx (see Store.Transaction).
+// Returns ErrNoStore if ctx carries no Store
+func Transaction(ctx context.Context, f func(ctx context.Context) error) error {
+	store := StoreFromContext(ctx)
+	if store == nil {
+		return ErrNoStore
 	}
-
-	ret.WriteString(" sslmode=" + viper.GetString(sslKey))
-
-	ret.WriteString(" port=" + strconv.Itoa(viper.GetInt(portKey)))
-
-	return ret.String(), nil
+	return store.Transaction(ctx, f)
 }
 
-// db is used by this package to access an *igor.Database
-func db() *igor.Database {
-	if dbInst == nil {
-		panic("db not yet initialised")
+// begin executes f in a transaction begun on database, that is committed if f returns nil
+// and rolled back otherwise
+func begin(database *igor.Database, f func(tx *igor.Database) error) (e error) {
+	tx, e := database.BeginTx()
+	if e != nil {
+		return e
 	}
 
-	return dbInst
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			panic(r)
+		}
+
+		if e != nil {
+			tx.Rollback()
+		} else {
+			e = tx.Commit()
+		}
+	}()
+
+	return f(tx)
 }
 
 const (
@@ -111,6 +127,27 @@ const (
 	passKey  = viperScope + "password"
 	portKey  = viperScope + "port"
 	sslKey   = viperScope + "ssl"
+
+	replicasKey = viperScope + "replicas"
+
+	maxOpenKey     = viperScope + "pool.max_open"
+	maxIdleKey     = viperScope + "pool.max_idle"
+	maxLifetimeKey = viperScope + "pool.max_lifetime"
+
+	healthIntervalKey   = viperScope + "health.interval"
+	breakerThresholdKey = viperScope + "health.threshold"
+	breakerCooldownKey  = viperScope + "health.cooldown"
+
+	retryAttemptsKey   = viperScope + "retry.attempts"
+	retryBackoffKey    = viperScope + "retry.backoff"
+	retryMaxBackoffKey = viperScope + "retry.max_backoff"
+
+	cursorSecretKey = viperScope + "cursor_secret"
+
+	logLevelKey  = viperScope + "log.level"
+	logOutputKey = viperScope + "log.output"
+	slowQueryKey = viperScope + "log.slow_query"
+	redactKey    = viperScope + "log.redact"
 )
 
 // setDefaults sets into viper the default values to access the database.
@@ -119,4 +156,12 @@ func setDefaults() {
 	viper.SetDefault(hostKey, "localhost")
 	viper.SetDefault(portKey, 5432)
 	viper.SetDefault(sslKey, "disable")
+	viper.SetDefault(healthIntervalKey, "5s")
+	viper.SetDefault(breakerThresholdKey, 5)
+	viper.SetDefault(breakerCooldownKey, "10s")
+	viper.SetDefault(retryAttemptsKey, 3)
+	viper.SetDefault(retryBackoffKey, "50ms")
+	viper.SetDefault(retryMaxBackoffKey, "1s")
+	viper.SetDefault(logLevelKey, "warn")
+	viper.SetDefault(slowQueryKey, "200ms")
 }
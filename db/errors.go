@@ -0,0 +1,78 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Kinds of the errors returned by the package. Every error of the package that has a kind
+// is an *Error that wraps its kind: check it with errors.Is(err, ErrNotFound)
+var (
+	// ErrNotFound is the kind of the errors caused by a missing record
+	ErrNotFound = errors.New("not found")
+	// ErrPermissionDenied is the kind of the errors caused by operations that the user is not allowed to perform
+	ErrPermissionDenied = errors.New("permission denied")
+	// ErrInvalidArgument is the kind of the errors caused by invalid parameters
+	ErrInvalidArgument = errors.New("invalid argument")
+	// ErrConflict is the kind of the errors caused by the violation of a unique constraint
+	ErrConflict = errors.New("conflict")
+	// ErrAuthFailed is the kind of the errors caused by wrong credentials
+	ErrAuthFailed = errors.New("authentication failed")
+	// ErrUnavailable is the kind of the errors caused by a database that is down:
+	// they are returned immediately, until the database is back
+	ErrUnavailable = errors.New("unavailable")
+)
+
+// Error is an error of a specific kind
+type Error struct {
+	// Kind is one of ErrNotFound, ErrPermissionDenied, ErrInvalidArgument, ErrConflict, ErrAuthFailed and ErrUnavailable
+	Kind    error
+	Message string
+}
+
+// NewError returns an *Error of the specified kind, whose message is formatted according to format
+func NewError(kind error, format string, args ...interface{}) *Error {
+	return &Error{Kind: kind, Message: fmt.Sprintf(format, args...)}
+}
+
+// Error returns the message of the error
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the kind of the error
+func (e *Error) Unwrap() error {
+	return e.Kind
+}
+
+// notFound returns an error of kind ErrNotFound
+func notFound(format string, args ...interface{}) error {
+	return NewError(ErrNotFound, format, args...)
+}
+
+// permissionDenied returns an error of kind ErrPermissionDenied
+func permissionDenied(format string, args ...interface{}) error {
+	return NewError(ErrPermissionDenied, format, args...)
+}
+
+// invalidArgument returns an error of kind ErrInvalidArgument
+func invalidArgument(format string, args ...interface{}) error {
+	return NewError(ErrInvalidArgument, format, args...)
+}
@@ -0,0 +1,345 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/nerdzeu/nerdz-core/db/igor"
+)
+
+// EventType identifies the kind of an Event
+type EventType uint8
+
+const (
+	// EventPost is a new post, on a user or on a project board
+	EventPost EventType = iota
+	// EventComment is a new comment, on a user or on a project post
+	EventComment
+	// EventPm is a new private message
+	EventPm
+	// EventVote is a new vote on a post or on a comment
+	EventVote
+	// EventMention is a new mention of a user in a post or in a comment
+	EventMention
+	// EventFollow is a new follower of a user or of a project
+	EventFollow
+	// EventTyping is a user typing a private message to another user
+	EventTyping
+	// EventRead is a user that read the private messages sent by another user
+	EventRead
+)
+
+// Event is something that happened on NERDZ. The events are published as the changes they describe are committed,
+// and delivered to the users they concern (see User.Events)
+type Event struct {
+	Type EventType `json:"type"`
+	// From is the user that caused the event
+	From uint64 `json:"from"`
+	// To is the user the event is addressed to: the recipient of the pm, the author of the voted content,
+	// the mentioned user, the followed user (the owner, for a project), the user that's being written to
+	// or whose pms have been read. It's 0 for the posts and the comments
+	To uint64 `json:"to,omitempty"`
+	// Board is the board of the post or of the comment, or the followed board. Project is true if it's a project
+	Board   uint64 `json:"board,omitempty"`
+	Project bool   `json:"project,omitempty"`
+	// Hpid, Hcid and Pmid reference the content of the event, if any: the content created, voted or that mentions
+	Hpid uint64 `json:"hpid,omitempty"`
+	Hcid uint64 `json:"hcid,omitempty"`
+	Pmid uint64 `json:"pmid,omitempty"`
+	// Vote is the value of the vote of an EventVote
+	Vote int8      `json:"vote,omitempty"`
+	Time time.Time `json:"time"`
+}
+
+// eventsChannel is the PostgreSQL channel the events are published on
+const eventsChannel = "nerdz_events"
+
+// subscriptionBuffer is the number of events that a subscription buffers: the subscriptions
+// that fall further behind are closed
+const subscriptionBuffer = 64
+
+// hub delivers the events published on the storage of a Store to its subscriptions.
+// It starts listening to the storage when the first subscription is created
+type hub struct {
+	storage Storage
+
+	// listenMu serializes the attempts to listen to the storage
+	listenMu  sync.Mutex
+	listening bool
+
+	mu            sync.Mutex
+	subscriptions map[chan Event]struct{}
+}
+
+// newHub returns a hub of the events published on storage
+func newHub(storage Storage) *hub {
+	return &hub{storage: storage, subscriptions: make(map[chan Event]struct{})}
+}
+
+// subscribe returns a channel that receives the events published from now on.
+// The channel is closed by unsubscribe, or if its buffer is full when an event is published
+func (h *hub) subscribe() (chan Event, error) {
+	h.listenMu.Lock()
+	defer h.listenMu.Unlock()
+	if !h.listening {
+		if err := h.storage.Listen(h.publish); err != nil {
+			return nil, err
+		}
+		h.listening = true
+	}
+
+	subscription := make(chan Event, subscriptionBuffer)
+	h.mu.Lock()
+	h.subscriptions[subscription] = struct{}{}
+	h.mu.Unlock()
+	return subscription, nil
+}
+
+// unsubscribe closes subscription, if it's still open
+func (h *hub) unsubscribe(subscription chan Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.subscriptions[subscription]; ok {
+		delete(h.subscriptions, subscription)
+		close(subscription)
+	}
+}
+
+// close closes every subscription
+func (h *hub) close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for subscription := range h.subscriptions {
+		delete(h.subscriptions, subscription)
+		close(subscription)
+	}
+}
+
+// publish delivers event to every subscription, without blocking
+func (h *hub) publish(event Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for subscription := range h.subscriptions {
+		select {
+		case subscription <- event:
+		default:
+			delete(h.subscriptions, subscription)
+			close(subscription)
+		}
+	}
+}
+
+// publish publishes event on the storage of ctx, once the unit of work of ctx, if any, is committed
+func publish(ctx context.Context, event Event) error {
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+	return storage(ctx).Publish(ctx, event)
+}
+
+// contentEvent returns the event of the creation of message
+func contentEvent(message Content) Event {
+	event := Event{From: message.NumericSender()}
+	switch content := message.(type) {
+	case *UserPost:
+		event.Type, event.Board, event.Hpid, event.Time = EventPost, content.To, content.Hpid, content.Time
+	case *ProjectPost:
+		event.Type, event.Board, event.Project, event.Hpid, event.Time = EventPost, content.To, true, content.Hpid, content.Time
+	case *UserPostComment:
+		event.Type, event.Board, event.Hpid, event.Hcid, event.Time = EventComment, content.To, content.Hpid, content.Hcid, content.Time
+	case *ProjectPostComment:
+		event.Type, event.Board, event.Project, event.Hpid, event.Hcid, event.Time = EventComment, content.To, true, content.Hpid, content.Hcid, content.Time
+	case *PM:
+		event.Type, event.To, event.Pmid, event.Time = EventPm, content.To, content.Pmid, content.Time
+	}
+	return event
+}
+
+// Events returns a channel that receives the events that concern user, as they happen, until ctx is done:
+// the posts on the boards user follows and on the board of user, the comments on the posts user lurks,
+// the pms, votes and mentions addressed to user, the new followers of user and of the projects user owns,
+// and the users that are typing a pm to user or that read the pms of user.
+// The events caused by user, and the ones user can't see in the home (because of the blacklist or
+// of the visibility of a project), are not delivered.
+//
+// The boards user follows, the posts user lurks and the blacklists are loaded when the subscription is
+// created, and reloaded when user follows a board or every interestsRefresh: the changes made
+// in the meantime may take up to interestsRefresh to apply.
+//
+// The channel is closed when ctx is done, or when its events are not received fast enough:
+// in that case, the events that followed are lost. Returns ErrNoStore if ctx carries no Store
+func (user *User) Events(ctx context.Context) (<-chan Event, error) {
+	store := StoreFromContext(ctx)
+	if store == nil {
+		return nil, ErrNoStore
+	}
+
+	interests, err := user.interests(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription, err := store.events.subscribe()
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan Event)
+	go func() {
+		defer close(events)
+		defer store.events.unsubscribe(subscription)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-subscription:
+				if !ok {
+					return
+				}
+				if (event.Type == EventFollow && event.From == user.ID()) || time.Since(interests.loaded) > interestsRefresh {
+					// on error, the previous interests are used until the next attempt
+					if reloaded, err := user.interests(ctx); err == nil {
+						interests = reloaded
+					}
+				}
+				if !interests.concerned(ctx, event) {
+					continue
+				}
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return events, nil
+}
+
+// interestsRefresh is the interval after which the interests of a subscription are reloaded
+const interestsRefresh = time.Minute
+
+// interests are the relations of a user that select the events delivered to them (see User.Events)
+type interests struct {
+	user             uint64
+	userFollowing    map[uint64]struct{}
+	projectFollowing map[uint64]struct{}
+	userLurking      map[uint64]struct{}
+	projectLurking   map[uint64]struct{}
+	blacklist        map[uint64]struct{}
+	blacklisting     map[uint64]struct{}
+	// visible caches whether the projects of the events are visible to user
+	visible map[uint64]bool
+	loaded  time.Time
+}
+
+// interests loads the interests of user
+func (user *User) interests(ctx context.Context) (*interests, error) {
+	loaded := &interests{user: user.ID(), visible: make(map[uint64]bool), loaded: time.Now()}
+	sets := []struct {
+		set         *map[uint64]struct{}
+		description igor.DBModel
+		column      string
+	}{
+		{&loaded.userFollowing, &UserFollower{From: user.ID()}, "to"},
+		{&loaded.projectFollowing, &ProjectFollower{From: user.ID()}, "to"},
+		{&loaded.userLurking, &UserPostLurk{From: user.ID()}, "hpid"},
+		{&loaded.projectLurking, &ProjectPostLurk{From: user.ID()}, "hpid"},
+		{&loaded.blacklist, &Blacklist{From: user.ID()}, "to"},
+		{&loaded.blacklisting, &Blacklist{To: user.ID()}, "from"},
+	}
+	for _, s := range sets {
+		var ids []uint64
+		if err := storage(ctx).Pluck(ctx, s.description, s.column, &ids); err != nil {
+			return nil, err
+		}
+		*s.set = make(map[uint64]struct{}, len(ids))
+		for _, id := range ids {
+			(*s.set)[id] = struct{}{}
+		}
+	}
+	return loaded, nil
+}
+
+// contains returns true if id is in set
+func contains(set map[uint64]struct{}, id uint64) bool {
+	_, ok := set[id]
+	return ok
+}
+
+// concerned returns true if event must be delivered to the user of i (see User.Events)
+func (i *interests) concerned(ctx context.Context, event Event) bool {
+	if event.From == i.user {
+		return false
+	}
+
+	switch event.Type {
+	case EventPost:
+		if event.Project && !contains(i.projectFollowing, event.Board) {
+			return false
+		}
+		if !event.Project && event.Board != i.user && !contains(i.userFollowing, event.Board) {
+			return false
+		}
+	case EventComment:
+		if event.Project && !contains(i.projectLurking, event.Hpid) {
+			return false
+		}
+		if !event.Project && !contains(i.userLurking, event.Hpid) {
+			return false
+		}
+	case EventPm, EventVote, EventMention, EventFollow:
+		if event.To != i.user {
+			return false
+		}
+	case EventTyping, EventRead:
+		// the users that blacklisted user can't chat with them, either
+		if event.To != i.user || contains(i.blacklisting, event.From) {
+			return false
+		}
+	default:
+		return false
+	}
+
+	// the rules of the home: the blacklisted users and boards are excluded,
+	// as the invisible projects user is not a member of
+	if contains(i.blacklist, event.From) {
+		return false
+	}
+	if event.Board == 0 {
+		return true
+	}
+	if !event.Project {
+		return !contains(i.blacklist, event.Board)
+	}
+	visible, ok := i.visible[event.Board]
+	if !ok {
+		// the projects that can't be loaded are not cached, and their events not delivered
+		project, err := NewProject(ctx, event.Board)
+		if err != nil {
+			return false
+		}
+		visible = (&User{Counter: i.user}).CanSee(ctx, project)
+		i.visible[event.Board] = visible
+	}
+	return visible
+}
@@ -0,0 +1,132 @@
+//go:build postgres
+// +build postgres
+
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nerdzeu/nerdz-core/db"
+)
+
+// subscribe subscribes user to the events, and returns a function that returns the next event delivered.
+// The events that must not be delivered are followed by one that must, so that they can be detected
+func subscribe(t *testing.T, user *db.User) (func() db.Event, context.CancelFunc) {
+	subscribed, cancel := context.WithCancel(ctx)
+	events, err := user.Events(subscribed)
+	if err != nil {
+		cancel()
+		t.Fatalf("No error should happen when subscribing to the events, but got: %s", err)
+	}
+
+	next := func() db.Event {
+		select {
+		case event := <-events:
+			return event
+		case <-time.After(5 * time.Second):
+			t.Fatalf("An event should have been delivered")
+		}
+		return db.Event{}
+	}
+	return next, cancel
+}
+
+// deletePm deletes pm, that can't be deleted by its users alone
+func deletePm(pm *db.PM) {
+	db.StoreFromContext(ctx).Storage().Delete(ctx, &db.PM{Pmid: pm.Pmid})
+}
+
+func TestEvents(t *testing.T) {
+	next, cancel := subscribe(t, me)
+	defer cancel()
+
+	if err := blacklisted.Typing(ctx, me.ID()); !errors.Is(err, db.ErrPermissionDenied) {
+		t.Errorf("A blacklisted user should not be able to type to the user, but got: %v", err)
+	}
+
+	rolledBack := errors.New("rolled back")
+	if err := db.Transaction(ctx, func(ctx context.Context) error {
+		if err := other.Typing(ctx, me.ID()); err != nil {
+			return err
+		}
+		return rolledBack
+	}); err != rolledBack {
+		t.Fatalf("The transaction should fail with its error, but got: %v", err)
+	}
+	if err := me.Typing(ctx, other.ID()); err != nil {
+		t.Fatalf("No error should happen when typing, but got: %s", err)
+	}
+
+	post := db.UserPost{}
+	post.To, post.Message = me.ID(), "On your board"
+	if err := other.Submit(ctx, &post); err != nil {
+		t.Fatalf("No error should happen when posting, but got: %s", err)
+	}
+	defer other.Delete(ctx, &post)
+	if event := next(); event.Type != db.EventPost || event.Hpid != post.Hpid || event.Board != me.ID() || event.From != other.ID() {
+		t.Errorf("Only the post on the board of the user should be delivered, but got %+v", event)
+	}
+
+	pm := db.PM{To: me.ID(), Message: "Hi!"}
+	if err := other.Submit(ctx, &pm); err != nil {
+		t.Fatalf("No error should happen when sending a pm, but got: %s", err)
+	}
+	defer deletePm(&pm)
+	if event := next(); event.Type != db.EventPm || event.Pmid != pm.Pmid || event.To != me.ID() {
+		t.Errorf("The pm sent to the user should be delivered, but got %+v", event)
+	}
+}
+
+func TestChat(t *testing.T) {
+	next, cancel := subscribe(t, other)
+	defer cancel()
+
+	if err := me.Typing(ctx, other.ID()); err != nil {
+		t.Fatalf("No error should happen when typing, but got: %s", err)
+	}
+	if event := next(); event.Type != db.EventTyping || event.From != me.ID() || event.To != other.ID() {
+		t.Errorf("The typing indicator should be delivered, but got %+v", event)
+	}
+
+	pm := db.PM{To: me.ID(), Message: "Read me"}
+	if err := other.Submit(ctx, &pm); err != nil {
+		t.Fatalf("No error should happen when sending a pm, but got: %s", err)
+	}
+	defer deletePm(&pm)
+	if err := me.ReadPms(ctx, other.ID()); err != nil {
+		t.Fatalf("No error should happen when reading the pms, but got: %s", err)
+	}
+	if event := next(); event.Type != db.EventRead || event.From != me.ID() || event.To != other.ID() {
+		t.Errorf("The read receipt should be delivered, but got %+v", event)
+	}
+
+	conversations, err := me.Conversations(ctx)
+	if err != nil {
+		t.Fatalf("No error should happen when listing the conversations, but got: %s", err)
+	}
+	for _, conversation := range *conversations {
+		if conversation.To == other.ID() && conversation.ToRead {
+			t.Errorf("The conversation should have been read, but got %+v", conversation)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package health
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// Backoff describes how an operation is retried: the delay between two attempts starts from Initial
+// and doubles after every attempt, up to Max. Every delay is randomised by up to a half,
+// so that the callers that failed together don't retry together
+type Backoff struct {
+	// Attempts is the maximum number of attempts. If it's lower than 2, the operation is not retried
+	Attempts int
+	Initial  time.Duration
+	Max      time.Duration
+}
+
+// Delay returns the delay before the retry-th retry, starting from 0
+func (b Backoff) Delay(retry int) time.Duration {
+	delay := b.Initial
+	for i := 0; i < retry && (b.Max <= 0 || delay < b.Max); i++ {
+		delay *= 2
+	}
+	if b.Max > 0 && delay > b.Max {
+		delay = b.Max
+	}
+	if delay <= 0 {
+		return 0
+	}
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(delay-half)))
+}
+
+// Retry calls f until it succeeds, it fails with an error that is not retryable or the attempts are over,
+// and returns its last error. It stops waiting as soon as ctx is done, returning the last error of f
+func (b Backoff) Retry(ctx context.Context, retryable func(error) bool, f func() error) error {
+	err := f()
+	for retry := 0; err != nil && retry < b.Attempts-1 && retryable(err); retry++ {
+		timer := time.NewTimer(b.Delay(retry))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		err = f()
+	}
+	return err
+}
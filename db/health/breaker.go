@@ -0,0 +1,139 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package health keeps track of the availability of the NERDZ databases.
+//
+// A Breaker opens after a number of consecutive failures, so that the callers fail fast instead of
+// waiting for a database that is down, and lets a trial through once its cooldown has elapsed.
+// A Monitor checks a database periodically and reports to its Breaker, so that the breaker closes as
+// soon as the database is back. Backoff retries the operations that fail because of transient errors.
+package health
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// State is the state of a Breaker
+type State int
+
+const (
+	// Closed lets every call through
+	Closed State = iota
+	// Open rejects every call, until the cooldown has elapsed
+	Open
+	// HalfOpen lets a single trial through: its outcome closes or opens the breaker again
+	HalfOpen
+)
+
+// String returns the name of the state
+func (state State) String() string {
+	switch state {
+	case Closed:
+		return "closed"
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half-open"
+	}
+	return fmt.Sprintf("State(%d)", int(state))
+}
+
+// Breaker is a circuit breaker. It's safe for concurrent use
+type Breaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	// trial is true while the trial of the half-open breaker is in progress
+	trial bool
+	err   error
+}
+
+// NewBreaker returns a closed Breaker that opens after threshold consecutive failures,
+// and lets a trial through cooldown after it opened. If threshold is 0, the breaker never opens
+func NewBreaker(threshold int, cooldown time.Duration) *Breaker {
+	return &Breaker{threshold: threshold, cooldown: cooldown}
+}
+
+// Allow returns true if a call can be performed. The outcome of an allowed call must be
+// reported with Success or Failure
+func (b *Breaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case Open:
+		if time.Since(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = HalfOpen
+		b.trial = true
+		return true
+	case HalfOpen:
+		if b.trial {
+			return false
+		}
+		b.trial = true
+		return true
+	}
+	return true
+}
+
+// Success records a successful call, that closes the breaker
+func (b *Breaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = Closed
+	b.failures = 0
+	b.trial = false
+	b.err = nil
+}
+
+// Failure records a call failed with err. The breaker opens if the call was its trial,
+// or if the consecutive failures reached its threshold
+func (b *Breaker) Failure(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.err = err
+	b.trial = false
+	if b.state == HalfOpen || (b.threshold > 0 && b.failures >= b.threshold) {
+		b.state = Open
+		b.openedAt = time.Now()
+	}
+}
+
+// State returns the state of the breaker
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// Err returns the error of the last failure, or nil if the last call succeeded
+func (b *Breaker) Err() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.err
+}
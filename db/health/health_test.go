@@ -0,0 +1,150 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package health_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nerdzeu/nerdz-core/db/health"
+)
+
+var errDown = errors.New("connection refused")
+
+func TestBreaker(t *testing.T) {
+	breaker := health.NewBreaker(2, 20*time.Millisecond)
+
+	breaker.Failure(errDown)
+	if !breaker.Allow() {
+		t.Fatalf("The breaker should stay closed below its threshold")
+	}
+
+	breaker.Failure(errDown)
+	if breaker.State() != health.Open || breaker.Allow() {
+		t.Fatalf("The breaker should open at its threshold, but it's %s", breaker.State())
+	}
+	if breaker.Err() != errDown {
+		t.Errorf("The breaker should report its last failure, but got %v", breaker.Err())
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if !breaker.Allow() {
+		t.Fatalf("The breaker should let a trial through after its cooldown")
+	}
+	if breaker.State() != health.HalfOpen || breaker.Allow() {
+		t.Fatalf("The half-open breaker should let a single trial through")
+	}
+
+	breaker.Failure(errDown)
+	if breaker.State() != health.Open {
+		t.Fatalf("A failed trial should open the breaker again, but it's %s", breaker.State())
+	}
+
+	breaker.Success()
+	if breaker.State() != health.Closed || !breaker.Allow() || breaker.Err() != nil {
+		t.Errorf("A success should close the breaker, but it's %s", breaker.State())
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	backoff := health.Backoff{Attempts: 3, Initial: time.Millisecond, Max: 2 * time.Millisecond}
+	for retry := 0; retry < 5; retry++ {
+		if delay := backoff.Delay(retry); delay > backoff.Max || delay < backoff.Initial/2 {
+			t.Errorf("The delay of the retry %d should be bounded, but got %s", retry, delay)
+		}
+	}
+
+	retryable := func(err error) bool { return err == errDown }
+
+	var calls int
+	err := backoff.Retry(context.Background(), retryable, func() error {
+		calls++
+		return errDown
+	})
+	if err != errDown || calls != backoff.Attempts {
+		t.Errorf("Retry should stop after %d attempts, but got %d calls and %v", backoff.Attempts, calls, err)
+	}
+
+	calls = 0
+	err = backoff.Retry(context.Background(), retryable, func() error {
+		calls++
+		if calls == 1 {
+			return errDown
+		}
+		return nil
+	})
+	if err != nil || calls != 2 {
+		t.Errorf("Retry should stop after the first success, but got %d calls and %v", calls, err)
+	}
+
+	calls = 0
+	permanent := errors.New("syntax error")
+	if err = backoff.Retry(context.Background(), retryable, func() error {
+		calls++
+		return permanent
+	}); err != permanent || calls != 1 {
+		t.Errorf("The errors that are not retryable should not be retried, but got %d calls", calls)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls = 0
+	health.Backoff{Attempts: 3, Initial: time.Hour}.Retry(ctx, retryable, func() error {
+		calls++
+		return errDown
+	})
+	if calls != 1 {
+		t.Errorf("Retry should stop waiting when its context is done, but got %d calls", calls)
+	}
+}
+
+func TestMonitor(t *testing.T) {
+	breaker := health.NewBreaker(1, time.Hour)
+
+	var down, resets int32 = 1, 0
+	monitor := health.Watch(5*time.Millisecond, breaker, func(context.Context) error {
+		if atomic.LoadInt32(&down) == 1 {
+			return errDown
+		}
+		return nil
+	}, func() {
+		atomic.AddInt32(&resets, 1)
+	})
+	defer monitor.Stop()
+
+	waitFor := func(state health.State) {
+		deadline := time.Now().Add(time.Second)
+		for breaker.State() != state {
+			if time.Now().After(deadline) {
+				t.Fatalf("The breaker should be %s, but it's %s", state, breaker.State())
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	waitFor(health.Open)
+	if atomic.LoadInt32(&resets) == 0 {
+		t.Errorf("A failed check should reset the connections")
+	}
+
+	atomic.StoreInt32(&down, 0)
+	waitFor(health.Closed)
+}
@@ -0,0 +1,67 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package health
+
+import (
+	"context"
+	"time"
+)
+
+// Monitor checks a database periodically, until it's stopped
+type Monitor struct {
+	stop chan struct{}
+	done chan struct{}
+}
+
+// Watch checks a database every interval, calling check with a context that expires after interval,
+// and reports the outcome of every check to breaker. check must bypass the breaker, so that it closes
+// as soon as the database is back. After every failed check, reset is called to discard the connections
+// that the failure could have broken
+func Watch(interval time.Duration, breaker *Breaker, check func(ctx context.Context) error, reset func()) *Monitor {
+	m := &Monitor{stop: make(chan struct{}), done: make(chan struct{})}
+	go func() {
+		defer close(m.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-m.stop:
+				return
+			case <-ticker.C:
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), interval)
+			err := check(ctx)
+			cancel()
+			if err != nil {
+				breaker.Failure(err)
+				reset()
+			} else {
+				breaker.Success()
+			}
+		}
+	}()
+	return m
+}
+
+// Stop stops the monitor and waits for its last check to return
+func (m *Monitor) Stop() {
+	close(m.stop)
+	<-m.done
+}
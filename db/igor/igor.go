@@ -34,11 +34,22 @@ limitations under the License.
 //    - Does not support callbacks
 //    - Does not have any specific method for data migration and DDL operations
 //    - Does not support soft delete
+//
+// This is the subset of galeone/igor (https://github.com/galeone/igor) at 1109e1158d585e449deda975c021200887e3018b
+// used by nerdz-core, kept in tree because of some changes that are not upstream yet:
+//  - ConnectWith, to open the connections with a driver.Connector (used to log the queries)
+//  - WithContext and Context, to cancel the running queries when the request is cancelled
+//  - BeginTx and the errors of Exec, Scan and commonRawQuery, that were swallowed before
+//  - StopListening, and the notifications delivered in order to the Listen callback
+//  - Scan of the structs that embed models with fields of the same name (e.g. a user joined with its profile)
+// When the changes are merged upstream, this package should be dropped in favour of the vendored one.
 package igor
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
+	"database/sql/driver"
 	"errors"
 	"fmt"
 	"log"
@@ -51,14 +62,27 @@ import (
 
 // Connect opens the connection to PostgreSQL using connectionString
 func Connect(connectionString string) (*Database, error) {
-	var e error
-	db := new(Database)
-	if db.db, e = sql.Open("postgres", connectionString); e != nil {
+	database, e := sql.Open("postgres", connectionString)
+	if e != nil {
 		return nil, e
 	}
+	return connect(database, connectionString)
+}
+
+// ConnectWith opens the connection to PostgreSQL described by connectionString,
+// using connector to open the connections (e.g. to wrap the driver)
+func ConnectWith(connector driver.Connector, connectionString string) (*Database, error) {
+	return connect(sql.OpenDB(connector), connectionString)
+}
+
+// connect pings database, that is the PostgreSQL database described by connectionString
+func connect(database *sql.DB, connectionString string) (*Database, error) {
+	db := new(Database)
+	db.db = database
 
 	// Ping the database to see if the connection is real
-	if e = db.DB().Ping(); e != nil {
+	if e := db.DB().Ping(); e != nil {
+		database.Close()
 		return nil, errors.New("Connection failed. Unable to ping the DB: " + e.Error())
 	}
 
@@ -67,6 +91,22 @@ func Connect(connectionString string) (*Database, error) {
 	return db, nil
 }
 
+// WithContext returns a copy of the database handle that uses ctx for every query.
+// When ctx is cancelled or its deadline expires, the running query is cancelled
+func (db *Database) WithContext(ctx context.Context) *Database {
+	db = db.clone()
+	db.ctx = ctx
+	return db
+}
+
+// Context returns the context used by the queries. Defaults to context.Background()
+func (db *Database) Context() context.Context {
+	if db.ctx == nil {
+		return context.Background()
+	}
+	return db.ctx
+}
+
 // Log sets the query logger
 func (db *Database) Log(logger *log.Logger) *Database {
 	db.logger = logger
@@ -131,12 +171,12 @@ func (db *Database) Delete(value DBModel) error {
 	// Compile query
 	var stmt *sql.Stmt
 	var err error
-	if stmt, err = db.db.Prepare(db.buildDelete()); err != nil {
+	if stmt, err = db.db.PrepareContext(db.Context(), db.buildDelete()); err != nil {
 		return err
 	}
 
 	// Pass query parameters and executes the query
-	if _, err = stmt.Exec(db.whereValues...); err != nil {
+	if _, err = stmt.ExecContext(db.Context(), db.whereValues...); err != nil {
 		return err
 	}
 
@@ -187,15 +227,6 @@ func (db *Database) Count(value *uint8) error {
 	return db.Scan(value)
 }
 
-// First Scans the result of the selection query of type model using the specified id
-// Panics if key is not compatible with the primary key filed type or if the query formulation fails
-func (db *Database) First(dest DBModel, key interface{}) error {
-	modelKey, _ := primaryKey(dest)
-	in := reflect.Indirect(reflect.ValueOf(dest))
-	in.FieldByName(modelKey).Set(reflect.Indirect(reflect.ValueOf(key)))
-	return db.Model(dest).Where(in.Interface()).Scan(dest)
-}
-
 // Scan build the SELECT query and scans the query result query into dest.
 // Panics if scan fails or the query fail
 func (db *Database) Scan(dest ...interface{}) error {
@@ -205,6 +236,11 @@ func (db *Database) Scan(dest ...interface{}) error {
 		return errors.New("Required at least one parameter to Scan method")
 	}
 
+	// Raw failed because its context is done
+	if db.rawErr != nil {
+		return db.rawErr
+	}
+
 	var err error
 	var rows *sql.Rows
 	destIndirect := reflect.Indirect(reflect.ValueOf(dest[0]))
@@ -239,12 +275,12 @@ func (db *Database) Scan(dest ...interface{}) error {
 			}
 		}
 
-		if stmt, err = db.db.Prepare(db.buildSelect()); err != nil {
+		if stmt, err = db.db.PrepareContext(db.Context(), db.buildSelect()); err != nil {
 			return err
 		}
 
 		// Pass query parameters and execute it
-		if rows, err = stmt.Query(append(db.cteSelectValues, db.whereValues...)...); err != nil {
+		if rows, err = stmt.QueryContext(db.Context(), append(db.cteSelectValues, db.whereValues...)...); err != nil {
 			return err
 		}
 
@@ -277,7 +313,7 @@ func (db *Database) Scan(dest ...interface{}) error {
 		if defaultElem.Kind() == reflect.Struct {
 			fields := getFields(defaultElem.Interface())
 			for _, field := range fields {
-				interfaces = append(interfaces, reflect.Indirect(defaultElem.FieldByName(field.Name)).Addr().Interface())
+				interfaces = append(interfaces, reflect.Indirect(defaultElem.FieldByIndex(field.Index)).Addr().Interface())
 			}
 		} else {
 			// else convert defaultElem into interfaces, use the address
@@ -309,25 +345,33 @@ func (db *Database) Scan(dest ...interface{}) error {
 }
 
 // Exec prepares and execute a raw query and replace placeholders (?) with the one supported by PostgreSQL
-// Exec panics if can't build the query
+// Exec returns the error that prevented the execution of the query (e.g. the connection is lost)
 // Use Exec instead of Raw when you don't need the results (or there's no result)
 func (db *Database) Exec(query string, args ...interface{}) error {
 	defer db.clear()
-	stmt := db.commonRawQuery(query, args...)
-	_, e := stmt.Exec(db.whereValues...)
+	stmt, e := db.commonRawQuery(query, args...)
+	if e != nil {
+		return e
+	}
+	_, e = stmt.ExecContext(db.Context(), db.whereValues...)
 	return e
 }
 
 // Raw prepares and executes a raw query and replace placeholders (?) with the one supported by PostgreSQL
-// Raw panics if can't build the query
+// If the query can't be executed (e.g. the connection is lost or the context is done), Scan returns the error
 // To fetch results call Scan
 func (db *Database) Raw(query string, args ...interface{}) *Database {
 	db = db.clone()
 	var err error
-	stmt := db.commonRawQuery(query, args...)
+	var stmt *sql.Stmt
+	if stmt, err = db.commonRawQuery(query, args...); err != nil {
+		db.rawErr = err
+		return db
+	}
 	// Pass query parameters and executes the query
-	if db.rawRows, err = stmt.Query(db.whereValues...); err != nil {
-		db.panicLog(err.Error())
+	if db.rawRows, err = stmt.QueryContext(db.Context(), db.whereValues...); err != nil {
+		db.printLog(err.Error())
+		db.rawErr = err
 	}
 	return db
 }
@@ -431,13 +475,6 @@ func (db *Database) Limit(limit int) *Database {
 	return db
 }
 
-// Offset sets the OFFSET value to the query
-func (db *Database) Offset(offset int) *Database {
-	db = db.clone()
-	db.offset = offset
-	return db
-}
-
 // Order sets the ORDER BY value to the query
 func (db *Database) Order(value string) *Database {
 	db = db.clone()
@@ -453,23 +490,32 @@ func (db *Database) DB() *sql.DB {
 
 // Transactions
 
-// Begin initialize a transaction
+// Begin initialize a transaction. The transaction is rolled back if the context is done before Commit
 // panics if begin has been already called
 // Returns nil on error (if logger is enabled write error on log)
 func (db *Database) Begin() *Database {
+	tx, err := db.BeginTx()
+	if err != nil {
+		return nil
+	}
+	return tx
+}
+
+// BeginTx is like Begin, but it returns the error that prevented the transaction from beginning
+func (db *Database) BeginTx() (*Database, error) {
 	db = db.clone()
 	// Initialize transaction
 	var tx *sql.Tx
 	var err error
-	if tx, err = db.db.(*sql.DB).Begin(); err != nil {
+	if tx, err = db.db.(*sql.DB).BeginTx(db.Context(), nil); err != nil {
 		db.printLog(err.Error())
-		return nil
+		return nil, err
 	}
 	// backup db.db into db.connection
 	db.connection = db.db.(*sql.DB)
 	// replace db.db with the transaction
 	db.db = tx
-	return db
+	return db, nil
 }
 
 // Commit commits the transaction.
@@ -176,7 +176,6 @@ func (db *Database) clear() {
 	db.whereFields = nil
 	db.order = ""
 	db.limit = 0
-	db.offset = 0
 	db.varCount = 1
 }
 
@@ -210,8 +209,8 @@ func handleIdentifier(clause string) string {
 }
 
 // commonRawQuery executes common operations when using raw queries
-// returns the prepared statement
-func (db *Database) commonRawQuery(query string, args ...interface{}) *sql.Stmt {
+// returns the prepared statement, or the error that prevented its preparation
+func (db *Database) commonRawQuery(query string, args ...interface{}) (*sql.Stmt, error) {
 	// Replace ? with $n
 	query = db.replaceMarks(query)
 	// Append args content to current values
@@ -221,10 +220,11 @@ func (db *Database) commonRawQuery(query string, args ...interface{}) *sql.Stmt
 	// Compile query
 	var stmt *sql.Stmt
 	var err error
-	if stmt, err = db.db.Prepare(query + ";"); err != nil {
-		db.panicLog(err.Error())
+	if stmt, err = db.db.PrepareContext(db.Context(), query+";"); err != nil {
+		db.printLog(err.Error())
+		return nil, err
 	}
-	return stmt
+	return stmt, nil
 }
 
 // commonCreateUpdate executs common operation in preparation of create / update statements
@@ -253,13 +253,13 @@ func (db *Database) commonCreateUpdate(value DBModel, builder func() string) err
 	// Compile query
 	var stmt *sql.Stmt
 	var err error
-	if stmt, err = db.db.Prepare(builder()); err != nil {
+	if stmt, err = db.db.PrepareContext(db.Context(), builder()); err != nil {
 		return err
 	}
 
 	// Pass query parameters and executes the query
 	// set db.rawRows to query results (of returning) in order to make it possible to scan rows into result
-	if db.rawRows, err = stmt.Query(append(db.updateCreateValues, db.whereValues...)...); err != nil {
+	if db.rawRows, err = stmt.QueryContext(db.Context(), append(db.updateCreateValues, db.whereValues...)...); err != nil {
 		return err
 	}
 	if err = db.Scan(value); err != nil {
@@ -361,7 +361,8 @@ func getSQLFields(s DBModel) (ret []string) {
 }
 
 // getFields returns a slice of reflect.StructField that represents the exported struct Fields in s
-// that are not excluded in sql generation
+// that are not excluded in sql generation. The Index of the fields of the embedded structs is
+// relative to s, so that the fields with the same name are told apart
 func getFields(s interface{}) (ret []reflect.StructField) {
 	val := reflect.Indirect(reflect.ValueOf(s))
 	// addIf adds filedType to ret if is not marked as `sql:"-"`
@@ -384,7 +385,10 @@ func getFields(s interface{}) (ret []reflect.StructField) {
 			case reflect.Struct:
 				// if it's anonymous, embed its fields in the query
 				if fieldType.Anonymous {
-					ret = append(ret, getFields(fieldValue.Interface())...)
+					for _, embedded := range getFields(fieldValue.Interface()) {
+						embedded.Index = append([]int{i}, embedded.Index...)
+						ret = append(ret, embedded)
+					}
 				} else { // use its name only (to work with structs like time.Time)
 					addIf(fieldType)
 				}
@@ -457,12 +461,6 @@ func (db *Database) buildSelect() string {
 		query.WriteString(strconv.Itoa(db.limit))
 	}
 
-	// Offset (optional)
-	if db.offset != 0 {
-		query.WriteString(" OFFSET ")
-		query.WriteString(strconv.Itoa(db.limit))
-	}
-
 	query.WriteString(";")
 	qs := query.String()
 	db.printLog(qs)
@@ -693,10 +691,11 @@ func (db *Database) clone() *Database {
 		selectFields:     db.selectFields,
 		order:            db.order,
 		limit:            db.limit,
-		offset:           db.offset,
 		varCount:         db.varCount,
 		connectionString: db.connectionString,
 		listener:         db.listener,
+		ctx:              db.ctx,
+		rawErr:           db.rawErr,
 	}
 
 	clone.tables = make([]string, len(db.tables))
@@ -26,6 +26,7 @@ import (
 )
 
 // Listen executes `LISTEN channel`. Uses f to handle received notifications on chanel.
+// f is called in the order the notifications are received, thus it must not block.
 // On error logs error messages (if a logs exists)
 func (db *Database) Listen(channel string, f func(payload ...string)) error {
 	// Create a new listener only if Listen is called for the first time
@@ -47,8 +48,15 @@ func (db *Database) Listen(channel string, f func(payload ...string)) error {
 		go func() {
 			for {
 				select {
-				case notification := <-db.listener.Notify:
-					go db.listenerCallbacks[notification.Channel](notification.Extra)
+				case notification, ok := <-db.listener.Notify:
+					if !ok {
+						// StopListening closed the listener
+						return
+					}
+					// nil is received when the connection has been re-established
+					if notification != nil {
+						db.listenerCallbacks[notification.Channel](notification.Extra)
+					}
 				case <-time.After(90 * time.Second):
 					go func() {
 						if db.listener.Ping() != nil {
@@ -74,21 +82,12 @@ func (db *Database) Listen(channel string, f func(payload ...string)) error {
 	return nil
 }
 
-// Unlisten executes `UNLISTEN channel`. Unregister function f, that was registered with Listen(channel ,f).
-func (db *Database) Unlisten(channel string) error {
+// StopListening closes the listener created by Listen, if any: the notifications are not received anymore
+func (db *Database) StopListening() error {
 	if db.listener == nil {
-		return errors.New("You must create a new listener first, calling Listen(channel)")
+		return nil
 	}
-
-	if channel == "*" {
-		return db.listener.UnlistenAll()
-	}
-	return db.listener.Unlisten(channel)
-}
-
-// UnlistenAll executes `UNLISTEN *`. Thus do not receive any notification from any channel
-func (db *Database) UnlistenAll() error {
-	return db.Unlisten("*")
+	return db.listener.Close()
 }
 
 // Notify sends a notification on channel, optional payloads are joined together and comma separated
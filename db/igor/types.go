@@ -17,6 +17,7 @@ limitations under the License.
 package igor
 
 import (
+	"context"
 	"database/sql"
 	"log"
 
@@ -32,6 +33,7 @@ type DBModel interface {
 // TxDB Interface to wrap methods common to *sql.Tx and *sql.DB
 type TxDB interface {
 	Prepare(query string) (*sql.Stmt, error)
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
 	Exec(query string, args ...interface{}) (sql.Result, error)
 	Query(query string, args ...interface{}) (*sql.Rows, error)
 	QueryRow(query string, args ...interface{}) *sql.Row
@@ -55,9 +57,10 @@ type Database struct {
 	whereFields        []string
 	order              string
 	limit              int
-	offset             int
 	varCount           int
 	connectionString   string
 	listener           *pq.Listener
 	listenerCallbacks  map[string]func(...string)
+	ctx                context.Context
+	rawErr             error
 }
@@ -1,8 +1,6 @@
 package db
 
 import (
-	"fmt"
-
 	"github.com/nerdzeu/nerdz-core/utils"
 )
 
@@ -27,5 +25,5 @@ func sanitiseLanguage(lang, defaultLang string) (string, error) {
 		return lang, nil
 	}
 
-	return "", fmt.Errorf("language '%s' is not a valid or supported language", lang)
+	return "", invalidArgument("language '%s' is not a valid or supported language", lang)
 }
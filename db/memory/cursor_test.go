@@ -0,0 +1,119 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nerdzeu/nerdz-core/db"
+)
+
+func TestCursors(t *testing.T) {
+	store, ctx := newStore()
+	me := newUser(t, store, ctx, "me")
+
+	project := db.Project{Name: "NERDZ", Visible: true}
+	if err := store.Storage().Create(ctx, &project); err != nil {
+		t.Fatalf("No error should happen when creating a project, but got: %s", err)
+	}
+	if err := store.Storage().Create(ctx, &db.ProjectOwner{From: me.ID(), To: project.Counter}); err != nil {
+		t.Fatalf("No error should happen when adding the owner of a project, but got: %s", err)
+	}
+
+	// The user and the project posts have the same hpids and are created at the same time
+	now := time.Now().UTC()
+	for i := 0; i < 3; i++ {
+		userPost := db.UserPost{Post: db.Post{From: me.ID(), To: me.ID(), Message: "Mine", Time: now}}
+		if err := store.Storage().Create(ctx, &userPost); err != nil {
+			t.Fatalf("No error should happen when creating a user post, but got: %s", err)
+		}
+		projectPost := db.ProjectPost{Post: db.Post{From: me.ID(), To: project.Counter, Message: "Ours", Time: now}}
+		if err := store.Storage().Create(ctx, &projectPost); err != nil {
+			t.Fatalf("No error should happen when creating a project post, but got: %s", err)
+		}
+	}
+
+	home := *me.Home(ctx, db.PostlistOptions{})
+	if len(home) != 6 {
+		t.Fatalf("The home should contain every post, but got %d posts", len(home))
+	}
+
+	// Page through the home, 2 posts at a time, passing back the tokens
+	var pages [][]db.Message
+	options := db.PostlistOptions{N: 2}
+	for {
+		page := *me.Home(ctx, options)
+		if len(page) == 0 {
+			break
+		}
+		pages = append(pages, page)
+
+		token, err := db.NewCursor(&page[len(page)-1]).Token(ctx)
+		if err != nil {
+			t.Fatalf("No error should happen when signing a token, but got: %s", err)
+		}
+		older, err := db.ParseCursor(ctx, token, db.UserPost{}.TableName(), db.ProjectPost{}.TableName())
+		if err != nil {
+			t.Fatalf("No error should happen when parsing a token, but got: %s", err)
+		}
+		options.Older = older
+	}
+
+	if len(pages) != 3 {
+		t.Fatalf("Expected 3 pages, but got %d", len(pages))
+	}
+	for i, page := range pages {
+		for j, post := range page {
+			if expected := home[2*i+j]; post.Type != expected.Type || post.Hpid != expected.Hpid {
+				t.Errorf("Page %d: expected post %d of type %d, but got post %d of type %d", i, expected.Hpid, expected.Type, post.Hpid, post.Type)
+			}
+		}
+	}
+
+	// The newer posts are the ones nearest to the cursor
+	prev := *me.Home(ctx, db.PostlistOptions{N: 2, Newer: db.NewCursor(&pages[2][0])})
+	if len(prev) != 2 || prev[0].Hpid != pages[1][0].Hpid || prev[0].Type != pages[1][0].Type {
+		t.Errorf("Expected the second page, but got: %+v", prev)
+	}
+
+	token, err := db.NewCursor(&home[0]).Token(ctx)
+	if err != nil {
+		t.Fatalf("No error should happen when signing a token, but got: %s", err)
+	}
+	if _, err := db.ParseCursor(ctx, token, db.PM{}.TableName()); !errors.Is(err, db.ErrInvalidArgument) {
+		t.Errorf("A cursor of another list should be rejected with ErrInvalidArgument, but got: %v", err)
+	}
+
+	tampered := strings.Replace(token, token[:4], "AAAA", 1)
+	if _, err := db.ParseCursor(ctx, tampered, db.UserPost{}.TableName(), db.ProjectPost{}.TableName()); !errors.Is(err, db.ErrInvalidArgument) {
+		t.Errorf("A tampered token should be rejected with ErrInvalidArgument, but got: %v", err)
+	}
+
+	_, otherCtx := newStore()
+	if _, err := db.ParseCursor(otherCtx, token, db.UserPost{}.TableName(), db.ProjectPost{}.TableName()); !errors.Is(err, db.ErrInvalidArgument) {
+		t.Errorf("A token signed by another store should be rejected with ErrInvalidArgument, but got: %v", err)
+	}
+
+	if token, err := db.NewCursor(&home[0]).Token(context.Background()); err != db.ErrNoStore || token != "" {
+		t.Errorf("Signing a token without a store should fail with ErrNoStore, but got: %q, %v", token, err)
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/nerdzeu/nerdz-core/db"
+)
+
+// broker delivers the events to the listeners of a storage
+type broker struct {
+	mu        sync.Mutex
+	listeners []func(db.Event)
+}
+
+// publish calls every listener with the events, in order
+func (b *broker) publish(events ...db.Event) {
+	b.mu.Lock()
+	listeners := b.listeners
+	b.mu.Unlock()
+
+	for _, event := range events {
+		for _, listener := range listeners {
+			listener(event)
+		}
+	}
+}
+
+// publish publishes the events or, if s is the copy used by a transaction, keeps them until it's committed.
+// The caller must hold s.mu
+func (s *storage) publish(events ...db.Event) {
+	if s.transaction {
+		s.pending = append(s.pending, events...)
+		return
+	}
+	s.broker.publish(events...)
+}
+
+func (s *storage) Publish(ctx context.Context, event db.Event) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.publish(event)
+	return nil
+}
+
+func (s *storage) Listen(f func(db.Event)) error {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	s.broker.listeners = append(s.broker.listeners, f)
+	return nil
+}
@@ -0,0 +1,184 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nerdzeu/nerdz-core/db"
+)
+
+func TestEvents(t *testing.T) {
+	store, ctx := newStore()
+	me := newUser(t, store, ctx, "me")
+	other := newUser(t, store, ctx, "other")
+	third := newUser(t, store, ctx, "third")
+
+	if err := me.Follow(ctx, other); err != nil {
+		t.Fatalf("No error should happen when following a user, but got: %s", err)
+	}
+	post := newPost(t, ctx, other, other, "Lurk me")
+	if _, err := me.Lurk(ctx, post); err != nil {
+		t.Fatalf("No error should happen when lurking a post, but got: %s", err)
+	}
+	if err := me.BlacklistUser(ctx, third, "spam"); err != nil {
+		t.Fatalf("No error should happen when blacklisting a user, but got: %s", err)
+	}
+
+	subscribed, cancel := context.WithCancel(ctx)
+	events, err := me.Events(subscribed)
+	if err != nil {
+		t.Fatalf("No error should happen when subscribing to the events, but got: %s", err)
+	}
+
+	// next returns the next event delivered: the events that must not be delivered
+	// are followed by one that must, so that they can be detected
+	next := func() db.Event {
+		select {
+		case event := <-events:
+			return event
+		case <-time.After(time.Second):
+			t.Fatalf("An event should have been delivered")
+		}
+		return db.Event{}
+	}
+
+	newPost(t, ctx, third, third, "Not followed")
+	newPost(t, ctx, me, other, "Mine")
+	followed := newPost(t, ctx, other, other, "Followed")
+	if event := next(); event.Type != db.EventPost || event.Hpid != followed.Hpid || event.From != other.ID() {
+		t.Errorf("The post on a followed board should be delivered, but got %+v", event)
+	}
+
+	blacklisted := db.UserPostComment{Hpid: post.Hpid, Message: "Blacklisted"}
+	if err = third.Submit(ctx, &blacklisted); err != nil {
+		t.Fatalf("No error should happen when commenting, but got: %s", err)
+	}
+	comment := db.UserPostComment{Hpid: post.Hpid, Message: "Lurked"}
+	if err = other.Submit(ctx, &comment); err != nil {
+		t.Fatalf("No error should happen when commenting, but got: %s", err)
+	}
+	if event := next(); event.Type != db.EventComment || event.Hcid != comment.Hcid || event.Hpid != post.Hpid {
+		t.Errorf("The comment of a lurked post should be delivered, but not the one of a blacklisted user: got %+v", event)
+	}
+
+	mine := newPost(t, ctx, me, me, "Vote me")
+	if _, err = other.Vote(ctx, mine, 1); err != nil {
+		t.Fatalf("No error should happen when voting, but got: %s", err)
+	}
+	if event := next(); event.Type != db.EventVote || event.Hpid != mine.Hpid || event.Vote != 1 || event.To != me.ID() {
+		t.Errorf("The vote of a post of the user should be delivered, but got %+v", event)
+	}
+
+	rolledBack := errors.New("rolled back")
+	if err = db.Transaction(ctx, func(ctx context.Context) error {
+		newPost(t, ctx, other, other, "Rolled back")
+		return rolledBack
+	}); err != rolledBack {
+		t.Fatalf("The transaction should fail with its error, but got: %v", err)
+	}
+	pm := db.PM{To: me.ID(), Message: "Hi!"}
+	if err = other.Submit(ctx, &pm); err != nil {
+		t.Fatalf("No error should happen when sending a pm, but got: %s", err)
+	}
+	if event := next(); event.Type != db.EventPm || event.Pmid != pm.Pmid {
+		t.Errorf("The events of a rolled back transaction should not be delivered, but got %+v", event)
+	}
+
+	if err = third.Follow(ctx, other); err != nil {
+		t.Fatalf("No error should happen when following a user, but got: %s", err)
+	}
+	if err = other.Follow(ctx, me); err != nil {
+		t.Fatalf("No error should happen when following a user, but got: %s", err)
+	}
+	if event := next(); event.Type != db.EventFollow || event.From != other.ID() || event.To != me.ID() {
+		t.Errorf("The new follower of the user should be delivered, but got %+v", event)
+	}
+
+	fourth := newUser(t, store, ctx, "fourth")
+	if err = me.Follow(ctx, fourth); err != nil {
+		t.Fatalf("No error should happen when following a user, but got: %s", err)
+	}
+	followed = newPost(t, ctx, fourth, fourth, "Followed later")
+	if event := next(); event.Type != db.EventPost || event.Hpid != followed.Hpid {
+		t.Errorf("The post on a board followed after the subscription should be delivered, but got %+v", event)
+	}
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestChat(t *testing.T) {
+	store, ctx := newStore()
+	me := newUser(t, store, ctx, "me")
+	other := newUser(t, store, ctx, "other")
+	third := newUser(t, store, ctx, "third")
+
+	if err := third.BlacklistUser(ctx, me, "spam"); err != nil {
+		t.Fatalf("No error should happen when blacklisting a user, but got: %s", err)
+	}
+
+	subscribed, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events, err := other.Events(subscribed)
+	if err != nil {
+		t.Fatalf("No error should happen when subscribing to the events, but got: %s", err)
+	}
+	next := func() db.Event {
+		select {
+		case event := <-events:
+			return event
+		case <-time.After(time.Second):
+			t.Fatalf("An event should have been delivered")
+		}
+		return db.Event{}
+	}
+
+	if err = me.Typing(ctx, third.ID()); !errors.Is(err, db.ErrPermissionDenied) {
+		t.Errorf("A blacklisted user should not be able to type to the user that blacklisted them, but got: %v", err)
+	}
+	if err = me.Typing(ctx, other.ID()); err != nil {
+		t.Fatalf("No error should happen when typing, but got: %s", err)
+	}
+	if event := next(); event.Type != db.EventTyping || event.From != me.ID() || event.To != other.ID() {
+		t.Errorf("The typing indicator should be delivered, but got %+v", event)
+	}
+
+	pm := db.PM{To: me.ID(), Message: "Hi!"}
+	if err = other.Submit(ctx, &pm); err != nil {
+		t.Fatalf("No error should happen when sending a pm, but got: %s", err)
+	}
+	if err = me.ReadPms(ctx, other.ID()); err != nil {
+		t.Fatalf("No error should happen when reading the pms, but got: %s", err)
+	}
+	if event := next(); event.Type != db.EventRead || event.From != me.ID() || event.To != other.ID() {
+		t.Errorf("The read receipt should be delivered, but got %+v", event)
+	}
+
+	conversations, err := me.Conversations(ctx)
+	if err != nil {
+		t.Fatalf("No error should happen when listing the conversations, but got: %s", err)
+	}
+	if len(*conversations) != 1 || (*conversations)[0].ToRead {
+		t.Errorf("The conversation should have been read, but got %+v", *conversations)
+	}
+}
@@ -0,0 +1,190 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package memory
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/nerdzeu/nerdz-core/db/igor"
+	"github.com/nerdzeu/nerdz-core/utils"
+)
+
+// field describes a column of a model
+type field struct {
+	column     string
+	index      []int
+	primaryKey bool
+	// def is the default value of the column, empty if the column has no default
+	def string
+}
+
+// fields returns the columns of the model of type t, flattening the embedded structs
+func fields(t reflect.Type) []field {
+	var columns []field
+	for i := 0; i < t.NumField(); i++ {
+		structField := t.Field(i)
+		if structField.PkgPath != "" || structField.Tag.Get("sql") == "-" {
+			continue
+		}
+
+		if structField.Anonymous && structField.Type.Kind() == reflect.Struct {
+			for _, embedded := range fields(structField.Type) {
+				embedded.index = append([]int{i}, embedded.index...)
+				columns = append(columns, embedded)
+			}
+			continue
+		}
+
+		tags := utils.ParseTag(structField.Tag.Get("igor"))
+		column := tags["COLUMN"]
+		if column == "" {
+			column = namingConvention(structField.Name)
+		}
+		_, primaryKey := tags["PRIMARY_KEY"]
+
+		var def string
+		if sql := structField.Tag.Get("sql"); strings.HasPrefix(sql, "default:") {
+			def = strings.TrimPrefix(sql, "default:")
+		}
+
+		columns = append(columns, field{
+			column:     column,
+			index:      structField.Index,
+			primaryKey: primaryKey,
+			def:        def,
+		})
+	}
+	return columns
+}
+
+// namingConvention returns the name of the column associated to the field name,
+// following the convention of igor
+func namingConvention(name string) string {
+	var buffer bytes.Buffer
+	buffer.WriteRune(rune(name[0]))
+	for i := 1; i < len(name); i++ {
+		if unicode.IsLower(rune(name[i-1])) && unicode.IsUpper(rune(name[i])) {
+			buffer.WriteByte('_')
+		}
+		buffer.WriteByte(name[i])
+	}
+	return strings.ToLower(buffer.String())
+}
+
+// column returns the field of row associated to column, if any
+func column(row reflect.Value, column string) (reflect.Value, bool) {
+	for _, f := range fields(row.Type()) {
+		if f.column == column {
+			return row.FieldByIndex(f.index), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// primaryKey returns the primary key of row, if any
+func primaryKey(row reflect.Value) (reflect.Value, bool) {
+	for _, f := range fields(row.Type()) {
+		if f.primaryKey {
+			return row.FieldByIndex(f.index), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// uintValue returns the value of field, if field is an uint64 or a valid sql.NullInt64 column
+func uintValue(field reflect.Value) (uint64, bool) {
+	switch value := field.Interface().(type) {
+	case uint64:
+		return value, true
+	case sql.NullInt64:
+		return uint64(value.Int64), value.Valid
+	}
+	return 0, false
+}
+
+// isZero returns true if value is the zero value of its type
+func isZero(value reflect.Value) bool {
+	return reflect.DeepEqual(value.Interface(), reflect.Zero(value.Type()).Interface())
+}
+
+// matches returns true if every non-zero field of description equals the same field of row
+func matches(row, description reflect.Value) bool {
+	for _, f := range fields(description.Type()) {
+		condition := description.FieldByIndex(f.index)
+		if isZero(condition) {
+			continue
+		}
+		value, ok := column(row, f.column)
+		if !ok || !reflect.DeepEqual(value.Interface(), condition.Interface()) {
+			return false
+		}
+	}
+	return true
+}
+
+// conditions returns true if description has at least a non-zero field
+func conditions(description reflect.Value) bool {
+	for _, f := range fields(description.Type()) {
+		if !isZero(description.FieldByIndex(f.index)) {
+			return true
+		}
+	}
+	return false
+}
+
+// setDefaults sets the default values of the zero fields of row,
+// as the NERDZ database does
+func setDefaults(row reflect.Value) {
+	now := reflect.ValueOf(time.Now().UTC())
+	for _, f := range fields(row.Type()) {
+		value := row.FieldByIndex(f.index)
+		if f.def == "" || !isZero(value) {
+			continue
+		}
+		switch value.Interface().(type) {
+		case time.Time:
+			value.Set(now)
+		case bool:
+			value.SetBool(f.def == "true")
+		case igor.JSON:
+			value.Set(reflect.MakeMap(value.Type()))
+		}
+	}
+}
+
+// indirect returns the struct pointed by model
+func indirect(model interface{}) (reflect.Value, error) {
+	value := reflect.Indirect(reflect.ValueOf(model))
+	if value.Kind() != reflect.Struct {
+		return reflect.Value{}, errors.New("the model must be a struct or a pointer to a struct")
+	}
+	return value, nil
+}
+
+// clone returns an addressable copy of row
+func clone(row reflect.Value) reflect.Value {
+	value := reflect.New(row.Type()).Elem()
+	value.Set(row)
+	return value
+}
@@ -0,0 +1,508 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package memory implements a db.Storage that keeps the NERDZ data in memory.
+//
+// The storage enforces the rules of the NERDZ database that the db package relies on
+// (blacklists, whitelists, closed boards and posts, unique relations, votes, lurks...),
+// so that the db package and the services built on it can be tested without PostgreSQL:
+//
+//	store := db.NewStore(memory.New())
+//	ctx := store.Context(context.Background())
+//
+// Passwords are stored and compared as they are.
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/nerdzeu/nerdz-core/db"
+	"github.com/nerdzeu/nerdz-core/db/igor"
+)
+
+// storage is the in-memory db.Storage
+type storage struct {
+	mu sync.RWMutex
+	// tables maps the name of a table to its rows, in insertion order
+	tables map[string][]reflect.Value
+	// keys maps the name of a table to the last primary key assigned
+	keys map[string]uint64
+
+	// broker delivers the events published on the storage and on its transactions
+	broker *broker
+	// transaction is true if the storage is the copy used by a transaction,
+	// whose events are pending until it's committed
+	transaction bool
+	pending     []db.Event
+}
+
+// New returns an empty db.Storage that keeps the data in memory. It's safe for concurrent use
+func New() db.Storage {
+	return &storage{
+		tables: make(map[string][]reflect.Value),
+		keys:   make(map[string]uint64),
+		broker: new(broker),
+	}
+}
+
+// where returns the rows of the table of description that match description
+func (s *storage) where(description igor.DBModel) []reflect.Value {
+	condition, err := indirect(description)
+	if err != nil {
+		return nil
+	}
+
+	var rows []reflect.Value
+	for _, row := range s.tables[description.TableName()] {
+		if matches(row, condition) {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// exists returns true if at least a row matches description
+func (s *storage) exists(description igor.DBModel) bool {
+	return len(s.where(description)) > 0
+}
+
+// first loads into dest, a pointer to a model, the first row that matches description.
+// It returns false if there are no rows
+func (s *storage) first(description igor.DBModel, dest interface{}) bool {
+	rows := s.where(description)
+	if len(rows) == 0 {
+		return false
+	}
+	reflect.ValueOf(dest).Elem().Set(rows[0])
+	return true
+}
+
+// remove removes the rows of table that match description and returns them
+func (s *storage) remove(description igor.DBModel) []reflect.Value {
+	condition, err := indirect(description)
+	if err != nil {
+		return nil
+	}
+
+	table := description.TableName()
+	var kept, removed []reflect.Value
+	for _, row := range s.tables[table] {
+		if matches(row, condition) {
+			removed = append(removed, row)
+		} else {
+			kept = append(kept, row)
+		}
+	}
+	s.tables[table] = kept
+	return removed
+}
+
+func (s *storage) Create(ctx context.Context, model igor.DBModel) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if reflect.ValueOf(model).Kind() != reflect.Ptr {
+		return errors.New("the model must be a pointer to a struct")
+	}
+	row, err := indirect(model)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	setDefaults(row)
+	if err = s.beforeCreate(model); err != nil {
+		return err
+	}
+	if err = s.unique(model); err != nil {
+		return err
+	}
+
+	table := model.TableName()
+	if key, ok := primaryKey(row); ok {
+		if key.Kind() == reflect.Uint64 {
+			if key.Uint() == 0 {
+				key.SetUint(s.keys[table] + 1)
+			}
+			if key.Uint() > s.keys[table] {
+				s.keys[table] = key.Uint()
+			}
+		}
+		for _, other := range s.tables[table] {
+			if otherKey, _ := primaryKey(other); reflect.DeepEqual(otherKey.Interface(), key.Interface()) {
+				return db.NewError(db.ErrConflict, "duplicate key value violates the primary key of %s", table)
+			}
+		}
+	}
+
+	s.tables[table] = append(s.tables[table], clone(row))
+	s.afterCreate(model)
+	return nil
+}
+
+func (s *storage) Updates(ctx context.Context, model igor.DBModel) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	changes, err := indirect(model)
+	if err != nil {
+		return err
+	}
+	key, ok := primaryKey(changes)
+	if !ok || isZero(key) {
+		return db.NewError(db.ErrInvalidArgument, "the primary key of the model is required to update it")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, row := range s.tables[model.TableName()] {
+		if rowKey, _ := primaryKey(row); !reflect.DeepEqual(rowKey.Interface(), key.Interface()) {
+			continue
+		}
+
+		if err = s.beforeUpdate(row, changes); err != nil {
+			return err
+		}
+		for _, f := range fields(changes.Type()) {
+			if value := changes.FieldByIndex(f.index); !f.primaryKey && !isZero(value) {
+				row.FieldByIndex(f.index).Set(value)
+			}
+		}
+		if changes.CanSet() {
+			changes.Set(row)
+		}
+	}
+	return nil
+}
+
+func (s *storage) Delete(ctx context.Context, description igor.DBModel) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	condition, err := indirect(description)
+	if err != nil {
+		return err
+	}
+	if !conditions(condition) {
+		return db.NewError(db.ErrInvalidArgument, "at least a condition is required to delete")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, row := range s.remove(description) {
+		s.afterDelete(row)
+	}
+	return nil
+}
+
+func (s *storage) DeleteIn(ctx context.Context, model igor.DBModel, name string, values []uint64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	in := make(map[uint64]bool, len(values))
+	for _, value := range values {
+		in[value] = true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	table := model.TableName()
+	var kept, removed []reflect.Value
+	for _, row := range s.tables[table] {
+		field, ok := column(row, name)
+		if !ok {
+			return fmt.Errorf("column %s does not exist in %s", name, table)
+		}
+		if value, ok := uintValue(field); ok && in[value] {
+			removed = append(removed, row)
+		} else {
+			kept = append(kept, row)
+		}
+	}
+	s.tables[table] = kept
+	for _, row := range removed {
+		s.afterDelete(row)
+	}
+	return nil
+}
+
+func (s *storage) Notified(ctx context.Context, model igor.DBModel, name string, values []uint64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	in := make(map[uint64]bool, len(values))
+	for _, value := range values {
+		in[value] = true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, row := range s.tables[model.TableName()] {
+		field, ok := column(row, name)
+		if !ok {
+			return fmt.Errorf("column %s does not exist in %s", name, model.TableName())
+		}
+		toNotify, ok := column(row, "to_notify")
+		if !ok {
+			return fmt.Errorf("column to_notify does not exist in %s", model.TableName())
+		}
+		if value, ok := uintValue(field); ok && in[value] {
+			toNotify.SetBool(false)
+		}
+	}
+	return nil
+}
+
+func (s *storage) Find(ctx context.Context, description igor.DBModel, dest interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return load(s.where(description), dest)
+}
+
+func (s *storage) FindIn(ctx context.Context, model igor.DBModel, name string, values []uint64, dest interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if reflect.Indirect(reflect.ValueOf(dest)).Kind() != reflect.Slice {
+		return errors.New("dest must be a pointer to a slice")
+	}
+
+	in := make(map[uint64]bool, len(values))
+	for _, value := range values {
+		in[value] = true
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var rows []reflect.Value
+	for _, row := range s.tables[model.TableName()] {
+		field, ok := column(row, name)
+		if !ok {
+			return fmt.Errorf("column %s does not exist in %s", name, model.TableName())
+		}
+		if value, ok := uintValue(field); ok && in[value] {
+			rows = append(rows, row)
+		}
+	}
+	return load(rows, dest)
+}
+
+func (s *storage) UpdateIn(ctx context.Context, model igor.DBModel, name string, values []uint64, changes map[string]interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	in := make(map[uint64]bool, len(values))
+	for _, value := range values {
+		in[value] = true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	table := model.TableName()
+	for _, row := range s.tables[table] {
+		field, ok := column(row, name)
+		if !ok {
+			return fmt.Errorf("column %s does not exist in %s", name, table)
+		}
+		if value, ok := uintValue(field); !ok || !in[value] {
+			continue
+		}
+
+		for changed, value := range changes {
+			field, ok := column(row, changed)
+			if !ok {
+				return fmt.Errorf("column %s does not exist in %s", changed, table)
+			}
+			if value == nil {
+				field.Set(reflect.Zero(field.Type()))
+				continue
+			}
+			if !reflect.TypeOf(value).ConvertibleTo(field.Type()) {
+				return fmt.Errorf("unable to set %T into %s", value, field.Type())
+			}
+			field.Set(reflect.ValueOf(value).Convert(field.Type()))
+		}
+	}
+	return nil
+}
+
+// LockIn does nothing but checking ctx: the transactions of the storage are already serialized
+func (s *storage) LockIn(ctx context.Context, model igor.DBModel, column string, values []uint64) error {
+	return ctx.Err()
+}
+
+// Transaction executes f on a copy of the storage, that replaces the storage if f succeeds.
+// The storage is locked until f returns: the transactions are serialized
+func (s *storage) Transaction(ctx context.Context, f func(db.Storage) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx := s.copy()
+	if err := f(tx); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.tables, s.keys = tx.tables, tx.keys
+	s.publish(tx.pending...)
+	return nil
+}
+
+// copy returns a copy of the storage, whose rows can be changed without affecting s
+func (s *storage) copy() *storage {
+	tx := &storage{
+		tables:      make(map[string][]reflect.Value, len(s.tables)),
+		keys:        make(map[string]uint64, len(s.keys)),
+		broker:      s.broker,
+		transaction: true,
+	}
+	for table, rows := range s.tables {
+		copied := make([]reflect.Value, len(rows))
+		for i, row := range rows {
+			copied[i] = clone(row)
+		}
+		tx.tables[table] = copied
+	}
+	for table, key := range s.keys {
+		tx.keys[table] = key
+	}
+	return tx
+}
+
+// load loads rows into dest, a pointer to a slice of models or to a model
+func load(rows []reflect.Value, dest interface{}) error {
+	value := reflect.ValueOf(dest)
+	if value.Kind() != reflect.Ptr {
+		return errors.New("dest must be a pointer")
+	}
+	value = value.Elem()
+
+	switch value.Kind() {
+	case reflect.Slice:
+		elements := reflect.MakeSlice(value.Type(), 0, len(rows))
+		for _, row := range rows {
+			if !row.Type().AssignableTo(value.Type().Elem()) {
+				return fmt.Errorf("unable to load %s into %s", row.Type(), value.Type().Elem())
+			}
+			elements = reflect.Append(elements, row)
+		}
+		if len(rows) > 0 {
+			value.Set(elements)
+		}
+	case reflect.Struct:
+		if len(rows) > 0 {
+			if !rows[0].Type().AssignableTo(value.Type()) {
+				return fmt.Errorf("unable to load %s into %s", rows[0].Type(), value.Type())
+			}
+			value.Set(rows[0])
+		}
+	default:
+		return errors.New("dest must be a pointer to a slice or to a struct")
+	}
+	return nil
+}
+
+func (s *storage) Pluck(ctx context.Context, description igor.DBModel, name string, dest interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	value := reflect.ValueOf(dest)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Slice {
+		return errors.New("dest must be a pointer to a slice")
+	}
+	value = value.Elem()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	elements := value
+	for _, row := range s.where(description) {
+		field, ok := column(row, name)
+		if !ok {
+			return fmt.Errorf("column %s does not exist in %s", name, description.TableName())
+		}
+		if !field.Type().ConvertibleTo(value.Type().Elem()) {
+			return fmt.Errorf("unable to load %s into %s", field.Type(), value.Type().Elem())
+		}
+		elements = reflect.Append(elements, field.Convert(value.Type().Elem()))
+	}
+	value.Set(elements)
+	return nil
+}
+
+func (s *storage) Count(ctx context.Context, description igor.DBModel) (uint64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return uint64(len(s.where(description))), nil
+}
+
+func (s *storage) Sum(ctx context.Context, description igor.DBModel, name string) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var sum int64
+	for _, row := range s.where(description) {
+		field, ok := column(row, name)
+		if !ok {
+			return 0, fmt.Errorf("column %s does not exist in %s", name, description.TableName())
+		}
+		switch field.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			sum += field.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			sum += int64(field.Uint())
+		default:
+			return 0, fmt.Errorf("unable to sum the %s column %s", field.Type(), name)
+		}
+	}
+	return sum, nil
+}
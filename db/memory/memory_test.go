@@ -0,0 +1,621 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/RangelReale/osin"
+	"github.com/nerdzeu/nerdz-core/db"
+	"github.com/nerdzeu/nerdz-core/db/memory"
+)
+
+// newStore returns a store backed by an empty in-memory storage, and its context
+func newStore() (*db.Store, context.Context) {
+	store := db.NewStore(memory.New())
+	return store, store.Context(context.Background())
+}
+
+// newUser creates the user username, with password "password"
+func newUser(t *testing.T, store *db.Store, ctx context.Context, username string) *db.User {
+	user := db.User{
+		Username:  username,
+		Password:  "password",
+		Email:     username + "@nerdz.eu",
+		Lang:      "en",
+		BoardLang: "en",
+	}
+	if err := store.Storage().Create(ctx, &user); err != nil {
+		t.Fatalf("No error should happen when creating a user, but got: %s", err)
+	}
+
+	created, err := store.NewUser(ctx, user.Counter)
+	if err != nil {
+		t.Fatalf("No error should happen when loading an existing user, but got: %s", err)
+	}
+	return created
+}
+
+// newPost submits a post of from on the board of to
+func newPost(t *testing.T, ctx context.Context, from, to *db.User, message string) *db.UserPost {
+	post := db.UserPost{}
+	post.To = to.ID()
+	post.Message = message
+	if err := from.Submit(ctx, &post); err != nil {
+		t.Fatalf("No error should happen when submitting a post, but got: %s", err)
+	}
+	return &post
+}
+
+func TestLogin(t *testing.T) {
+	store, ctx := newStore()
+	admin := newUser(t, store, ctx, "admin")
+
+	for _, login := range []string{"1", "admin@nerdz.eu", "admin", "ADMIN"} {
+		user, err := db.Login(ctx, login, "password")
+		if err != nil {
+			t.Fatalf("Login using %s and password should work, but got: %s", login, err)
+		}
+		if user.ID() != admin.ID() {
+			t.Errorf("Login using %s should return the user %d, but got %d", login, admin.ID(), user.ID())
+		}
+	}
+
+	if _, err := db.Login(ctx, "admin", "wrong"); err == nil {
+		t.Errorf("Login using a wrong password should fail")
+	}
+
+	if _, err := db.Login(ctx, "BANANA", "password"); err == nil {
+		t.Errorf("Login using a wrong username should fail")
+	}
+}
+
+func TestUnique(t *testing.T) {
+	store, ctx := newStore()
+	newUser(t, store, ctx, "admin")
+
+	if err := store.Storage().Create(ctx, &db.User{Username: "Admin"}); err == nil {
+		t.Errorf("Creating a user with a username already in use should fail")
+	}
+
+	me := newUser(t, store, ctx, "me")
+	other := newUser(t, store, ctx, "other")
+	if err := me.Follow(ctx, other); err != nil {
+		t.Fatalf("No error should happen when following a user, but got: %s", err)
+	}
+	if err := me.Follow(ctx, other); err == nil {
+		t.Errorf("Following twice the same user should fail")
+	}
+	if err := me.Follow(ctx, me); err == nil {
+		t.Errorf("Following yourself should fail")
+	}
+}
+
+func TestBlacklist(t *testing.T) {
+	store, ctx := newStore()
+	me := newUser(t, store, ctx, "me")
+	other := newUser(t, store, ctx, "other")
+
+	newPost(t, ctx, other, other, "Hi!")
+	if err := me.Follow(ctx, other); err != nil {
+		t.Fatalf("No error should happen when following a user, but got: %s", err)
+	}
+
+	if err := me.BlacklistUser(ctx, other, "spammer"); err != nil {
+		t.Fatalf("No error should happen when blacklisting a user, but got: %s", err)
+	}
+
+	if following := me.NumericUserFollowing(ctx); len(following) != 0 {
+		t.Errorf("Blacklisting a user should stop following them, but still following: %v", following)
+	}
+
+	post := db.UserPost{}
+	post.To = me.ID()
+	post.Message = "Hey, it's me"
+	if err := other.Submit(ctx, &post); err == nil {
+		t.Errorf("A blacklisted user should not be able to write on the board of the user that blacklisted them")
+	}
+
+	if err := other.Follow(ctx, me); err == nil {
+		t.Errorf("A blacklisted user should not be able to follow the user that blacklisted them")
+	}
+
+	if home := me.Home(ctx, db.PostlistOptions{}); len(*home) != 0 {
+		t.Errorf("The home should not contain the posts of the blacklisted users, but got: %+v", *home)
+	}
+
+	if err := me.UnblacklistUser(ctx, other); err != nil {
+		t.Fatalf("No error should happen when removing a user from the blacklist, but got: %s", err)
+	}
+
+	if home := me.Home(ctx, db.PostlistOptions{}); len(*home) != 1 {
+		t.Errorf("The home should contain the post of the user removed from the blacklist, but got: %+v", *home)
+	}
+}
+
+func TestClosedProfile(t *testing.T) {
+	store, ctx := newStore()
+	me := newUser(t, store, ctx, "me")
+	other := newUser(t, store, ctx, "other")
+
+	if err := store.Storage().Updates(ctx, &db.Profile{Counter: me.ID(), Closed: true}); err != nil {
+		t.Fatalf("No error should happen when closing a profile, but got: %s", err)
+	}
+
+	post := db.UserPost{}
+	post.To = me.ID()
+	post.Message = "Can I?"
+	if err := other.Submit(ctx, &post); err == nil {
+		t.Errorf("Writing on a closed board should fail")
+	}
+
+	if err := me.WhitelistUser(ctx, other); err != nil {
+		t.Fatalf("No error should happen when whitelisting a user, but got: %s", err)
+	}
+
+	post = db.UserPost{}
+	post.To = me.ID()
+	post.Message = "Now I can"
+	if err := other.Submit(ctx, &post); err != nil {
+		t.Errorf("A whitelisted user should be able to write on a closed board, but got: %s", err)
+	}
+}
+
+func TestVotes(t *testing.T) {
+	store, ctx := newStore()
+	me := newUser(t, store, ctx, "me")
+	other := newUser(t, store, ctx, "other")
+	post := newPost(t, ctx, me, me, "Vote me")
+
+	for _, vote := range []int8{1, -1} {
+		if _, err := other.Vote(ctx, post, vote); err != nil {
+			t.Fatalf("No error should happen when voting a post, but got: %s", err)
+		}
+		if count := post.VotesCount(ctx); count != int(vote) {
+			t.Errorf("The vote should replace the previous one: expected %d, got %d", vote, count)
+		}
+	}
+
+	third := newUser(t, store, ctx, "third")
+	if _, err := third.Vote(ctx, post, -1); err != nil {
+		t.Fatalf("No error should happen when voting a post, but got: %s", err)
+	}
+	if count := post.VotesCount(ctx); count != -2 {
+		t.Errorf("The votes should be summed: expected -2, got %d", count)
+	}
+
+	if _, err := other.Vote(ctx, post, 0); err != nil {
+		t.Fatalf("No error should happen when removing a vote, but got: %s", err)
+	}
+	if votes := post.Votes(ctx); len(*votes) != 1 || post.VotesCount(ctx) != -1 {
+		t.Errorf("The post should have only the vote of the third user, but got: %+v", *votes)
+	}
+}
+
+func TestBookmarksAndLurks(t *testing.T) {
+	store, ctx := newStore()
+	me := newUser(t, store, ctx, "me")
+	other := newUser(t, store, ctx, "other")
+	post := newPost(t, ctx, me, me, "Something interesting")
+
+	if _, err := other.Bookmark(ctx, post); err != nil {
+		t.Fatalf("No error should happen when bookmarking a post, but got: %s", err)
+	}
+	if _, err := other.Bookmark(ctx, post); err == nil {
+		t.Errorf("Bookmarking twice the same post should fail")
+	}
+	if count := post.BookmarksCount(ctx); count != 1 {
+		t.Errorf("The post should have a bookmark, but got %d", count)
+	}
+
+	if _, err := other.Lurk(ctx, post); err != nil {
+		t.Fatalf("No error should happen when lurking a post, but got: %s", err)
+	}
+
+	comment := db.UserPostComment{Hpid: post.ID(), Message: "I was lurking"}
+	if err := other.Submit(ctx, &comment); err != nil {
+		t.Fatalf("No error should happen when commenting a post, but got: %s", err)
+	}
+	if count := post.LurkersCount(ctx); count != 0 {
+		t.Errorf("Commenting a post should stop lurking it, but the post has %d lurkers", count)
+	}
+	if _, err := other.Lurk(ctx, post); err == nil {
+		t.Errorf("Lurking a commented post should fail")
+	}
+
+	if _, err := other.Lurk(ctx, &db.UserPost{Post: db.Post{Hpid: 100}}); err == nil {
+		t.Errorf("Lurking a post that does not exist should fail")
+	}
+}
+
+func TestEditAndDelete(t *testing.T) {
+	store, ctx := newStore()
+	me := newUser(t, store, ctx, "me")
+	post := newPost(t, ctx, me, me, "Frist")
+
+	comment := db.UserPostComment{Hpid: post.ID(), Message: "Typo"}
+	if err := me.Submit(ctx, &comment); err != nil {
+		t.Fatalf("No error should happen when commenting a post, but got: %s", err)
+	}
+
+	post.Message = "First"
+	if err := me.Edit(ctx, post); err != nil {
+		t.Fatalf("No error should happen when editing a post, but got: %s", err)
+	}
+	if revisions := post.Revisions(ctx); len(revisions) != 1 || revisions[0] != "Frist" {
+		t.Errorf("Editing a post should store the previous message as a revision, but got: %v", revisions)
+	}
+
+	if err := me.Delete(ctx, post); err != nil {
+		t.Fatalf("No error should happen when deleting a post, but got: %s", err)
+	}
+	if count, _ := store.Storage().Count(ctx, &db.UserPostComment{Hpid: post.ID()}); count != 0 {
+		t.Errorf("Deleting a post should delete its comments, but %d comments remain", count)
+	}
+}
+
+func TestPostlist(t *testing.T) {
+	store, ctx := newStore()
+	me := newUser(t, store, ctx, "me")
+	other := newUser(t, store, ctx, "other")
+	stranger := newUser(t, store, ctx, "stranger")
+
+	for i := 0; i < 3; i++ {
+		newPost(t, ctx, me, me, "Mine")
+		newPost(t, ctx, other, other, "Other")
+		newPost(t, ctx, stranger, stranger, "Stranger")
+	}
+
+	if err := me.Follow(ctx, other); err != nil {
+		t.Fatalf("No error should happen when following a user, but got: %s", err)
+	}
+
+	home := *me.UserHome(ctx, db.PostlistOptions{Following: true})
+	if len(home) != 6 {
+		t.Fatalf("The home should contain the posts of the user and of the following, but got %d posts", len(home))
+	}
+	for i, post := range home {
+		if post.From == stranger.ID() {
+			t.Errorf("The home should not contain the posts of who the user does not follow")
+		}
+		if i > 0 && post.Hpid > home[i-1].Hpid {
+			t.Errorf("The posts should be sorted from the newest")
+		}
+	}
+
+	older := *me.UserHome(ctx, db.PostlistOptions{N: 2, Older: db.NewCursor(&home[0])})
+	if len(older) != 2 || older[0].Hpid >= home[0].Hpid {
+		t.Errorf("Expected 2 posts older than %d, got: %+v", home[0].Hpid, older)
+	}
+
+	postlist := *other.Postlist(ctx, db.PostlistOptions{})
+	if len(postlist) != 3 {
+		t.Errorf("The postlist of the user should contain the posts on the board of the user, but got %d posts", len(postlist))
+	}
+}
+
+func TestPms(t *testing.T) {
+	store, ctx := newStore()
+	me := newUser(t, store, ctx, "me")
+	other := newUser(t, store, ctx, "other")
+
+	for _, message := range []string{"Hi", "How are you?"} {
+		pm := db.PM{To: other.ID(), Message: message}
+		if err := me.Submit(ctx, &pm); err != nil {
+			t.Fatalf("No error should happen when sending a pm, but got: %s", err)
+		}
+	}
+
+	pms, err := other.Pms(ctx, me.ID(), db.PmsOptions{})
+	if err != nil {
+		t.Fatalf("No error should happen when loading the pms, but got: %s", err)
+	}
+	if len(*pms) != 2 || (*pms)[0].Message != "How are you?" {
+		t.Errorf("Expected 2 pms, newest first, but got: %+v", *pms)
+	}
+
+	conversations, err := other.Conversations(ctx)
+	if err != nil {
+		t.Fatalf("No error should happen when loading the conversations, but got: %s", err)
+	}
+	if len(*conversations) != 1 || !(*conversations)[0].ToRead || (*conversations)[0].LastMessage != "How are you?" {
+		t.Errorf("Expected an unread conversation with the last message, but got: %+v", *conversations)
+	}
+
+	if err = other.DeleteConversation(ctx, me.ID()); err != nil {
+		t.Fatalf("No error should happen when deleting a conversation, but got: %s", err)
+	}
+	if conversations, _ = me.Conversations(ctx); len(*conversations) != 0 {
+		t.Errorf("The conversation should be deleted, but got: %+v", *conversations)
+	}
+}
+
+func TestLoadUsers(t *testing.T) {
+	store, ctx := newStore()
+	me := newUser(t, store, ctx, "me")
+	other := newUser(t, store, ctx, "other")
+
+	if err := store.Storage().Updates(ctx, &db.Profile{Counter: other.ID(), Closed: true}); err != nil {
+		t.Fatalf("No error should happen when closing a profile, but got: %s", err)
+	}
+
+	users, missing, err := store.LoadUsers(ctx, []uint64{other.ID(), 42, me.ID(), other.ID(), 42})
+	if err != nil {
+		t.Fatalf("No error should happen when loading the users, but got: %s", err)
+	}
+	if len(users) != 3 || users[0].ID() != other.ID() || users[1].ID() != me.ID() || users[2].ID() != other.ID() {
+		t.Fatalf("The users should be returned in the order of the ids, but got: %+v", users)
+	}
+	if !users[0].Profile.Closed {
+		t.Errorf("The users should be loaded with their profile")
+	}
+	if len(missing) != 1 || missing[0] != 42 {
+		t.Errorf("The missing ids should be reported once, but got: %v", missing)
+	}
+
+	project := db.Project{Name: "NERDZ", Visible: true}
+	if err = store.Storage().Create(ctx, &project); err != nil {
+		t.Fatalf("No error should happen when creating a project, but got: %s", err)
+	}
+	if err = store.Storage().Create(ctx, &db.ProjectOwner{From: me.ID(), To: project.Counter}); err != nil {
+		t.Fatalf("No error should happen when setting the owner of a project, but got: %s", err)
+	}
+
+	projects, missing, err := store.LoadProjects(ctx, []uint64{project.Counter, 42})
+	if err != nil || len(projects) != 1 || len(missing) != 1 {
+		t.Fatalf("Expected a project and a missing id, but got: %+v, %v, %v", projects, missing, err)
+	}
+
+	infos, err := db.Infos(ctx, projects)
+	if err != nil || len(infos) != 1 || infos[0].Owner == nil || infos[0].Owner.ID != me.ID() {
+		t.Errorf("The info of the project should contain the info of its owner, but got: %+v, %v", infos, err)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, _, err = store.LoadUsers(cancelled, []uint64{me.ID()}); err == nil {
+		t.Errorf("LoadUsers should return the error of the storage")
+	}
+	if _, err = db.Users(cancelled, []uint64{me.ID()}); err == nil {
+		t.Errorf("Users should return the error of the storage")
+	}
+	if _, err = db.Infos(cancelled, projects); err == nil {
+		t.Errorf("Infos should return the error of the storage")
+	}
+	if _, err = other.Followers(cancelled); err == nil {
+		t.Errorf("Followers should return the error of the storage")
+	}
+}
+
+func TestErrors(t *testing.T) {
+	store, ctx := newStore()
+	me := newUser(t, store, ctx, "me")
+	other := newUser(t, store, ctx, "other")
+	post := newPost(t, ctx, me, me, "Mine")
+
+	if _, err := store.NewUser(ctx, 42); !errors.Is(err, db.ErrNotFound) {
+		t.Errorf("Loading a missing user should fail with ErrNotFound, but got: %v", err)
+	}
+	if _, err := store.NewUser(ctx, 0); !errors.Is(err, db.ErrNotFound) {
+		t.Errorf("Loading the user with id 0 should fail with ErrNotFound, but got: %v", err)
+	}
+	if _, err := db.NewOAuth2Client(ctx, 0); !errors.Is(err, db.ErrNotFound) {
+		t.Errorf("Loading the client with id 0 should fail with ErrNotFound, but got: %v", err)
+	}
+	if _, err := store.NewUserWhere(ctx, &db.User{}); !errors.Is(err, db.ErrInvalidArgument) {
+		t.Errorf("Loading a user with an empty description should fail with ErrInvalidArgument, but got: %v", err)
+	}
+
+	if _, err := store.Login(ctx, "me", "wrong"); !errors.Is(err, db.ErrAuthFailed) {
+		t.Errorf("Login with a wrong password should fail with ErrAuthFailed, but got: %v", err)
+	}
+
+	if err := other.Delete(ctx, post); !errors.Is(err, db.ErrPermissionDenied) {
+		t.Errorf("Deleting the post of another user should fail with ErrPermissionDenied, but got: %v", err)
+	}
+
+	if err := other.Follow(ctx, me); err != nil {
+		t.Fatalf("No error should happen when following a user, but got: %s", err)
+	}
+	err := other.Follow(ctx, me)
+	if !errors.Is(err, db.ErrConflict) {
+		t.Errorf("Following twice the same user should fail with ErrConflict, but got: %v", err)
+	}
+
+	var dbErr *db.Error
+	if !errors.As(err, &dbErr) || dbErr.Kind != db.ErrConflict {
+		t.Errorf("The errors should be of type *db.Error, but got: %T", err)
+	}
+
+	if _, err = other.Lurk(ctx, &db.UserPost{Post: db.Post{Hpid: 42}}); !errors.Is(err, db.ErrNotFound) {
+		t.Errorf("Lurking a missing post should fail with ErrNotFound, but got: %v", err)
+	}
+
+	if _, err = db.NewUser(context.Background(), me.ID()); !errors.Is(err, db.ErrNoStore) {
+		t.Errorf("Using a context without a Store should fail with ErrNoStore, but got: %v", err)
+	}
+}
+
+func TestOAuth2(t *testing.T) {
+	store, ctx := newStore()
+	me := newUser(t, store, ctx, "me")
+	storage := store.OAuth2Storage()
+
+	client, err := storage.CreateClient(&osin.DefaultClient{Secret: "secret", RedirectUri: "https://example.com/callback", UserData: me.ID()}, "client")
+	if err != nil {
+		t.Fatalf("No error should happen when creating a client, but got: %s", err)
+	}
+	if _, err = storage.CreateClient(&osin.DefaultClient{Secret: "other", RedirectUri: "https://example.com/callback", UserData: me.ID()}, "client"); !errors.Is(err, db.ErrConflict) {
+		t.Errorf("Creating a client with a name already in use should fail with ErrConflict, but got: %v", err)
+	}
+
+	first := &osin.AccessData{
+		Client:       client,
+		AccessToken:  "access",
+		RefreshToken: "refresh",
+		ExpiresIn:    3600,
+		Scope:        db.ScopePostsRead,
+		CreatedAt:    time.Now().UTC(),
+		UserData:     me.ID()}
+	if err = storage.SaveAccess(first); err != nil {
+		t.Fatalf("No error should happen when saving the access data, but got: %s", err)
+	}
+
+	refreshed, err := storage.LoadRefresh("refresh")
+	if err != nil || refreshed.AccessToken != "access" || refreshed.UserData.(uint64) != me.ID() {
+		t.Fatalf("LoadRefresh should return the access data of the refresh token, but got: %+v, %v", refreshed, err)
+	}
+
+	second := *first
+	second.AccessToken, second.RefreshToken, second.AccessData = "access2", "refresh2", refreshed
+	if err = storage.SaveAccess(&second); err != nil {
+		t.Fatalf("No error should happen when rotating the refresh token, but got: %s", err)
+	}
+
+	if _, err = storage.LoadRefresh("refresh"); !errors.Is(err, db.ErrPermissionDenied) {
+		t.Errorf("Reusing a rotated refresh token should fail with ErrPermissionDenied, but got: %v", err)
+	}
+	for _, token := range []string{"access", "access2"} {
+		if _, err = storage.LoadAccess(token); !errors.Is(err, db.ErrNotFound) {
+			t.Errorf("The reuse of a refresh token should revoke its token family, but %s has been loaded: %v", token, err)
+		}
+	}
+
+	expired := *first
+	expired.AccessToken, expired.RefreshToken, expired.CreatedAt = "expired", "", time.Now().UTC().Add(-2*time.Hour)
+	if err = storage.SaveAccess(&expired); err != nil {
+		t.Fatalf("No error should happen when saving the access data, but got: %s", err)
+	}
+	if removed, err := storage.RemoveExpiredAccess(10); err != nil || removed != 1 {
+		t.Errorf("RemoveExpiredAccess should remove the expired access data, but removed %d: %v", removed, err)
+	}
+
+	if err = storage.RemoveClient(client.ID); err != nil {
+		t.Errorf("No error should happen when removing the client, but got: %s", err)
+	}
+}
+
+func TestTags(t *testing.T) {
+	store, ctx := newStore()
+	me := newUser(t, store, ctx, "me")
+	other := newUser(t, store, ctx, "other")
+
+	project := db.Project{Name: "Secret"}
+	if err := store.Storage().Create(ctx, &project); err != nil {
+		t.Fatalf("No error should happen when creating a project, but got: %s", err)
+	}
+	if err := store.Storage().Create(ctx, &db.ProjectOwner{From: me.ID(), To: project.Counter}); err != nil {
+		t.Fatalf("No error should happen when setting the owner of a project, but got: %s", err)
+	}
+	projectPost := db.ProjectPost{}
+	projectPost.To, projectPost.Message = project.Counter, "#golang in private"
+	if err := me.Submit(ctx, &projectPost); err != nil {
+		t.Fatalf("No error should happen when posting on a project, but got: %s", err)
+	}
+
+	post := newPost(t, ctx, me, me, "I like #Golang, #nerdz and issue #42")
+	newPost(t, ctx, other, other, "#golang #golang")
+
+	if posts, err := db.Tagged(ctx, me, "golang", db.PostlistOptions{}); err != nil || len(*posts) != 3 {
+		t.Errorf("The owner of the project should see 3 posts tagged #golang, but got %+v (%v)", posts, err)
+	}
+	if posts, err := db.Tagged(ctx, nil, "#GoLang", db.PostlistOptions{}); err != nil || len(*posts) != 2 {
+		t.Errorf("An anonymous user should not see the posts of a private project, but got %+v (%v)", posts, err)
+	}
+	if _, err := db.Tagged(ctx, nil, "42", db.PostlistOptions{}); err == nil {
+		t.Errorf("A tag made of digits only should be invalid")
+	}
+
+	post.Message = "I like #nerdz"
+	if err := me.Edit(ctx, post); err != nil {
+		t.Fatalf("No error should happen when editing a post, but got: %s", err)
+	}
+	trends, err := db.Trending(ctx, nil, time.Hour, 10)
+	if err != nil || len(trends) != 2 || trends[0] != (db.Trend{Tag: "#golang", Count: 1}) || trends[1] != (db.Trend{Tag: "#nerdz", Count: 1}) {
+		t.Errorf("The tags of the edited post should be replaced, but got %+v (%v)", trends, err)
+	}
+	if trends, _ = db.Trending(ctx, me, time.Hour, 1); len(trends) != 1 || trends[0] != (db.Trend{Tag: "#golang", Count: 2}) {
+		t.Errorf("The most used tag should be #golang, but got %+v", trends)
+	}
+}
+
+func TestReadable(t *testing.T) {
+	store, ctx := newStore()
+	me := newUser(t, store, ctx, "me")
+	other := newUser(t, store, ctx, "other")
+	troll := newUser(t, store, ctx, "troll")
+
+	project := db.Project{Name: "Secret"}
+	if err := store.Storage().Create(ctx, &project); err != nil {
+		t.Fatalf("No error should happen when creating a project, but got: %s", err)
+	}
+	if err := store.Storage().Create(ctx, &db.ProjectOwner{From: me.ID(), To: project.Counter}); err != nil {
+		t.Fatalf("No error should happen when setting the owner of a project, but got: %s", err)
+	}
+	if err := store.Storage().Create(ctx, &db.ProjectMember{From: troll.ID(), To: project.Counter}); err != nil {
+		t.Fatalf("No error should happen when adding a member to a project, but got: %s", err)
+	}
+	secret, err := db.NewProject(ctx, project.Counter)
+	if err != nil {
+		t.Fatalf("No error should happen when loading an existing project, but got: %s", err)
+	}
+
+	for _, from := range []*db.User{me, troll} {
+		projectPost := db.ProjectPost{}
+		projectPost.To, projectPost.Message = project.Counter, "Hi from "+from.Username
+		if err := from.Submit(ctx, &projectPost); err != nil {
+			t.Fatalf("No error should happen when posting on a project, but got: %s", err)
+		}
+	}
+	if err := me.BlacklistUser(ctx, troll, "troll"); err != nil {
+		t.Fatalf("No error should happen when blacklisting a user, but got: %s", err)
+	}
+
+	if posts, err := secret.ReadablePostlist(ctx, me, db.PostlistOptions{}); err != nil || len(*posts) != 1 {
+		t.Errorf("The posts of the blacklisted users should be excluded, but got %+v (%v)", posts, err)
+	}
+	for _, user := range []*db.User{nil, other} {
+		if _, err := secret.ReadablePostlist(ctx, user, db.PostlistOptions{}); !errors.Is(err, db.ErrNotFound) {
+			t.Errorf("Only the owner and the members should read the posts of a private project, but got: %v", err)
+		}
+	}
+
+	posts := *secret.Postlist(ctx, db.PostlistOptions{})
+	if err := db.CanRead(ctx, other, posts[0]); !errors.Is(err, db.ErrNotFound) {
+		t.Errorf("A post of a private project should not be readable, but got: %v", err)
+	}
+	for _, post := range posts {
+		if err := db.CanRead(ctx, me, post); (post.NumericSender() == troll.ID()) != errors.Is(err, db.ErrPermissionDenied) {
+			t.Errorf("Only the posts of the blacklisted users should not be readable, but got: %v", err)
+		}
+	}
+
+	post := newPost(t, ctx, troll, troll, "Hi!")
+	if err := db.CanRead(ctx, nil, post); err != nil {
+		t.Errorf("A post on a user board should be readable by the anonymous users, but got: %s", err)
+	}
+	if err := troll.BlacklistUser(ctx, other, "spam"); err != nil {
+		t.Fatalf("No error should happen when blacklisting a user, but got: %s", err)
+	}
+	if err := db.CanRead(ctx, other, post); !errors.Is(err, db.ErrPermissionDenied) {
+		t.Errorf("A post on the board of a user that blacklisted you should not be readable, but got: %v", err)
+	}
+}
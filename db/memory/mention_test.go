@@ -0,0 +1,74 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package memory_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/nerdzeu/nerdz-core/db"
+)
+
+func TestMentions(t *testing.T) {
+	store, ctx := newStore()
+	me := newUser(t, store, ctx, "me")
+	other := newUser(t, store, ctx, "other")
+	third := newUser(t, store, ctx, "third")
+	if err := third.BlacklistUser(ctx, me, "spammer"); err != nil {
+		t.Fatalf("No error should happen when blacklisting a user, but got: %s", err)
+	}
+
+	post := newPost(t, ctx, me, me, "Hi @other, @me, @nobody and [user]third[/user].")
+	if mentions, err := post.Mentions(ctx, db.MentionsOptions{}); err != nil || len(*mentions) != 1 || (*mentions)[0].To != other.ID() {
+		t.Fatalf("Only the existing users that didn't blacklist the author should be mentioned, but got %+v (%v)", mentions, err)
+	}
+
+	comment := db.UserPostComment{Hpid: post.Hpid, Message: "Thanks [user]me[/user]"}
+	if err := other.Submit(ctx, &comment); err != nil {
+		t.Fatalf("No error should happen when commenting, but got: %s", err)
+	}
+	post.Message = "Hi @other!"
+	if err := me.Edit(ctx, post); err != nil {
+		t.Fatalf("No error should happen when editing a post, but got: %s", err)
+	}
+
+	first, err := post.Mentions(ctx, db.MentionsOptions{N: 1})
+	if err != nil || len(*first) != 1 || (*first)[0].To != me.ID() || (*first)[0].UHpid != post.Hpid {
+		t.Fatalf("The mention in the comment should be the newest, but got %+v (%v)", first, err)
+	}
+	second, err := post.Mentions(ctx, db.MentionsOptions{Older: db.NewCursor(&(*first)[0])})
+	if err != nil || len(*second) != 1 || (*second)[0].To != other.ID() {
+		t.Errorf("Editing a post should not mention again, but got %+v (%v)", second, err)
+	}
+
+	if mentions, err := other.Mentions(ctx, db.MentionsOptions{}); err != nil || len(*mentions) != 1 || (*mentions)[0].From != me.ID() {
+		t.Errorf("The user should have been mentioned once, but got %+v (%v)", mentions, err)
+	}
+	if count, _ := other.CountNotifications(ctx, db.NotificationMention); count != 1 {
+		t.Errorf("The mention should be notified, but got %d notifications", count)
+	}
+
+	text := "@OTHER @Other"
+	for i := 0; i < db.MaxMentioned; i++ {
+		text += " @" + newUser(t, store, ctx, fmt.Sprintf("user%d", i)).Username
+	}
+	crowded := newPost(t, ctx, me, me, text)
+	if mentions, err := crowded.Mentions(ctx, db.MentionsOptions{}); err != nil || len(*mentions) != db.MaxMentioned {
+		t.Errorf("A post should mention at most %d users once, but got %+v (%v)", db.MaxMentioned, mentions, err)
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package memory_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nerdzeu/nerdz-core/db"
+	"github.com/nerdzeu/nerdz-core/db/igor"
+)
+
+func TestNotifications(t *testing.T) {
+	store, ctx := newStore()
+	me := newUser(t, store, ctx, "me")
+	other := newUser(t, store, ctx, "other")
+	third := newUser(t, store, ctx, "third")
+
+	post := newPost(t, ctx, me, me, "Comment me")
+	for _, user := range []*db.User{other, third} {
+		comment := db.UserPostComment{Hpid: post.Hpid, Message: "Hi!"}
+		if err := user.Submit(ctx, &comment); err != nil {
+			t.Fatalf("No error should happen when commenting, but got: %s", err)
+		}
+	}
+	if err := third.Follow(ctx, me); err != nil {
+		t.Fatalf("No error should happen when following a user, but got: %s", err)
+	}
+	if count, err := me.CountNotifications(ctx); err != nil || count != 3 {
+		t.Fatalf("The user should have 3 notifications, but got %d (%v)", count, err)
+	}
+
+	if _, err := me.LockPost(ctx, post, third); err != nil {
+		t.Fatalf("No error should happen when locking a post, but got: %s", err)
+	}
+	if count, _ := me.CountNotifications(ctx, db.NotificationComment); count != 1 {
+		t.Errorf("The notifications of the comments of a locked user should be omitted, but got %d", count)
+	}
+
+	first, err := me.Notifications(ctx, db.NotificationsOptions{N: 1})
+	if err != nil || len(first) != 1 {
+		t.Fatalf("A notification should be returned, but got %+v (%v)", first, err)
+	}
+	second, err := me.Notifications(ctx, db.NotificationsOptions{N: 1, Older: first[0].Cursor()})
+	if err != nil || len(second) != 1 || second[0] == first[0] {
+		t.Fatalf("The next notification should be returned, but got %+v (%v)", second, err)
+	}
+
+	// the story is shared with the website, that stores its own entries
+	site := map[string]interface{}{"from": float64(other.ID()), "pid": float64(1)}
+	if err = store.Storage().Updates(ctx, &db.User{Counter: me.ID(), NotifyStory: igor.JSON{"0": site, "last": "post"}}); err != nil {
+		t.Fatalf("No error should happen when updating the story, but got: %s", err)
+	}
+
+	if err = me.ReadNotifications(ctx, db.NotificationFollower); err != nil {
+		t.Fatalf("No error should happen when reading the notifications, but got: %s", err)
+	}
+	var stored db.User
+	if err = store.Storage().Find(ctx, &db.User{Counter: me.ID()}, &stored); err != nil {
+		t.Fatalf("No error should happen when loading a user, but got: %s", err)
+	}
+	if len(stored.NotifyStory) != 3 || !reflect.DeepEqual(stored.NotifyStory["1"], site) || stored.NotifyStory["last"] != "post" {
+		t.Errorf("The read notification should be prepended to the entries of the story, but got %+v", stored.NotifyStory)
+	}
+	if count, _ := me.CountNotifications(ctx); count != 1 {
+		t.Errorf("Only the notification of the comment should be unread, but got %d", count)
+	}
+	read, err := me.Notifications(ctx, db.NotificationsOptions{Read: true})
+	if err != nil || len(read) != 1 || read[0].Type != db.NotificationFollower || read[0].From != third.ID() {
+		t.Errorf("The read notification should be kept in the story, but got %+v (%v)", read, err)
+	}
+
+	if err = me.ClearNotifications(ctx); err != nil {
+		t.Fatalf("No error should happen when clearing the notifications, but got: %s", err)
+	}
+	if count, _ := me.CountNotifications(ctx); count != 0 {
+		t.Errorf("No notification should be unread, but got %d", count)
+	}
+	if read, _ = me.Notifications(ctx, db.NotificationsOptions{Read: true}); len(read) != 0 {
+		t.Errorf("The story should be empty, but got %+v", read)
+	}
+	store.Storage().Find(ctx, &db.User{Counter: me.ID()}, &stored)
+	if len(stored.NotifyStory) != 2 || !reflect.DeepEqual(stored.NotifyStory["0"], site) {
+		t.Errorf("The entries of the website should be kept in the story, but got %+v", stored.NotifyStory)
+	}
+}
@@ -0,0 +1,179 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package memory_test
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/RangelReale/osin"
+	"github.com/nerdzeu/nerdz-core/db"
+)
+
+func TestOAuth2PKCE(t *testing.T) {
+	store, ctx := newStore()
+	me := newUser(t, store, ctx, "me")
+	storage := store.OAuth2Storage()
+
+	config := osin.NewServerConfig()
+	config.AllowedAccessTypes = osin.AllowedAccessType{osin.AUTHORIZATION_CODE}
+	config.RequirePKCEForPublicClients = true
+	server := osin.NewServer(config, storage)
+
+	const redirectURI = "https://example.com/callback"
+	client, err := storage.CreateClient(&osin.DefaultClient{RedirectUri: redirectURI, UserData: me.ID()}, "public client")
+	if err != nil {
+		t.Fatalf("No error should happen when creating a public client, but got: %s", err)
+	}
+	if !client.Public || !client.ClientSecretMatches("") {
+		t.Fatalf("A client without secret should be public: %+v", client)
+	}
+
+	verifier := "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	sum := sha256.Sum256([]byte(verifier))
+	challenge := base64.RawURLEncoding.EncodeToString(sum[:])
+
+	authorize := func(values url.Values) *osin.Response {
+		values.Set("response_type", "code")
+		values.Set("client_id", client.GetId())
+		values.Set("redirect_uri", redirectURI)
+		values.Set("scope", db.ScopePostsRead)
+		req, _ := http.NewRequest("GET", "/authorize?"+values.Encode(), nil)
+
+		resp := server.NewResponse()
+		if ar := server.HandleAuthorizeRequest(resp, req); ar != nil {
+			ar.UserData = me.ID()
+			ar.Authorized = true
+			server.FinishAuthorizeRequest(resp, req, ar)
+		}
+		resp.Close()
+		return resp
+	}
+
+	access := func(code, verifier string) *osin.Response {
+		req, _ := http.NewRequest("POST", "/token", strings.NewReader(url.Values{
+			"grant_type":    {"authorization_code"},
+			"code":          {code},
+			"redirect_uri":  {redirectURI},
+			"code_verifier": {verifier}}.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.SetBasicAuth(client.GetId(), "")
+
+		resp := server.NewResponse()
+		if ar := server.HandleAccessRequest(resp, req); ar != nil {
+			ar.Authorized = true
+			server.FinishAccessRequest(resp, req, ar)
+		}
+		resp.Close()
+		return resp
+	}
+
+	if resp := authorize(url.Values{}); !resp.IsError {
+		t.Error("Public clients should not be authorized without code challenge")
+	}
+
+	resp := authorize(url.Values{"code_challenge": {challenge}, "code_challenge_method": {"S256"}})
+	if resp.IsError {
+		t.Fatalf("Authorization request failed: %s (%v)", resp.ErrorId, resp.InternalError)
+	}
+	code := resp.Output["code"].(string)
+
+	authorizeData, err := storage.LoadAuthorize(code)
+	if err != nil || authorizeData.CodeChallenge != challenge || authorizeData.CodeChallengeMethod != "S256" {
+		t.Fatalf("The code challenge should be stored with the authorize data, but got: %+v, %v", authorizeData, err)
+	}
+
+	if resp = access(code, "wrong-verifier-wrong-verifier-wrong-verifier-wrong"); !resp.IsError {
+		t.Error("The access request with a wrong code verifier should be rejected")
+	}
+
+	if resp = access(code, verifier); resp.IsError {
+		t.Fatalf("Access request failed: %s (%v)", resp.ErrorId, resp.InternalError)
+	}
+	if _, err = storage.LoadAccess(resp.Output["access_token"].(string)); err != nil {
+		t.Errorf("The public client should obtain an access token, but got: %s", err)
+	}
+}
+
+func TestOAuth2ClientCertificates(t *testing.T) {
+	store, ctx := newStore()
+	me, other := newUser(t, store, ctx, "me"), newUser(t, store, ctx, "other")
+
+	client, _, err := me.CreateOAuth2Client(ctx, "certified client", "https://example.com/callback", false)
+	if err != nil {
+		t.Fatalf("No error should happen when creating a client, but got: %s", err)
+	}
+
+	sum := sha256.Sum256([]byte("certificate"))
+	fp := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	if _, err = other.AddOAuth2ClientCertificate(ctx, client, fp); !errors.Is(err, db.ErrPermissionDenied) {
+		t.Errorf("Only the owner of the client should add its certificates, but got: %v", err)
+	}
+	if _, err = me.AddOAuth2ClientCertificate(ctx, client, "not a fingerprint"); !errors.Is(err, db.ErrInvalidArgument) {
+		t.Errorf("An invalid fingerprint should be rejected, but got: %v", err)
+	}
+	if _, err = db.OAuth2ClientByCertificate(ctx, fp); !errors.Is(err, db.ErrNotFound) {
+		t.Errorf("An unknown certificate should authenticate no client, but got: %v", err)
+	}
+
+	certificate, err := me.AddOAuth2ClientCertificate(ctx, client, fp)
+	if err != nil {
+		t.Fatalf("No error should happen when adding a certificate, but got: %s", err)
+	}
+	if certificate.Fingerprint != strings.ToLower(fp) {
+		t.Errorf("The fingerprint should be stored lower cased, but got: %s", certificate.Fingerprint)
+	}
+	if _, err = me.AddOAuth2ClientCertificate(ctx, client, fp); !errors.Is(err, db.ErrConflict) {
+		t.Errorf("A certificate should be added once, but got: %v", err)
+	}
+
+	if found, err := db.OAuth2ClientByCertificate(ctx, fp); err != nil || found.ID != client.ID {
+		t.Errorf("The certificate should authenticate the client, but got: %v, %v", found, err)
+	}
+
+	if err = other.RevokeOAuth2ClientCertificate(ctx, client, fp); !errors.Is(err, db.ErrPermissionDenied) {
+		t.Errorf("Only the owner of the client should revoke its certificates, but got: %v", err)
+	}
+	if err = me.RevokeOAuth2ClientCertificate(ctx, client, fp); err != nil {
+		t.Fatalf("No error should happen when revoking a certificate, but got: %s", err)
+	}
+	if _, err = db.OAuth2ClientByCertificate(ctx, fp); !errors.Is(err, db.ErrPermissionDenied) {
+		t.Errorf("A revoked certificate should authenticate no client, but got: %v", err)
+	}
+	if _, err = me.AddOAuth2ClientCertificate(ctx, client, fp); !errors.Is(err, db.ErrConflict) {
+		t.Errorf("A revoked certificate should not be added again, but got: %v", err)
+	}
+
+	if certificates, err := me.OAuth2ClientCertificates(ctx, client); err != nil || len(certificates) != 1 || !certificates[0].Revoked {
+		t.Errorf("The revoked certificate should be listed, but got: %+v, %v", certificates, err)
+	}
+
+	if err = me.DeleteOAuth2Client(ctx, client); err != nil {
+		t.Fatalf("No error should happen when deleting the client, but got: %s", err)
+	}
+	if _, err = db.OAuth2ClientByCertificate(ctx, fp); !errors.Is(err, db.ErrNotFound) {
+		t.Errorf("The certificates should be deleted with their client, but got: %v", err)
+	}
+}
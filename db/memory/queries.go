@@ -0,0 +1,788 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nerdzeu/nerdz-core/db"
+	"github.com/nerdzeu/nerdz-core/db/igor"
+)
+
+func (s *storage) Login(ctx context.Context, username, password string) (uint64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, row := range s.tables[db.User{}.TableName()] {
+		if user := row.Interface().(db.User); strings.EqualFold(user.Username, username) && user.Password == password {
+			return user.Counter, nil
+		}
+	}
+	return 0, nil
+}
+
+func (s *storage) UsersByUsername(ctx context.Context, usernames []string) ([]db.User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var users []db.User
+	for _, row := range s.tables[db.User{}.TableName()] {
+		user := row.Interface().(db.User)
+		for _, username := range usernames {
+			if strings.EqualFold(user.Username, username) {
+				users = append(users, user)
+				break
+			}
+		}
+	}
+	return users, nil
+}
+
+func (s *storage) UsersIn(ctx context.Context, ids []uint64) ([]db.User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	in := make(map[uint64]bool, len(ids))
+	for _, id := range ids {
+		in[id] = true
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var users []db.User
+	for _, row := range s.tables[db.User{}.TableName()] {
+		user := row.Interface().(db.User)
+		if in[user.Counter] && s.first(&db.Profile{Counter: user.Counter}, &user.Profile) {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
+// posts returns the user posts (type 1) and the project posts (type 0) that satisfy condition
+func (s *storage) posts(condition func(db.Message) bool) []db.Message {
+	var posts []db.Message
+	for _, row := range s.tables[db.UserPost{}.TableName()] {
+		if post := (db.Message{Post: row.Interface().(db.UserPost).Post, Type: 1}); condition(post) {
+			posts = append(posts, post)
+		}
+	}
+	for _, row := range s.tables[db.ProjectPost{}.TableName()] {
+		if post := (db.Message{Post: row.Interface().(db.ProjectPost).Post, Type: 0}); condition(post) {
+			posts = append(posts, post)
+		}
+	}
+	return posts
+}
+
+// pluck returns the values of the uint64 column of the rows that match description
+func (s *storage) pluck(description igor.DBModel, name string) map[uint64]bool {
+	values := make(map[uint64]bool)
+	if condition, _ := indirect(description); !conditions(condition) {
+		return values
+	}
+	for _, row := range s.where(description) {
+		value, _ := column(row, name)
+		values[value.Uint()] = true
+	}
+	return values
+}
+
+// visible returns true if user can see the posts of the project
+func (s *storage) visible(project, user uint64) bool {
+	var p db.Project
+	return s.project(project, &p) && (p.Visible || s.projectMember(project, user))
+}
+
+// compare returns -1, 0 or +1 if post precedes, is or follows the element pointed by cursor in a list sorted
+// like postlistQueryBuilder does: by time, type and hpid if messages is true, by hpid otherwise
+func compare(post db.Message, cursor *db.Cursor, messages bool) int {
+	if messages {
+		if !post.Time.Equal(cursor.Time) {
+			return compareKeys(post.Time.After(cursor.Time), post.Time.Before(cursor.Time))
+		}
+		if post.Type != cursor.Type() {
+			return compareKeys(post.Type > cursor.Type(), post.Type < cursor.Type())
+		}
+	}
+	return compareKeys(post.Hpid > cursor.ID, post.Hpid < cursor.ID)
+}
+
+// compareKeys returns the position of an element in a list sorted in descending order,
+// given if its key is greater or less than the key of another element
+func compareKeys(greater, less bool) int {
+	switch {
+	case greater:
+		return -1
+	case less:
+		return 1
+	}
+	return 0
+}
+
+// between returns true if the element whose position with respect to the older and newer cursors
+// is computed by compare, follows the element pointed by older and precedes the one pointed by newer
+func between(compare func(*db.Cursor) int, older, newer *db.Cursor) bool {
+	return (older == nil || compare(older) > 0) && (newer == nil || compare(newer) < 0)
+}
+
+// page returns the bounds of the n elements of a selected list, of length elements, to return:
+// the first ones, or the last ones (the nearest to newer) if the list is selected only by a newer cursor
+func page(length, n int, older, newer *db.Cursor) (int, int) {
+	if n > length {
+		n = length
+	}
+	if older == nil && newer != nil {
+		return length - n, length
+	}
+	return 0, n
+}
+
+// postlist filters posts according to options, like postlistQueryBuilder does, and sorts them.
+// If user is not 0, it's the user whose following and followers are used.
+// If messages is true, the posts are sorted by time and type, like the posts of the home
+func (s *storage) postlist(posts []db.Message, options db.PostlistOptions, user uint64, messages bool) []db.Message {
+	if messages {
+		sort.SliceStable(posts, func(i, j int) bool {
+			if !posts[i].Time.Equal(posts[j].Time) {
+				return posts[i].Time.After(posts[j].Time)
+			}
+			if posts[i].Type != posts[j].Type {
+				return posts[i].Type > posts[j].Type
+			}
+			return posts[i].Hpid > posts[j].Hpid
+		})
+	} else {
+		sort.SliceStable(posts, func(i, j int) bool { return posts[i].Hpid > posts[j].Hpid })
+	}
+
+	var senders map[uint64]bool
+	if user != 0 && (options.Following || options.Followers) {
+		following := s.pluck(&db.UserFollower{From: user}, "to")
+		followers := s.pluck(&db.UserFollower{To: user}, "from")
+		senders = map[uint64]bool{user: true}
+		for other := range following {
+			if !options.Followers || followers[other] {
+				senders[other] = true
+			}
+		}
+		if !options.Following {
+			for other := range followers {
+				senders[other] = true
+			}
+		}
+	}
+
+	selected := []db.Message{}
+	for _, post := range posts {
+		if senders != nil && !senders[post.From] {
+			continue
+		}
+		if options.Language != "" && post.Lang != options.Language {
+			continue
+		}
+
+		position := func(cursor *db.Cursor) int { return compare(post, cursor, messages) }
+		if between(position, options.Older, options.Newer) {
+			selected = append(selected, post)
+		}
+	}
+
+	from, to := page(len(selected), int(db.AtMostPosts(uint64(options.N))), options.Older, options.Newer)
+	return selected[from:to]
+}
+
+// userPosts converts the messages to user posts
+func userPosts(messages []db.Message) []db.UserPost {
+	posts := make([]db.UserPost, len(messages))
+	for i, message := range messages {
+		posts[i] = db.UserPost{Post: message.Post}
+	}
+	return posts
+}
+
+// projectPosts converts the messages to project posts
+func projectPosts(messages []db.Message) []db.ProjectPost {
+	posts := make([]db.ProjectPost, len(messages))
+	for i, message := range messages {
+		posts[i] = db.ProjectPost{Post: message.Post}
+	}
+	return posts
+}
+
+func (s *storage) UserHome(ctx context.Context, user uint64, options db.PostlistOptions) ([]db.UserPost, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	blacklist := s.pluck(&db.Blacklist{From: user}, "to")
+	posts := s.posts(func(post db.Message) bool {
+		return post.Type == 1 && !blacklist[post.To]
+	})
+	return userPosts(s.postlist(posts, options, user, false)), nil
+}
+
+func (s *storage) ProjectHome(ctx context.Context, user uint64, options db.PostlistOptions) ([]db.ProjectPost, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	blacklist := s.pluck(&db.Blacklist{From: user}, "to")
+	posts := s.posts(func(post db.Message) bool {
+		return post.Type == 0 && !blacklist[post.From] && s.visible(post.To, user)
+	})
+	return projectPosts(s.postlist(posts, options, user, false)), nil
+}
+
+func (s *storage) Home(ctx context.Context, user uint64, options db.PostlistOptions) ([]db.Message, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.postlist(s.posts(s.home(user)), options, user, true), nil
+}
+
+// home returns the condition of the posts that user, 0 for an anonymous user, can see in the home,
+// like homeConditions selects them
+func (s *storage) home(user uint64) func(db.Message) bool {
+	blacklist := s.pluck(&db.Blacklist{From: user}, "to")
+	return func(post db.Message) bool {
+		if blacklist[post.From] {
+			return false
+		}
+		if post.Type == 1 {
+			return !blacklist[post.To]
+		}
+		return s.visible(post.To, user)
+	}
+}
+
+// classified returns a condition of the posts classified with the tags of the rows that match description
+func (s *storage) classified(description *db.PostClassification) func(db.Message) bool {
+	userPosts, projectPosts := s.pluck(description, "uhpid"), s.pluck(description, "ghpid")
+	return func(post db.Message) bool {
+		if post.Type == 1 {
+			return userPosts[post.Hpid]
+		}
+		return projectPosts[post.Hpid]
+	}
+}
+
+func (s *storage) Tagged(ctx context.Context, user uint64, tag string, options db.PostlistOptions) ([]db.Message, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	home, tagged := s.home(user), s.classified(&db.PostClassification{Tag: tag})
+	posts := s.posts(func(post db.Message) bool {
+		return tagged(post) && home(post)
+	})
+	return s.postlist(posts, options, user, true), nil
+}
+
+func (s *storage) Trending(ctx context.Context, user uint64, since time.Time, n uint8) ([]db.Trend, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	home := s.home(user)
+	userPosts, projectPosts := make(map[uint64]bool), make(map[uint64]bool)
+	for _, post := range s.posts(func(post db.Message) bool { return !post.Time.Before(since) && home(post) }) {
+		if post.Type == 1 {
+			userPosts[post.Hpid] = true
+		} else {
+			projectPosts[post.Hpid] = true
+		}
+	}
+
+	counts := make(map[string]uint64)
+	for _, row := range s.tables[db.PostClassification{}.TableName()] {
+		if classification := row.Interface().(db.PostClassification); userPosts[classification.UHpid] || projectPosts[classification.GHpid] {
+			counts[classification.Tag]++
+		}
+	}
+
+	trends := []db.Trend{}
+	for tag, count := range counts {
+		trends = append(trends, db.Trend{Tag: tag, Count: count})
+	}
+	sort.Slice(trends, func(i, j int) bool {
+		if trends[i].Count != trends[j].Count {
+			return trends[i].Count > trends[j].Count
+		}
+		return trends[i].Tag < trends[j].Tag
+	})
+	if len(trends) > int(n) {
+		trends = trends[:n]
+	}
+	return trends, nil
+}
+
+func (s *storage) UserPostlist(ctx context.Context, user uint64, options db.PostlistOptions) ([]db.UserPost, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	posts := s.posts(func(post db.Message) bool {
+		return post.Type == 1 && post.To == user
+	})
+	return userPosts(s.postlist(posts, options, user, false)), nil
+}
+
+func (s *storage) ProjectPostlist(ctx context.Context, project, user uint64, options db.PostlistOptions) ([]db.ProjectPost, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	blacklist := s.pluck(&db.Blacklist{From: user}, "to")
+	posts := s.posts(func(post db.Message) bool {
+		return post.Type == 0 && post.To == project && !blacklist[post.From]
+	})
+	return projectPosts(s.postlist(posts, options, 0, false)), nil
+}
+
+// selected returns true if id, in a list sorted by descending id, is between the older and newer cursors,
+// like commentlistQueryBuilder, pmsQueryBuilder and mentionsQueryBuilder do
+func selected(id uint64, older, newer *db.Cursor) bool {
+	position := func(cursor *db.Cursor) int { return compareKeys(id > cursor.ID, id < cursor.ID) }
+	return between(position, older, newer)
+}
+
+func (s *storage) UserPostComments(ctx context.Context, hpid uint64, options db.CommentlistOptions) ([]db.UserPostComment, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rows := s.where(&db.UserPostComment{Hpid: hpid})
+	comments := []db.UserPostComment{}
+	for i := len(rows) - 1; i >= 0; i-- {
+		if comment := rows[i].Interface().(db.UserPostComment); selected(comment.Hcid, options.Older, options.Newer) {
+			comments = append(comments, comment)
+		}
+	}
+
+	from, to := page(len(comments), int(db.AtMostComments(uint64(options.N))), options.Older, options.Newer)
+	return comments[from:to], nil
+}
+
+func (s *storage) ProjectPostComments(ctx context.Context, hpid uint64, options db.CommentlistOptions) ([]db.ProjectPostComment, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rows := s.where(&db.ProjectPostComment{Hpid: hpid})
+	comments := []db.ProjectPostComment{}
+	for i := len(rows) - 1; i >= 0; i-- {
+		if comment := rows[i].Interface().(db.ProjectPostComment); selected(comment.Hcid, options.Older, options.Newer) {
+			comments = append(comments, comment)
+		}
+	}
+
+	from, to := page(len(comments), int(db.AtMostComments(uint64(options.N))), options.Older, options.Newer)
+	return comments[from:to], nil
+}
+
+func (s *storage) Pms(ctx context.Context, user, other uint64, options db.PmsOptions) ([]db.PM, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rows := s.tables[db.PM{}.TableName()]
+	pms := []db.PM{}
+	for i := len(rows) - 1; i >= 0; i-- {
+		pm := rows[i].Interface().(db.PM)
+		if !(pm.From == user && pm.To == other) && !(pm.From == other && pm.To == user) {
+			continue
+		}
+		if selected(pm.Pmid, options.Older, options.Newer) {
+			pms = append(pms, pm)
+		}
+	}
+
+	from, to := page(len(pms), int(db.AtMostPms(uint64(options.N))), options.Older, options.Newer)
+	return pms[from:to], nil
+}
+
+func (s *storage) Conversations(ctx context.Context, user uint64) ([]db.Conversation, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	conversations := make(map[uint64]*db.Conversation)
+	var others []uint64
+	for _, row := range s.tables[db.PM{}.TableName()] {
+		pm := row.Interface().(db.PM)
+		other := pm.To
+		if pm.To == user {
+			other = pm.From
+		} else if pm.From != user {
+			continue
+		}
+
+		conversation, ok := conversations[other]
+		if !ok {
+			conversation = &db.Conversation{From: user, To: other}
+			conversations[other] = conversation
+			others = append(others, other)
+		}
+		if !pm.Time.Before(conversation.Time) {
+			conversation.LastMessage = pm.Message
+			conversation.Time = pm.Time
+		}
+		if pm.To == user && pm.ToRead {
+			conversation.ToRead = true
+		}
+	}
+
+	convList := make([]db.Conversation, len(others))
+	for i, other := range others {
+		convList[i] = *conversations[other]
+	}
+	sort.SliceStable(convList, func(i, j int) bool {
+		if convList[i].ToRead != convList[j].ToRead {
+			return convList[i].ToRead
+		}
+		return convList[i].Time.After(convList[j].Time)
+	})
+	return convList, nil
+}
+
+// locked returns true if user locked the notifications caused by from on the post hpid:
+// a user post if project is false, a project post otherwise
+func (s *storage) locked(user, from, hpid uint64, project bool) bool {
+	if project {
+		return s.exists(&db.ProjectPostLock{User: user, Hpid: hpid}) || s.exists(&db.ProjectPostUserLock{From: user, To: from, Hpid: hpid})
+	}
+	return s.exists(&db.UserPostLock{User: user, Hpid: hpid}) || s.exists(&db.UserPostUserLock{From: user, To: from, Hpid: hpid})
+}
+
+// notifications returns the unread notifications of user of types, excluding the locked ones, like notificationQueries does
+func (s *storage) notifications(user uint64, types []db.NotificationType) []db.Notification {
+	var notifications []db.Notification
+	for _, notificationType := range types {
+		switch notificationType {
+		case db.NotificationComment:
+			for _, row := range s.where(&db.UserPostCommentsNotify{To: user}) {
+				n := row.Interface().(db.UserPostCommentsNotify)
+				var post db.UserPost
+				if s.userPost(n.Hpid, &post) && !s.locked(user, n.From, n.Hpid, false) {
+					notifications = append(notifications, db.Notification{Type: notificationType, ID: n.Counter,
+						From: n.From, Board: post.To, Hpid: n.Hpid, Time: n.Time})
+				}
+			}
+		case db.NotificationProjectComment:
+			for _, row := range s.where(&db.ProjectPostCommentsNotify{To: user}) {
+				n := row.Interface().(db.ProjectPostCommentsNotify)
+				var post db.ProjectPost
+				if s.projectPost(n.Hpid, &post) && !s.locked(user, n.From, n.Hpid, true) {
+					notifications = append(notifications, db.Notification{Type: notificationType, ID: n.Counter,
+						From: n.From, Board: post.To, Project: true, Hpid: n.Hpid, Time: n.Time})
+				}
+			}
+		case db.NotificationProjectPost:
+			for _, row := range s.where(&db.ProjectNotify{To: user}) {
+				n := row.Interface().(db.ProjectNotify)
+				var post db.ProjectPost
+				if s.projectPost(n.Hpid, &post) {
+					notifications = append(notifications, db.Notification{Type: notificationType, ID: n.Counter,
+						From: post.From, Board: n.From, Project: true, Hpid: n.Hpid, Time: n.Time})
+				}
+			}
+		case db.NotificationMention:
+			for _, row := range s.where(&db.Mention{To: user, ToNotify: true}) {
+				m := row.Interface().(db.Mention)
+				notification := db.Notification{Type: notificationType, ID: m.ID, From: m.From, Time: m.Time}
+				var userPost db.UserPost
+				var projectPost db.ProjectPost
+				switch {
+				case s.userPost(m.UHpid, &userPost):
+					notification.Board, notification.Hpid = userPost.To, m.UHpid
+				case s.projectPost(m.GHpid, &projectPost):
+					notification.Board, notification.Project, notification.Hpid = projectPost.To, true, m.GHpid
+				}
+				if !s.locked(user, m.From, m.UHpid, false) && !s.locked(user, m.From, m.GHpid, true) {
+					notifications = append(notifications, notification)
+				}
+			}
+		case db.NotificationFollower:
+			for _, row := range s.where(&db.UserFollower{To: user, ToNotify: true}) {
+				f := row.Interface().(db.UserFollower)
+				notifications = append(notifications, db.Notification{Type: notificationType, ID: f.Counter,
+					From: f.From, Board: f.To, Time: f.Time})
+			}
+		case db.NotificationProjectFollower:
+			owned := s.pluck(&db.ProjectOwner{From: user}, "to")
+			for _, row := range s.where(&db.ProjectFollower{ToNotify: true}) {
+				if f := row.Interface().(db.ProjectFollower); owned[f.To] {
+					notifications = append(notifications, db.Notification{Type: notificationType, ID: f.Counter,
+						From: f.From, Board: f.To, Project: true, Time: f.Time})
+				}
+			}
+		case db.NotificationMember:
+			for _, row := range s.where(&db.ProjectMember{From: user, ToNotify: true}) {
+				m := row.Interface().(db.ProjectMember)
+				notifications = append(notifications, db.Notification{Type: notificationType, ID: m.Counter,
+					Board: m.To, Project: true, Time: m.Time})
+			}
+		}
+	}
+	return notifications
+}
+
+// notificationPosition returns the position of notification with respect to the one pointed by cursor
+// in a list sorted by time, type and ID, newest first: -1, 0 or +1 if it precedes, is or follows it
+func notificationPosition(notification db.Notification, cursor *db.Cursor) int {
+	if !notification.Time.Equal(cursor.Time) {
+		return compareKeys(notification.Time.After(cursor.Time), notification.Time.Before(cursor.Time))
+	}
+	if other := notificationType(cursor.Table); notification.Type != other {
+		return compareKeys(notification.Type > other, notification.Type < other)
+	}
+	return compareKeys(notification.ID > cursor.ID, notification.ID < cursor.ID)
+}
+
+// notificationType returns the type of the notifications stored in table
+func notificationType(table string) db.NotificationType {
+	for i, model := range db.NotificationModels() {
+		if model.TableName() == table {
+			return db.NotificationType(i)
+		}
+	}
+	return 0
+}
+
+func (s *storage) Notifications(ctx context.Context, user uint64, options db.NotificationsOptions) ([]db.Notification, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	notifications := s.notifications(user, options.Types)
+	sort.SliceStable(notifications, func(i, j int) bool {
+		return notificationPosition(notifications[i], notifications[j].Cursor()) < 0
+	})
+
+	selected := []db.Notification{}
+	for _, notification := range notifications {
+		position := func(cursor *db.Cursor) int { return notificationPosition(notification, cursor) }
+		if between(position, options.Older, options.Newer) {
+			selected = append(selected, notification)
+		}
+	}
+
+	from, to := page(len(selected), int(db.AtMostNotifications(uint64(options.N))), options.Older, options.Newer)
+	return selected[from:to], nil
+}
+
+func (s *storage) CountNotifications(ctx context.Context, user uint64, types []db.NotificationType) (uint64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return uint64(len(s.notifications(user, types))), nil
+}
+
+func (s *storage) Mentions(ctx context.Context, description *db.Mention, options db.MentionsOptions) ([]db.Mention, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rows := s.where(description)
+	mentions := []db.Mention{}
+	for i := len(rows) - 1; i >= 0; i-- {
+		if mention := rows[i].Interface().(db.Mention); selected(mention.ID, options.Older, options.Newer) {
+			mentions = append(mentions, mention)
+		}
+	}
+
+	from, to := page(len(mentions), int(db.AtMostMentions(uint64(options.N))), options.Older, options.Newer)
+	return mentions[from:to], nil
+}
+
+func (s *storage) ReadPms(ctx context.Context, user, other uint64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, row := range s.where(&db.PM{From: other, To: user}) {
+		row.Addr().Interface().(*db.PM).ToRead = false
+	}
+	return nil
+}
+
+// accesses returns the access data stored, mapped by id, and the ids of the access data
+// obtained by refreshing every access data
+func (s *storage) accesses() (map[uint64]*db.OAuth2AccessData, map[uint64][]uint64) {
+	accesses := make(map[uint64]*db.OAuth2AccessData)
+	children := make(map[uint64][]uint64)
+	for _, row := range s.tables[db.OAuth2AccessData{}.TableName()] {
+		access := row.Addr().Interface().(*db.OAuth2AccessData)
+		accesses[access.ID] = access
+		if access.AccessDataID.Valid {
+			parent := uint64(access.AccessDataID.Int64)
+			children[parent] = append(children[parent], access.ID)
+		}
+	}
+	return accesses, children
+}
+
+func (s *storage) OAuth2Family(ctx context.Context, id uint64) ([]uint64, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	accesses, children := s.accesses()
+	root, ok := accesses[id]
+	if !ok {
+		return nil, nil
+	}
+	for root.AccessDataID.Valid {
+		parent, ok := accesses[uint64(root.AccessDataID.Int64)]
+		if !ok {
+			break
+		}
+		root = parent
+	}
+
+	family := []uint64{root.ID}
+	for i := 0; i < len(family); i++ {
+		family = append(family, children[family[i]]...)
+	}
+	return family, nil
+}
+
+func (s *storage) ExpiredOAuth2Authorize(ctx context.Context, limit int) ([]uint64, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := time.Now().UTC()
+	var ids []uint64
+	for _, row := range s.tables[db.OAuth2AuthorizeData{}.TableName()] {
+		authorize := row.Interface().(db.OAuth2AuthorizeData)
+		if authorize.CreatedAt.Add(time.Duration(authorize.ExpiresIn) * time.Second).Before(now) {
+			ids = append(ids, authorize.ID)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	return ids, nil
+}
+
+func (s *storage) DeadOAuth2Families(ctx context.Context, refreshTTL time.Duration, limit int) ([]uint64, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := time.Now().UTC()
+	accesses, children := s.accesses()
+	var tips []uint64
+	for id, access := range accesses {
+		refreshable := access.RefreshTokenID.Valid && !access.CreatedAt.Add(refreshTTL).Before(now)
+		expired := access.CreatedAt.Add(time.Duration(access.ExpiresIn) * time.Second).Before(now)
+		if !refreshable && expired && len(children[id]) == 0 {
+			tips = append(tips, id)
+		}
+	}
+	sort.Slice(tips, func(i, j int) bool { return tips[i] < tips[j] })
+	if len(tips) > limit {
+		tips = tips[:limit]
+	}
+
+	var family []uint64
+	seen := make(map[uint64]bool)
+	for _, id := range tips {
+		for access, ok := accesses[id]; ok && !seen[access.ID]; {
+			seen[access.ID] = true
+			family = append(family, access.ID)
+			if !access.AccessDataID.Valid {
+				break
+			}
+			access, ok = accesses[uint64(access.AccessDataID.Int64)]
+		}
+	}
+	return family, nil
+}
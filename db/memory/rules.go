@@ -0,0 +1,656 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package memory
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/nerdzeu/nerdz-core/db"
+	"github.com/nerdzeu/nerdz-core/db/igor"
+	"github.com/nerdzeu/nerdz-core/utils"
+)
+
+// Rules of the NERDZ database, enforced by the triggers and the constraints of PostgreSQL
+
+// uniques maps the name of a table to its unique constraints
+var uniques = map[string][][]string{
+	db.UserFollower{}.TableName():           {{"from", "to"}},
+	db.ProjectFollower{}.TableName():        {{"from", "to"}},
+	db.Blacklist{}.TableName():              {{"from", "to"}},
+	db.Whitelist{}.TableName():              {{"from", "to"}},
+	db.ProjectMember{}.TableName():          {{"from", "to"}},
+	db.ProjectOwner{}.TableName():           {{"to"}},
+	db.UserPostBookmark{}.TableName():       {{"from", "hpid"}},
+	db.ProjectPostBookmark{}.TableName():    {{"from", "hpid"}},
+	db.UserPostLurk{}.TableName():           {{"from", "hpid"}},
+	db.ProjectPostLurk{}.TableName():        {{"from", "hpid"}},
+	db.UserPostLock{}.TableName():           {{"user", "hpid"}},
+	db.ProjectPostLock{}.TableName():        {{"user", "hpid"}},
+	db.UserPostUserLock{}.TableName():       {{"from", "to", "hpid"}},
+	db.ProjectPostUserLock{}.TableName():    {{"from", "to", "hpid"}},
+	db.UserPostVote{}.TableName():           {{"from", "hpid"}},
+	db.ProjectPostVote{}.TableName():        {{"from", "hpid"}},
+	db.UserPostCommentVote{}.TableName():    {{"from", "hcid"}},
+	db.ProjectPostCommentVote{}.TableName(): {{"from", "hcid"}},
+	db.OAuth2Client{}.TableName():           {{"name"}},
+}
+
+// unique returns an error if model violates a unique constraint of its table
+func (s *storage) unique(model igor.DBModel) error {
+	row, _ := indirect(model)
+	for _, constraint := range uniques[model.TableName()] {
+		for _, other := range s.tables[model.TableName()] {
+			duplicate := true
+			for _, name := range constraint {
+				a, _ := column(row, name)
+				b, _ := column(other, name)
+				if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+					duplicate = false
+					break
+				}
+			}
+			if duplicate {
+				return db.NewError(db.ErrConflict, "duplicate key value violates unique constraint on %s (%s)",
+					model.TableName(), strings.Join(constraint, ", "))
+			}
+		}
+	}
+	return nil
+}
+
+// user returns true if the user exists
+func (s *storage) user(counter uint64) bool {
+	return counter != 0 && s.exists(&db.User{Counter: counter})
+}
+
+// project loads the project into dest and returns true if it exists
+func (s *storage) project(counter uint64, dest *db.Project) bool {
+	return counter != 0 && s.first(&db.Project{Counter: counter}, dest)
+}
+
+// projectOwner returns the owner of the project, 0 if it has no owner
+func (s *storage) projectOwner(project uint64) uint64 {
+	var owner db.ProjectOwner
+	if project != 0 {
+		s.first(&db.ProjectOwner{To: project}, &owner)
+	}
+	return owner.From
+}
+
+// projectMember returns true if user is the owner or a member of the project
+func (s *storage) projectMember(project, user uint64) bool {
+	return user != 0 && (s.projectOwner(project) == user || s.exists(&db.ProjectMember{From: user, To: project}))
+}
+
+// userPost loads the user post into dest and returns true if it exists
+func (s *storage) userPost(hpid uint64, dest *db.UserPost) bool {
+	return hpid != 0 && s.first(&db.UserPost{Post: db.Post{Hpid: hpid}}, dest)
+}
+
+// projectPost loads the project post into dest and returns true if it exists
+func (s *storage) projectPost(hpid uint64, dest *db.ProjectPost) bool {
+	return hpid != 0 && s.first(&db.ProjectPost{Post: db.Post{Hpid: hpid}}, dest)
+}
+
+// blacklisted returns true if from blacklisted to
+func (s *storage) blacklisted(from, to uint64) bool {
+	return from != 0 && to != 0 && s.exists(&db.Blacklist{From: from, To: to})
+}
+
+// blacklistControl returns an error if from and to are different users and one
+// of them blacklisted the other
+func (s *storage) blacklistControl(from, to uint64) error {
+	if from == to {
+		return nil
+	}
+	if s.blacklisted(from, to) {
+		return db.NewError(db.ErrPermissionDenied, "you blacklisted this user")
+	}
+	if s.blacklisted(to, from) {
+		return db.NewError(db.ErrPermissionDenied, "you have been blacklisted by this user")
+	}
+	return nil
+}
+
+// users returns an error if any of the users does not exist
+func (s *storage) users(users ...uint64) error {
+	for _, user := range users {
+		if !s.user(user) {
+			return db.NewError(db.ErrNotFound, "the user does not exist")
+		}
+	}
+	return nil
+}
+
+// nextPid returns the pid of the next post on the board, described by posts
+func (s *storage) nextPid(posts igor.DBModel) (pid uint64) {
+	for _, row := range s.where(posts) {
+		if current, _ := column(row, "pid"); current.Uint() > pid {
+			pid = current.Uint()
+		}
+	}
+	return pid + 1
+}
+
+// validVote returns an error if vote is not -1 or 1
+func validVote(vote int8) error {
+	if vote != -1 && vote != 1 {
+		return db.NewError(db.ErrInvalidArgument, "the vote must be -1 or 1")
+	}
+	return nil
+}
+
+// beforeCreate checks that model can be created, and sets the fields of model
+// that the database sets
+func (s *storage) beforeCreate(model igor.DBModel) error {
+	switch m := model.(type) {
+	case *db.User:
+		if m.Username == "" {
+			return db.NewError(db.ErrInvalidArgument, "the username is required")
+		}
+		for _, row := range s.tables[m.TableName()] {
+			user := row.Interface().(db.User)
+			if strings.EqualFold(user.Username, m.Username) {
+				return db.NewError(db.ErrConflict, "the username is already in use")
+			}
+			if m.Email != "" && strings.EqualFold(user.Email, m.Email) {
+				return db.NewError(db.ErrConflict, "the email is already in use")
+			}
+		}
+
+	case *db.Project:
+		if m.Name == "" {
+			return db.NewError(db.ErrInvalidArgument, "the project name is required")
+		}
+		for _, row := range s.tables[m.TableName()] {
+			if strings.EqualFold(row.Interface().(db.Project).Name, m.Name) {
+				return db.NewError(db.ErrConflict, "the project name is already in use")
+			}
+		}
+
+	case *db.ProjectOwner:
+		var project db.Project
+		if !s.project(m.To, &project) {
+			return db.NewError(db.ErrNotFound, "the project does not exist")
+		}
+		return s.users(m.From)
+
+	case *db.ProjectMember:
+		var project db.Project
+		if !s.project(m.To, &project) {
+			return db.NewError(db.ErrNotFound, "the project does not exist")
+		}
+		if err := s.users(m.From); err != nil {
+			return err
+		}
+		return s.blacklistControl(m.From, s.projectOwner(m.To))
+
+	case *db.UserFollower:
+		if err := s.users(m.From, m.To); err != nil {
+			return err
+		}
+		if m.From == m.To {
+			return db.NewError(db.ErrInvalidArgument, "you can't follow yourself")
+		}
+		return s.blacklistControl(m.From, m.To)
+
+	case *db.ProjectFollower:
+		var project db.Project
+		if !s.project(m.To, &project) {
+			return db.NewError(db.ErrNotFound, "the project does not exist")
+		}
+		if err := s.users(m.From); err != nil {
+			return err
+		}
+		return s.blacklistControl(m.From, s.projectOwner(m.To))
+
+	case *db.Blacklist:
+		if err := s.users(m.From, m.To); err != nil {
+			return err
+		}
+		if m.From == m.To {
+			return db.NewError(db.ErrInvalidArgument, "you can't blacklist yourself")
+		}
+
+	case *db.Whitelist:
+		if err := s.users(m.From, m.To); err != nil {
+			return err
+		}
+		if m.From == m.To {
+			return db.NewError(db.ErrInvalidArgument, "you can't whitelist yourself")
+		}
+		return s.blacklistControl(m.From, m.To)
+
+	case *db.Interest:
+		if err := s.users(m.From); err != nil {
+			return err
+		}
+		for _, row := range s.where(&db.Interest{From: m.From}) {
+			if strings.EqualFold(row.Interface().(db.Interest).Value, m.Value) {
+				return db.NewError(db.ErrConflict, "the interest already exists")
+			}
+		}
+
+	case *db.PM:
+		if err := s.users(m.From, m.To); err != nil {
+			return err
+		}
+		if err := s.blacklistControl(m.From, m.To); err != nil {
+			return err
+		}
+		m.ToRead = true
+
+	case *db.UserPost:
+		if err := s.users(m.From, m.To); err != nil {
+			return err
+		}
+		if err := s.blacklistControl(m.From, m.To); err != nil {
+			return err
+		}
+		if m.From != m.To {
+			var profile db.Profile
+			s.first(&db.Profile{Counter: m.To}, &profile)
+			if profile.Closed && !s.exists(&db.Whitelist{From: m.To, To: m.From}) {
+				return db.NewError(db.ErrPermissionDenied, "the board is closed")
+			}
+		}
+		m.Pid = s.nextPid(&db.UserPost{Post: db.Post{To: m.To}})
+
+	case *db.ProjectPost:
+		var project db.Project
+		if !s.project(m.To, &project) {
+			return db.NewError(db.ErrNotFound, "the project does not exist")
+		}
+		if err := s.users(m.From); err != nil {
+			return err
+		}
+		if err := s.blacklistControl(m.From, s.projectOwner(m.To)); err != nil {
+			return err
+		}
+		if !project.Open && !s.projectMember(m.To, m.From) {
+			return db.NewError(db.ErrPermissionDenied, "the project is closed")
+		}
+		m.Pid = s.nextPid(&db.ProjectPost{Post: db.Post{To: m.To}})
+
+	case *db.UserPostComment:
+		var post db.UserPost
+		if !s.userPost(m.Hpid, &post) {
+			return db.NewError(db.ErrNotFound, "the post does not exist")
+		}
+		if err := s.users(m.From); err != nil {
+			return err
+		}
+		for _, user := range []uint64{post.From, post.To} {
+			if err := s.blacklistControl(m.From, user); err != nil {
+				return err
+			}
+		}
+		if post.Closed && m.From != post.From && m.From != post.To {
+			return db.NewError(db.ErrPermissionDenied, "the post is closed")
+		}
+		m.To = post.To
+
+	case *db.ProjectPostComment:
+		var post db.ProjectPost
+		if !s.projectPost(m.Hpid, &post) {
+			return db.NewError(db.ErrNotFound, "the post does not exist")
+		}
+		if err := s.users(m.From); err != nil {
+			return err
+		}
+		for _, user := range []uint64{post.From, s.projectOwner(post.To)} {
+			if err := s.blacklistControl(m.From, user); err != nil {
+				return err
+			}
+		}
+		if post.Closed && m.From != post.From && !s.projectMember(post.To, m.From) {
+			return db.NewError(db.ErrPermissionDenied, "the post is closed")
+		}
+		m.To = post.To
+
+	case *db.UserPostVote:
+		var post db.UserPost
+		if !s.userPost(m.Hpid, &post) {
+			return db.NewError(db.ErrNotFound, "the post does not exist")
+		}
+		if err := s.users(m.From); err != nil {
+			return err
+		}
+		if err := validVote(m.Vote); err != nil {
+			return err
+		}
+		if err := s.blacklistControl(m.From, post.From); err != nil {
+			return err
+		}
+		if m.To == 0 {
+			m.To = post.To
+		}
+		s.remove(&db.UserPostVote{Hpid: m.Hpid, From: m.From})
+
+	case *db.ProjectPostVote:
+		var post db.ProjectPost
+		if !s.projectPost(m.Hpid, &post) {
+			return db.NewError(db.ErrNotFound, "the post does not exist")
+		}
+		if err := s.users(m.From); err != nil {
+			return err
+		}
+		if err := validVote(m.Vote); err != nil {
+			return err
+		}
+		if err := s.blacklistControl(m.From, post.From); err != nil {
+			return err
+		}
+		if m.To == 0 {
+			m.To = post.To
+		}
+		s.remove(&db.ProjectPostVote{Hpid: m.Hpid, From: m.From})
+
+	case *db.UserPostCommentVote:
+		var comment db.UserPostComment
+		if m.Hcid == 0 || !s.first(&db.UserPostComment{Hcid: m.Hcid}, &comment) {
+			return db.NewError(db.ErrNotFound, "the comment does not exist")
+		}
+		if err := s.users(m.From); err != nil {
+			return err
+		}
+		if err := validVote(m.Vote); err != nil {
+			return err
+		}
+		if err := s.blacklistControl(m.From, comment.From); err != nil {
+			return err
+		}
+		s.remove(&db.UserPostCommentVote{Hcid: m.Hcid, From: m.From})
+
+	case *db.ProjectPostCommentVote:
+		var comment db.ProjectPostComment
+		if m.Hcid == 0 || !s.first(&db.ProjectPostComment{Hcid: m.Hcid}, &comment) {
+			return db.NewError(db.ErrNotFound, "the comment does not exist")
+		}
+		if err := s.users(m.From); err != nil {
+			return err
+		}
+		if err := validVote(m.Vote); err != nil {
+			return err
+		}
+		if err := s.blacklistControl(m.From, comment.From); err != nil {
+			return err
+		}
+		if m.To == 0 {
+			m.To = comment.To
+		}
+		s.remove(&db.ProjectPostCommentVote{Hcid: m.Hcid, From: m.From})
+
+	case *db.UserPostBookmark:
+		var post db.UserPost
+		if !s.userPost(m.Hpid, &post) {
+			return db.NewError(db.ErrNotFound, "the post does not exist")
+		}
+		return s.users(m.From)
+
+	case *db.ProjectPostBookmark:
+		var post db.ProjectPost
+		if !s.projectPost(m.Hpid, &post) {
+			return db.NewError(db.ErrNotFound, "the post does not exist")
+		}
+		return s.users(m.From)
+
+	case *db.UserPostLurk:
+		var post db.UserPost
+		if !s.userPost(m.Hpid, &post) {
+			return db.NewError(db.ErrNotFound, "the post does not exist")
+		}
+		if err := s.users(m.From); err != nil {
+			return err
+		}
+		if s.exists(&db.UserPostComment{Hpid: m.Hpid, From: m.From}) {
+			return db.NewError(db.ErrPermissionDenied, "you can't lurk a post you commented")
+		}
+		m.To = post.To
+
+	case *db.ProjectPostLurk:
+		var post db.ProjectPost
+		if !s.projectPost(m.Hpid, &post) {
+			return db.NewError(db.ErrNotFound, "the post does not exist")
+		}
+		if err := s.users(m.From); err != nil {
+			return err
+		}
+		if s.exists(&db.ProjectPostComment{Hpid: m.Hpid, From: m.From}) {
+			return db.NewError(db.ErrPermissionDenied, "you can't lurk a post you commented")
+		}
+		m.To = post.To
+
+	case *db.UserPostLock:
+		var post db.UserPost
+		if !s.userPost(m.Hpid, &post) {
+			return db.NewError(db.ErrNotFound, "the post does not exist")
+		}
+		return s.users(m.User)
+
+	case *db.ProjectPostLock:
+		var post db.ProjectPost
+		if !s.projectPost(m.Hpid, &post) {
+			return db.NewError(db.ErrNotFound, "the post does not exist")
+		}
+		return s.users(m.User)
+
+	case *db.UserPostUserLock:
+		var post db.UserPost
+		if !s.userPost(m.Hpid, &post) {
+			return db.NewError(db.ErrNotFound, "the post does not exist")
+		}
+		return s.users(m.From, m.To)
+
+	case *db.ProjectPostUserLock:
+		var post db.ProjectPost
+		if !s.projectPost(m.Hpid, &post) {
+			return db.NewError(db.ErrNotFound, "the post does not exist")
+		}
+		return s.users(m.From, m.To)
+
+	case *db.OAuth2ClientCertificate:
+		if m.ClientID == 0 || !s.exists(&db.OAuth2Client{ID: m.ClientID}) {
+			return db.NewError(db.ErrNotFound, "the OAuth2 client does not exist")
+		}
+	}
+	return nil
+}
+
+// afterCreate applies the side effects of the creation of model
+func (s *storage) afterCreate(model igor.DBModel) {
+	switch m := model.(type) {
+	case *db.User:
+		if !s.exists(&db.Profile{Counter: m.Counter}) {
+			profile := reflect.ValueOf(&db.Profile{Counter: m.Counter}).Elem()
+			setDefaults(profile)
+			s.tables[db.Profile{}.TableName()] = append(s.tables[db.Profile{}.TableName()], profile)
+		}
+
+	case *db.Blacklist:
+		// blacklisting someone ends the relations with them
+		s.remove(&db.UserFollower{From: m.From, To: m.To})
+		s.remove(&db.UserFollower{From: m.To, To: m.From})
+		s.remove(&db.Whitelist{From: m.From, To: m.To})
+		s.remove(&db.Whitelist{From: m.To, To: m.From})
+
+	case *db.UserPostComment:
+		var post db.UserPost
+		s.userPost(m.Hpid, &post)
+		users := s.notified(m.From, map[uint64]bool{post.From: true, post.To: true},
+			s.pluck(&db.UserPostComment{Hpid: m.Hpid}, "from"), s.pluck(&db.UserPostLurk{Hpid: m.Hpid}, "from"))
+		s.remove(&db.UserPostLurk{Hpid: m.Hpid, From: m.From})
+		for _, to := range users {
+			if s.exists(&db.UserPostLock{User: to, Hpid: m.Hpid}) || s.exists(&db.UserPostUserLock{From: to, To: m.From, Hpid: m.Hpid}) {
+				continue
+			}
+			s.remove(&db.UserPostCommentsNotify{From: m.From, To: to, Hpid: m.Hpid})
+			s.insert(&db.UserPostCommentsNotify{From: m.From, To: to, Hpid: m.Hpid, Time: m.Time})
+		}
+
+	case *db.ProjectPostComment:
+		var post db.ProjectPost
+		s.projectPost(m.Hpid, &post)
+		users := s.notified(m.From, map[uint64]bool{post.From: true},
+			s.pluck(&db.ProjectPostComment{Hpid: m.Hpid}, "from"), s.pluck(&db.ProjectPostLurk{Hpid: m.Hpid}, "from"))
+		s.remove(&db.ProjectPostLurk{Hpid: m.Hpid, From: m.From})
+		for _, to := range users {
+			if s.exists(&db.ProjectPostLock{User: to, Hpid: m.Hpid}) || s.exists(&db.ProjectPostUserLock{From: to, To: m.From, Hpid: m.Hpid}) {
+				continue
+			}
+			s.remove(&db.ProjectPostCommentsNotify{From: m.From, To: to, Hpid: m.Hpid})
+			s.insert(&db.ProjectPostCommentsNotify{From: m.From, To: to, Hpid: m.Hpid, Time: m.Time})
+		}
+
+	case *db.ProjectPost:
+		users := s.notified(m.From, map[uint64]bool{s.projectOwner(m.To): true},
+			s.pluck(&db.ProjectMember{To: m.To}, "from"), s.pluck(&db.ProjectFollower{To: m.To}, "from"))
+		for _, to := range users {
+			s.insert(&db.ProjectNotify{From: m.To, To: to, Hpid: m.Hpid, Time: m.Time})
+		}
+	}
+}
+
+// notified returns, sorted, the users that must be notified of something done by from:
+// every one of users but from and the users that blacklisted from
+func (s *storage) notified(from uint64, users ...map[uint64]bool) []uint64 {
+	var ret []uint64
+	for _, set := range users {
+		for user := range set {
+			if user != 0 && user != from && !s.blacklisted(user, from) && !utils.InSlice(user, ret) {
+				ret = append(ret, user)
+			}
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i] < ret[j] })
+	return ret
+}
+
+// insert stores model as a side effect of another change, assigning its primary key and its default values
+func (s *storage) insert(model igor.DBModel) {
+	row, _ := indirect(model)
+	setDefaults(row)
+
+	table := model.TableName()
+	s.keys[table]++
+	key, _ := primaryKey(row)
+	key.SetUint(s.keys[table])
+
+	s.tables[table] = append(s.tables[table], clone(row))
+}
+
+// beforeUpdate checks that row can be updated with the non-zero fields of changes,
+// storing the revisions of the edited messages
+func (s *storage) beforeUpdate(row, changes reflect.Value) error {
+	switch current := row.Interface().(type) {
+	case db.UserPost:
+		if message := changes.Interface().(db.UserPost).Message; message != "" && message != current.Message {
+			s.revision(&db.UserPostRevision{Hpid: current.Hpid, Message: current.Message}, &db.UserPostRevision{Hpid: current.Hpid})
+		}
+
+	case db.ProjectPost:
+		if message := changes.Interface().(db.ProjectPost).Message; message != "" && message != current.Message {
+			s.revision(&db.ProjectPostRevision{Hpid: current.Hpid, Message: current.Message}, &db.ProjectPostRevision{Hpid: current.Hpid})
+		}
+
+	case db.UserPostComment:
+		if message := changes.Interface().(db.UserPostComment).Message; message != "" && message != current.Message {
+			if !current.Editable {
+				return db.NewError(db.ErrPermissionDenied, "the comment is not editable")
+			}
+			s.revision(&db.UserPostCommentRevision{Hcid: current.Hcid, Message: current.Message}, &db.UserPostCommentRevision{Hcid: current.Hcid})
+		}
+
+	case db.ProjectPostComment:
+		if message := changes.Interface().(db.ProjectPostComment).Message; message != "" && message != current.Message {
+			if !current.Editable {
+				return db.NewError(db.ErrPermissionDenied, "the comment is not editable")
+			}
+			s.revision(&db.ProjectPostCommentRevision{Hcid: current.Hcid, Message: current.Message}, &db.ProjectPostCommentRevision{Hcid: current.Hcid})
+		}
+	}
+	return nil
+}
+
+// revision stores the revision, numbering it after the previous revisions
+// of the same message, described by previous
+func (s *storage) revision(revision, previous igor.DBModel) {
+	row, _ := indirect(revision)
+	setDefaults(row)
+
+	table := revision.TableName()
+	s.keys[table]++
+	key, _ := primaryKey(row)
+	key.SetUint(s.keys[table])
+
+	revNo, _ := column(row, "rev_no")
+	number := len(s.where(previous)) + 1
+	if revNo.Kind() == reflect.Int8 {
+		revNo.SetInt(int64(number))
+	} else {
+		revNo.SetUint(uint64(number))
+	}
+
+	s.tables[table] = append(s.tables[table], clone(row))
+}
+
+// afterDelete deletes the rows that depend on the deleted row
+func (s *storage) afterDelete(row reflect.Value) {
+	switch deleted := row.Interface().(type) {
+	case db.UserPost:
+		hpid := deleted.Hpid
+		for _, comment := range s.remove(&db.UserPostComment{Hpid: hpid}) {
+			s.afterDelete(comment)
+		}
+		s.remove(&db.UserPostVote{Hpid: hpid})
+		s.remove(&db.UserPostBookmark{Hpid: hpid})
+		s.remove(&db.UserPostLurk{Hpid: hpid})
+		s.remove(&db.UserPostLock{Hpid: hpid})
+		s.remove(&db.UserPostUserLock{Hpid: hpid})
+		s.remove(&db.UserPostCommentsNotify{Hpid: hpid})
+		s.remove(&db.UserPostRevision{Hpid: hpid})
+		s.remove(&db.Mention{UHpid: hpid})
+		s.remove(&db.PostClassification{UHpid: hpid})
+
+	case db.ProjectPost:
+		hpid := deleted.Hpid
+		for _, comment := range s.remove(&db.ProjectPostComment{Hpid: hpid}) {
+			s.afterDelete(comment)
+		}
+		s.remove(&db.ProjectPostVote{Hpid: hpid})
+		s.remove(&db.ProjectPostBookmark{Hpid: hpid})
+		s.remove(&db.ProjectPostLurk{Hpid: hpid})
+		s.remove(&db.ProjectPostLock{Hpid: hpid})
+		s.remove(&db.ProjectPostUserLock{Hpid: hpid})
+		s.remove(&db.ProjectPostCommentsNotify{Hpid: hpid})
+		s.remove(&db.ProjectNotify{Hpid: hpid})
+		s.remove(&db.ProjectPostRevision{Hpid: hpid})
+		s.remove(&db.Mention{GHpid: hpid})
+		s.remove(&db.PostClassification{GHpid: hpid})
+
+	case db.UserPostComment:
+		s.remove(&db.UserPostCommentVote{Hcid: deleted.Hcid})
+		s.remove(&db.UserPostCommentRevision{Hcid: deleted.Hcid})
+
+	case db.ProjectPostComment:
+		s.remove(&db.ProjectPostCommentVote{Hcid: deleted.Hcid})
+		s.remove(&db.ProjectPostCommentRevision{Hcid: deleted.Hcid})
+	}
+}
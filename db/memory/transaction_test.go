@@ -0,0 +1,84 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nerdzeu/nerdz-core/db"
+)
+
+func TestTransaction(t *testing.T) {
+	store, ctx := newStore()
+	me := newUser(t, store, ctx, "me")
+	other := newUser(t, store, ctx, "other")
+	post := newPost(t, ctx, me, me, "Mine")
+
+	// The second lock refers to a missing user: the first one must be rolled back
+	if _, err := me.LockPost(ctx, post, other, &db.User{Counter: 42}); err == nil {
+		t.Fatalf("Locking a post for a missing user should fail")
+	}
+	if count, _ := store.Storage().Count(ctx, &db.UserPostUserLock{Hpid: post.Hpid}); count != 0 {
+		t.Errorf("A failed lock should leave no locks, but got %d", count)
+	}
+
+	failure := errors.New("failure")
+	err := store.Transaction(ctx, func(ctx context.Context) error {
+		newPost(t, ctx, me, me, "Rolled back")
+		return db.Transaction(ctx, func(ctx context.Context) error {
+			newPost(t, ctx, other, me, "Rolled back too")
+			return failure
+		})
+	})
+	if err != failure {
+		t.Errorf("The transaction should return the error of f, but got: %v", err)
+	}
+	if count, _ := store.Storage().Count(ctx, &db.UserPost{Post: db.Post{To: me.ID()}}); count != 1 {
+		t.Errorf("The posts of a failed transaction should be rolled back, but there are %d posts", count)
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("The panic of f should be propagated")
+			}
+		}()
+		store.Transaction(ctx, func(ctx context.Context) error {
+			newPost(t, ctx, me, me, "Rolled back")
+			panic("failure")
+		})
+	}()
+	if count, _ := store.Storage().Count(ctx, &db.UserPost{Post: db.Post{To: me.ID()}}); count != 1 {
+		t.Errorf("The posts of a panicking transaction should be rolled back, but there are %d posts", count)
+	}
+
+	post.SetText("Edited")
+	if err = me.Edit(ctx, post); err != nil {
+		t.Fatalf("No error should happen when editing a post, but got: %s", err)
+	}
+	if revisions := post.RevisionsNumber(ctx); revisions != 1 {
+		t.Errorf("The edit should record a revision, but got %d revisions", revisions)
+	}
+
+	post.SetText("Not mine")
+	if err = other.Edit(ctx, post); !errors.Is(err, db.ErrPermissionDenied) || post.Text() != "Not mine" {
+		t.Errorf("Editing the post of another user should fail with ErrPermissionDenied and keep the text, but got: %v", err)
+	}
+}
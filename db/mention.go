@@ -0,0 +1,196 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db
+
+import (
+	"context"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/nerdzeu/nerdz-core/db/igor"
+)
+
+const (
+	// MinMentions represents the minimum mentions number that can be required in a list of mentions
+	MinMentions uint64 = 1
+	// MaxMentions represents the maximum mentions number that can be required in a list of mentions
+	MaxMentions uint64 = 20
+	// MaxMentioned is the maximum number of usernames a post or a comment can mention: the others are ignored
+	MaxMentioned = 10
+)
+
+// MentionsOptions represent the configuration used to fetch a list of mentions
+type MentionsOptions struct {
+	N     uint8   // number of mentions to return
+	Older *Cursor // if specified, tells to the function that is using this struct to return N mentions OLDER (created before) than the mention pointed by the cursor
+	Newer *Cursor // if specified, tells to the function that is using this struct to return the N mentions NEWER (created after) than the mention pointed by the cursor, nearest first
+}
+
+// mentionsQueryBuilder returns the same pointer passed as first argument, with new specified options setted
+func mentionsQueryBuilder(query *igor.Database, options MentionsOptions) *igor.Database {
+	query = query.Limit(int(AtMostMentions(uint64(options.N))))
+	return keysetQueryBuilder(query, []string{"id"}, options.Older, options.Newer)
+}
+
+var (
+	// atMention matches @username, when the @ is not part of a word (like in an email address)
+	atMention = regexp.MustCompile(`(?:^|[^\pL\pN_])@([\pL\pN_.\-]+)`)
+	// tagMention matches [user]username[/user]
+	tagMention = regexp.MustCompile(`(?i)\[user\](.+?)\[/user\]`)
+)
+
+// mentionedUsernames returns the usernames referenced in text, encoded by populateContent,
+// with @username or [user]username[/user], in order of appearance and without duplicates
+func mentionedUsernames(text string) []string {
+	type match struct {
+		position int
+		username string
+	}
+	var matches []match
+	for _, m := range atMention.FindAllStringSubmatchIndex(text, -1) {
+		// a dot at the end is the one of the sentence
+		matches = append(matches, match{m[2], strings.TrimRight(text[m[2]:m[3]], ".")})
+	}
+	for _, m := range tagMention.FindAllStringSubmatchIndex(text, -1) {
+		matches = append(matches, match{m[2], strings.TrimSpace(html.UnescapeString(text[m[2]:m[3]]))})
+	}
+
+	// the two kinds of references are merged in the order they appear
+	for i := 1; i < len(matches); i++ {
+		for j := i; j > 0 && matches[j].position < matches[j-1].position; j-- {
+			matches[j], matches[j-1] = matches[j-1], matches[j]
+		}
+	}
+
+	// the usernames are unique ignoring the case
+	var usernames []string
+	seen := make(map[string]bool)
+	for _, m := range matches {
+		if key := strings.ToLower(m.username); m.username != "" && !seen[key] {
+			seen[key] = true
+			usernames = append(usernames, m.username)
+		}
+	}
+	return usernames
+}
+
+// mention stores the mentions of the users referenced in message, a post or a comment, and tells them.
+// Only the first MaxMentioned usernames are considered.
+// The author, the users that don't exist, the ones that can't see the board and the ones
+// blacklisted by or blacklisting the author are not mentioned.
+// A user is mentioned by an author once per post until the mention is read, and an edited message
+// mentions only the users that have never been mentioned by its author in the post
+func (user *User) mention(ctx context.Context, message Content, edited bool) error {
+	var description Mention
+	var project uint64
+	switch content := message.(type) {
+	case *UserPost:
+		description.UHpid = content.Hpid
+	case *UserPostComment:
+		description.UHpid = content.Hpid
+	case *ProjectPost:
+		description.GHpid, project = content.Hpid, content.To
+	case *ProjectPostComment:
+		description.GHpid, project = content.Hpid, content.To
+	default:
+		// the private messages don't mention
+		return nil
+	}
+
+	usernames := mentionedUsernames(message.Text())
+	if len(usernames) > MaxMentioned {
+		usernames = usernames[:MaxMentioned]
+	}
+	if len(usernames) == 0 {
+		return nil
+	}
+	mentioned, err := storage(ctx).UsersByUsername(ctx, usernames)
+	if err != nil || len(mentioned) == 0 {
+		return err
+	}
+
+	// excluded are the author, the users of the blacklists, the ones already mentioned and,
+	// for an invisible project, the users that are neither its owner nor its members
+	description.From = user.ID()
+	excluded := map[uint64]bool{user.ID(): true}
+	var allowed map[uint64]bool
+	var ids []uint64
+	lists := []struct {
+		description igor.DBModel
+		column      string
+	}{
+		{&Blacklist{From: user.ID()}, "to"},
+		{&Blacklist{To: user.ID()}, "from"},
+		// the unread mentions or, for an edited message, any mention
+		{&Mention{From: user.ID(), UHpid: description.UHpid, GHpid: description.GHpid, ToNotify: !edited}, "to"},
+	}
+	for _, list := range lists {
+		ids = nil
+		if err = storage(ctx).Pluck(ctx, list.description, list.column, &ids); err != nil {
+			return err
+		}
+		for _, id := range ids {
+			excluded[id] = true
+		}
+	}
+	if project != 0 {
+		board, err := NewProject(ctx, project)
+		if err != nil {
+			return err
+		}
+		if !board.Visible {
+			allowed = make(map[uint64]bool)
+			for _, members := range []igor.DBModel{&ProjectOwner{To: project}, &ProjectMember{To: project}} {
+				ids = nil
+				if err = storage(ctx).Pluck(ctx, members, "from", &ids); err != nil {
+					return err
+				}
+				for _, id := range ids {
+					allowed[id] = true
+				}
+			}
+		}
+	}
+
+	for _, other := range mentioned {
+		if excluded[other.ID()] || (allowed != nil && !allowed[other.ID()]) {
+			continue
+		}
+		excluded[other.ID()] = true
+
+		mention := description
+		mention.To = other.ID()
+		if err := storage(ctx).Create(ctx, &mention); err != nil {
+			return err
+		}
+
+		event := contentEvent(message)
+		event.Type, event.To, event.Time = EventMention, other.ID(), mention.Time
+		if err := publish(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Mentions returns the mentions of user, selected by options, from the newest to the oldest
+func (user *User) Mentions(ctx context.Context, options MentionsOptions) (*[]Mention, error) {
+	mentions, e := storage(ctx).Mentions(ctx, &Mention{To: user.ID()}, options)
+	return &mentions, e
+}
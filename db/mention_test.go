@@ -0,0 +1,54 @@
+//go:build postgres
+// +build postgres
+
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db_test
+
+import (
+	"testing"
+
+	"github.com/nerdzeu/nerdz-core/db"
+)
+
+func TestMentions(t *testing.T) {
+	post := db.UserPost{}
+	post.Message = "Hi @" + other.Username + ", @" + me.Username + ", @" + blacklisted.Username + " and @nobody_core"
+	if err := me.Submit(ctx, &post); err != nil {
+		t.Fatalf("No error should happen when posting, but got: %s", err)
+	}
+	defer me.Delete(ctx, &post)
+
+	mentions, err := post.Mentions(ctx, db.MentionsOptions{})
+	if err != nil || len(*mentions) != 1 || (*mentions)[0].To != other.ID() {
+		t.Fatalf("Only the existing users that are not in the blacklist should be mentioned, but got %+v (%v)", mentions, err)
+	}
+
+	post.Message = "Hi [user]" + other.Username + "[/user]!"
+	if err = me.Edit(ctx, &post); err != nil {
+		t.Fatalf("No error should happen when editing a post, but got: %s", err)
+	}
+	if mentions, err = post.Mentions(ctx, db.MentionsOptions{}); err != nil || len(*mentions) != 1 {
+		t.Errorf("Editing a post should not mention again, but got %+v (%v)", mentions, err)
+	}
+
+	mentions, err = other.Mentions(ctx, db.MentionsOptions{N: 1})
+	if err != nil || len(*mentions) != 1 || (*mentions)[0].From != me.ID() || (*mentions)[0].UHpid != post.Hpid {
+		t.Errorf("The mention should be the newest of the user, but got %+v (%v)", mentions, err)
+	}
+}
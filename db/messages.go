@@ -18,7 +18,9 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package db
 
 import (
-	"github.com/galeone/igor"
+	"context"
+
+	"github.com/nerdzeu/nerdz-core/db/igor"
 )
 
 // Type definitions for [comment, post, pm]
@@ -41,17 +43,18 @@ type Content interface {
 	SetLanguage(string) error
 	ClearDefaults()
 
-	Sender() *User
+	Language() string
+	Sender(context.Context) *User
 	NumericSender() uint64
-	Reference() Reference
+	Reference(context.Context) Reference
 	NumericReference() uint64
 	IsEditable() bool
-	NumericOwners() []uint64
-	Owners() []*User
-	Revisions() []string
-	RevisionsNumber() uint8
-	VotesCount() int
-	Votes() *[]Vote
+	NumericOwners(context.Context) []uint64
+	Owners(context.Context) ([]*User, error)
+	Revisions(context.Context) []string
+	RevisionsNumber(context.Context) uint8
+	VotesCount(context.Context) int
+	Votes(context.Context) *[]Vote
 }
 
 // Reference represents a reference.
@@ -59,13 +62,12 @@ type Content interface {
 // A post, refers to a user/project board
 type Reference interface {
 	ID() uint64
-	Language() string
 }
 
 type userReferenceRelation interface {
-	Sender() *User
+	Sender(context.Context) *User
 	NumericSender() uint64
-	Reference() Reference
+	Reference(context.Context) Reference
 	NumericReference() uint64
 }
 
@@ -94,16 +96,17 @@ type Lock interface {
 type ExistingPost interface {
 	Content
 
-	Comments(CommentlistOptions) *[]ExistingComment
-	CommentsCount() uint8
-	NumericBookmarkers() []uint64
-	Bookmarkers() []*User
-	BookmarksCount() uint8
-	Bookmarks() *[]Bookmark
-	NumericLurkers() []uint64
-	Lurkers() []*User
-	LurkersCount() uint8
-	Lurks() *[]Lurk
+	Comments(context.Context, CommentlistOptions) *[]ExistingComment
+	CommentsCount(context.Context) uint8
+	NumericBookmarkers(context.Context) []uint64
+	Bookmarkers(context.Context) ([]*User, error)
+	BookmarksCount(context.Context) uint8
+	Bookmarks(context.Context) *[]Bookmark
+	NumericLurkers(context.Context) []uint64
+	Lurkers(context.Context) ([]*User, error)
+	LurkersCount(context.Context) uint8
+	Lurks(context.Context) *[]Lurk
+	Mentions(context.Context, MentionsOptions) (*[]Mention, error)
 	IsClosed() bool
 	NumericType() uint8
 	Type() string
@@ -113,5 +116,5 @@ type ExistingPost interface {
 type ExistingComment interface {
 	Content
 
-	Post() (ExistingPost, error)
+	Post(context.Context) (ExistingPost, error)
 }
@@ -0,0 +1,3 @@
+-- The names of the OAuth2 clients are unique: the violations are reported as ErrConflict
+-- when a client is created (see oauth2CreateClient)
+CREATE UNIQUE INDEX IF NOT EXISTS oauth2_clients_name_key ON oauth2_clients (name);
@@ -0,0 +1,8 @@
+-- Public OAuth2 clients, that authenticate without secret and must use PKCE,
+-- and the PKCE code challenge stored with the authorization code (see OAuth2Client and OAuth2AuthorizeData)
+ALTER TABLE oauth2_clients ADD COLUMN IF NOT EXISTS public boolean NOT NULL DEFAULT FALSE;
+
+ALTER TABLE oauth2_authorize
+    ADD COLUMN IF NOT EXISTS code_challenge text NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS code_challenge_method varchar(5) NOT NULL DEFAULT ''
+        CHECK (code_challenge_method IN ('', 'plain', 'S256'));
@@ -0,0 +1,10 @@
+-- The TLS client certificates that authenticate the OAuth2 clients, identified by their
+-- SHA-256 fingerprint, and their revocation (see OAuth2ClientCertificate)
+CREATE TABLE IF NOT EXISTS oauth2_client_certificates (
+    fingerprint char(64) PRIMARY KEY CHECK (fingerprint ~ '^[0-9a-f]{64}$'),
+    client_id bigint NOT NULL REFERENCES oauth2_clients(id) ON DELETE CASCADE,
+    revoked boolean NOT NULL DEFAULT FALSE,
+    created_at timestamp without time zone NOT NULL DEFAULT (now() at time zone 'utc')
+);
+
+CREATE INDEX IF NOT EXISTS oauth2_client_certificates_client_id_idx ON oauth2_client_certificates (client_id);
@@ -21,7 +21,7 @@ import (
 	"database/sql"
 	"time"
 
-	"github.com/galeone/igor"
+	"github.com/nerdzeu/nerdz-core/db/igor"
 )
 
 // Enrich models structure with unexported types
@@ -126,8 +126,8 @@ type UserFollower struct {
 	From     uint64
 	To       uint64
 	Time     time.Time `sql:"default:(now() at time zone 'utc')"`
-	ToNotify bool
-	Counter  uint64 `igor:"primary_key"`
+	ToNotify bool      `sql:"default:true"`
+	Counter  uint64    `igor:"primary_key"`
 }
 
 // TableName returns the table name associated with the structure
@@ -423,8 +423,8 @@ type ProjectMember struct {
 	From     uint64
 	To       uint64
 	Time     time.Time `sql:"default:(now() at time zone 'utc')"`
-	ToNotify bool
-	Counter  uint64 `igor:"primary_key"`
+	ToNotify bool      `sql:"default:true"`
+	Counter  uint64    `igor:"primary_key"`
 }
 
 // TableName returns the table name associated with the structure
@@ -548,8 +548,8 @@ type ProjectFollower struct {
 	From     uint64
 	To       uint64
 	Time     time.Time `sql:"default:(now() at time zone 'utc')"`
-	ToNotify bool
-	Counter  uint64 `igor:"primary_key"`
+	ToNotify bool      `sql:"default:true"`
+	Counter  uint64    `igor:"primary_key"`
 }
 
 // TableName returns the table name associated with the structure
@@ -641,7 +641,7 @@ type Mention struct {
 	From     uint64
 	To       uint64
 	Time     time.Time `sql:"default:(now() at time zone 'utc')"`
-	ToNotify bool
+	ToNotify bool      `sql:"default:true"`
 }
 
 // TableName returns the table name associated with the structure
@@ -672,6 +672,9 @@ type OAuth2Client struct {
 	RedirectURI string
 	// UserID references User that created this client
 	UserID uint64
+	// Public is true if the client can't keep its secret confidential (e.g. mobile and browser applications).
+	// Public clients authenticate without secret and must use PKCE
+	Public bool
 }
 
 // TableName returns the table name associated with the structure
@@ -679,6 +682,24 @@ func (OAuth2Client) TableName() string {
 	return "oauth2_clients"
 }
 
+// OAuth2ClientCertificate is the model for the relation oauth2_client_certificates,
+// that maps the TLS client certificates to the OAuth2 clients they authenticate
+type OAuth2ClientCertificate struct {
+	// Fingerprint is the hex encoded, lower cased, SHA-256 fingerprint of the certificate
+	Fingerprint string `igor:"primary_key"`
+	// ClientID references the client authenticated by the certificate
+	ClientID uint64
+	// Revoked is true if the certificate does not authenticate the client anymore
+	Revoked bool
+	// CreatedAt is the instant of creation of the OAuth2ClientCertificate
+	CreatedAt time.Time `sql:"default:(now() at time zone 'utc')"`
+}
+
+// TableName returns the table name associated with the structure
+func (OAuth2ClientCertificate) TableName() string {
+	return "oauth2_client_certificates"
+}
+
 // OAuth2AuthorizeData is the model for the relation oauth2_authorize
 // that represents the authorization granted to to the client
 type OAuth2AuthorizeData struct {
@@ -700,6 +721,10 @@ type OAuth2AuthorizeData struct {
 	RedirectURI string
 	// UserID is references the User that created the authorization request and thus the AuthorizeData
 	UserID uint64
+	// CodeChallenge is the PKCE code challenge. Can be empty
+	CodeChallenge string
+	// CodeChallengeMethod is the PKCE code challenge method: plain or S256
+	CodeChallengeMethod string
 }
 
 // TableName returns the table name associated with the structure
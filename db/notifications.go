@@ -0,0 +1,444 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nerdzeu/nerdz-core/db/igor"
+)
+
+const (
+	// MinNotifications represents the minimum notifications number that can be required in a list
+	MinNotifications uint64 = 1
+	// MaxNotifications represents the maximum notifications number that can be required in a list
+	MaxNotifications uint64 = 20
+	// MaxNotificationsStory is the number of read notifications kept in the story of a user
+	MaxNotificationsStory = 100
+)
+
+// NotificationType identifies the kind of a Notification
+type NotificationType uint8
+
+const (
+	// NotificationComment is a new comment on a user post that the user wrote, commented, lurks or that is on their board
+	NotificationComment NotificationType = iota
+	// NotificationProjectComment is a new comment on a project post that the user wrote, commented or lurks
+	NotificationProjectComment
+	// NotificationProjectPost is a new post on a project the user owns, is a member of or follows
+	NotificationProjectPost
+	// NotificationMention is a mention of the user in a post or in a comment
+	NotificationMention
+	// NotificationFollower is a new follower of the user
+	NotificationFollower
+	// NotificationProjectFollower is a new follower of a project the user owns
+	NotificationProjectFollower
+	// NotificationMember is the user, added to the members of a project
+	NotificationMember
+)
+
+// notificationModels maps every NotificationType to the relation that stores the unread notifications of that type
+var notificationModels = [...]igor.DBModel{
+	NotificationComment:         UserPostCommentsNotify{},
+	NotificationProjectComment:  ProjectPostCommentsNotify{},
+	NotificationProjectPost:     ProjectNotify{},
+	NotificationMention:         Mention{},
+	NotificationFollower:        UserFollower{},
+	NotificationProjectFollower: ProjectFollower{},
+	NotificationMember:          ProjectMember{},
+}
+
+// notificationQueries maps every NotificationType to the query that selects the unread notifications of that type
+// of the user in me (a CTE of the query), excluding the ones the user locked. The columns are the fields of Notification
+var notificationQueries = [...]string{
+	NotificationComment: `SELECT 0, n.counter, n."from", p."to", FALSE, n.hpid, n."time"
+		FROM comments_notify n JOIN posts p ON p.hpid = n.hpid CROSS JOIN me
+		WHERE n."to" = me.id
+		AND NOT EXISTS (SELECT 1 FROM posts_no_notify l WHERE l."user" = me.id AND l.hpid = n.hpid)
+		AND NOT EXISTS (SELECT 1 FROM comments_no_notify l WHERE l."from" = me.id AND l."to" = n."from" AND l.hpid = n.hpid)`,
+	NotificationProjectComment: `SELECT 1, n.counter, n."from", p."to", TRUE, n.hpid, n."time"
+		FROM groups_comments_notify n JOIN groups_posts p ON p.hpid = n.hpid CROSS JOIN me
+		WHERE n."to" = me.id
+		AND NOT EXISTS (SELECT 1 FROM groups_posts_no_notify l WHERE l."user" = me.id AND l.hpid = n.hpid)
+		AND NOT EXISTS (SELECT 1 FROM groups_comments_no_notify l WHERE l."from" = me.id AND l."to" = n."from" AND l.hpid = n.hpid)`,
+	// the notifications of the project posts come from the project
+	NotificationProjectPost: `SELECT 2, n.counter, p."from", n."from", TRUE, n.hpid, n."time"
+		FROM groups_notify n JOIN groups_posts p ON p.hpid = n.hpid CROSS JOIN me
+		WHERE n."to" = me.id`,
+	NotificationMention: `SELECT 3, m.id, m."from", COALESCE(p."to", g."to", 0), g.hpid IS NOT NULL, COALESCE(p.hpid, g.hpid, 0), m."time"
+		FROM mentions m LEFT JOIN posts p ON p.hpid = m.uhpid LEFT JOIN groups_posts g ON g.hpid = m.ghpid CROSS JOIN me
+		WHERE m."to" = me.id AND m.to_notify
+		AND NOT EXISTS (SELECT 1 FROM posts_no_notify l WHERE l."user" = me.id AND l.hpid = m.uhpid)
+		AND NOT EXISTS (SELECT 1 FROM comments_no_notify l WHERE l."from" = me.id AND l."to" = m."from" AND l.hpid = m.uhpid)
+		AND NOT EXISTS (SELECT 1 FROM groups_posts_no_notify l WHERE l."user" = me.id AND l.hpid = m.ghpid)
+		AND NOT EXISTS (SELECT 1 FROM groups_comments_no_notify l WHERE l."from" = me.id AND l."to" = m."from" AND l.hpid = m.ghpid)`,
+	NotificationFollower: `SELECT 4, f.counter, f."from", f."to", FALSE, 0, f."time"
+		FROM followers f CROSS JOIN me
+		WHERE f."to" = me.id AND f.to_notify`,
+	NotificationProjectFollower: `SELECT 5, f.counter, f."from", f."to", TRUE, 0, f."time"
+		FROM groups_followers f CROSS JOIN me
+		WHERE f.to_notify AND f."to" IN (SELECT "to" FROM groups_owners WHERE "from" = me.id)`,
+	NotificationMember: `SELECT 6, m.counter, 0, m."to", TRUE, 0, m."time"
+		FROM groups_members m CROSS JOIN me
+		WHERE m."from" = me.id AND m.to_notify`,
+}
+
+// notificationsQuery returns the query that selects the unread notifications of types of a user,
+// the first parameter, sorted by time, type and ID, newest first, as the notifications relation
+func notificationsQuery(types []NotificationType) string {
+	queries := make([]string, len(types))
+	for i, notificationType := range types {
+		queries[i] = notificationQueries[notificationType]
+	}
+	return `WITH me(id) AS (SELECT ?::bigint),
+	notifications(type, id, "from", board, project, hpid, "time") AS (` + strings.Join(queries, "\n\tUNION ALL\n") + `)`
+}
+
+// NotificationModels returns the relations that store the notifications, whose names are the tables of their cursors
+func NotificationModels() []igor.DBModel {
+	return append([]igor.DBModel(nil), notificationModels[:]...)
+}
+
+// Notification tells a user something that happened on NERDZ.
+// The unread notifications are the records of the relations that cause them; once read,
+// the last MaxNotificationsStory are kept in the NotifyStory of the user (see notificationsStory)
+type Notification struct {
+	Type NotificationType `json:"type"`
+	// ID is the primary key of the record of the notification
+	ID uint64 `json:"id"`
+	// From is the user that caused the notification: the author of the comment, of the post or of the mention,
+	// or the new follower. It's 0 for a NotificationMember
+	From uint64 `json:"from,omitempty"`
+	// Board is the board of the post commented, created or that mentions the user, the followed board
+	// or the project the user is a member of. Project is true if it's a project
+	Board   uint64 `json:"board"`
+	Project bool   `json:"project,omitempty"`
+	// Hpid is the post commented, created or that mentions the user, if any
+	Hpid uint64    `json:"hpid,omitempty"`
+	Time time.Time `json:"time"`
+}
+
+// Cursor returns the cursor of the notification, in a list of notifications
+func (n *Notification) Cursor() *Cursor {
+	return &Cursor{Table: notificationModels[n.Type].TableName(), Time: n.Time, ID: n.ID}
+}
+
+// NotificationsOptions represent the configuration used to fetch a list of notifications
+type NotificationsOptions struct {
+	N     uint8              // number of notifications to return
+	Older *Cursor            // if specified, tells to return N notifications OLDER than the one pointed by the cursor
+	Newer *Cursor            // if specified, tells to return the N notifications NEWER than the one pointed by the cursor, nearest first
+	Types []NotificationType // if not empty, only the notifications of these types are returned
+	Read  bool               // if true, the read notifications are returned, instead of the unread ones
+}
+
+// notificationTypes returns types, or every NotificationType if types is empty.
+// Returns an error if a type is not a valid NotificationType
+func notificationTypes(types []NotificationType) ([]NotificationType, error) {
+	for _, notificationType := range types {
+		if int(notificationType) >= len(notificationModels) {
+			return nil, invalidArgument("invalid notification type %d", notificationType)
+		}
+	}
+	if len(types) > 0 {
+		return types, nil
+	}
+	all := make([]NotificationType, len(notificationModels))
+	for i := range all {
+		all[i] = NotificationType(i)
+	}
+	return all, nil
+}
+
+// notificationTypeOf returns the type of the notifications stored in table
+func notificationTypeOf(table string) NotificationType {
+	for i, model := range notificationModels {
+		if model.TableName() == table {
+			return NotificationType(i)
+		}
+	}
+	return 0
+}
+
+// Notifications returns the notifications of user selected by options, newest first.
+// The notifications of the comments and of the mentions on the posts that user locked,
+// and the ones caused by the users whose notifications user locked on a post, are not returned (see LockPost)
+func (user *User) Notifications(ctx context.Context, options NotificationsOptions) ([]Notification, error) {
+	types, err := notificationTypes(options.Types)
+	if err != nil {
+		return nil, err
+	}
+	options.Types = types
+
+	if !options.Read {
+		return storage(ctx).Notifications(ctx, user.ID(), options)
+	}
+
+	story, err := user.notificationsStory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := []Notification{}
+	for _, entry := range story.entries {
+		if entry.notification == nil || !hasNotificationType(types, entry.notification.Type) {
+			continue
+		}
+		cursor := entry.notification.Cursor()
+		if (options.Older == nil || notificationPosition(cursor, options.Older) > 0) &&
+			(options.Newer == nil || notificationPosition(cursor, options.Newer) < 0) {
+			selected = append(selected, *entry.notification)
+		}
+	}
+
+	if n := int(AtMostNotifications(uint64(options.N))); len(selected) > n {
+		if newerOnly(options.Older, options.Newer) {
+			return selected[len(selected)-n:], nil
+		}
+		return selected[:n], nil
+	}
+	return selected, nil
+}
+
+// notificationPosition returns -1, 0 or +1 if the notification pointed by a precedes, is or follows
+// the one pointed by b in a list of notifications, sorted by time, type and ID, newest first
+func notificationPosition(a, b *Cursor) int {
+	position := func(greater, less bool) int {
+		switch {
+		case greater:
+			return -1
+		case less:
+			return 1
+		}
+		return 0
+	}
+	if !a.Time.Equal(b.Time) {
+		return position(a.Time.After(b.Time), a.Time.Before(b.Time))
+	}
+	if a.Table != b.Table {
+		typeA, typeB := notificationTypeOf(a.Table), notificationTypeOf(b.Table)
+		return position(typeA > typeB, typeA < typeB)
+	}
+	return position(a.ID > b.ID, a.ID < b.ID)
+}
+
+// CountNotifications returns the number of unread notifications of user of types, of every type if types is empty
+func (user *User) CountNotifications(ctx context.Context, types ...NotificationType) (uint64, error) {
+	types, err := notificationTypes(types)
+	if err != nil {
+		return 0, err
+	}
+	return storage(ctx).CountNotifications(ctx, user.ID(), types)
+}
+
+// ReadNotifications marks as read the notifications of user of types, of every type if types is empty,
+// keeping them in the story of user
+func (user *User) ReadNotifications(ctx context.Context, types ...NotificationType) error {
+	return user.discardNotifications(ctx, types, true)
+}
+
+// ClearNotifications deletes the notifications of user of types, of every type if types is empty:
+// the unread ones and the ones kept in the story of user
+func (user *User) ClearNotifications(ctx context.Context, types ...NotificationType) error {
+	return user.discardNotifications(ctx, types, false)
+}
+
+// discardNotifications marks as read the unread notifications of user of types, and moves them into the story
+// of user if keep is true. Otherwise, the notifications of types are removed from the story.
+// Only the notifications read are discarded: the ones created meanwhile stay unread
+func (user *User) discardNotifications(ctx context.Context, types []NotificationType, keep bool) error {
+	types, err := notificationTypes(types)
+	if err != nil {
+		return err
+	}
+
+	return Transaction(ctx, func(ctx context.Context) error {
+		var read []Notification
+		options := NotificationsOptions{N: uint8(MaxNotifications), Types: types}
+		for {
+			unread, err := storage(ctx).Notifications(ctx, user.ID(), options)
+			if err != nil {
+				return err
+			}
+			if len(unread) == 0 {
+				break
+			}
+			if err = readNotifications(ctx, unread); err != nil {
+				return err
+			}
+			if keep && len(read) < MaxNotificationsStory {
+				read = append(read, unread...)
+			}
+			options.Older = unread[len(unread)-1].Cursor()
+		}
+
+		story, err := user.notificationsStory(ctx)
+		if err != nil {
+			return err
+		}
+		if keep {
+			story.add(read)
+		} else {
+			story.remove(types)
+		}
+
+		updated := story.json()
+		if err = storage(ctx).Updates(ctx, &User{Counter: user.ID(), NotifyStory: updated}); err != nil {
+			return err
+		}
+		user.NotifyStory = updated
+		return nil
+	})
+}
+
+// readNotifications marks as read the notifications: the records of the comments and of the project posts
+// are deleted, the other ones are no more to notify
+func readNotifications(ctx context.Context, notifications []Notification) error {
+	ids := make(map[NotificationType][]uint64)
+	for _, notification := range notifications {
+		ids[notification.Type] = append(ids[notification.Type], notification.ID)
+	}
+
+	for notificationType, ids := range ids {
+		var err error
+		switch model := notificationModels[notificationType]; notificationType {
+		case NotificationComment, NotificationProjectComment, NotificationProjectPost:
+			err = storage(ctx).DeleteIn(ctx, model, "counter", ids)
+		case NotificationMention:
+			err = storage(ctx).Notified(ctx, model, "id", ids)
+		default:
+			err = storage(ctx).Notified(ctx, model, "counter", ids)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// notificationsStory is the NotifyStory of a user, shared with the NERDZ website.
+// The story is an object whose keys are the indexes of its entries, newest first: the read notifications
+// stored by the core and the entries stored by the website, that are kept as they are.
+// The keys that are not indexes are kept too
+type notificationsStory struct {
+	entries []storyEntry
+	others  igor.JSON
+}
+
+// storyEntry is an entry of the story: a notification read, or an entry not stored by the core
+type storyEntry struct {
+	notification *Notification
+	raw          interface{}
+}
+
+// notificationsStory returns the story of user, as stored
+func (user *User) notificationsStory(ctx context.Context) (*notificationsStory, error) {
+	var stored User
+	if err := storage(ctx).Find(ctx, &User{Counter: user.ID()}, &stored); err != nil {
+		return nil, err
+	}
+
+	story := &notificationsStory{others: igor.JSON{}}
+	var indexes []int
+	for key, value := range stored.NotifyStory {
+		if index, err := strconv.Atoi(key); err == nil && index >= 0 {
+			indexes = append(indexes, index)
+		} else {
+			story.others[key] = value
+		}
+	}
+	sort.Ints(indexes)
+
+	for _, index := range indexes {
+		raw := stored.NotifyStory[strconv.Itoa(index)]
+		story.entries = append(story.entries, storyEntry{notification: storedNotification(raw), raw: raw})
+	}
+	return story, nil
+}
+
+// storedNotification returns the notification stored in the entry raw of a story,
+// or nil if the entry has not been stored by the core
+func storedNotification(raw interface{}) *Notification {
+	payload, err := json.Marshal(raw)
+	if err != nil {
+		return nil
+	}
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(payload, &fields) != nil || fields["id"] == nil || fields["type"] == nil {
+		return nil
+	}
+	var notification Notification
+	if json.Unmarshal(payload, &notification) != nil || int(notification.Type) >= len(notificationModels) {
+		return nil
+	}
+	return &notification
+}
+
+// add prepends the notifications read, newest first, to the story, keeping its last MaxNotificationsStory entries
+func (story *notificationsStory) add(read []Notification) {
+	entries := make([]storyEntry, 0, len(read)+len(story.entries))
+	for i := range read {
+		entries = append(entries, storyEntry{notification: &read[i]})
+	}
+	story.entries = append(entries, story.entries...)
+	if len(story.entries) > MaxNotificationsStory {
+		story.entries = story.entries[:MaxNotificationsStory]
+	}
+}
+
+// remove removes the notifications of types from the story
+func (story *notificationsStory) remove(types []NotificationType) {
+	var kept []storyEntry
+	for _, entry := range story.entries {
+		if entry.notification == nil || !hasNotificationType(types, entry.notification.Type) {
+			kept = append(kept, entry)
+		}
+	}
+	story.entries = kept
+}
+
+// json returns the story, as stored
+func (story *notificationsStory) json() igor.JSON {
+	ret := igor.JSON{}
+	for key, value := range story.others {
+		ret[key] = value
+	}
+	for i, entry := range story.entries {
+		if entry.notification != nil {
+			ret[strconv.Itoa(i)] = entry.notification
+		} else {
+			ret[strconv.Itoa(i)] = entry.raw
+		}
+	}
+	return ret
+}
+
+// hasNotificationType returns true if notificationType is one of types
+func hasNotificationType(types []NotificationType, notificationType NotificationType) bool {
+	for _, t := range types {
+		if t == notificationType {
+			return true
+		}
+	}
+	return false
+}
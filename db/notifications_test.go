@@ -0,0 +1,69 @@
+//go:build postgres
+// +build postgres
+
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db_test
+
+import (
+	"testing"
+
+	"github.com/nerdzeu/nerdz-core/db"
+)
+
+func TestNotifications(t *testing.T) {
+	post := db.UserPost{}
+	post.Message = "Comment me"
+	if err := me.Submit(ctx, &post); err != nil {
+		t.Fatalf("No error should happen when posting, but got: %s", err)
+	}
+	defer me.Delete(ctx, &post)
+
+	comment := db.UserPostComment{Hpid: post.Hpid, Message: "Notify me"}
+	if err := other.Submit(ctx, &comment); err != nil {
+		t.Fatalf("No error should happen when commenting, but got: %s", err)
+	}
+
+	options := db.NotificationsOptions{N: 1, Types: []db.NotificationType{db.NotificationComment}}
+	unread, err := me.Notifications(ctx, options)
+	if err != nil || len(unread) != 1 || unread[0].From != other.ID() || unread[0].Hpid != post.Hpid {
+		t.Fatalf("The comment should be the newest notification, but got %+v (%v)", unread, err)
+	}
+	if count, err := me.CountNotifications(ctx, db.NotificationComment); err != nil || count == 0 {
+		t.Errorf("The comment should be counted, but got %d (%v)", count, err)
+	}
+
+	if err = me.ReadNotifications(ctx, db.NotificationComment); err != nil {
+		t.Fatalf("No error should happen when reading the notifications, but got: %s", err)
+	}
+	if count, _ := me.CountNotifications(ctx, db.NotificationComment); count != 0 {
+		t.Errorf("No comment should be unread, but got %d", count)
+	}
+	options.Read = true
+	read, err := me.Notifications(ctx, options)
+	if err != nil || len(read) != 1 || read[0].ID != unread[0].ID || read[0].From != other.ID() {
+		t.Errorf("The read notification should be kept in the story, but got %+v (%v)", read, err)
+	}
+
+	if err = me.ClearNotifications(ctx, db.NotificationComment); err != nil {
+		t.Fatalf("No error should happen when clearing the notifications, but got: %s", err)
+	}
+	if read, _ = me.Notifications(ctx, options); len(read) != 0 {
+		t.Errorf("The comments should be removed from the story, but got %+v", read)
+	}
+}
@@ -0,0 +1,162 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OAuth2 scopes. An access token grants the actions of the scopes listed, space separated, in its Scope.
+// Reading public data requires no scope
+const (
+	// ScopePostsRead grants to read the home of the user
+	ScopePostsRead = "posts:read"
+	// ScopePostsWrite grants to submit, edit, delete, vote, bookmark, lurk and lock posts and comments
+	ScopePostsWrite = "posts:write"
+	// ScopePmsRead grants to read the private conversations of the user
+	ScopePmsRead = "pms:read"
+	// ScopePmsWrite grants to send private messages and to delete private conversations
+	ScopePmsWrite = "pms:write"
+	// ScopeProfileRead grants to read the private fields of the user: email, birth date and last access
+	ScopeProfileRead = "profile:read"
+	// ScopeProfileWrite grants to change the profile of the user, including the whitelist and the blacklist
+	ScopeProfileWrite = "profile:write"
+	// ScopeFollow grants to follow and unfollow users and projects
+	ScopeFollow = "follow"
+	// ScopeClients grants to create, list, update and delete the OAuth2 clients of the user
+	ScopeClients = "clients"
+	// ScopeNotifications grants to read, mark as read and clear the notifications of the user
+	ScopeNotifications = "notifications"
+)
+
+// scopes contains every valid scope
+var scopes = map[string]bool{
+	ScopePostsRead:     true,
+	ScopePostsWrite:    true,
+	ScopePmsRead:       true,
+	ScopePmsWrite:      true,
+	ScopeProfileRead:   true,
+	ScopeProfileWrite:  true,
+	ScopeFollow:        true,
+	ScopeClients:       true,
+	ScopeNotifications: true,
+}
+
+// ParseScopes returns the scopes listed, space separated, in scope.
+// Returns an error if scope contains an unknown scope
+func ParseScopes(scope string) ([]string, error) {
+	ret := strings.Fields(scope)
+	for _, s := range ret {
+		if !scopes[s] {
+			return nil, invalidArgument("unknown scope %s", s)
+		}
+	}
+	return ret, nil
+}
+
+// NewOAuth2Client returns the OAuth2Client with the specified id
+func NewOAuth2Client(ctx context.Context, id uint64) (*OAuth2Client, error) {
+	if id == 0 {
+		return nil, notFound("Requested OAuth2Client does not exist")
+	}
+	return NewOAuth2ClientWhere(ctx, &OAuth2Client{ID: id})
+}
+
+// NewOAuth2ClientWhere returns the first OAuth2Client that matches the description
+func NewOAuth2ClientWhere(ctx context.Context, description *OAuth2Client) (client *OAuth2Client, e error) {
+	if emptyDescription(description) {
+		return nil, invalidArgument("the description of the OAuth2Client is empty")
+	}
+	client = new(OAuth2Client)
+	if e = storage(ctx).Find(ctx, description, client); e != nil {
+		return nil, e
+	}
+	if client.ID == 0 {
+		return nil, notFound("Requested OAuth2Client does not exist")
+	}
+	return
+}
+
+// Implements osin.Client interface
+
+// GetId returns the client ID
+func (c *OAuth2Client) GetId() string {
+	return strconv.FormatUint(c.ID, 10)
+}
+
+// GetSecret returns the hash of the client secret
+func (c *OAuth2Client) GetSecret() string {
+	return c.Secret
+}
+
+// ClientSecretMatches returns true if secret is the client secret. Implements osin.ClientSecretMatcher.
+// Public clients have no secret: they match only the empty secret
+func (c *OAuth2Client) ClientSecretMatches(secret string) bool {
+	if c.Public {
+		return secret == ""
+	}
+	return oauth2SecretMatches(c.Secret, secret)
+}
+
+// GetRedirectUri returns the base client uri
+func (c *OAuth2Client) GetRedirectUri() string {
+	return c.RedirectURI
+}
+
+// GetUserData returns the ID of the user that created the client
+func (c *OAuth2Client) GetUserData() interface{} {
+	return c.UserID
+}
+
+// NewOAuth2AccessDataWhere returns the first OAuth2AccessData that matches the description
+func NewOAuth2AccessDataWhere(ctx context.Context, description *OAuth2AccessData) (access *OAuth2AccessData, e error) {
+	if emptyDescription(description) {
+		return nil, invalidArgument("the description of the OAuth2AccessData is empty")
+	}
+	access = new(OAuth2AccessData)
+	if e = storage(ctx).Find(ReadYourWrites(ctx), description, access); e != nil {
+		return nil, e
+	}
+	if access.ID == 0 {
+		return nil, notFound("Requested OAuth2AccessData does not exist")
+	}
+	return
+}
+
+// ExpireAt returns the instant in which the access token expires
+func (access *OAuth2AccessData) ExpireAt() time.Time {
+	return access.CreatedAt.Add(time.Duration(access.ExpiresIn) * time.Second)
+}
+
+// HasScope returns true if the access token has been granted scope
+func (access *OAuth2AccessData) HasScope(scope string) bool {
+	for _, s := range strings.Fields(access.Scope) {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// IsExpired returns true if the access token is expired
+func (access *OAuth2AccessData) IsExpired() bool {
+	return access.ExpireAt().Before(time.Now())
+}
@@ -0,0 +1,311 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// secretHashPrefix prefixes the hashed client secrets.
+// Secrets stored without it have been created before secrets were hashed
+const secretHashPrefix = "sha256:"
+
+// OAuth2Clients returns the OAuth2 clients owned by the user
+func (user *User) OAuth2Clients(ctx context.Context) []*OAuth2Client {
+	clients := []OAuth2Client{}
+	storage(ctx).Find(ctx, &OAuth2Client{UserID: user.ID()}, &clients)
+	sort.Slice(clients, func(i, j int) bool { return clients[i].ID < clients[j].ID })
+	var ret []*OAuth2Client
+	for i := range clients {
+		ret = append(ret, &clients[i])
+	}
+	return ret
+}
+
+// CanManage returns true if the user owns the OAuth2 client
+func (user *User) CanManage(client *OAuth2Client) bool {
+	return client != nil && client.ID > 0 && client.UserID == user.ID()
+}
+
+// CreateOAuth2Client creates a new OAuth2 client named name, owned by the user.
+// Returns the client and its secret: only the hash of the secret is stored, hence
+// the secret can't be retrieved again. Public clients have no secret.
+// The names of the clients are unique: a Conflict error is returned if name is already in use
+func (user *User) CreateOAuth2Client(ctx context.Context, name, redirectURI string, public bool) (*OAuth2Client, string, error) {
+	if err := oauth2ValidRedirectURI(redirectURI); err != nil {
+		return nil, "", err
+	}
+
+	var secret string
+	if !public {
+		var err error
+		if secret, err = oauth2NewSecret(); err != nil {
+			return nil, "", err
+		}
+	}
+
+	stored, err := oauth2StoredSecret(secret)
+	if err != nil {
+		return nil, "", err
+	}
+
+	client := &OAuth2Client{
+		Name:        name,
+		Secret:      stored,
+		RedirectURI: redirectURI,
+		UserID:      user.ID(),
+		Public:      public}
+
+	if err = oauth2CreateClient(ctx, client); err != nil {
+		return nil, "", err
+	}
+	return client, secret, nil
+}
+
+// oauth2CreateClient creates client, whose name is trimmed and must be unique.
+// The uniqueness is enforced by the unique constraint on the name of the clients
+// (see migrations/001_oauth2_clients_name_key.sql), whose violation is reported as ErrConflict
+func oauth2CreateClient(ctx context.Context, client *OAuth2Client) error {
+	client.Name = strings.TrimSpace(client.Name)
+	if client.Name == "" {
+		return invalidArgument("empty client name")
+	}
+
+	if err := storage(ctx).Create(ctx, client); err != nil {
+		if errors.Is(err, ErrConflict) {
+			return NewError(ErrConflict, "client %s already exists", client.Name)
+		}
+		return err
+	}
+	return nil
+}
+
+// UpdateOAuth2ClientRedirectURI changes the redirect uri of the client owned by the user
+func (user *User) UpdateOAuth2ClientRedirectURI(ctx context.Context, client *OAuth2Client, redirectURI string) error {
+	if !user.CanManage(client) {
+		return permissionDenied("You can't manage this client")
+	}
+
+	if err := oauth2ValidRedirectURI(redirectURI); err != nil {
+		return err
+	}
+
+	if err := storage(ctx).Updates(ctx, &OAuth2Client{ID: client.ID, RedirectURI: redirectURI}); err != nil {
+		return err
+	}
+	client.RedirectURI = redirectURI
+	return nil
+}
+
+// RotateOAuth2ClientSecret replaces the secret of the client owned by the user.
+// Returns the new secret, that can't be retrieved again. The old secret stops working immediately
+func (user *User) RotateOAuth2ClientSecret(ctx context.Context, client *OAuth2Client) (string, error) {
+	if !user.CanManage(client) {
+		return "", permissionDenied("You can't manage this client")
+	}
+
+	if client.Public {
+		return "", invalidArgument("Public clients have no secret")
+	}
+
+	secret, err := oauth2NewSecret()
+	if err != nil {
+		return "", err
+	}
+
+	hash := oauth2HashSecret(secret)
+	if err = storage(ctx).Updates(ctx, &OAuth2Client{ID: client.ID, Secret: hash}); err != nil {
+		return "", err
+	}
+	client.Secret = hash
+	return secret, nil
+}
+
+// DeleteOAuth2Client deletes the client owned by the user, revoking every token issued to it
+// and deleting its certificates
+func (user *User) DeleteOAuth2Client(ctx context.Context, client *OAuth2Client) error {
+	if !user.CanManage(client) {
+		return permissionDenied("You can't manage this client")
+	}
+
+	return oauth2DeleteClient(ctx, client.ID)
+}
+
+// oauth2DeleteClient deletes the client with the specified id, revoking every token issued to it
+// and deleting its certificates
+func oauth2DeleteClient(ctx context.Context, id uint64) error {
+	return Transaction(ctx, func(ctx context.Context) error {
+		if err := oauth2Revoke(ctx, &OAuth2AccessData{ClientID: id}, &OAuth2AuthorizeData{ClientID: id}); err != nil {
+			return err
+		}
+		if err := storage(ctx).Delete(ctx, &OAuth2ClientCertificate{ClientID: id}); err != nil {
+			return err
+		}
+		return storage(ctx).Delete(ctx, &OAuth2Client{ID: id})
+	})
+}
+
+// OAuth2ClientCertificates returns the TLS certificates of the client owned by the user, revoked ones included
+func (user *User) OAuth2ClientCertificates(ctx context.Context, client *OAuth2Client) ([]OAuth2ClientCertificate, error) {
+	if !user.CanManage(client) {
+		return nil, permissionDenied("You can't manage this client")
+	}
+
+	certificates := []OAuth2ClientCertificate{}
+	if err := storage(ctx).Find(ctx, &OAuth2ClientCertificate{ClientID: client.ID}, &certificates); err != nil {
+		return nil, err
+	}
+	sort.Slice(certificates, func(i, j int) bool { return certificates[i].CreatedAt.Before(certificates[j].CreatedAt) })
+	return certificates, nil
+}
+
+// AddOAuth2ClientCertificate allows the client owned by the user to authenticate with the TLS certificate
+// whose SHA-256 fingerprint is fingerprint, hex encoded and optionally with its bytes separated by colons.
+// A certificate authenticates a single client: a Conflict error is returned if it is already in use, or revoked
+func (user *User) AddOAuth2ClientCertificate(ctx context.Context, client *OAuth2Client, fingerprint string) (*OAuth2ClientCertificate, error) {
+	if !user.CanManage(client) {
+		return nil, permissionDenied("You can't manage this client")
+	}
+
+	fp, err := oauth2Fingerprint(fingerprint)
+	if err != nil {
+		return nil, err
+	}
+
+	certificate := &OAuth2ClientCertificate{Fingerprint: fp, ClientID: client.ID}
+	if err = storage(ctx).Create(ctx, certificate); err != nil {
+		if errors.Is(err, ErrConflict) {
+			return nil, NewError(ErrConflict, "certificate %s already exists", fp)
+		}
+		return nil, err
+	}
+	return certificate, nil
+}
+
+// RevokeOAuth2ClientCertificate revokes the TLS certificate of the client owned by the user:
+// the certificate stops authenticating the client immediately. The revoked certificate is kept,
+// so that it can't be added again
+func (user *User) RevokeOAuth2ClientCertificate(ctx context.Context, client *OAuth2Client, fingerprint string) error {
+	if !user.CanManage(client) {
+		return permissionDenied("You can't manage this client")
+	}
+
+	fp, err := oauth2Fingerprint(fingerprint)
+	if err != nil {
+		return err
+	}
+
+	var certificate OAuth2ClientCertificate
+	if err = storage(ctx).Find(ctx, &OAuth2ClientCertificate{Fingerprint: fp, ClientID: client.ID}, &certificate); err != nil {
+		return err
+	}
+	if certificate.Fingerprint == "" {
+		return notFound("Requested certificate does not exist")
+	}
+	return storage(ctx).Updates(ctx, &OAuth2ClientCertificate{Fingerprint: fp, Revoked: true})
+}
+
+// OAuth2ClientByCertificate returns the OAuth2 client authenticated by the TLS certificate
+// whose SHA-256 fingerprint is fingerprint. Returns a NotFound error if the certificate or its client
+// do not exist, and a PermissionDenied error if the certificate has been revoked
+func OAuth2ClientByCertificate(ctx context.Context, fingerprint string) (*OAuth2Client, error) {
+	fp, err := oauth2Fingerprint(fingerprint)
+	if err != nil {
+		return nil, err
+	}
+
+	var certificate OAuth2ClientCertificate
+	if err = storage(ctx).Find(ctx, &OAuth2ClientCertificate{Fingerprint: fp}, &certificate); err != nil {
+		return nil, err
+	}
+	if certificate.Fingerprint == "" {
+		return nil, notFound("Requested certificate does not exist")
+	}
+	if certificate.Revoked {
+		return nil, permissionDenied("The certificate %s has been revoked", fp)
+	}
+	return NewOAuth2Client(ctx, certificate.ClientID)
+}
+
+// oauth2Fingerprint returns the certificate fingerprint fp lower cased, without the colons
+// that may separate its bytes. Returns an error if fp is not an hex encoded SHA-256 fingerprint
+func oauth2Fingerprint(fp string) (string, error) {
+	fp = strings.ToLower(strings.Replace(strings.TrimSpace(fp), ":", "", -1))
+	if b, err := hex.DecodeString(fp); err != nil || len(b) != sha256.Size {
+		return "", invalidArgument("invalid certificate fingerprint %s", fp)
+	}
+	return fp, nil
+}
+
+// oauth2NewSecret generates a random client secret
+func oauth2NewSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// oauth2StoredSecret returns the secret to store for a client: the hash of secret.
+// If secret is empty, the client is public and the hash of a random secret, never disclosed, is returned,
+// since the stored secrets must be unique
+func oauth2StoredSecret(secret string) (string, error) {
+	if secret == "" {
+		var err error
+		if secret, err = oauth2NewSecret(); err != nil {
+			return "", err
+		}
+	}
+	return oauth2HashSecret(secret), nil
+}
+
+// oauth2HashSecret returns the hash of the client secret, as stored
+func oauth2HashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return secretHashPrefix + hex.EncodeToString(sum[:])
+}
+
+// oauth2SecretMatches returns true if secret matches the stored secret
+func oauth2SecretMatches(stored, secret string) bool {
+	if secret == "" {
+		return false
+	}
+	if strings.HasPrefix(stored, secretHashPrefix) {
+		secret = oauth2HashSecret(secret)
+	}
+	return subtle.ConstantTimeCompare([]byte(stored), []byte(secret)) == 1
+}
+
+// oauth2ValidRedirectURI returns an error if uri is not an absolute uri without fragment,
+// as required for the redirection endpoints
+func oauth2ValidRedirectURI(uri string) error {
+	u, err := url.Parse(uri)
+	if err != nil || !u.IsAbs() || u.Host == "" || u.Fragment != "" {
+		return invalidArgument("invalid redirect uri %s", uri)
+	}
+	return nil
+}
@@ -0,0 +1,647 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/RangelReale/osin"
+)
+
+// OAuth2Storage implements the osin.Storage interface, storing the OAuth2 data
+// into the oauth2_* relations.
+//
+// The access data obtained by refreshing the same authorization form a token family,
+// linked by AccessDataID. Refresh tokens are rotated and their reuse revokes the whole family.
+//
+// The UserData of every osin.AuthorizeData and osin.AccessData saved must be the
+// ID (uint64) of the user that granted the authorization, or the *User itself.
+// The loaded data have the ID of the user as UserData.
+//
+// The osin.Storage methods can't receive a context: the storage uses the one
+// it has been created with (see WithContext), or context.Background().
+//
+// A refresh token can be used until its TTL, measured from the creation of its access data,
+// elapses (see WithRefreshTTL): then its token family can be removed.
+type OAuth2Storage struct {
+	ctx        context.Context
+	refreshTTL time.Duration
+}
+
+// DefaultOAuth2RefreshTTL is the TTL of the refresh tokens of a new OAuth2Storage
+const DefaultOAuth2RefreshTTL = 30 * 24 * time.Hour
+
+// NewOAuth2Storage creates a new OAuth2Storage
+func NewOAuth2Storage() *OAuth2Storage {
+	return &OAuth2Storage{refreshTTL: DefaultOAuth2RefreshTTL}
+}
+
+// WithContext returns a copy of the storage that uses ctx in every database call
+func (s *OAuth2Storage) WithContext(ctx context.Context) *OAuth2Storage {
+	return &OAuth2Storage{ctx: ctx, refreshTTL: s.refreshTTL}
+}
+
+// WithRefreshTTL returns a copy of the storage whose refresh tokens expire after ttl
+func (s *OAuth2Storage) WithRefreshTTL(ttl time.Duration) *OAuth2Storage {
+	return &OAuth2Storage{ctx: s.ctx, refreshTTL: ttl}
+}
+
+// Clone returns the storage itself, since it holds no per-request resources
+func (s *OAuth2Storage) Clone() osin.Storage {
+	return s
+}
+
+// context returns the context of the storage, context.Background() if none has been set.
+// The reads of the storage must see the tokens just issued, thus they are never served by a replica
+func (s *OAuth2Storage) context() context.Context {
+	if s.ctx == nil {
+		return ReadYourWrites(context.Background())
+	}
+	return ReadYourWrites(s.ctx)
+}
+
+// Close does nothing, since the storage holds no resources
+func (s *OAuth2Storage) Close() {}
+
+// GetClient loads the client by id
+func (s *OAuth2Storage) GetClient(id string) (osin.Client, error) {
+	clientID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return nil, invalidArgument("invalid client id %s", id)
+	}
+	return NewOAuth2Client(s.context(), clientID)
+}
+
+// CreateClient creates a new client named name, with the secret and the redirect uri of c.
+// Only the hash of the secret is stored. If the secret is empty, the client is public.
+// The names of the clients are unique: a Conflict error is returned if name is already in use.
+// The UserData of c must be the ID of the user that owns the client, or the *User itself
+func (s *OAuth2Storage) CreateClient(c osin.Client, name string) (*OAuth2Client, error) {
+	userID, err := oauth2UserID(c.GetUserData())
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := oauth2StoredSecret(c.GetSecret())
+	if err != nil {
+		return nil, err
+	}
+
+	client := &OAuth2Client{
+		Name:        name,
+		Secret:      secret,
+		RedirectURI: c.GetRedirectUri(),
+		UserID:      userID,
+		Public:      c.GetSecret() == ""}
+
+	if err = oauth2CreateClient(s.context(), client); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// RemoveClient deletes the client with the specified id, revoking every token issued to it
+func (s *OAuth2Storage) RemoveClient(id uint64) error {
+	if id == 0 {
+		return invalidArgument("invalid client id")
+	}
+	return oauth2DeleteClient(s.context(), id)
+}
+
+// SaveAuthorize saves the authorize data, together with its PKCE code challenge.
+// Returns an error if its scope contains an unknown scope, or if the client is public and no code challenge is present
+func (s *OAuth2Storage) SaveAuthorize(data *osin.AuthorizeData) error {
+	if _, err := ParseScopes(data.Scope); err != nil {
+		return err
+	}
+
+	clientID, err := oauth2ClientID(data.Client)
+	if err != nil {
+		return err
+	}
+
+	if data.CodeChallenge == "" {
+		client, err := NewOAuth2Client(s.context(), clientID)
+		if err != nil {
+			return err
+		}
+		if client.Public {
+			return invalidArgument("public clients must use PKCE")
+		}
+	}
+
+	userID, err := oauth2UserID(data.UserData)
+	if err != nil {
+		return err
+	}
+
+	return storage(s.context()).Create(s.context(), &OAuth2AuthorizeData{
+		ClientID:            clientID,
+		Code:                data.Code,
+		CreatedAt:           data.CreatedAt,
+		ExpiresIn:           uint64(data.ExpiresIn),
+		Scope:               data.Scope,
+		RedirectURI:         data.RedirectUri,
+		UserID:              userID,
+		CodeChallenge:       data.CodeChallenge,
+		CodeChallengeMethod: data.CodeChallengeMethod})
+}
+
+// LoadAuthorize looks up the authorize data by code
+func (s *OAuth2Storage) LoadAuthorize(code string) (*osin.AuthorizeData, error) {
+	if code == "" {
+		return nil, invalidArgument("empty authorization code")
+	}
+
+	authorize := new(OAuth2AuthorizeData)
+	if err := storage(s.context()).Find(s.context(), &OAuth2AuthorizeData{Code: code}, authorize); err != nil {
+		return nil, err
+	}
+	if authorize.ID == 0 {
+		return nil, notFound("Requested OAuth2AuthorizeData does not exist")
+	}
+
+	return authorize.osin(s.context())
+}
+
+// RemoveAuthorize deletes the authorize data identified by code.
+// The access data authorized by it are kept
+func (s *OAuth2Storage) RemoveAuthorize(code string) error {
+	if code == "" {
+		return invalidArgument("empty authorization code")
+	}
+
+	return Transaction(s.context(), func(ctx context.Context) error {
+		var authorize OAuth2AuthorizeData
+		if err := storage(ctx).Find(ctx, &OAuth2AuthorizeData{Code: code}, &authorize); err != nil || authorize.ID == 0 {
+			return err
+		}
+		return oauth2RemoveAuthorize(ctx, []uint64{authorize.ID})
+	})
+}
+
+// SaveAccess saves the access data and its refresh token, if any.
+// Returns an error if its scope contains an unknown scope.
+// When the access data refreshes a previous one, the access data are locked while it is rotated:
+// if it has already been refreshed by a concurrent request, its refresh token has been reused and its
+// whole token family is revoked (see LoadRefresh)
+func (s *OAuth2Storage) SaveAccess(data *osin.AccessData) error {
+	if _, err := ParseScopes(data.Scope); err != nil {
+		return err
+	}
+
+	clientID, err := oauth2ClientID(data.Client)
+	if err != nil {
+		return err
+	}
+
+	userID, err := oauth2UserID(data.UserData)
+	if err != nil {
+		return err
+	}
+
+	access := &OAuth2AccessData{
+		ClientID:    clientID,
+		CreatedAt:   data.CreatedAt,
+		ExpiresIn:   uint64(data.ExpiresIn),
+		RedirectURI: data.RedirectUri,
+		AccessToken: data.AccessToken,
+		Scope:       data.Scope,
+		UserID:      userID}
+
+	var reused bool
+	err = Transaction(s.context(), func(ctx context.Context) error {
+		if data.AuthorizeData != nil && data.AuthorizeData.Code != "" {
+			var authorize OAuth2AuthorizeData
+			if err := storage(ctx).Find(ctx, &OAuth2AuthorizeData{Code: data.AuthorizeData.Code}, &authorize); err != nil {
+				return err
+			}
+			if authorize.ID != 0 {
+				access.AuthorizeDataID = sql.NullInt64{Int64: int64(authorize.ID), Valid: true}
+			}
+		}
+
+		if data.AccessData != nil && data.AccessData.AccessToken != "" {
+			var previous OAuth2AccessData
+			if err := storage(ctx).Find(ctx, &OAuth2AccessData{AccessToken: data.AccessData.AccessToken}, &previous); err != nil {
+				return err
+			}
+			if previous.ID != 0 {
+				rotated, err := oauth2Rotated(ctx, previous.ID)
+				if err != nil {
+					return err
+				}
+				if rotated {
+					reused = true
+					return oauth2RevokeFamily(ctx, previous.ID)
+				}
+				access.AccessDataID = sql.NullInt64{Int64: int64(previous.ID), Valid: true}
+			}
+		}
+
+		if data.RefreshToken != "" {
+			refresh := &OAuth2RefreshToken{Token: data.RefreshToken}
+			if err := storage(ctx).Create(ctx, refresh); err != nil {
+				return err
+			}
+			access.RefreshTokenID = sql.NullInt64{Int64: int64(refresh.ID), Valid: true}
+		}
+
+		return storage(ctx).Create(ctx, access)
+	})
+	if err == nil && reused {
+		err = permissionDenied("reused refresh token: its token family has been revoked")
+	}
+	return err
+}
+
+// LoadAccess looks up the access data by token
+func (s *OAuth2Storage) LoadAccess(token string) (*osin.AccessData, error) {
+	if token == "" {
+		return nil, invalidArgument("empty access token")
+	}
+
+	access, err := NewOAuth2AccessDataWhere(s.context(), &OAuth2AccessData{AccessToken: token})
+	if err != nil {
+		return nil, err
+	}
+	return access.osin(s.context(), true)
+}
+
+// RemoveAccess revokes the access token.
+// If the access data has been refreshed, its token is expired but the access data is kept,
+// to detect the reuse of its rotated refresh token (see LoadRefresh).
+// Otherwise, the access data is revoked together with its whole token family
+func (s *OAuth2Storage) RemoveAccess(token string) error {
+	if token == "" {
+		return invalidArgument("empty access token")
+	}
+
+	return Transaction(s.context(), func(ctx context.Context) error {
+		access := new(OAuth2AccessData)
+		if err := storage(ctx).Find(ctx, &OAuth2AccessData{AccessToken: token}, access); err != nil {
+			return err
+		}
+		if access.ID == 0 {
+			return notFound("Requested OAuth2AccessData does not exist")
+		}
+
+		rotated, err := oauth2Rotated(ctx, access.ID)
+		if err != nil {
+			return err
+		}
+		if rotated {
+			return storage(ctx).UpdateIn(ctx, OAuth2AccessData{}, "id", []uint64{access.ID}, map[string]interface{}{"expires_in": 0})
+		}
+		return oauth2RevokeFamily(ctx, access.ID)
+	})
+}
+
+// LoadRefresh looks up the access data by refresh token.
+// Refresh tokens are rotated: once the access data has been refreshed, its refresh token can't
+// be used anymore. Presenting a rotated refresh token revokes the whole token family, since
+// the token has been leaked: it is used by both the legit client and an attacker
+func (s *OAuth2Storage) LoadRefresh(token string) (*osin.AccessData, error) {
+	if token == "" {
+		return nil, invalidArgument("empty refresh token")
+	}
+
+	var access *OAuth2AccessData
+	var reused bool
+	if err := Transaction(s.context(), func(ctx context.Context) (err error) {
+		if access, err = oauth2RefreshAccess(ctx, token); err != nil {
+			return err
+		}
+		if reused, err = oauth2Rotated(ctx, access.ID); err != nil || !reused {
+			return err
+		}
+		return oauth2RevokeFamily(ctx, access.ID)
+	}); err != nil {
+		return nil, err
+	}
+
+	if reused {
+		return nil, permissionDenied("reused refresh token: its token family has been revoked")
+	}
+	if time.Now().UTC().After(access.CreatedAt.Add(s.refreshTTL)) {
+		return nil, permissionDenied("expired refresh token")
+	}
+	return access.osin(s.context(), true)
+}
+
+// RemoveRefresh revokes the refresh token.
+// If the access data of the token has been refreshed, the token is kept to detect its reuse
+// (see LoadRefresh). Otherwise, the token is deleted and its access data is kept
+func (s *OAuth2Storage) RemoveRefresh(token string) error {
+	if token == "" {
+		return invalidArgument("empty refresh token")
+	}
+
+	return Transaction(s.context(), func(ctx context.Context) error {
+		access, err := oauth2RefreshAccess(ctx, token)
+		if err != nil {
+			return err
+		}
+
+		rotated, err := oauth2Rotated(ctx, access.ID)
+		if err != nil || rotated {
+			return err
+		}
+
+		if err = storage(ctx).UpdateIn(ctx, OAuth2AccessData{}, "id", []uint64{access.ID}, map[string]interface{}{"refresh_token_id": nil}); err != nil {
+			return err
+		}
+		return storage(ctx).Delete(ctx, &OAuth2RefreshToken{ID: uint64(access.RefreshTokenID.Int64)})
+	})
+}
+
+// RevokeToken revokes the token family of token, that can be either an access or a refresh
+// token issued to the client with the specified id
+func (s *OAuth2Storage) RevokeToken(clientID uint64, token string) error {
+	if token == "" {
+		return invalidArgument("empty token")
+	}
+
+	return Transaction(s.context(), func(ctx context.Context) error {
+		access := new(OAuth2AccessData)
+		if err := storage(ctx).Find(ctx, &OAuth2AccessData{ClientID: clientID, AccessToken: token}, access); err != nil {
+			return err
+		}
+		if access.ID == 0 {
+			var refresh OAuth2RefreshToken
+			if err := storage(ctx).Find(ctx, &OAuth2RefreshToken{Token: token}, &refresh); err != nil {
+				return err
+			}
+			if refresh.ID != 0 {
+				description := &OAuth2AccessData{ClientID: clientID, RefreshTokenID: sql.NullInt64{Int64: int64(refresh.ID), Valid: true}}
+				if err := storage(ctx).Find(ctx, description, access); err != nil {
+					return err
+				}
+			}
+		}
+		if access.ID == 0 {
+			return notFound("token not issued to the client")
+		}
+		return oauth2RevokeFamily(ctx, access.ID)
+	})
+}
+
+// RevokeClientTokens revokes every authorization code and token issued to the client with the specified id
+func (s *OAuth2Storage) RevokeClientTokens(clientID uint64) error {
+	return Transaction(s.context(), func(ctx context.Context) error {
+		return oauth2Revoke(ctx, &OAuth2AccessData{ClientID: clientID}, &OAuth2AuthorizeData{ClientID: clientID})
+	})
+}
+
+// RevokeUserTokens revokes every authorization code and token granted by the user with the specified id
+func (s *OAuth2Storage) RevokeUserTokens(userID uint64) error {
+	return Transaction(s.context(), func(ctx context.Context) error {
+		return oauth2Revoke(ctx, &OAuth2AccessData{UserID: userID}, &OAuth2AuthorizeData{UserID: userID})
+	})
+}
+
+// RemoveExpiredAuthorize deletes at most limit expired authorization codes.
+// Returns the number of authorization codes deleted
+func (s *OAuth2Storage) RemoveExpiredAuthorize(limit int) (removed int, e error) {
+	e = Transaction(s.context(), func(ctx context.Context) error {
+		ids, err := storage(ctx).ExpiredOAuth2Authorize(ctx, limit)
+		if err != nil {
+			return err
+		}
+		if err = oauth2RemoveAuthorize(ctx, ids); err != nil {
+			return err
+		}
+		removed = len(ids)
+		return nil
+	})
+	return
+}
+
+// RemoveExpiredAccess deletes at most limit dead token families: the families whose
+// last access token is expired and whose refresh token has been removed, never issued or is expired.
+// A family is always deleted as a whole, since its rotated access data are required
+// to detect the reuse of their refresh tokens (see LoadRefresh).
+// Returns the number of access data deleted
+func (s *OAuth2Storage) RemoveExpiredAccess(limit int) (removed int, e error) {
+	e = Transaction(s.context(), func(ctx context.Context) error {
+		ids, err := storage(ctx).DeadOAuth2Families(ctx, s.refreshTTL, limit)
+		if err != nil {
+			return err
+		}
+		if err = oauth2RevokeAccesses(ctx, ids); err != nil {
+			return err
+		}
+		removed = len(ids)
+		return nil
+	})
+	return
+}
+
+// oauth2Revoke revokes every token that matches access and every authorization code that matches authorize
+func oauth2Revoke(ctx context.Context, access *OAuth2AccessData, authorize *OAuth2AuthorizeData) error {
+	var ids []uint64
+	if err := storage(ctx).Pluck(ctx, access, "id", &ids); err != nil {
+		return err
+	}
+
+	if err := oauth2RevokeAccesses(ctx, ids); err != nil {
+		return err
+	}
+	return storage(ctx).Delete(ctx, authorize)
+}
+
+// oauth2RemoveAuthorize deletes the authorize data with the specified ids.
+// The access data authorized by them are kept
+func oauth2RemoveAuthorize(ctx context.Context, ids []uint64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if err := storage(ctx).UpdateIn(ctx, OAuth2AccessData{}, "oauth2_authorize_id", ids, map[string]interface{}{"oauth2_authorize_id": nil}); err != nil {
+		return err
+	}
+	return storage(ctx).DeleteIn(ctx, OAuth2AuthorizeData{}, "id", ids)
+}
+
+// oauth2RefreshAccess returns the access data of the refresh token
+func oauth2RefreshAccess(ctx context.Context, token string) (*OAuth2AccessData, error) {
+	var refresh OAuth2RefreshToken
+	if err := storage(ctx).Find(ctx, &OAuth2RefreshToken{Token: token}, &refresh); err != nil {
+		return nil, err
+	}
+
+	access := new(OAuth2AccessData)
+	if refresh.ID != 0 {
+		description := &OAuth2AccessData{RefreshTokenID: sql.NullInt64{Int64: int64(refresh.ID), Valid: true}}
+		if err := storage(ctx).Find(ctx, description, access); err != nil {
+			return nil, err
+		}
+	}
+	if access.ID == 0 {
+		return nil, notFound("Requested OAuth2RefreshToken does not exist")
+	}
+	return access, nil
+}
+
+// oauth2Rotated returns true if the access data with the specified id has been refreshed.
+// Its record is locked until the unit of work of ctx ends, thus it can't be refreshed concurrently
+func oauth2Rotated(ctx context.Context, id uint64) (bool, error) {
+	if err := storage(ctx).LockIn(ctx, OAuth2AccessData{}, "id", []uint64{id}); err != nil {
+		return false, err
+	}
+
+	refreshed, err := storage(ctx).Count(ctx, &OAuth2AccessData{AccessDataID: sql.NullInt64{Int64: int64(id), Valid: true}})
+	return refreshed > 0, err
+}
+
+// oauth2RevokeFamily revokes the token family of the access data with the specified id:
+// the access data obtained by refreshing the same authorization
+func oauth2RevokeFamily(ctx context.Context, id uint64) error {
+	ids, err := storage(ctx).OAuth2Family(ctx, id)
+	if err != nil {
+		return err
+	}
+	return oauth2RevokeAccesses(ctx, ids)
+}
+
+// oauth2RevokeAccesses deletes the access data with the specified ids, and their refresh tokens
+func oauth2RevokeAccesses(ctx context.Context, ids []uint64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var accesses []OAuth2AccessData
+	if err := storage(ctx).FindIn(ctx, OAuth2AccessData{}, "id", ids, &accesses); err != nil {
+		return err
+	}
+	var refreshIDs []uint64
+	for _, access := range accesses {
+		if access.RefreshTokenID.Valid {
+			refreshIDs = append(refreshIDs, uint64(access.RefreshTokenID.Int64))
+		}
+	}
+
+	if err := storage(ctx).DeleteIn(ctx, OAuth2AccessData{}, "id", ids); err != nil {
+		return err
+	}
+	return storage(ctx).DeleteIn(ctx, OAuth2RefreshToken{}, "id", refreshIDs)
+}
+
+// osin converts the authorize data into an *osin.AuthorizeData
+func (authorize *OAuth2AuthorizeData) osin(ctx context.Context) (*osin.AuthorizeData, error) {
+	client, err := NewOAuth2Client(ctx, authorize.ClientID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &osin.AuthorizeData{
+		Client:              client,
+		Code:                authorize.Code,
+		ExpiresIn:           int32(authorize.ExpiresIn),
+		Scope:               authorize.Scope,
+		RedirectUri:         authorize.RedirectURI,
+		CreatedAt:           authorize.CreatedAt,
+		UserData:            authorize.UserID,
+		CodeChallenge:       authorize.CodeChallenge,
+		CodeChallengeMethod: authorize.CodeChallengeMethod}, nil
+}
+
+// osin converts the access data into an *osin.AccessData.
+// If links is true, the linked authorize data and previous access data are loaded too
+func (access *OAuth2AccessData) osin(ctx context.Context, links bool) (*osin.AccessData, error) {
+	client, err := NewOAuth2Client(ctx, access.ClientID)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &osin.AccessData{
+		Client:      client,
+		AccessToken: access.AccessToken,
+		ExpiresIn:   int32(access.ExpiresIn),
+		Scope:       access.Scope,
+		RedirectUri: access.RedirectURI,
+		CreatedAt:   access.CreatedAt,
+		UserData:    access.UserID}
+
+	if access.RefreshTokenID.Valid {
+		var refresh OAuth2RefreshToken
+		if err = storage(ctx).Find(ctx, &OAuth2RefreshToken{ID: uint64(access.RefreshTokenID.Int64)}, &refresh); err != nil {
+			return nil, err
+		}
+		ret.RefreshToken = refresh.Token
+	}
+
+	if !links {
+		return ret, nil
+	}
+
+	if access.AuthorizeDataID.Valid {
+		var authorize OAuth2AuthorizeData
+		if err = storage(ctx).Find(ctx, &OAuth2AuthorizeData{ID: uint64(access.AuthorizeDataID.Int64)}, &authorize); err != nil {
+			return nil, err
+		}
+		if authorize.ID != 0 {
+			if ret.AuthorizeData, err = authorize.osin(ctx); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if access.AccessDataID.Valid {
+		var previous OAuth2AccessData
+		if err = storage(ctx).Find(ctx, &OAuth2AccessData{ID: uint64(access.AccessDataID.Int64)}, &previous); err != nil {
+			return nil, err
+		}
+		if previous.ID != 0 {
+			if ret.AccessData, err = previous.osin(ctx, false); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return ret, nil
+}
+
+// oauth2ClientID returns the ID of client
+func oauth2ClientID(client osin.Client) (uint64, error) {
+	if client == nil {
+		return 0, invalidArgument("missing client")
+	}
+
+	id, err := strconv.ParseUint(client.GetId(), 10, 64)
+	if err != nil {
+		return 0, invalidArgument("invalid client id %s", client.GetId())
+	}
+	return id, nil
+}
+
+// oauth2UserID returns the ID of the user stored into the UserData of an osin type
+func oauth2UserID(userData interface{}) (uint64, error) {
+	switch userData := userData.(type) {
+	case uint64:
+		return userData, nil
+	case *User:
+		if userData != nil {
+			return userData.ID(), nil
+		}
+	}
+	return 0, invalidArgument("UserData should be the ID of the user")
+}
@@ -0,0 +1,516 @@
+//go:build postgres
+// +build postgres
+
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db_test
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RangelReale/osin"
+	"github.com/nerdzeu/nerdz-core/db"
+)
+
+const (
+	redirectURI  = "https://example.com/oauth2/callback"
+	clientSecret = "secret"
+)
+
+var storage = db.NewOAuth2Storage().WithContext(ctx)
+
+func newOAuth2Server() *osin.Server {
+	config := osin.NewServerConfig()
+	config.AllowedAccessTypes = osin.AllowedAccessType{osin.AUTHORIZATION_CODE, osin.REFRESH_TOKEN}
+	config.RequirePKCEForPublicClients = true
+	return osin.NewServer(config, storage)
+}
+
+func createClient(t *testing.T) *db.OAuth2Client {
+	client, err := storage.CreateClient(&osin.DefaultClient{
+		Secret:      clientSecret,
+		RedirectUri: redirectURI,
+		UserData:    me.ID()}, "test client")
+	if err != nil {
+		t.Fatalf("No error should happen when creating a client, but got: %s", err)
+	}
+
+	if got, err := storage.GetClient(client.GetId()); err != nil || !got.(*db.OAuth2Client).ClientSecretMatches(clientSecret) || got.GetRedirectUri() != redirectURI {
+		t.Fatalf("GetClient should return the created client, but got: %+v, %v", got, err)
+	}
+	return client
+}
+
+// authorize performs the authorization request of user me, returning the authorization code
+func authorize(t *testing.T, server *osin.Server, client *db.OAuth2Client) string {
+	req, _ := http.NewRequest("GET", "/authorize?"+url.Values{
+		"response_type": {"code"},
+		"client_id":     {client.GetId()},
+		"redirect_uri":  {redirectURI},
+		"scope":         {db.ScopePostsRead + " " + db.ScopePmsRead}}.Encode(), nil)
+
+	resp := server.NewResponse()
+	defer resp.Close()
+
+	if ar := server.HandleAuthorizeRequest(resp, req); ar != nil {
+		ar.UserData = me.ID()
+		ar.Authorized = true
+		server.FinishAuthorizeRequest(resp, req, ar)
+	}
+
+	if resp.IsError {
+		t.Fatalf("Authorization request failed: %s (%v)", resp.ErrorId, resp.InternalError)
+	}
+	return resp.Output["code"].(string)
+}
+
+// access performs the access request described by values, returning the response output
+func access(t *testing.T, server *osin.Server, client *db.OAuth2Client, values url.Values) osin.ResponseData {
+	req, _ := http.NewRequest("POST", "/token", strings.NewReader(values.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if client.Public {
+		req.SetBasicAuth(client.GetId(), "")
+	} else {
+		req.SetBasicAuth(client.GetId(), clientSecret)
+	}
+
+	resp := server.NewResponse()
+	defer resp.Close()
+
+	if ar := server.HandleAccessRequest(resp, req); ar != nil {
+		ar.Authorized = true
+		server.FinishAccessRequest(resp, req, ar)
+	}
+
+	if resp.IsError {
+		t.Fatalf("Access request failed: %s (%v)", resp.ErrorId, resp.InternalError)
+	}
+	return resp.Output
+}
+
+func TestOAuth2AuthorizationCodeFlow(t *testing.T) {
+	server := newOAuth2Server()
+	client := createClient(t)
+	defer storage.RemoveClient(client.ID)
+
+	code := authorize(t, server, client)
+	authorizeData, err := storage.LoadAuthorize(code)
+	if err != nil {
+		t.Fatalf("No error should happen when loading the authorize data, but got: %s", err)
+	}
+
+	if authorizeData.UserData.(uint64) != me.ID() || authorizeData.Client.GetId() != client.GetId() || authorizeData.Scope != db.ScopePostsRead+" "+db.ScopePmsRead {
+		t.Errorf("Unexpected authorize data: %+v", authorizeData)
+	}
+
+	output := access(t, server, client, url.Values{
+		"grant_type":   {"authorization_code"},
+		"code":         {code},
+		"redirect_uri": {redirectURI}})
+
+	accessToken := output["access_token"].(string)
+	accessData, err := storage.LoadAccess(accessToken)
+	if err != nil {
+		t.Fatalf("No error should happen when loading the access data, but got: %s", err)
+	}
+
+	if accessData.UserData.(uint64) != me.ID() || accessData.Client.GetId() != client.GetId() || accessData.RefreshToken != output["refresh_token"].(string) {
+		t.Errorf("Unexpected access data: %+v", accessData)
+	}
+
+	// The authorization code can be used only once
+	if _, err = storage.LoadAuthorize(code); err == nil {
+		t.Error("The authorization code should be removed once exchanged")
+	}
+
+	if err = storage.RemoveAccess(accessToken); err != nil {
+		t.Errorf("No error should happen when removing the access data, but got: %s", err)
+	}
+
+	if _, err = storage.LoadAccess(accessToken); err == nil {
+		t.Error("The removed access data should not be loaded")
+	}
+}
+
+func TestOAuth2RefreshTokenFlow(t *testing.T) {
+	server := newOAuth2Server()
+	client := createClient(t)
+	defer storage.RemoveClient(client.ID)
+
+	output := access(t, server, client, url.Values{
+		"grant_type":   {"authorization_code"},
+		"code":         {authorize(t, server, client)},
+		"redirect_uri": {redirectURI}})
+
+	oldAccess, oldRefresh := output["access_token"].(string), output["refresh_token"].(string)
+
+	refreshed, err := storage.LoadRefresh(oldRefresh)
+	if err != nil {
+		t.Fatalf("No error should happen when loading the access data by refresh token, but got: %s", err)
+	}
+
+	if refreshed.AccessToken != oldAccess {
+		t.Errorf("LoadRefresh should return the access data of the refresh token. Expected %s, got %s", oldAccess, refreshed.AccessToken)
+	}
+
+	output = access(t, server, client, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {oldRefresh}})
+
+	newAccess, newRefresh := output["access_token"].(string), output["refresh_token"].(string)
+	if newAccess == oldAccess || newRefresh == oldRefresh {
+		t.Fatal("Refreshing should generate a new access token and a new refresh token")
+	}
+
+	accessData, err := storage.LoadAccess(newAccess)
+	if err != nil {
+		t.Fatalf("No error should happen when loading the refreshed access data, but got: %s", err)
+	}
+
+	if accessData.UserData.(uint64) != me.ID() || accessData.RefreshToken != newRefresh {
+		t.Errorf("Unexpected refreshed access data: %+v", accessData)
+	}
+
+	// The refreshed access data is kept expired, to detect the reuse of its refresh token
+	if oldData, err := storage.LoadAccess(oldAccess); err != nil || !oldData.IsExpired() {
+		t.Errorf("The refreshed access data should be expired, but got: %+v, %v", oldData, err)
+	}
+
+	if accessData.AccessData == nil || accessData.AccessData.AccessToken != oldAccess {
+		t.Errorf("The refreshed access data should be linked to the previous one, but got: %+v", accessData.AccessData)
+	}
+
+	if err = storage.RemoveRefresh(newRefresh); err != nil {
+		t.Errorf("No error should happen when removing the refresh token, but got: %s", err)
+	}
+
+	if _, err = storage.LoadRefresh(newRefresh); err == nil {
+		t.Error("The removed refresh token should not be loaded")
+	}
+
+	// The access data survives the removal of its refresh token
+	if accessData, err = storage.LoadAccess(newAccess); err != nil || accessData.RefreshToken != "" {
+		t.Errorf("The access data should be kept without refresh token, but got: %+v, %v", accessData, err)
+	}
+
+	if err = storage.RemoveAccess(newAccess); err != nil {
+		t.Errorf("No error should happen when removing the access data, but got: %s", err)
+	}
+}
+
+// refresh performs the authorization code flow and refreshes the obtained token, returning the
+// access and refresh tokens before and after the refresh
+func refresh(t *testing.T, server *osin.Server, client *db.OAuth2Client) (oldAccess, oldRefresh, newAccess, newRefresh string) {
+	output := access(t, server, client, url.Values{
+		"grant_type":   {"authorization_code"},
+		"code":         {authorize(t, server, client)},
+		"redirect_uri": {redirectURI}})
+	oldAccess, oldRefresh = output["access_token"].(string), output["refresh_token"].(string)
+
+	output = access(t, server, client, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {oldRefresh}})
+	newAccess, newRefresh = output["access_token"].(string), output["refresh_token"].(string)
+	return
+}
+
+func TestOAuth2RefreshTokenReuse(t *testing.T) {
+	server := newOAuth2Server()
+	client := createClient(t)
+	defer storage.RemoveClient(client.ID)
+
+	oldAccess, oldRefresh, newAccess, newRefresh := refresh(t, server, client)
+
+	if _, err := storage.LoadRefresh(oldRefresh); err == nil {
+		t.Fatal("The reuse of a rotated refresh token should fail")
+	}
+
+	for _, token := range []string{oldAccess, newAccess} {
+		if _, err := storage.LoadAccess(token); err == nil {
+			t.Errorf("The reuse of a rotated refresh token should revoke the access token %s", token)
+		}
+	}
+
+	if _, err := storage.LoadRefresh(newRefresh); err == nil {
+		t.Error("The reuse of a rotated refresh token should revoke the last refresh token")
+	}
+}
+
+func TestOAuth2Revoke(t *testing.T) {
+	server := newOAuth2Server()
+	client := createClient(t)
+	defer storage.RemoveClient(client.ID)
+
+	_, _, newAccess, newRefresh := refresh(t, server, client)
+
+	if err := storage.RevokeToken(client.ID+1, newRefresh); err == nil {
+		t.Error("A client should not revoke the tokens issued to another client")
+	}
+
+	if err := storage.RevokeToken(client.ID, newRefresh); err != nil {
+		t.Fatalf("No error should happen when revoking a refresh token, but got: %s", err)
+	}
+
+	if _, err := storage.LoadAccess(newAccess); err == nil {
+		t.Error("Revoking a refresh token should revoke its access token")
+	}
+
+	_, _, newAccess, _ = refresh(t, server, client)
+	if err := storage.RevokeClientTokens(client.ID); err != nil {
+		t.Fatalf("No error should happen when revoking the client tokens, but got: %s", err)
+	}
+
+	if _, err := storage.LoadAccess(newAccess); err == nil {
+		t.Error("RevokeClientTokens should revoke every token of the client")
+	}
+
+	_, _, newAccess, _ = refresh(t, server, client)
+	code := authorize(t, server, client)
+	if err := storage.RevokeUserTokens(me.ID()); err != nil {
+		t.Fatalf("No error should happen when revoking the user tokens, but got: %s", err)
+	}
+
+	if _, err := storage.LoadAccess(newAccess); err == nil {
+		t.Error("RevokeUserTokens should revoke every token of the user")
+	}
+
+	if _, err := storage.LoadAuthorize(code); err == nil {
+		t.Error("RevokeUserTokens should revoke every authorization code of the user")
+	}
+}
+
+func TestOAuth2Scopes(t *testing.T) {
+	if scopes, err := db.ParseScopes(db.ScopePostsRead + "  " + db.ScopeFollow); err != nil || len(scopes) != 2 {
+		t.Errorf("ParseScopes should return the 2 valid scopes, but got: %v, %v", scopes, err)
+	}
+
+	if _, err := db.ParseScopes(db.ScopePostsRead + " admin"); err == nil {
+		t.Error("ParseScopes should fail on unknown scopes")
+	}
+
+	access := &db.OAuth2AccessData{Scope: db.ScopePostsRead + " " + db.ScopePmsRead}
+	if !access.HasScope(db.ScopePmsRead) || access.HasScope(db.ScopePostsWrite) {
+		t.Errorf("Unexpected scopes granted by %s", access.Scope)
+	}
+}
+
+func TestOAuth2ClientManagement(t *testing.T) {
+	client, secret, err := me.CreateOAuth2Client(ctx, "managed client", redirectURI, false)
+	if err != nil {
+		t.Fatalf("No error should happen when creating a client, but got: %s", err)
+	}
+	defer storage.RemoveClient(client.ID)
+
+	if client.GetSecret() == secret || !client.ClientSecretMatches(secret) {
+		t.Error("Only the hash of the secret should be stored")
+	}
+
+	found := false
+	for _, c := range me.OAuth2Clients(ctx) {
+		found = found || c.ID == client.ID
+	}
+	if !found {
+		t.Error("OAuth2Clients should contain the created client")
+	}
+
+	if _, _, err = me.CreateOAuth2Client(ctx, "invalid client", "/relative", false); err == nil {
+		t.Error("Relative redirect uris should not be accepted")
+	}
+
+	if _, _, err = other.CreateOAuth2Client(ctx, "  managed client ", redirectURI, true); !errors.Is(err, db.ErrConflict) {
+		t.Errorf("The names of the clients should be unique once trimmed, but got: %v", err)
+	}
+
+	if _, _, err = me.CreateOAuth2Client(ctx, "   ", redirectURI, true); !errors.Is(err, db.ErrInvalidArgument) {
+		t.Errorf("Empty client names should not be accepted, but got: %v", err)
+	}
+
+	if err = other.UpdateOAuth2ClientRedirectURI(ctx, client, "https://example.org"); err == nil {
+		t.Error("Only the owner should update the redirect uri of a client")
+	}
+
+	if err = me.UpdateOAuth2ClientRedirectURI(ctx, client, "https://example.org"); err != nil {
+		t.Errorf("The owner should update the redirect uri of a client, but got: %s", err)
+	}
+
+	if _, err = other.RotateOAuth2ClientSecret(ctx, client); err == nil {
+		t.Error("Only the owner should rotate the secret of a client")
+	}
+
+	newSecret, err := me.RotateOAuth2ClientSecret(ctx, client)
+	if err != nil {
+		t.Fatalf("The owner should rotate the secret of a client, but got: %s", err)
+	}
+
+	stored, _ := db.NewOAuth2Client(ctx, client.ID)
+	if stored.RedirectURI != "https://example.org" || stored.ClientSecretMatches(secret) || !stored.ClientSecretMatches(newSecret) {
+		t.Errorf("Unexpected client after the update: %+v", stored)
+	}
+
+	if err = other.DeleteOAuth2Client(ctx, client); err == nil {
+		t.Error("Only the owner should delete a client")
+	}
+
+	if err = me.DeleteOAuth2Client(ctx, client); err != nil {
+		t.Errorf("The owner should delete a client, but got: %s", err)
+	}
+
+	if _, err = db.NewOAuth2Client(ctx, client.ID); err == nil {
+		t.Error("The deleted client should not exist")
+	}
+}
+
+func TestOAuth2RemoveExpired(t *testing.T) {
+	client := createClient(t)
+	defer storage.RemoveClient(client.ID)
+
+	expired := time.Now().UTC().Add(-time.Hour)
+	if err := storage.SaveAuthorize(&osin.AuthorizeData{
+		Client:      client,
+		Code:        "expired code",
+		ExpiresIn:   60,
+		RedirectUri: redirectURI,
+		CreatedAt:   expired,
+		UserData:    me.ID()}); err != nil {
+		t.Fatalf("No error should happen when saving the authorize data, but got: %s", err)
+	}
+
+	for _, data := range []*osin.AccessData{
+		{Client: client, AccessToken: "expired access", ExpiresIn: 60, CreatedAt: expired, UserData: me.ID()},
+		{Client: client, AccessToken: "refreshable access", RefreshToken: "live refresh", ExpiresIn: 60, CreatedAt: expired, UserData: me.ID()},
+	} {
+		if err := storage.SaveAccess(data); err != nil {
+			t.Fatalf("No error should happen when saving the access data, but got: %s", err)
+		}
+	}
+
+	if removed, err := storage.RemoveExpiredAuthorize(1000); err != nil || removed == 0 {
+		t.Errorf("RemoveExpiredAuthorize should remove the expired code, but got: %d, %v", removed, err)
+	}
+
+	if _, err := storage.LoadAuthorize("expired code"); err == nil {
+		t.Error("The expired code should have been removed")
+	}
+
+	if removed, err := storage.RemoveExpiredAccess(1000); err != nil || removed == 0 {
+		t.Errorf("RemoveExpiredAccess should remove the expired access token, but got: %d, %v", removed, err)
+	}
+
+	if _, err := storage.LoadAccess("expired access"); err == nil {
+		t.Error("The expired access token without refresh token should have been removed")
+	}
+
+	if _, err := storage.LoadRefresh("live refresh"); err != nil {
+		t.Errorf("The expired access token with a live refresh token should be kept, but got: %s", err)
+	}
+	// the refresh token, issued an hour ago, is expired for a storage with a shorter TTL
+	short := storage.WithRefreshTTL(time.Minute)
+	if _, err := short.LoadRefresh("live refresh"); err == nil {
+		t.Error("The refresh token older than the TTL should be rejected")
+	}
+	if removed, err := short.RemoveExpiredAccess(1000); err != nil || removed == 0 {
+		t.Errorf("RemoveExpiredAccess should remove the token family with an expired refresh token, but got: %d, %v", removed, err)
+	}
+	if _, err := storage.LoadAccess("refreshable access"); err == nil {
+		t.Error("The access token with an expired refresh token should have been removed")
+	}
+}
+
+func TestOAuth2PKCE(t *testing.T) {
+	server := newOAuth2Server()
+	client, err := storage.CreateClient(&osin.DefaultClient{
+		RedirectUri: redirectURI,
+		UserData:    me.ID()}, "public client")
+	if err != nil {
+		t.Fatalf("No error should happen when creating a public client, but got: %s", err)
+	}
+	defer storage.RemoveClient(client.ID)
+
+	if !client.Public || !client.ClientSecretMatches("") || client.ClientSecretMatches(clientSecret) {
+		t.Fatalf("A client without secret should be public: %+v", client)
+	}
+
+	verifier := "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	sum := sha256.Sum256([]byte(verifier))
+	challenge := base64.RawURLEncoding.EncodeToString(sum[:])
+
+	authorizeRequest := func(values url.Values) *osin.Response {
+		values.Set("response_type", "code")
+		values.Set("client_id", client.GetId())
+		values.Set("redirect_uri", redirectURI)
+		values.Set("scope", db.ScopePostsRead)
+		req, _ := http.NewRequest("GET", "/authorize?"+values.Encode(), nil)
+
+		resp := server.NewResponse()
+		if ar := server.HandleAuthorizeRequest(resp, req); ar != nil {
+			ar.UserData = me.ID()
+			ar.Authorized = true
+			server.FinishAuthorizeRequest(resp, req, ar)
+		}
+		return resp
+	}
+
+	resp := authorizeRequest(url.Values{})
+	resp.Close()
+	if !resp.IsError {
+		t.Error("Public clients should not be authorized without code challenge")
+	}
+
+	resp = authorizeRequest(url.Values{"code_challenge": {challenge}, "code_challenge_method": {"S256"}})
+	resp.Close()
+	if resp.IsError {
+		t.Fatalf("Authorization request failed: %s (%v)", resp.ErrorId, resp.InternalError)
+	}
+
+	code := resp.Output["code"].(string)
+	authorizeData, err := storage.LoadAuthorize(code)
+	if err != nil || authorizeData.CodeChallenge != challenge || authorizeData.CodeChallengeMethod != "S256" {
+		t.Fatalf("The code challenge should be stored with the authorize data, but got: %+v, %v", authorizeData, err)
+	}
+
+	req, _ := http.NewRequest("POST", "/token", strings.NewReader(url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {redirectURI},
+		"code_verifier": {"wrong-verifier-wrong-verifier-wrong-verifier-wrong"}}.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(client.GetId(), "")
+
+	resp = server.NewResponse()
+	if ar := server.HandleAccessRequest(resp, req); ar != nil {
+		t.Error("The access request with a wrong code verifier should be rejected")
+	}
+	resp.Close()
+
+	output := access(t, server, client, url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {redirectURI},
+		"code_verifier": {verifier}})
+
+	if _, err = storage.LoadAccess(output["access_token"].(string)); err != nil {
+		t.Errorf("The public client should obtain an access token, but got: %s", err)
+	}
+}
@@ -18,10 +18,10 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package db
 
 import (
-	"errors"
+	"context"
 	"time"
 
-	"github.com/galeone/igor"
+	"github.com/nerdzeu/nerdz-core/db/igor"
 )
 
 const (
@@ -33,22 +33,15 @@ const (
 
 // PmsOptions represent the configuration used to fetch a Pm list
 type PmsOptions struct {
-	N     uint8  // number of pms to return
-	Older uint64 // if specified, tells to the function that is using this struct to return N pms OLDER (created before) than the pms with the specified "Older" ID
-	Newer uint64 // if specified, tells to the function that is using this struct to return N pms NEWER (created after) than the comment with the spefified "Newer" ID
+	N     uint8   // number of pms to return
+	Older *Cursor // if specified, tells to the function that is using this struct to return N pms OLDER (created before) than the pm pointed by the cursor
+	Newer *Cursor // if specified, tells to the function that is using this struct to return the N pms NEWER (created after) than the pm pointed by the cursor, nearest first
 }
 
 // pmsQueryBuilder returns the same pointer passed as first argument, with new specified options setted
 func pmsQueryBuilder(query *igor.Database, options PmsOptions) *igor.Database {
-	query = query.Limit(int(AtMostPms(uint64(options.N)))).Order("pmid DESC")
-	if options.Older != 0 && options.Newer != 0 {
-		query = query.Where("pmid BETWEEN ? AND ?", options.Newer, options.Older)
-	} else if options.Older != 0 {
-		query = query.Where("pmid < ?", options.Older)
-	} else if options.Newer != 0 {
-		query = query.Where("pmid > ?", options.Newer)
-	}
-	return query
+	query = query.Limit(int(AtMostPms(uint64(options.N))))
+	return keysetQueryBuilder(query, []string{"pmid"}, options.Older, options.Newer)
 }
 
 // Conversation represents the details about a single private conversation between two users
@@ -61,18 +54,24 @@ type Conversation struct {
 }
 
 // NewPm initializes a Pm struct
-func NewPm(pmid uint64) (*PM, error) {
-	return NewPmWhere(&PM{Pmid: pmid})
+func NewPm(ctx context.Context, pmid uint64) (*PM, error) {
+	if pmid == 0 {
+		return nil, notFound("Requested Pm does not exist")
+	}
+	return NewPmWhere(ctx, &PM{Pmid: pmid})
 }
 
 // NewPmWhere returns the *Pm fetching the first one that matches the description
-func NewPmWhere(description *PM) (pm *PM, e error) {
+func NewPmWhere(ctx context.Context, description *PM) (pm *PM, e error) {
+	if emptyDescription(description) {
+		return nil, invalidArgument("the description of the Pm is empty")
+	}
 	pm = new(PM)
-	if e = db().Model(PM{}).Where(description).Scan(pm); e != nil {
+	if e = storage(ctx).Find(ctx, description, pm); e != nil {
 		return nil, e
 	}
 	if pm.Pmid == 0 {
-		return nil, errors.New("Requested Pm does not exist")
+		return nil, notFound("Requested Pm does not exist")
 	}
 	return
 }
@@ -123,8 +122,8 @@ func (pm *PM) NumericSender() uint64 {
 }
 
 // Sender returns the sender *User
-func (pm *PM) Sender() *User {
-	user, _ := NewUser(pm.NumericSender())
+func (pm *PM) Sender(ctx context.Context) *User {
+	user, _ := NewUser(ctx, pm.NumericSender())
 	return user
 }
 
@@ -134,8 +133,8 @@ func (pm *PM) NumericReference() uint64 {
 }
 
 // Reference returns the recipient *User
-func (pm *PM) Reference() Reference {
-	user, _ := NewUser(pm.NumericReference())
+func (pm *PM) Reference(ctx context.Context) Reference {
+	user, _ := NewUser(ctx, pm.NumericReference())
 	return user
 }
 
@@ -150,31 +149,31 @@ func (pm *PM) IsEditable() bool {
 }
 
 // NumericOwners returns a slice of ids of the owner of the pms (the ones that can perform actions)
-func (pm *PM) NumericOwners() []uint64 {
+func (pm *PM) NumericOwners(ctx context.Context) []uint64 {
 	return []uint64{pm.To, pm.From}
 }
 
 // Owners returns a slice of *User representing the users who own the pm
-func (pm *PM) Owners() (ret []*User) {
-	return Users(pm.NumericOwners())
+func (pm *PM) Owners(ctx context.Context) ([]*User, error) {
+	return Users(ctx, pm.NumericOwners(ctx))
 }
 
 // Revisions returns all the revisions of the message
-func (pm *PM) Revisions() (modifications []string) {
+func (pm *PM) Revisions(ctx context.Context) (modifications []string) {
 	return
 }
 
 // RevisionsNumber returns the number of the revisions
-func (pm *PM) RevisionsNumber() uint8 {
+func (pm *PM) RevisionsNumber(ctx context.Context) uint8 {
 	return 0
 }
 
 // Votes returns the pm's votes value
-func (pm *PM) VotesCount() int {
+func (pm *PM) VotesCount(ctx context.Context) int {
 	return 0
 }
 
 // Voters returns a slice of *Vote representing the votes
-func (pm *PM) Votes() (votes *[]Vote) {
+func (pm *PM) Votes(ctx context.Context) (votes *[]Vote) {
 	return
 }
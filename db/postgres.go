@@ -0,0 +1,640 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/nerdzeu/nerdz-core/db/health"
+	"github.com/nerdzeu/nerdz-core/db/igor"
+	"github.com/nerdzeu/nerdz-core/utils"
+)
+
+// postgres is the Storage backed by the NERDZ PostgreSQL database,
+// whose triggers enforce the rules of the relations
+type postgres struct {
+	db *igor.Database
+	// replicas serve the reads, in turn. The writes and the transactions use db
+	replicas []replica
+	next     uint32
+	// backoff describes how the reads that fail because of a transient error are retried
+	backoff health.Backoff
+}
+
+// replica is a read replica, whose breaker is open while it's down
+type replica struct {
+	db      *igor.Database
+	breaker *health.Breaker
+}
+
+// pqDriver is the PostgreSQL driver, that lib/pq registers without exporting it
+type pqDriver struct{}
+
+func (pqDriver) Open(name string) (driver.Conn, error) {
+	return pq.Open(name)
+}
+
+// query returns the database whose queries use ctx
+func (p *postgres) query(ctx context.Context) *igor.Database {
+	return p.db.WithContext(ctx)
+}
+
+// read returns the database that serves the reads of ctx, whose queries use ctx:
+// a replica that is not down, unless there are none or ctx requires to read its own writes (see ReadYourWrites)
+func (p *postgres) read(ctx context.Context) *igor.Database {
+	if len(p.replicas) == 0 || readYourWrites(ctx) {
+		return p.query(ctx)
+	}
+
+	n := uint32(len(p.replicas))
+	next := atomic.AddUint32(&p.next, 1)
+	for i := uint32(0); i < n; i++ {
+		if replica := p.replicas[(next+i)%n]; replica.breaker.State() != health.Open {
+			return replica.db.WithContext(ctx)
+		}
+	}
+	return p.query(ctx)
+}
+
+// retry executes the read f on the database returned by read, and executes it again,
+// after a bounded backoff, while it fails because of a transient error
+func (p *postgres) retry(ctx context.Context, f func(database *igor.Database) error) error {
+	return p.backoff.Retry(ctx, transient, func() error {
+		return f(p.read(ctx))
+	})
+}
+
+// storageError converts e, returned by PostgreSQL, into an *Error of the kind of its code
+func storageError(e error) error {
+	pqErr, ok := e.(*pq.Error)
+	if !ok {
+		return e
+	}
+
+	switch pqErr.Code.Name() {
+	case "unique_violation":
+		return NewError(ErrConflict, "%s", pqErr.Message)
+	case "foreign_key_violation":
+		return notFound("%s", pqErr.Message)
+	case "not_null_violation", "check_violation", "invalid_text_representation", "string_data_right_truncation":
+		return invalidArgument("%s", pqErr.Message)
+	case "raise_exception": // raised by the triggers that enforce the NERDZ rules
+		return permissionDenied("%s", pqErr.Message)
+	}
+	return e
+}
+
+func (p *postgres) Create(ctx context.Context, model igor.DBModel) error {
+	return storageError(p.query(ctx).Create(model))
+}
+
+func (p *postgres) Updates(ctx context.Context, model igor.DBModel) error {
+	return storageError(p.query(ctx).Updates(model))
+}
+
+func (p *postgres) Delete(ctx context.Context, description igor.DBModel) error {
+	return storageError(p.query(ctx).Delete(description))
+}
+
+func (p *postgres) DeleteIn(ctx context.Context, model igor.DBModel, column string, values []uint64) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return storageError(p.query(ctx).Where(`"`+column+`" IN (?)`, values).Delete(model))
+}
+
+func (p *postgres) Notified(ctx context.Context, model igor.DBModel, column string, values []uint64) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return storageError(p.query(ctx).Exec(`UPDATE `+model.TableName()+` SET to_notify = FALSE
+		WHERE "`+column+`" = ANY(?) AND to_notify`, pq.Array(values)))
+}
+
+func (p *postgres) UpdateIn(ctx context.Context, model igor.DBModel, column string, values []uint64, changes map[string]interface{}) error {
+	if len(values) == 0 || len(changes) == 0 {
+		return nil
+	}
+
+	columns := make([]string, 0, len(changes))
+	for name := range changes {
+		columns = append(columns, name)
+	}
+	sort.Strings(columns)
+
+	var set []string
+	var args []interface{}
+	for _, name := range columns {
+		if changes[name] == nil {
+			set = append(set, `"`+name+`" = NULL`)
+		} else {
+			set = append(set, `"`+name+`" = ?`)
+			args = append(args, changes[name])
+		}
+	}
+	return storageError(p.query(ctx).Exec(`UPDATE `+model.TableName()+` SET `+strings.Join(set, ", ")+`
+		WHERE "`+column+`" = ANY(?)`, append(args, pq.Array(values))...))
+}
+
+// rewind returns a function that restores the length of the slice pointed by dest, if dest points to a slice,
+// so that a retried query doesn't append its rows twice
+func rewind(dest interface{}) func() {
+	slice := reflect.Indirect(reflect.ValueOf(dest))
+	if slice.Kind() != reflect.Slice {
+		return func() {}
+	}
+	length := slice.Len()
+	return func() {
+		slice.SetLen(length)
+	}
+}
+
+func (p *postgres) Find(ctx context.Context, description igor.DBModel, dest interface{}) error {
+	restore := rewind(dest)
+	return p.retry(ctx, func(database *igor.Database) error {
+		restore()
+		query := database.Model(description).Where(description)
+		if reflect.Indirect(reflect.ValueOf(dest)).Kind() != reflect.Slice {
+			query = query.Limit(1)
+		}
+		return query.Scan(dest)
+	})
+}
+
+func (p *postgres) FindIn(ctx context.Context, model igor.DBModel, column string, values []uint64, dest interface{}) error {
+	if len(values) == 0 {
+		return nil
+	}
+	restore := rewind(dest)
+	return p.retry(ctx, func(database *igor.Database) error {
+		restore()
+		return database.Model(model).Where(`"`+column+`" IN (?)`, values).Scan(dest)
+	})
+}
+
+func (p *postgres) Pluck(ctx context.Context, description igor.DBModel, column string, dest interface{}) error {
+	restore := rewind(dest)
+	return p.retry(ctx, func(database *igor.Database) error {
+		restore()
+		return database.Model(description).Where(description).Pluck(`"`+column+`"`, dest)
+	})
+}
+
+func (p *postgres) Count(ctx context.Context, description igor.DBModel) (count uint64, e error) {
+	e = p.retry(ctx, func(database *igor.Database) error {
+		return database.Model(description).Where(description).Select("count(*)").Scan(&count)
+	})
+	return
+}
+
+func (p *postgres) Sum(ctx context.Context, description igor.DBModel, column string) (sum int64, e error) {
+	e = p.retry(ctx, func(database *igor.Database) error {
+		return database.Model(description).Where(description).Select(`COALESCE(sum("` + column + `"), 0)`).Scan(&sum)
+	})
+	return
+}
+
+func (p *postgres) Transaction(ctx context.Context, f func(Storage) error) error {
+	return begin(p.query(ctx), func(tx *igor.Database) error {
+		return f(&postgres{db: tx})
+	})
+}
+
+func (p *postgres) LockIn(ctx context.Context, model igor.DBModel, column string, values []uint64) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return storageError(p.query(ctx).Exec(`SELECT 1 FROM `+model.TableName()+` WHERE "`+column+`" = ANY(?) FOR UPDATE`, pq.Array(values)))
+}
+
+func (p *postgres) Publish(ctx context.Context, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return p.query(ctx).Notify(eventsChannel, string(payload))
+}
+
+func (p *postgres) Listen(f func(Event)) error {
+	return p.db.Listen(eventsChannel, func(payload ...string) {
+		var event Event
+		if len(payload) == 1 && json.Unmarshal([]byte(payload[0]), &event) == nil {
+			f(event)
+		}
+	})
+}
+
+func (p *postgres) Login(ctx context.Context, username, password string) (uint64, error) {
+	var logged bool
+	var counter uint64
+
+	if e := p.query(ctx).Model(User{}).Select("login(?, ?) AS logged, counter", username, password).Where("LOWER(username) = ?", username).Scan(&logged, &counter); e != nil {
+		return 0, e
+	}
+
+	if !logged {
+		return 0, nil
+	}
+	return counter, nil
+}
+
+func (p *postgres) UsersByUsername(ctx context.Context, usernames []string) ([]User, error) {
+	if len(usernames) == 0 {
+		return nil, nil
+	}
+	lower := make([]string, len(usernames))
+	for i, username := range usernames {
+		lower[i] = strings.ToLower(username)
+	}
+
+	var users []User
+	err := p.retry(ctx, func(database *igor.Database) error {
+		users = nil
+		return database.Model(User{}).Where("LOWER(username) IN (?)", lower).Scan(&users)
+	})
+	return users, err
+}
+
+// userProfile is a user joined with its profile, as selected by UsersIn
+type userProfile struct {
+	User
+	Profile
+}
+
+// TableName returns the name of the common table expression of UsersIn
+func (userProfile) TableName() string {
+	return "users_profiles"
+}
+
+func (p *postgres) UsersIn(ctx context.Context, ids []uint64) ([]User, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var rows []userProfile
+	err := p.retry(ctx, func(database *igor.Database) error {
+		rows = nil
+		return database.
+			CTE(`WITH users_profiles AS (SELECT * FROM `+User{}.TableName()+` JOIN `+Profile{}.TableName()+` USING (counter) WHERE counter = ANY(?))`, pq.Array(ids)).
+			Model(userProfile{}).
+			Scan(&rows)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]User, len(rows))
+	for i, row := range rows {
+		users[i] = row.User
+		users[i].Profile = row.Profile
+	}
+	return users, nil
+}
+
+func (p *postgres) UserHome(ctx context.Context, user uint64, options PostlistOptions) ([]UserPost, error) {
+	var userPost UserPost
+	options.Model = userPost
+
+	var posts []UserPost
+	err := p.retry(ctx, func(database *igor.Database) error {
+		query := database.Model(userPost)
+		query = query.Where("("+UserPost{}.TableName()+`."to" NOT IN (SELECT "to" FROM blacklist WHERE "from" = ?))`, user)
+		query = postlistQueryBuilder(query, options, &User{Counter: user})
+
+		posts = nil
+		return query.Scan(&posts)
+	})
+	if newerOnly(options.Older, options.Newer) {
+		posts = utils.ReverseSlice(posts).([]UserPost)
+	}
+	return posts, err
+}
+
+func (p *postgres) ProjectHome(ctx context.Context, user uint64, options PostlistOptions) ([]ProjectPost, error) {
+	var projectPost ProjectPost
+	options.Model = projectPost
+
+	var projectPosts []ProjectPost
+	err := p.retry(ctx, func(database *igor.Database) error {
+		query := database.Model(projectPost)
+		query = projectPostlistConditions(query, &User{Counter: user})
+		query = postlistQueryBuilder(query, options, &User{Counter: user})
+
+		projectPosts = nil
+		return query.Scan(&projectPosts)
+	})
+	if newerOnly(options.Older, options.Newer) {
+		projectPosts = utils.ReverseSlice(projectPosts).([]ProjectPost)
+	}
+	return projectPosts, err
+}
+
+func (p *postgres) Home(ctx context.Context, user uint64, options PostlistOptions) ([]Message, error) {
+	var message Message
+	options.Model = message
+
+	var posts []Message
+	err := p.retry(ctx, func(database *igor.Database) error {
+		query := database.
+			CTE(`WITH blist AS (SELECT "to" FROM blacklist WHERE "from" = ?)`, user). // WITH cte
+			Table(message.TableName()).                                               // select * from messages
+			Where(homeConditions, user)
+		query = postlistQueryBuilder(query, options, &User{Counter: user}) // handle following, followers, language, newer, older, between...
+
+		posts = nil
+		return query.Scan(&posts)
+	})
+	if newerOnly(options.Older, options.Newer) {
+		posts = utils.ReverseSlice(posts).([]Message)
+	}
+	return posts, err
+}
+
+func (p *postgres) Tagged(ctx context.Context, user uint64, tag string, options PostlistOptions) ([]Message, error) {
+	var message Message
+	options.Model = message
+	classifications := PostClassification{}.TableName()
+
+	var posts []Message
+	err := p.retry(ctx, func(database *igor.Database) error {
+		query := database.
+			CTE(`WITH blist AS (SELECT "to" FROM blacklist WHERE "from" = ?)`, user).
+			Table(message.TableName()).
+			Where(homeConditions, user).
+			Where(`CASE type
+			WHEN 1 THEN hpid IN (SELECT uhpid FROM `+classifications+` WHERE tag = ?)
+			ELSE hpid IN (SELECT ghpid FROM `+classifications+` WHERE tag = ?)
+			END`, tag, tag)
+		query = postlistQueryBuilder(query, options, &User{Counter: user})
+
+		posts = nil
+		return query.Scan(&posts)
+	})
+	if newerOnly(options.Older, options.Newer) {
+		posts = utils.ReverseSlice(posts).([]Message)
+	}
+	return posts, err
+}
+
+func (p *postgres) Trending(ctx context.Context, user uint64, since time.Time, n uint8) ([]Trend, error) {
+	var trends []Trend
+	err := p.retry(ctx, func(database *igor.Database) error {
+		trends = nil
+		return database.Raw(`WITH blist AS (SELECT "to" FROM blacklist WHERE "from" = ?)
+		SELECT c.tag, COUNT(*) AS "count"
+		FROM `+PostClassification{}.TableName()+` c
+		INNER JOIN messages ON messages.hpid = CASE messages.type WHEN 1 THEN c.uhpid ELSE c.ghpid END
+		WHERE messages."time" >= ? AND `+homeConditions+`
+		GROUP BY c.tag
+		ORDER BY "count" DESC, c.tag
+		LIMIT ?`, user, since, user, n).Scan(&trends)
+	})
+	return trends, err
+}
+
+func (p *postgres) UserPostlist(ctx context.Context, user uint64, options PostlistOptions) ([]UserPost, error) {
+	users := User{}.TableName()
+	var post UserPost
+	options.Model = post
+
+	var userPosts []UserPost
+	err := p.retry(ctx, func(database *igor.Database) error {
+		query := database.Model(UserPost{}).
+			Joins("JOIN "+users+" ON "+users+".counter = "+post.TableName()+".to").
+			Where(`"to" = ?`, user)
+		query = postlistQueryBuilder(query, options, &User{Counter: user})
+
+		userPosts = nil
+		return query.Scan(&userPosts)
+	})
+	if newerOnly(options.Older, options.Newer) {
+		userPosts = utils.ReverseSlice(userPosts).([]UserPost)
+	}
+	return userPosts, err
+}
+
+func (p *postgres) ProjectPostlist(ctx context.Context, project, user uint64, options PostlistOptions) ([]ProjectPost, error) {
+	var posts []ProjectPost
+	var projectPost ProjectPost
+	projectPosts := projectPost.TableName()
+	users := new(User).TableName()
+	options.Model = projectPost
+
+	err := p.retry(ctx, func(database *igor.Database) error {
+		query := database.Model(projectPost).
+			Joins("JOIN "+users+" ON "+users+".counter = "+projectPosts+".to"). //PostListOptions.Language support
+			Where(`"to" = ?`, project)
+		if user != 0 {
+			query = query.Where(projectPosts+`."from" NOT IN (SELECT "to" FROM `+Blacklist{}.TableName()+` WHERE "from" = ?)`, user)
+		}
+		query = postlistQueryBuilder(query, options)
+
+		posts = nil
+		return query.Scan(&posts)
+	})
+	if newerOnly(options.Older, options.Newer) {
+		posts = utils.ReverseSlice(posts).([]ProjectPost)
+	}
+	return posts, err
+}
+
+func (p *postgres) UserPostComments(ctx context.Context, hpid uint64, options CommentlistOptions) ([]UserPostComment, error) {
+	var comments []UserPostComment
+
+	err := p.retry(ctx, func(database *igor.Database) error {
+		query := database.Where(&UserPostComment{Hpid: hpid})
+		query = commentlistQueryBuilder(query, options)
+
+		comments = nil
+		return query.Scan(&comments)
+	})
+	if newerOnly(options.Older, options.Newer) {
+		comments = utils.ReverseSlice(comments).([]UserPostComment)
+	}
+	return comments, err
+}
+
+func (p *postgres) ProjectPostComments(ctx context.Context, hpid uint64, options CommentlistOptions) ([]ProjectPostComment, error) {
+	var comments []ProjectPostComment
+
+	err := p.retry(ctx, func(database *igor.Database) error {
+		query := database.Where(&ProjectPostComment{Hpid: hpid})
+		query = commentlistQueryBuilder(query, options)
+
+		comments = nil
+		return query.Scan(&comments)
+	})
+	if newerOnly(options.Older, options.Newer) {
+		comments = utils.ReverseSlice(comments).([]ProjectPostComment)
+	}
+	return comments, err
+}
+
+func (p *postgres) Pms(ctx context.Context, user, other uint64, options PmsOptions) ([]PM, error) {
+	var pms []PM
+
+	err := p.retry(ctx, func(database *igor.Database) error {
+		query := database.Model(PM{}).Where(
+			`("from" = ? AND "to" = ?) OR ("from" = ? AND "to" = ?)`,
+			user, other, other, user)
+		// build query in function of parameters
+		query = pmsQueryBuilder(query, options)
+
+		pms = nil
+		return query.Scan(&pms)
+	})
+	if newerOnly(options.Older, options.Newer) {
+		pms = utils.ReverseSlice(pms).([]PM)
+	}
+	return pms, err
+}
+
+func (p *postgres) Conversations(ctx context.Context, user uint64) ([]Conversation, error) {
+	var convList []Conversation
+	err := p.retry(ctx, func(database *igor.Database) error {
+		convList = nil
+		return database.Raw(`WITH conversations_with_duplicates AS (
+			SELECT DISTINCT ?::bigint AS me, otherid, MAX(times) as "time", to_read FROM (
+				SELECT MAX("time") AS times, "from" as otherid, to_read FROM pms WHERE "to" = ? GROUP BY "from", to_read
+				UNION
+				SELECT MAX("time") AS times, "to" as otherid, FALSE AS to_read FROM pms WHERE "from" = ? GROUP BY "to", to_read
+			) AS tmp GROUP BY otherid, to_read
+		)
+		SELECT c.me, c.otherid, p.message, MAX(c."time") AS t, c.to_read
+		FROM conversations_with_duplicates c
+		INNER JOIN pms p
+		ON c."time" = p."time" AND (
+			(c.me = p."from" AND c.otherid = p."to")
+			OR
+			(c.me = p."to" AND c.otherid = p."from")
+		)
+		GROUP BY c.me, c.otherid, p.message, c.to_read
+		ORDER BY to_read DESC, t DESC`, user, user, user).Scan(&convList)
+	})
+	return convList, err
+}
+
+func (p *postgres) Notifications(ctx context.Context, user uint64, options NotificationsOptions) ([]Notification, error) {
+	query := notificationsQuery(options.Types) + `
+	SELECT type, id, "from", board, project, hpid, "time" FROM notifications WHERE TRUE`
+	args := []interface{}{user}
+	if options.Older != nil {
+		query += ` AND ("time", type, id) < (?, ?, ?)`
+		args = append(args, options.Older.Time, notificationTypeOf(options.Older.Table), options.Older.ID)
+	}
+	if options.Newer != nil {
+		query += ` AND ("time", type, id) > (?, ?, ?)`
+		args = append(args, options.Newer.Time, notificationTypeOf(options.Newer.Table), options.Newer.ID)
+	}
+	direction := " DESC"
+	if newerOnly(options.Older, options.Newer) {
+		direction = " ASC"
+	}
+	query += ` ORDER BY "time"` + direction + `, type` + direction + `, id` + direction + ` LIMIT ?`
+	args = append(args, AtMostNotifications(uint64(options.N)))
+
+	var notifications []Notification
+	err := p.retry(ctx, func(database *igor.Database) error {
+		notifications = nil
+		return database.Raw(query, args...).Scan(&notifications)
+	})
+	if newerOnly(options.Older, options.Newer) {
+		notifications = utils.ReverseSlice(notifications).([]Notification)
+	}
+	return notifications, err
+}
+
+func (p *postgres) CountNotifications(ctx context.Context, user uint64, types []NotificationType) (count uint64, e error) {
+	e = p.retry(ctx, func(database *igor.Database) error {
+		return database.Raw(notificationsQuery(types)+` SELECT COUNT(*) FROM notifications`, user).Scan(&count)
+	})
+	return
+}
+
+func (p *postgres) Mentions(ctx context.Context, description *Mention, options MentionsOptions) ([]Mention, error) {
+	var mentions []Mention
+
+	err := p.retry(ctx, func(database *igor.Database) error {
+		query := database.Model(Mention{}).Where(description)
+		query = mentionsQueryBuilder(query, options)
+
+		mentions = nil
+		return query.Scan(&mentions)
+	})
+	if newerOnly(options.Older, options.Newer) {
+		mentions = utils.ReverseSlice(mentions).([]Mention)
+	}
+	return mentions, err
+}
+
+func (p *postgres) ReadPms(ctx context.Context, user, other uint64) error {
+	return storageError(p.query(ctx).Exec(`UPDATE `+PM{}.TableName()+` SET to_read = FALSE
+		WHERE "from" = ? AND "to" = ? AND to_read`, other, user))
+}
+
+func (p *postgres) OAuth2Family(ctx context.Context, id uint64) ([]uint64, error) {
+	table := OAuth2AccessData{}.TableName()
+
+	var ids []uint64
+	err := p.query(ctx).Raw(`WITH RECURSIVE ancestors(id, parent) AS (
+		SELECT id, oauth2_access_id FROM `+table+` WHERE id = ?
+		UNION
+		SELECT a.id, a.oauth2_access_id FROM `+table+` a JOIN ancestors p ON a.id = p.parent
+	), family(id) AS (
+		SELECT id FROM ancestors WHERE parent IS NULL
+		UNION
+		SELECT a.id FROM `+table+` a JOIN family f ON a.oauth2_access_id = f.id
+	)
+	SELECT id FROM family`, id).Scan(&ids)
+	return ids, err
+}
+
+func (p *postgres) ExpiredOAuth2Authorize(ctx context.Context, limit int) ([]uint64, error) {
+	var ids []uint64
+	err := p.query(ctx).Raw(`SELECT id FROM `+OAuth2AuthorizeData{}.TableName()+`
+		WHERE created_at + expires_in * interval '1 second' < (now() at time zone 'utc')
+		ORDER BY id LIMIT ?`, limit).Scan(&ids)
+	return ids, err
+}
+
+func (p *postgres) DeadOAuth2Families(ctx context.Context, refreshTTL time.Duration, limit int) ([]uint64, error) {
+	table := OAuth2AccessData{}.TableName()
+
+	var ids []uint64
+	err := p.query(ctx).Raw(`WITH RECURSIVE tips(id) AS (
+		SELECT t.id FROM `+table+` t
+		WHERE (t.refresh_token_id IS NULL OR t.created_at + ? * interval '1 second' < (now() at time zone 'utc'))
+		AND t.created_at + t.expires_in * interval '1 second' < (now() at time zone 'utc')
+		AND NOT EXISTS (SELECT 1 FROM `+table+` s WHERE s.oauth2_access_id = t.id)
+		ORDER BY t.id LIMIT ?
+	), family(id, parent) AS (
+		SELECT id, oauth2_access_id FROM `+table+` WHERE id IN (SELECT id FROM tips)
+		UNION
+		SELECT a.id, a.oauth2_access_id FROM `+table+` a JOIN family f ON a.id = f.parent
+	)
+	SELECT id FROM family`, int64(refreshTTL/time.Second), limit).Scan(&ids)
+	return ids, err
+}
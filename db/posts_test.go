@@ -1,3 +1,6 @@
+//go:build postgres
+// +build postgres
+
 /*
 Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
 
@@ -30,30 +33,26 @@ var (
 )
 
 func init() {
-	err := db.Init()
-	if err != nil {
-		panic(err)
-	}
-
-	if projectPost, err = db.NewProjectPost(uint64(3)); err != nil {
+	var err error
+	if projectPost, err = db.NewProjectPost(ctx, uint64(3)); err != nil {
 		panic(fmt.Sprintf("No error should happen when create existing post, but got: %+v", err))
 	}
 
-	if userPost, err = db.NewUserPost(6); err != nil {
+	if userPost, err = db.NewUserPost(ctx, 6); err != nil {
 		panic(fmt.Sprintf("No error should happen when create existing post, but got: %+v", err))
 	}
 
-	userPost1, _ = db.NewUserPost(20)
+	userPost1, _ = db.NewUserPost(ctx, 20)
 }
 
 func TestFrom(t *testing.T) {
-	from := userPost.Sender()
+	from := userPost.Sender(ctx)
 
 	if from.Counter != 1 {
 		t.Fatalf("Counter should be 1, but go: %d", from.Counter)
 	}
 
-	fromPrj := projectPost.Sender()
+	fromPrj := projectPost.Sender(ctx)
 
 	if fromPrj.Counter != 4 {
 		t.Fatalf("Counter should be 4, but go: %d", fromPrj.Counter)
@@ -63,7 +62,7 @@ func TestFrom(t *testing.T) {
 }
 
 func TestTo(t *testing.T) {
-	to := userPost.Reference()
+	to := userPost.Reference(ctx)
 
 	user := to.(*db.User)
 
@@ -71,7 +70,7 @@ func TestTo(t *testing.T) {
 		t.Fatalf("Counter should be 1, got: %d", user.Counter)
 	}
 
-	to = projectPost.Reference()
+	to = projectPost.Reference(ctx)
 
 	project := to.(*db.Project)
 
@@ -83,38 +82,38 @@ func TestTo(t *testing.T) {
 }
 
 func TestComments(t *testing.T) {
-	comments := *userPost.Comments(db.CommentlistOptions{})
+	comments := *userPost.Comments(ctx, db.CommentlistOptions{})
 	if len(comments) == 0 {
 		t.Error("No comments found. Expected > 1")
 	}
 
-	comments = *userPost.Comments(db.CommentlistOptions{N: 4})
+	comments = *userPost.Comments(ctx, db.CommentlistOptions{N: 4})
 	if len(comments) != 4 {
 		t.Fatalf("Expected the last 4 comments, got: %d", len(comments))
 	}
 
-	comments = *userPost.Comments(db.CommentlistOptions{
-		// Comments are fetched in inversed temporal order
-		Older: comments[0].ID(),
-		Newer: comments[3].ID() - 1,
+	comments = *userPost.Comments(ctx, db.CommentlistOptions{
+		// Comments are returned in temporal order: the cursors exclude the oldest and the newest
+		Older: db.NewCursor(comments[3]),
+		Newer: db.NewCursor(comments[0]),
 	})
-	if len(comments) != 3 {
-		t.Fatalf("Expected 3 comments, received: %d", len(comments))
+	if len(comments) != 2 {
+		t.Fatalf("Expected 2 comments, received: %d", len(comments))
 	}
 	t.Logf("%+v\n", comments)
 
-	prjComments := *projectPost.Comments(db.CommentlistOptions{})
+	prjComments := *projectPost.Comments(ctx, db.CommentlistOptions{})
 	if len(prjComments) == 0 {
 		t.Error("No comments found. Expected > 1")
 	}
 
-	prjComments = *projectPost.Comments(db.CommentlistOptions{N: 4})
+	prjComments = *projectPost.Comments(ctx, db.CommentlistOptions{N: 4})
 	if len(prjComments) != 1 {
 		t.Fatalf("Expected the last  comment, got: %d", len(prjComments))
 	}
 	t.Logf("%+v\n", prjComments)
 
-	prjComments = *projectPost.Comments(db.CommentlistOptions{Newer: 100})
+	prjComments = *projectPost.Comments(ctx, db.CommentlistOptions{Newer: &db.Cursor{Table: db.ProjectPostComment{}.TableName(), ID: 100}})
 	if len(prjComments) != 0 {
 		t.Fatalf("Expected no comment, received: %d", len(prjComments))
 	}
@@ -122,24 +121,27 @@ func TestComments(t *testing.T) {
 }
 
 func TestVotes(t *testing.T) {
-	num := userPost.VotesCount()
+	num := userPost.VotesCount(ctx)
 	if num != -2 {
 		t.Fatalf("Expected -2, but got %d", num)
 	}
 
-	num = projectPost.VotesCount()
+	num = projectPost.VotesCount(ctx)
 	if num != 1 {
 		t.Fatalf("Expected 1, but got %d", num)
 	}
 }
 
 func TestBookmarks(t *testing.T) {
-	users := userPost.Bookmarkers()
+	users, err := userPost.Bookmarkers(ctx)
+	if err != nil {
+		t.Fatalf("No error should happen when loading the users, but got: %s", err)
+	}
 	if len(users) != 1 {
 		t.Fatalf("Expected only 1 users, but got: %d", len(users))
 	}
 
-	n := userPost.BookmarksCount()
+	n := userPost.BookmarksCount(ctx)
 	if 1 != n {
 		t.Fatalf("BookmarksCount returned %d instead of 1", n)
 	}
@@ -148,12 +150,15 @@ func TestBookmarks(t *testing.T) {
 		t.Fatalf("Post shoud be bookmarked by 'admin', but got: %v", users[0].Username)
 	}
 
-	users = projectPost.Bookmarkers()
+	users, err = projectPost.Bookmarkers(ctx)
+	if err != nil {
+		t.Fatalf("No error should happen when loading the users, but got: %s", err)
+	}
 	if len(users) != 1 {
 		t.Fatalf("Expected only 1 users, but got: %d", len(users))
 	}
 
-	n = projectPost.BookmarksCount()
+	n = projectPost.BookmarksCount(ctx)
 
 	if 1 != n {
 		t.Fatalf("BookmarksCount returned %d instead of 1", n)
@@ -165,13 +170,16 @@ func TestBookmarks(t *testing.T) {
 }
 
 func TestLurkers(t *testing.T) {
-	users := userPost1.Lurkers()
+	users, err := userPost1.Lurkers(ctx)
+	if err != nil {
+		t.Fatalf("No error should happen when loading the users, but got: %s", err)
+	}
 
 	if len(users) != 1 {
 		t.Fatalf("Expected only 1 users, but got: %d", len(users))
 	}
 
-	n := userPost1.LurkersCount()
+	n := userPost1.LurkersCount(ctx)
 
 	if 1 != n {
 		t.Fatalf("LurkersCount returned %d instead of 1", n)
@@ -181,12 +189,15 @@ func TestLurkers(t *testing.T) {
 		t.Fatalf("Post shoud be lurked by 'admin', but got: %v", users[0].Username)
 	}
 
-	users = projectPost.Lurkers()
+	users, err = projectPost.Lurkers(ctx)
+	if err != nil {
+		t.Fatalf("No error should happen when loading the users, but got: %s", err)
+	}
 	if len(users) != 0 {
 		t.Fatalf("Expected 0 users, but got: %d", len(users))
 	}
 
-	n = projectPost.LurkersCount()
+	n = projectPost.LurkersCount(ctx)
 	if 0 != n {
 		t.Fatalf("LurkersCount returned %d instead of 0", n)
 	}
@@ -18,6 +18,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package db
 
 import (
+	"context"
 	"net/url"
 )
 
@@ -40,15 +41,24 @@ type ProjectInfo struct {
 }
 
 // NewProject returns the user with the specified id
-func NewProject(id uint64) (*Project, error) {
-	return NewProjectWhere(&Project{Counter: id})
+func NewProject(ctx context.Context, id uint64) (*Project, error) {
+	if id == 0 {
+		return nil, notFound("Requested Project does not exist")
+	}
+	return NewProjectWhere(ctx, &Project{Counter: id})
 }
 
 // NewProjectWhere returns the first user that matches the description
-func NewProjectWhere(description *Project) (project *Project, e error) {
+func NewProjectWhere(ctx context.Context, description *Project) (project *Project, e error) {
+	if emptyDescription(description) {
+		return nil, invalidArgument("the description of the Project is empty")
+	}
 	project = new(Project)
-	if e = db().Where(description).Scan(project); e != nil {
-		return
+	if e = storage(ctx).Find(ctx, description, project); e != nil {
+		return nil, e
+	}
+	if project.ID() == 0 {
+		return nil, notFound("Requested Project does not exist")
 	}
 	return
 }
@@ -56,53 +66,65 @@ func NewProjectWhere(description *Project) (project *Project, e error) {
 // Begin *Numeric* Methods
 
 // NumericFollowers returns a slice containing the IDs of users that followed this project
-func (prj *Project) NumericFollowers() (followers []uint64) {
-	db().Model(ProjectFollower{}).Where(ProjectFollower{To: prj.ID()}).Pluck(`"from"`, &followers)
+func (prj *Project) NumericFollowers(ctx context.Context) (followers []uint64) {
+	storage(ctx).Pluck(ctx, ProjectFollower{To: prj.ID()}, "from", &followers)
 	return
 }
 
 // NumericMembers returns a slice containing the IDs of users that are member of this project
-func (prj *Project) NumericMembers() (members []uint64) {
-	db().Model(ProjectMember{}).Where(ProjectMember{To: prj.ID()}).Pluck(`"from"`, &members)
+func (prj *Project) NumericMembers(ctx context.Context) (members []uint64) {
+	storage(ctx).Pluck(ctx, ProjectMember{To: prj.ID()}, "from", &members)
 	return
 }
 
 // Followers returns a []*User that follows the project
-func (prj *Project) Followers() []*User {
-	return Users(prj.NumericFollowers())
+func (prj *Project) Followers(ctx context.Context) ([]*User, error) {
+	return Users(ctx, prj.NumericFollowers(ctx))
 }
 
 // End *Numeric* Methods
 
 // Members returns a slice of Users members of the project
-func (prj *Project) Members() []*User {
-	return Users(prj.NumericMembers())
+func (prj *Project) Members(ctx context.Context) ([]*User, error) {
+	return Users(ctx, prj.NumericMembers(ctx))
 }
 
 // NumericOwner returns the Id of the owner of the project
-func (prj *Project) NumericOwner() (owner uint64) {
-	db().Model(ProjectOwner{}).Select(`"from"`).Where(ProjectOwner{To: prj.ID()}).Scan(&owner)
+func (prj *Project) NumericOwner(ctx context.Context) (owner uint64) {
+	var owners []uint64
+	if storage(ctx).Pluck(ctx, ProjectOwner{To: prj.ID()}, "from", &owners); len(owners) > 0 {
+		owner = owners[0]
+	}
 	return
 }
 
 // Owner returns the *User owner of the project
-func (prj *Project) Owner() (owner *User) {
-	owner, _ = NewUser(prj.NumericOwner())
+func (prj *Project) Owner(ctx context.Context) (owner *User) {
+	owner, _ = NewUser(ctx, prj.NumericOwner(ctx))
 	return
 }
 
 // ProjectInfo returns a ProjectInfo struct
-func (prj *Project) ProjectInfo() *ProjectInfo {
+func (prj *Project) ProjectInfo(ctx context.Context) (*ProjectInfo, error) {
 	website, _ := url.Parse(prj.Website.String)
 	photo, _ := url.Parse(prj.Photo.String)
 
+	members, err := prj.Members(ctx)
+	if err != nil {
+		return nil, err
+	}
+	followers, err := prj.Followers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	return &ProjectInfo{
 		ID:               prj.ID(),
-		Owner:            prj.Owner(),
-		Members:          prj.Members(),
-		NumericMembers:   prj.NumericMembers(),
-		Followers:        prj.Followers(),
-		NumericFollowers: prj.NumericFollowers(),
+		Owner:            prj.Owner(ctx),
+		Members:          members,
+		NumericMembers:   prj.NumericMembers(ctx),
+		Followers:        followers,
+		NumericFollowers: prj.NumericFollowers(ctx),
 		Description:      prj.Description,
 		Name:             prj.Name,
 		Photo:            photo,
@@ -110,19 +132,28 @@ func (prj *Project) ProjectInfo() *ProjectInfo {
 		Goal:             prj.Goal,
 		Visible:          prj.Visible,
 		Private:          prj.Private,
-		Open:             prj.Open}
+		Open:             prj.Open}, nil
 }
 
 // Implements Board interface
 
 //Info returns a *info struct
-func (prj *Project) Info() *Info {
+func (prj *Project) Info(ctx context.Context) *Info {
+	var owner *Info
+	if user := prj.Owner(ctx); user != nil {
+		owner = user.Info(ctx)
+	}
+	return prj.info(owner)
+}
+
+// info returns the *Info of the project, whose owner has the specified info
+func (prj *Project) info(owner *Info) *Info {
 	website, _ := url.Parse(prj.Website.String)
 	image, _ := url.Parse(prj.Photo.String)
 
 	return &Info{
 		ID:       prj.ID(),
-		Owner:    prj.Owner().Info(),
+		Owner:    owner,
 		Name:     prj.Name,
 		Username: "",
 		Website:  website,
@@ -132,20 +163,32 @@ func (prj *Project) Info() *Info {
 }
 
 //Postlist returns the specified posts on the project
-func (prj *Project) Postlist(options PostlistOptions) *[]ExistingPost {
-	var posts []ProjectPost
-	var projectPost ProjectPost
-	projectPosts := projectPost.TableName()
-	users := new(User).TableName()
+func (prj *Project) Postlist(ctx context.Context, options PostlistOptions) *[]ExistingPost {
+	posts, _ := storage(ctx).ProjectPostlist(ctx, prj.ID(), 0, options)
+	return projectPostlist(posts)
+}
 
-	query := db().Model(projectPost).Order("hpid DESC").
-		Joins("JOIN "+users+" ON "+users+".counter = "+projectPosts+".to"). //PostListOptions.Language support
-		Where(`"to" = ?`, prj.ID())
+// ReadablePostlist returns the posts on the project, selected by options, that user (nil for the anonymous users) can read:
+// the posts sent by the users that user blacklisted are excluded.
+// Returns a NotFound error if the project is not visible and user is neither its owner nor a member
+func (prj *Project) ReadablePostlist(ctx context.Context, user *User, options PostlistOptions) (*[]ExistingPost, error) {
+	if !prj.Visible && (user == nil || !user.CanSee(ctx, prj)) {
+		return nil, notFound("project %d does not exist", prj.ID())
+	}
 
-	options.Model = projectPost
-	query = postlistQueryBuilder(query, options)
-	query.Scan(&posts)
+	var id uint64
+	if user != nil {
+		id = user.ID()
+	}
+	posts, err := storage(ctx).ProjectPostlist(ctx, prj.ID(), id, options)
+	if err != nil {
+		return nil, err
+	}
+	return projectPostlist(posts), nil
+}
 
+// projectPostlist converts posts into a slice of ExistingPost
+func projectPostlist(posts []ProjectPost) *[]ExistingPost {
 	var retPosts []ExistingPost
 	for _, p := range posts {
 		projectPost := p
@@ -163,6 +206,9 @@ func (prj *Project) ID() uint64 {
 }
 
 // Language returns the project language
-func (prj *Project) Language() string {
-	return prj.Owner().Language()
+func (prj *Project) Language(ctx context.Context) string {
+	if owner := prj.Owner(ctx); owner != nil {
+		return owner.Language()
+	}
+	return ""
 }
@@ -18,25 +18,31 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package db
 
 import (
-	"fmt"
+	"context"
 	"time"
 
 	"github.com/nerdzeu/nerdz-core/utils"
 )
 
 // NewProjectPost initializes a ProjectPost struct
-func NewProjectPost(hpid uint64) (*ProjectPost, error) {
-	return NewProjectPostWhere(&ProjectPost{Post{Hpid: hpid}})
+func NewProjectPost(ctx context.Context, hpid uint64) (*ProjectPost, error) {
+	if hpid == 0 {
+		return nil, notFound("Requested ProjectPost does not exist")
+	}
+	return NewProjectPostWhere(ctx, &ProjectPost{Post{Hpid: hpid}})
 }
 
 // NewProjectPostWhere returns the *ProjectPost fetching the first one that matches the description
-func NewProjectPostWhere(description *ProjectPost) (post *ProjectPost, e error) {
+func NewProjectPostWhere(ctx context.Context, description *ProjectPost) (post *ProjectPost, e error) {
+	if emptyDescription(description) {
+		return nil, invalidArgument("the description of the ProjectPost is empty")
+	}
 	post = new(ProjectPost)
-	if e = db().Model(ProjectPost{}).Where(description).Scan(post); e != nil {
+	if e = storage(ctx).Find(ctx, description, post); e != nil {
 		return nil, e
 	}
 	if post.ID() == 0 {
-		return nil, fmt.Errorf("Requested ProjectPost does not exist")
+		return nil, notFound("Requested ProjectPost does not exist")
 	}
 	return
 }
@@ -76,8 +82,8 @@ func (post *ProjectPost) NumericSender() uint64 {
 }
 
 // Sender returns the sender *User
-func (post *ProjectPost) Sender() *User {
-	user, _ := NewUser(post.NumericSender())
+func (post *ProjectPost) Sender(ctx context.Context) *User {
+	user, _ := NewUser(ctx, post.NumericSender())
 	return user
 }
 
@@ -87,8 +93,8 @@ func (post *ProjectPost) NumericReference() uint64 {
 }
 
 // Reference returns the recipient *Project
-func (post *ProjectPost) Reference() Reference {
-	project, _ := NewProject(post.NumericReference())
+func (post *ProjectPost) Reference(ctx context.Context) Reference {
+	project, _ := NewProject(ctx, post.NumericReference())
 	return project
 }
 
@@ -108,17 +114,17 @@ func (post *ProjectPost) IsClosed() bool {
 }
 
 // NumericOwners returns a slice of ids of the owner of the posts (the ones that can perform actions)
-func (post *ProjectPost) NumericOwners() (ret []uint64) {
+func (post *ProjectPost) NumericOwners(ctx context.Context) (ret []uint64) {
 	ret = append(ret, post.From)
-	project, _ := NewProject(post.To)
-	ret = append(ret, project.NumericOwner())
-	ret = append(ret, project.NumericMembers()...)
+	project, _ := NewProject(ctx, post.To)
+	ret = append(ret, project.NumericOwner(ctx))
+	ret = append(ret, project.NumericMembers(ctx)...)
 	return
 }
 
 // Owners returns a slice of *User representing the users who own the post
-func (post *ProjectPost) Owners() (ret []*User) {
-	return Users(post.NumericOwners())
+func (post *ProjectPost) Owners(ctx context.Context) ([]*User, error) {
+	return Users(ctx, post.NumericOwners(ctx))
 }
 
 // SetLanguage set the language of the post
@@ -134,27 +140,25 @@ func (post *ProjectPost) Language() string {
 }
 
 // Revisions returns all the revisions of the message
-func (post *ProjectPost) Revisions() (modifications []string) {
-	db().Model(ProjectPostRevision{}).Where(&ProjectPostRevision{Hpid: post.ID()}).Pluck("message", &modifications)
+func (post *ProjectPost) Revisions(ctx context.Context) (modifications []string) {
+	storage(ctx).Pluck(ctx, &ProjectPostRevision{Hpid: post.ID()}, "message", &modifications)
 	return
 }
 
 // RevisionsNumber returns the number of the revisions
-func (post *ProjectPost) RevisionsNumber() (count uint8) {
-	db().Model(ProjectPostRevision{}).Where(&ProjectPostRevision{Hpid: post.ID()}).Count(&count)
-	return
+func (post *ProjectPost) RevisionsNumber(ctx context.Context) (count uint8) {
+	return countWhere(ctx, &ProjectPostRevision{Hpid: post.ID()})
 }
 
 // Votes returns the post's votes value
-func (post *ProjectPost) VotesCount() (sum int) {
-	db().Model(ProjectPostVote{}).Select("COALESCE(sum(vote), 0)").Where(&ProjectPostVote{Hpid: post.ID()}).Scan(&sum)
-	return
+func (post *ProjectPost) VotesCount(ctx context.Context) (sum int) {
+	return votesSum(ctx, &ProjectPostVote{Hpid: post.ID()})
 }
 
 // Votes returns a pointer to a slice of Vote
-func (post *ProjectPost) Votes() *[]Vote {
+func (post *ProjectPost) Votes(ctx context.Context) *[]Vote {
 	ret := []ProjectPostVote{}
-	db().Model(ProjectPostVote{}).Where(&ProjectPostVote{Hpid: post.ID()}).Scan(&ret)
+	storage(ctx).Find(ctx, &ProjectPostVote{Hpid: post.ID()}, &ret)
 	var retVotes []Vote
 	for _, v := range ret {
 		vote := v
@@ -165,9 +169,9 @@ func (post *ProjectPost) Votes() *[]Vote {
 }
 
 // Bookmarks returns a pointer to a slice of Bookmark
-func (post *ProjectPost) Bookmarks() *[]Bookmark {
+func (post *ProjectPost) Bookmarks(ctx context.Context) *[]Bookmark {
 	ret := []ProjectPostBookmark{}
-	db().Model(ProjectPostBookmark{}).Where(&ProjectPostBookmark{Hpid: post.ID()}).Scan(&ret)
+	storage(ctx).Find(ctx, &ProjectPostBookmark{Hpid: post.ID()}, &ret)
 	var retBookmarks []Bookmark
 	for _, b := range ret {
 		bookmark := b
@@ -178,9 +182,9 @@ func (post *ProjectPost) Bookmarks() *[]Bookmark {
 }
 
 // Lurks returns a pointer to a slice of Lurk
-func (post *ProjectPost) Lurks() *[]Lurk {
+func (post *ProjectPost) Lurks(ctx context.Context) *[]Lurk {
 	ret := []ProjectPostLurk{}
-	db().Model(ProjectPostLurk{}).Where(&ProjectPostLurk{Hpid: post.ID()}).Scan(&ret)
+	storage(ctx).Find(ctx, &ProjectPostLurk{Hpid: post.ID()}, &ret)
 	var retLurkers []Lurk
 	for _, l := range ret {
 		lurker := l
@@ -190,10 +194,16 @@ func (post *ProjectPost) Lurks() *[]Lurk {
 	return &retLurkers
 }
 
+// Mentions returns the mentions in the post and in its comments, selected by options, from the newest to the oldest
+func (post *ProjectPost) Mentions(ctx context.Context, options MentionsOptions) (*[]Mention, error) {
+	mentions, e := storage(ctx).Mentions(ctx, &Mention{GHpid: post.ID()}, options)
+	return &mentions, e
+}
+
 // Locks returns a pointer to a slice of Lock
-func (post *ProjectPost) Locks() *[]Lock {
+func (post *ProjectPost) Locks(ctx context.Context) *[]Lock {
 	ret := []ProjectPostLock{}
-	db().Model(ProjectPostLock{}).Where(&ProjectPostLock{Hpid: post.ID()}).Scan(&ret)
+	storage(ctx).Find(ctx, &ProjectPostLock{Hpid: post.ID()}, &ret)
 	var retLockers []Lock
 	for _, l := range ret {
 		locker := l
@@ -205,12 +215,8 @@ func (post *ProjectPost) Locks() *[]Lock {
 
 // Comments returns the full comments list, or the selected range of comments
 // Comments(options)  returns the comment list, using selected options
-func (post *ProjectPost) Comments(options CommentlistOptions) *[]ExistingComment {
-	var comments []ProjectPostComment
-
-	query := db().Where(&ProjectPostComment{Hpid: post.ID()})
-	query = commentlistQueryBuilder(query, options)
-	query.Scan(&comments)
+func (post *ProjectPost) Comments(ctx context.Context, options CommentlistOptions) *[]ExistingComment {
+	comments, _ := storage(ctx).ProjectPostComments(ctx, post.ID(), options)
 
 	comments = utils.ReverseSlice(comments).([]ProjectPostComment)
 
@@ -223,9 +229,8 @@ func (post *ProjectPost) Comments(options CommentlistOptions) *[]ExistingComment
 }
 
 // CommentsCount returns the number of comment's post
-func (post *ProjectPost) CommentsCount() (count uint8) {
-	db().Model(ProjectPostComment{}).Where(&ProjectPostComment{Hpid: post.ID()}).Count(&count)
-	return
+func (post *ProjectPost) CommentsCount(ctx context.Context) (count uint8) {
+	return countWhere(ctx, &ProjectPostComment{Hpid: post.ID()})
 }
 
 // NumericType returns the numeric type of the post
@@ -239,35 +244,33 @@ func (*ProjectPost) Type() string {
 }
 
 // NumericBookmarkers returns a slice of uint64 representing the ids of the users that bookmarked the post
-func (post *ProjectPost) NumericBookmarkers() (bookmarkers []uint64) {
-	db().Model(ProjectPostBookmark{}).Where(&ProjectPostBookmark{Hpid: post.ID()}).Pluck(`"from"`, &bookmarkers)
+func (post *ProjectPost) NumericBookmarkers(ctx context.Context) (bookmarkers []uint64) {
+	storage(ctx).Pluck(ctx, &ProjectPostBookmark{Hpid: post.ID()}, "from", &bookmarkers)
 	return
 }
 
 // Bookmarks returns a slice of users that bookmarked the post
-func (post *ProjectPost) Bookmarkers() []*User {
-	return Users(post.NumericBookmarkers())
+func (post *ProjectPost) Bookmarkers(ctx context.Context) ([]*User, error) {
+	return Users(ctx, post.NumericBookmarkers(ctx))
 }
 
 // BookmarksCount returns the number of users that bookmarked the post
-func (post *ProjectPost) BookmarksCount() (count uint8) {
-	db().Model(ProjectPostBookmark{}).Where(&ProjectPostBookmark{Hpid: post.ID()}).Count(&count)
-	return
+func (post *ProjectPost) BookmarksCount(ctx context.Context) (count uint8) {
+	return countWhere(ctx, &ProjectPostBookmark{Hpid: post.ID()})
 }
 
 // NumericLurkers returns a slice of uint64 representing the ids of the users that lurked the post
-func (post *ProjectPost) NumericLurkers() (lurkers []uint64) {
-	db().Model(ProjectPostLurk{}).Where(&ProjectPostLurk{Hpid: post.ID()}).Pluck(`"from"`, &lurkers)
+func (post *ProjectPost) NumericLurkers(ctx context.Context) (lurkers []uint64) {
+	storage(ctx).Pluck(ctx, &ProjectPostLurk{Hpid: post.ID()}, "from", &lurkers)
 	return
 }
 
 // Lurkers returns a slice of users that are lurking the post
-func (post *ProjectPost) Lurkers() []*User {
-	return Users(post.NumericLurkers())
+func (post *ProjectPost) Lurkers(ctx context.Context) ([]*User, error) {
+	return Users(ctx, post.NumericLurkers(ctx))
 }
 
 // LurkersCount returns the number of users that are lurking the post
-func (post *ProjectPost) LurkersCount() (count uint8) {
-	db().Model(ProjectPostLurk{}).Where(&ProjectPostLurk{Hpid: post.ID()}).Count(&count)
-	return
+func (post *ProjectPost) LurkersCount(ctx context.Context) (count uint8) {
+	return countWhere(ctx, &ProjectPostLurk{Hpid: post.ID()})
 }
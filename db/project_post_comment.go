@@ -18,23 +18,29 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package db
 
 import (
-	"fmt"
+	"context"
 	"time"
 )
 
 // NewProjectPostComment initializes a ProjectPostComment struct
-func NewProjectPostComment(hcid uint64) (comment *ProjectPostComment, e error) {
-	return NewProjectPostCommentWhere(&ProjectPostComment{Hcid: hcid})
+func NewProjectPostComment(ctx context.Context, hcid uint64) (comment *ProjectPostComment, e error) {
+	if hcid == 0 {
+		return nil, notFound("Requested ProjectPostComment does not exist")
+	}
+	return NewProjectPostCommentWhere(ctx, &ProjectPostComment{Hcid: hcid})
 }
 
 // NewProjectPostCommentWhere returns the *ProjectPostComment fetching the first one that matches the description
-func NewProjectPostCommentWhere(description *ProjectPostComment) (comment *ProjectPostComment, e error) {
+func NewProjectPostCommentWhere(ctx context.Context, description *ProjectPostComment) (comment *ProjectPostComment, e error) {
+	if emptyDescription(description) {
+		return nil, invalidArgument("the description of the ProjectPostComment is empty")
+	}
 	comment = new(ProjectPostComment)
-	if e = db().Model(ProjectPostComment{}).Where(description).Scan(comment); e != nil {
+	if e = storage(ctx).Find(ctx, description, comment); e != nil {
 		return nil, e
 	}
 	if comment.Hcid == 0 {
-		return nil, fmt.Errorf("Requested ProjectPostComment does not exist")
+		return nil, notFound("Requested ProjectPostComment does not exist")
 	}
 	return
 }
@@ -47,8 +53,8 @@ func (comment *ProjectPostComment) NumericReference() uint64 {
 }
 
 // Reference returns the recipient *ProjectPost
-func (comment *ProjectPostComment) Reference() Reference {
-	post, _ := NewProjectPost(comment.NumericReference())
+func (comment *ProjectPostComment) Reference(ctx context.Context) Reference {
+	post, _ := NewProjectPost(ctx, comment.NumericReference())
 	return post
 }
 
@@ -58,21 +64,20 @@ func (comment *ProjectPostComment) NumericSender() uint64 {
 }
 
 // Sender returns the sender *User
-func (comment *ProjectPostComment) Sender() *User {
-	user, _ := NewUser(comment.NumericSender())
+func (comment *ProjectPostComment) Sender(ctx context.Context) *User {
+	user, _ := NewUser(ctx, comment.NumericSender())
 	return user
 }
 
 // Votes returns the post's votes value
-func (comment *ProjectPostComment) VotesCount() (sum int) {
-	db().Model(ProjectPostCommentVote{}).Select("COALESCE(sum(vote), 0)").Where(&ProjectPostCommentVote{Hcid: comment.Hcid}).Scan(&sum)
-	return
+func (comment *ProjectPostComment) VotesCount(ctx context.Context) (sum int) {
+	return votesSum(ctx, &ProjectPostCommentVote{Hcid: comment.Hcid})
 }
 
 // Votes returns a pointer to a slice of Vote
-func (comment *ProjectPostComment) Votes() *[]Vote {
+func (comment *ProjectPostComment) Votes(ctx context.Context) *[]Vote {
 	ret := []ProjectPostCommentVote{}
-	db().Model(ProjectPostCommentVote{}).Where(&ProjectPostCommentVote{Hcid: comment.Hcid}).Scan(&ret)
+	storage(ctx).Find(ctx, &ProjectPostCommentVote{Hcid: comment.Hcid}, &ret)
 	var retVotes []Vote
 	for _, v := range ret {
 		vote := v
@@ -95,10 +100,10 @@ func (comment *ProjectPostComment) ID() uint64 {
 }
 
 // Post returns the ExistingPost sturct to which the projectComment is related
-func (comment *ProjectPostComment) Post() (ExistingPost, error) {
+func (comment *ProjectPostComment) Post(ctx context.Context) (ExistingPost, error) {
 	var post *ProjectPost
 	var err error
-	if post, err = NewProjectPost(comment.Hpid); err != nil {
+	if post, err = NewProjectPost(ctx, comment.Hpid); err != nil {
 		return nil, err
 	}
 	return ExistingPost(post), nil
@@ -144,23 +149,22 @@ func (comment *ProjectPostComment) IsEditable() bool {
 }
 
 // NumericOwners returns a slice of ids of the owner of the comment (the ones that can perform actions)
-func (comment *ProjectPostComment) NumericOwners() []uint64 {
+func (comment *ProjectPostComment) NumericOwners(ctx context.Context) []uint64 {
 	return []uint64{comment.From, comment.To}
 }
 
 // Owners returns a slice of *User representing the users who own the comment
-func (comment *ProjectPostComment) Owners() []*User {
-	return Users(comment.NumericOwners())
+func (comment *ProjectPostComment) Owners(ctx context.Context) ([]*User, error) {
+	return Users(ctx, comment.NumericOwners(ctx))
 }
 
 // Revisions returns all the revisions of the message
-func (comment *ProjectPostComment) Revisions() (modifications []string) {
-	db().Model(ProjectPostCommentRevision{}).Where(&ProjectPostCommentRevision{Hcid: comment.Hcid}).Pluck("message", &modifications)
+func (comment *ProjectPostComment) Revisions(ctx context.Context) (modifications []string) {
+	storage(ctx).Pluck(ctx, &ProjectPostCommentRevision{Hcid: comment.Hcid}, "message", &modifications)
 	return
 }
 
 // RevisionsNumber returns the number of the revisions
-func (comment *ProjectPostComment) RevisionsNumber() (count uint8) {
-	db().Model(ProjectPostCommentRevision{}).Where(&ProjectPostCommentRevision{Hcid: comment.Hcid}).Count(&count)
-	return
+func (comment *ProjectPostComment) RevisionsNumber(ctx context.Context) (count uint8) {
+	return countWhere(ctx, &ProjectPostCommentRevision{Hcid: comment.Hcid})
 }
@@ -17,6 +17,8 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 
 package db
 
+import "context"
+
 // Implementing Vote interface
 
 // Value returns the vote's value
@@ -25,8 +27,8 @@ func (vote *ProjectPostCommentVote) Value() int8 {
 }
 
 // Sender returns the User that casted the vote
-func (vote *ProjectPostCommentVote) Sender() (user *User) {
-	user, _ = NewUser(vote.From)
+func (vote *ProjectPostCommentVote) Sender(ctx context.Context) (user *User) {
+	user, _ = NewUser(ctx, vote.From)
 	return
 }
 
@@ -36,8 +38,8 @@ func (vote *ProjectPostCommentVote) NumericSender() uint64 {
 }
 
 // Reference returns the reference of the vote
-func (vote *ProjectPostCommentVote) Reference() Reference {
-	post, _ := NewProjectPostComment(vote.Hcid)
+func (vote *ProjectPostCommentVote) Reference(ctx context.Context) Reference {
+	post, _ := NewProjectPostComment(ctx, vote.Hcid)
 	return post
 }
 
@@ -17,6 +17,8 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 
 package db
 
+import "context"
+
 // ProjectPostVote: implementing Vote interface
 
 // Value returns the vote's value
@@ -25,8 +27,8 @@ func (vote *ProjectPostVote) Value() int8 {
 }
 
 // Sender returns the User that casted the vote
-func (vote *ProjectPostVote) Sender() (user *User) {
-	user, _ = NewUser(vote.From)
+func (vote *ProjectPostVote) Sender(ctx context.Context) (user *User) {
+	user, _ = NewUser(ctx, vote.From)
 	return
 }
 
@@ -36,8 +38,8 @@ func (vote *ProjectPostVote) NumericSender() uint64 {
 }
 
 // Reference returns the reference of the vote
-func (vote *ProjectPostVote) Reference() Reference {
-	post, _ := NewProjectPost(vote.Hpid)
+func (vote *ProjectPostVote) Reference(ctx context.Context) Reference {
+	post, _ := NewProjectPost(ctx, vote.Hpid)
 	return post
 }
 
@@ -49,8 +51,8 @@ func (vote *ProjectPostVote) NumericReference() uint64 {
 // ProjectPostBookmark: implementing Bookmark interface
 
 // Sender returns the User that casted the bookmark
-func (bookmark *ProjectPostBookmark) Sender() (user *User) {
-	user, _ = NewUser(bookmark.From)
+func (bookmark *ProjectPostBookmark) Sender(ctx context.Context) (user *User) {
+	user, _ = NewUser(ctx, bookmark.From)
 	return
 }
 
@@ -60,8 +62,8 @@ func (bookmark *ProjectPostBookmark) NumericSender() uint64 {
 }
 
 // Reference returns the reference of the bookmark
-func (bookmark *ProjectPostBookmark) Reference() Reference {
-	post, _ := NewProjectPost(bookmark.Hpid)
+func (bookmark *ProjectPostBookmark) Reference(ctx context.Context) Reference {
+	post, _ := NewProjectPost(ctx, bookmark.Hpid)
 	return post
 }
 
@@ -73,8 +75,8 @@ func (bookmark *ProjectPostBookmark) NumericReference() uint64 {
 // ProjectPostLurk: implementing Lurk interface
 
 // Sender returns the User that casted the lurk
-func (lurk *ProjectPostLurk) Sender() (user *User) {
-	user, _ = NewUser(lurk.From)
+func (lurk *ProjectPostLurk) Sender(ctx context.Context) (user *User) {
+	user, _ = NewUser(ctx, lurk.From)
 	return
 }
 
@@ -84,8 +86,8 @@ func (lurk *ProjectPostLurk) NumericSender() uint64 {
 }
 
 // Reference returns the reference of the lurk
-func (lurk *ProjectPostLurk) Reference() Reference {
-	post, _ := NewProjectPost(lurk.Hpid)
+func (lurk *ProjectPostLurk) Reference(ctx context.Context) Reference {
+	post, _ := NewProjectPost(ctx, lurk.Hpid)
 	return post
 }
 
@@ -97,8 +99,8 @@ func (lurk *ProjectPostLurk) NumericReference() uint64 {
 // ProjectPostLock: implementing Lock interface
 
 // Sender returns the User that casted the lock
-func (lock *ProjectPostLock) Sender() (user *User) {
-	user, _ = NewUser(lock.User)
+func (lock *ProjectPostLock) Sender(ctx context.Context) (user *User) {
+	user, _ = NewUser(ctx, lock.User)
 	return
 }
 
@@ -108,8 +110,8 @@ func (lock *ProjectPostLock) NumericSender() uint64 {
 }
 
 // Reference returns the reference of the lurk
-func (lock *ProjectPostLock) Reference() Reference {
-	post, _ := NewProjectPost(lock.Hpid)
+func (lock *ProjectPostLock) Reference(ctx context.Context) Reference {
+	post, _ := NewProjectPost(ctx, lock.Hpid)
 	return post
 }
 
@@ -121,8 +123,8 @@ func (lock *ProjectPostLock) NumericReference() uint64 {
 // ProjectPostUserLock: implementing Lock interface
 
 // Sender returns the User that casted the lock
-func (lock *ProjectPostUserLock) Sender() (user *User) {
-	user, _ = NewUser(lock.From)
+func (lock *ProjectPostUserLock) Sender(ctx context.Context) (user *User) {
+	user, _ = NewUser(ctx, lock.From)
 	return
 }
 
@@ -132,8 +134,8 @@ func (lock *ProjectPostUserLock) NumericSender() uint64 {
 }
 
 // Reference returns the reference of the lurk
-func (lock *ProjectPostUserLock) Reference() Reference {
-	post, _ := NewProjectPost(lock.Hpid)
+func (lock *ProjectPostUserLock) Reference(ctx context.Context) Reference {
+	post, _ := NewProjectPost(ctx, lock.Hpid)
 	return post
 }
 
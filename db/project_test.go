@@ -1,3 +1,6 @@
+//go:build postgres
+// +build postgres
+
 /*
 Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
 
@@ -27,21 +30,17 @@ import (
 var prj *db.Project
 
 func init() {
-	err := db.Init()
-	if err != nil {
-		panic(err)
-	}
-
-	prj, err = db.NewProject(1)
+	var err error
+	prj, err = db.NewProject(ctx, 1)
 	if err != nil {
 		panic(fmt.Sprintf("No error should happen when create existing user, but got: %+v", err))
 	}
 }
 
 func TestProjectInfo(t *testing.T) {
-	info := prj.ProjectInfo()
-	if info == nil {
-		t.Error("null info")
+	info, err := prj.ProjectInfo(ctx)
+	if err != nil {
+		t.Fatalf("No error should happen when loading the info of a project, but got: %s", err)
 	}
 
 	t.Logf("Struct: %+v\nMembers:", *info)
@@ -57,7 +56,7 @@ func TestProjectInfo(t *testing.T) {
 }
 
 func TestProjectPostlist(t *testing.T) {
-	postList := *prj.Postlist(db.PostlistOptions{})
+	postList := *prj.Postlist(ctx, db.PostlistOptions{})
 	if len(postList) != 4 {
 		t.Fatalf("Expected 4  posts, but got: %+v\n", len(postList))
 	}
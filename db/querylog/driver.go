@@ -0,0 +1,149 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package querylog
+
+import (
+	"context"
+	"database/sql/driver"
+	"time"
+)
+
+// connector opens the connections with a driver and wraps them, to log their queries
+type connector struct {
+	driver driver.Driver
+	name   string
+	logger *Logger
+}
+
+// Connector returns a driver.Connector that opens the connections with d, using the data source name,
+// and logs their queries with l
+func (l *Logger) Connector(d driver.Driver, name string) driver.Connector {
+	return &connector{driver: d, name: name, logger: l}
+}
+
+func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	inner, err := c.driver.Open(c.name)
+	if err != nil {
+		return nil, err
+	}
+	return &conn{Conn: inner, logger: c.logger}, nil
+}
+
+func (c *connector) Driver() driver.Driver {
+	return c.driver
+}
+
+// conn logs the queries executed by the wrapped connection
+type conn struct {
+	driver.Conn
+	logger *Logger
+}
+
+// log logs query, executed with args since start, unless the driver skipped it
+func (l *Logger) log(query string, args []driver.NamedValue, start time.Time, err error) {
+	if err != driver.ErrSkip {
+		l.Log(query, args, time.Since(start), err)
+	}
+}
+
+func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	var inner driver.Stmt
+	var err error
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		inner, err = preparer.PrepareContext(ctx, query)
+	} else if err = ctx.Err(); err == nil {
+		inner, err = c.Conn.Prepare(query)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &stmt{Stmt: inner, query: query, logger: c.logger}, nil
+}
+
+func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.Conn.Begin()
+}
+
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	rows, err := queryer.QueryContext(ctx, query, args)
+	c.logger.log(query, args, start, err)
+	return rows, err
+}
+
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	result, err := execer.ExecContext(ctx, query, args)
+	c.logger.log(query, args, start, err)
+	return result, err
+}
+
+// stmt logs the executions of the wrapped prepared statement
+type stmt struct {
+	driver.Stmt
+	query  string
+	logger *Logger
+}
+
+// values returns the values of args
+func values(args []driver.NamedValue) []driver.Value {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+	return values
+}
+
+func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (rows driver.Rows, err error) {
+	start := time.Now()
+	if queryer, ok := s.Stmt.(driver.StmtQueryContext); ok {
+		rows, err = queryer.QueryContext(ctx, args)
+	} else if err = ctx.Err(); err == nil {
+		rows, err = s.Stmt.Query(values(args))
+	}
+	s.logger.log(s.query, args, start, err)
+	return rows, err
+}
+
+func (s *stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (result driver.Result, err error) {
+	start := time.Now()
+	if execer, ok := s.Stmt.(driver.StmtExecContext); ok {
+		result, err = execer.ExecContext(ctx, args)
+	} else if err = ctx.Err(); err == nil {
+		result, err = s.Stmt.Exec(values(args))
+	}
+	s.logger.log(s.query, args, start, err)
+	return result, err
+}
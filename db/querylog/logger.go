@@ -0,0 +1,147 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package querylog logs the queries sent to the NERDZ database.
+//
+// Every entry is a JSON object written on its own line, with the query, its arguments, its duration
+// and its error. Redaction is deny by default: every argument of a statement that references
+// a sensitive column, table or function (passwords, OAuth2 secrets, codes and tokens, the OAuth2 tables
+// and login) is redacted before anything is written, wherever the reference appears in the statement.
+package querylog
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Level is the minimum severity of the queries logged
+type Level int
+
+const (
+	// Debug logs every query
+	Debug Level = iota
+	// Warn logs the slow and the failed queries
+	Warn
+	// Error logs the failed queries
+	Error
+	// Off disables the logging
+	Off
+)
+
+var levels = map[string]Level{"debug": Debug, "warn": Warn, "error": Error, "off": Off}
+
+// ParseLevel returns the level named name: debug, warn, error or off
+func ParseLevel(name string) (Level, error) {
+	level, ok := levels[strings.ToLower(name)]
+	if !ok {
+		return Off, fmt.Errorf("invalid log level %q", name)
+	}
+	return level, nil
+}
+
+// String returns the name of the level
+func (level Level) String() string {
+	for name, value := range levels {
+		if value == level {
+			return name
+		}
+	}
+	return fmt.Sprintf("Level(%d)", int(level))
+}
+
+// Config describes a Logger
+type Config struct {
+	Level Level
+	// Output is where the entries are written
+	Output io.Writer
+	// SlowThreshold is the duration after which a query is slow. If 0, no query is slow
+	SlowThreshold time.Duration
+	// Redact lists the columns, tables and functions that make a statement sensitive,
+	// in addition to the default ones
+	Redact []string
+}
+
+// Logger logs the queries. It's safe for concurrent use
+type Logger struct {
+	config Config
+	// identifiers are the sensitive columns, tables and functions, lower cased
+	identifiers map[string]bool
+	mu          sync.Mutex
+}
+
+// entry is a line of the log
+type entry struct {
+	Time     time.Time     `json:"time"`
+	Level    string        `json:"level"`
+	Message  string        `json:"msg"`
+	Query    string        `json:"query"`
+	Args     []interface{} `json:"args,omitempty"`
+	Duration float64       `json:"duration_ms"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// New returns a Logger described by config
+func New(config Config) *Logger {
+	l := &Logger{config: config, identifiers: make(map[string]bool)}
+	for _, names := range [][]string{defaultSensitive, config.Redact} {
+		for _, name := range names {
+			l.identifiers[strings.ToLower(strings.Trim(strings.TrimSpace(name), `"`))] = true
+		}
+	}
+	return l
+}
+
+// Log logs query, executed with args in duration, if its severity is at least the level of the logger.
+// err is the error returned by the query, if any
+func (l *Logger) Log(query string, args []driver.NamedValue, duration time.Duration, err error) {
+	level, message := Debug, "query"
+	switch {
+	case err != nil:
+		level, message = Error, "query failed"
+	case l.config.SlowThreshold > 0 && duration >= l.config.SlowThreshold:
+		level, message = Warn, "slow query"
+	}
+	if level < l.config.Level || l.config.Level == Off || l.config.Output == nil {
+		return
+	}
+
+	e := entry{
+		Time:     time.Now().UTC(),
+		Level:    level.String(),
+		Message:  message,
+		Query:    query,
+		Args:     l.redact(query, args),
+		Duration: float64(duration) / float64(time.Millisecond),
+	}
+	if err != nil {
+		e.Error = err.Error()
+	}
+
+	line, jsonErr := json.Marshal(e)
+	if jsonErr != nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.config.Output.Write(append(line, '\n'))
+}
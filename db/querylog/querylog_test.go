@@ -0,0 +1,173 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package querylog_test
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nerdzeu/nerdz-core/db/querylog"
+)
+
+// entry is a line of the log
+type entry struct {
+	Level   string        `json:"level"`
+	Message string        `json:"msg"`
+	Query   string        `json:"query"`
+	Args    []interface{} `json:"args"`
+	Error   string        `json:"error"`
+}
+
+// entries returns the lines written in output
+func entries(t *testing.T, output *bytes.Buffer) []entry {
+	var ret []entry
+	for _, line := range strings.Split(strings.TrimSpace(output.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var e entry
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatalf("Every line should be a JSON object, but got %q: %s", line, err)
+		}
+		ret = append(ret, e)
+	}
+	return ret
+}
+
+// named returns values as arguments of a query
+func named(values ...driver.Value) []driver.NamedValue {
+	args := make([]driver.NamedValue, len(values))
+	for i, value := range values {
+		args[i] = driver.NamedValue{Ordinal: i + 1, Value: value}
+	}
+	return args
+}
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		query    string
+		args     []driver.NamedValue
+		redacted bool
+	}{
+		{`SELECT login($1, $2) AS logged, counter FROM users WHERE LOWER(username) = $3`, named("me", "pwd", "me"), true},
+		{`INSERT INTO oauth2_clients("name","secret","redirect_uri") VALUES ($1,$2,$3) RETURNING "id"`, named("web", "hash", "http://"), true},
+		{`UPDATE users SET "email" = $1, "password" = $2 WHERE "counter" = $3`, named("me@nerdz.eu", "pwd", int64(1)), true},
+		{`SELECT * FROM oauth2_access WHERE $1 = access_token`, named("a"), true},
+		{`SELECT * FROM oauth2_access WHERE (client_id, access_token) = ($1, $2)`, named(int64(1), "a"), true},
+		{`SELECT * FROM users WHERE "counter" = $1 AND "PIN" = $2`, named(int64(1), "1234"), true},
+		{`SELECT crypt($1, $2) FROM posts WHERE "hpid" = $3`, named("salt", "pwd", int64(1)), true},
+		{`SELECT * FROM posts WHERE "hpid" = $1 AND "message" LIKE $2`, named(int64(1), "%nerdz%"), false},
+		{`SELECT * FROM users WHERE "username" = $1 -- no secrets here`, named("me"), false},
+	}
+
+	var output bytes.Buffer
+	logger := querylog.New(querylog.Config{Level: querylog.Debug, Output: &output, Redact: []string{"pin", `"crypt"`}})
+	for _, test := range tests {
+		logger.Log(test.query, test.args, time.Millisecond, nil)
+	}
+
+	logged := entries(t, &output)
+	if len(logged) != len(tests) {
+		t.Fatalf("Every query should be logged, but got %d entries", len(logged))
+	}
+	for i, e := range logged {
+		if len(e.Args) != len(tests[i].args) {
+			t.Fatalf("%s: every argument should be logged, but got %v", tests[i].query, e.Args)
+		}
+		for j, arg := range e.Args {
+			if (arg == querylog.Redacted) != tests[i].redacted {
+				t.Errorf("%s: argument %d should be redacted: %t, but got %v", tests[i].query, j+1, tests[i].redacted, arg)
+			}
+		}
+	}
+}
+
+func TestLevels(t *testing.T) {
+	var output bytes.Buffer
+	logger := querylog.New(querylog.Config{Level: querylog.Warn, Output: &output, SlowThreshold: time.Second})
+
+	logger.Log("SELECT 1", nil, time.Millisecond, nil)
+	logger.Log("SELECT 2", nil, 2*time.Second, nil)
+	logger.Log("SELECT 3", nil, time.Millisecond, errors.New("failure"))
+
+	logged := entries(t, &output)
+	if len(logged) != 2 {
+		t.Fatalf("Only the slow and the failed queries should be logged, but got %d entries", len(logged))
+	}
+	if logged[0].Query != "SELECT 2" || logged[0].Level != "warn" || logged[0].Message != "slow query" {
+		t.Errorf("Expected the slow query, but got %+v", logged[0])
+	}
+	if logged[1].Query != "SELECT 3" || logged[1].Level != "error" || logged[1].Error != "failure" {
+		t.Errorf("Expected the failed query, but got %+v", logged[1])
+	}
+
+	if _, err := querylog.ParseLevel("verbose"); err == nil {
+		t.Errorf("Parsing an invalid level should fail")
+	}
+	if level, err := querylog.ParseLevel("ERROR"); err != nil || level != querylog.Error {
+		t.Errorf("Expected the error level, but got %s (%v)", level, err)
+	}
+}
+
+// fakeDriver accepts every statement
+type fakeDriver struct{}
+
+type fakeConn struct{}
+
+type fakeStmt struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error)         { return fakeConn{}, nil }
+func (fakeConn) Prepare(string) (driver.Stmt, error)        { return fakeStmt{}, nil }
+func (fakeConn) Close() error                               { return nil }
+func (fakeConn) Begin() (driver.Tx, error)                  { return nil, errors.New("not supported") }
+func (fakeStmt) Close() error                               { return nil }
+func (fakeStmt) NumInput() int                              { return -1 }
+func (fakeStmt) Exec([]driver.Value) (driver.Result, error) { return driver.RowsAffected(1), nil }
+func (fakeStmt) Query([]driver.Value) (driver.Rows, error)  { return nil, errors.New("not supported") }
+
+func TestConnector(t *testing.T) {
+	var output bytes.Buffer
+	logger := querylog.New(querylog.Config{Level: querylog.Debug, Output: &output})
+
+	database := sql.OpenDB(logger.Connector(fakeDriver{}, ""))
+	defer database.Close()
+
+	if _, err := database.Exec(`UPDATE oauth2_clients SET "secret" = $1 WHERE "id" = $2`, "hash", 1); err != nil {
+		t.Fatalf("No error should happen executing a statement, but got: %s", err)
+	}
+	if _, err := database.Query("SELECT 1"); err == nil {
+		t.Fatalf("The error of the driver should be returned")
+	}
+
+	logged := entries(t, &output)
+	if len(logged) != 2 {
+		t.Fatalf("Every query should be logged, but got %d entries", len(logged))
+	}
+	if len(logged[0].Args) != 2 || logged[0].Args[0] != querylog.Redacted || logged[0].Args[1] != querylog.Redacted {
+		t.Errorf("Every argument of a statement that sets a secret should be redacted, but got %v", logged[0].Args)
+	}
+	if logged[1].Level != "error" {
+		t.Errorf("The failed query should be logged as an error, but got %+v", logged[1])
+	}
+}
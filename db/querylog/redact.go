@@ -0,0 +1,85 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package querylog
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Redacted replaces the value of the redacted arguments
+const Redacted = "[REDACTED]"
+
+// defaultSensitive are the identifiers of the NERDZ database that make a statement sensitive:
+// the columns of the passwords, of the OAuth2 client secrets, authorization codes, PKCE challenges and tokens,
+// the OAuth2 tables and the login function
+var defaultSensitive = []string{
+	"password", "secret", "code", "code_challenge", "access_token", "token",
+	"oauth2_clients", "oauth2_authorize", "oauth2_access", "oauth2_refresh",
+	"login",
+}
+
+// words returns the words of query, lower cased: the identifiers, quoted or qualified, and the keywords,
+// but also the words of the string literals and of the comments, so that no identifier can be missed
+func words(query string) []string {
+	return strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// sensitive returns true if query references a sensitive identifier
+func (l *Logger) sensitive(query string) bool {
+	for _, word := range words(query) {
+		if l.identifiers[word] {
+			return true
+		}
+	}
+	return false
+}
+
+// redact returns the values of args or, if query is sensitive, as many Redacted
+func (l *Logger) redact(query string, args []driver.NamedValue) []interface{} {
+	if len(args) == 0 {
+		return nil
+	}
+
+	sensitive := l.sensitive(query)
+	values := make([]interface{}, len(args))
+	for i, arg := range args {
+		if sensitive {
+			values[i] = Redacted
+		} else {
+			values[i] = printable(arg.Value)
+		}
+	}
+	return values
+}
+
+// printable returns value in a form that can be encoded as JSON
+func printable(value driver.Value) interface{} {
+	switch value := value.(type) {
+	case nil, int64, float64, bool, string, time.Time:
+		return value
+	case []byte:
+		return string(value)
+	}
+	return fmt.Sprint(value)
+}
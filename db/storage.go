@@ -0,0 +1,220 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/nerdzeu/nerdz-core/db/igor"
+)
+
+// Storage is the interface that wraps the persistence of the users, the projects,
+// the posts, the comments and the private messages, with their relations.
+//
+// A description is a model whose non-zero fields are the conditions that the records
+// must satisfy. Columns are named as the columns of the NERDZ database.
+//
+// Every Storage must enforce the rules that the NERDZ database enforces: e.g. a blacklisted user
+// can't write on the board of the user that blacklisted them, and the votes, the bookmarks and the lurks
+// refer to existing posts.
+type Storage interface {
+	// Create stores model, setting its primary key and the default values of its fields
+	Create(ctx context.Context, model igor.DBModel) error
+	// Updates updates the non-zero fields of model, identified by its primary key
+	Updates(ctx context.Context, model igor.DBModel) error
+	// Delete deletes the records that match description
+	Delete(ctx context.Context, description igor.DBModel) error
+	// DeleteIn deletes the records of the table of model whose column is one of values
+	DeleteIn(ctx context.Context, model igor.DBModel, column string, values []uint64) error
+	// Notified sets to_notify to false in the records of the table of model whose column is one of values
+	Notified(ctx context.Context, model igor.DBModel, column string, values []uint64) error
+	// UpdateIn sets the columns of changes, to NULL for the nil values, in the records of the table of model
+	// whose column is one of values
+	UpdateIn(ctx context.Context, model igor.DBModel, column string, values []uint64, changes map[string]interface{}) error
+	// Find loads into dest the records that match description. dest is a pointer to a slice of models,
+	// or a pointer to a model to load the first record. dest is left untouched if there are no records
+	Find(ctx context.Context, description igor.DBModel, dest interface{}) error
+	// FindIn loads into dest, a pointer to a slice of models, the records of the table of model
+	// whose column is one of values
+	FindIn(ctx context.Context, model igor.DBModel, column string, values []uint64, dest interface{}) error
+	// Pluck loads into dest, a pointer to a slice, the column of the records that match description
+	Pluck(ctx context.Context, description igor.DBModel, column string, dest interface{}) error
+	// Count returns the number of records that match description
+	Count(ctx context.Context, description igor.DBModel) (uint64, error)
+	// Sum returns the sum of the column of the records that match description, 0 if there are none
+	Sum(ctx context.Context, description igor.DBModel, column string) (int64, error)
+	// Transaction executes f with a Storage whose operations are committed if f returns nil,
+	// and rolled back if f returns an error or panics
+	Transaction(ctx context.Context, f func(Storage) error) error
+	// LockIn locks the records of the table of model whose column is one of values until the transaction
+	// of the storage ends, so that the transactions that lock them concurrently wait. The records can still be read.
+	// The storages whose transactions are serialized do nothing
+	LockIn(ctx context.Context, model igor.DBModel, column string, values []uint64) error
+
+	// Publish publishes event to the listeners of the storage, also the ones of the other processes
+	// that share it. The events published in a transaction are published when it's committed,
+	// and discarded if it's rolled back
+	Publish(ctx context.Context, event Event) error
+	// Listen calls f with every event published from now on, in the order they are received.
+	// f must not block
+	Listen(f func(Event)) error
+
+	// Login returns the ID of the user identified by username and password, 0 if the credentials are wrong
+	Login(ctx context.Context, username, password string) (uint64, error)
+	// UsersByUsername returns the users whose username is in usernames, ignoring the case
+	UsersByUsername(ctx context.Context, usernames []string) ([]User, error)
+	// UsersIn returns the users whose counter is one of ids, with their profiles, loaded together
+	UsersIn(ctx context.Context, ids []uint64) ([]User, error)
+
+	// UserHome returns the user posts selected by options, excluding the boards blacklisted by user.
+	// The following and the followers of options are the ones of user
+	UserHome(ctx context.Context, user uint64, options PostlistOptions) ([]UserPost, error)
+	// ProjectHome returns the project posts selected by options, that user can see.
+	// The following and the followers of options are the ones of user
+	ProjectHome(ctx context.Context, user uint64, options PostlistOptions) ([]ProjectPost, error)
+	// Home returns the user and project posts selected by options, that user can see.
+	// The following and the followers of options are the ones of user
+	Home(ctx context.Context, user uint64, options PostlistOptions) ([]Message, error)
+	// UserPostlist returns the posts on the board of user, selected by options.
+	// The following and the followers of options are the ones of user
+	UserPostlist(ctx context.Context, user uint64, options PostlistOptions) ([]UserPost, error)
+	// ProjectPostlist returns the posts on the board of project, selected by options.
+	// If user is not 0, the posts sent by the users in the blacklist of user are excluded
+	ProjectPostlist(ctx context.Context, project, user uint64, options PostlistOptions) ([]ProjectPost, error)
+
+	// UserPostComments returns the comments of the user post hpid selected by options, newest first
+	UserPostComments(ctx context.Context, hpid uint64, options CommentlistOptions) ([]UserPostComment, error)
+	// ProjectPostComments returns the comments of the project post hpid selected by options, newest first
+	ProjectPostComments(ctx context.Context, hpid uint64, options CommentlistOptions) ([]ProjectPostComment, error)
+
+	// Pms returns the private messages exchanged by user and other, selected by options, newest first
+	Pms(ctx context.Context, user, other uint64, options PmsOptions) ([]PM, error)
+	// Conversations returns the conversations of user, the ones with unread messages first
+	Conversations(ctx context.Context, user uint64) ([]Conversation, error)
+	// ReadPms marks as read the private messages that other sent to user
+	ReadPms(ctx context.Context, user, other uint64) error
+
+	// Notifications returns the unread notifications of user of options.Types, selected by options, newest first.
+	// The notifications locked by user are excluded (see LockPost)
+	Notifications(ctx context.Context, user uint64, options NotificationsOptions) ([]Notification, error)
+	// CountNotifications returns the number of unread notifications of user of types, excluding the locked ones
+	CountNotifications(ctx context.Context, user uint64, types []NotificationType) (uint64, error)
+
+	// Mentions returns the mentions that match description, selected by options, newest first
+	Mentions(ctx context.Context, description *Mention, options MentionsOptions) ([]Mention, error)
+
+	// Tagged returns the user and project posts classified with tag, selected by options, that user can see
+	// as in the home. user is 0 for an anonymous user. The following and the followers of options are the ones of user
+	Tagged(ctx context.Context, user uint64, tag string, options PostlistOptions) ([]Message, error)
+	// Trending returns the n tags that classify the most posts created since since, that user can see
+	// as in the home, most used first and then by tag
+	Trending(ctx context.Context, user uint64, since time.Time, n uint8) ([]Trend, error)
+
+	// OAuth2Family returns the IDs of the access data of the token family of the access data with the specified id:
+	// the access data obtained by refreshing the same authorization (see OAuth2Storage)
+	OAuth2Family(ctx context.Context, id uint64) ([]uint64, error)
+	// ExpiredOAuth2Authorize returns the IDs of at most limit expired OAuth2 authorization codes
+	ExpiredOAuth2Authorize(ctx context.Context, limit int) ([]uint64, error)
+	// DeadOAuth2Families returns the IDs of the access data of at most limit dead token families: the families
+	// whose last access data is expired and whose refresh token has been removed, never issued or
+	// issued more than refreshTTL ago (see OAuth2Storage)
+	DeadOAuth2Families(ctx context.Context, refreshTTL time.Duration, limit int) ([]uint64, error)
+}
+
+// noStorage is the Storage of the contexts that carry no Store: every operation fails with ErrNoStore
+type noStorage struct{}
+
+func (noStorage) Create(context.Context, igor.DBModel) error  { return ErrNoStore }
+func (noStorage) Updates(context.Context, igor.DBModel) error { return ErrNoStore }
+func (noStorage) Delete(context.Context, igor.DBModel) error  { return ErrNoStore }
+func (noStorage) DeleteIn(context.Context, igor.DBModel, string, []uint64) error {
+	return ErrNoStore
+}
+func (noStorage) Notified(context.Context, igor.DBModel, string, []uint64) error {
+	return ErrNoStore
+}
+func (noStorage) UpdateIn(context.Context, igor.DBModel, string, []uint64, map[string]interface{}) error {
+	return ErrNoStore
+}
+func (noStorage) Find(context.Context, igor.DBModel, interface{}) error { return ErrNoStore }
+func (noStorage) FindIn(context.Context, igor.DBModel, string, []uint64, interface{}) error {
+	return ErrNoStore
+}
+func (noStorage) Pluck(context.Context, igor.DBModel, string, interface{}) error { return ErrNoStore }
+func (noStorage) Count(context.Context, igor.DBModel) (uint64, error)            { return 0, ErrNoStore }
+func (noStorage) Sum(context.Context, igor.DBModel, string) (int64, error)       { return 0, ErrNoStore }
+func (noStorage) Transaction(context.Context, func(Storage) error) error         { return ErrNoStore }
+func (noStorage) LockIn(context.Context, igor.DBModel, string, []uint64) error   { return ErrNoStore }
+func (noStorage) Publish(context.Context, Event) error                           { return ErrNoStore }
+func (noStorage) Listen(func(Event)) error                                       { return ErrNoStore }
+func (noStorage) Login(context.Context, string, string) (uint64, error)          { return 0, ErrNoStore }
+func (noStorage) UsersByUsername(context.Context, []string) ([]User, error)      { return nil, ErrNoStore }
+func (noStorage) UsersIn(context.Context, []uint64) ([]User, error)              { return nil, ErrNoStore }
+func (noStorage) UserHome(context.Context, uint64, PostlistOptions) ([]UserPost, error) {
+	return nil, ErrNoStore
+}
+func (noStorage) ProjectHome(context.Context, uint64, PostlistOptions) ([]ProjectPost, error) {
+	return nil, ErrNoStore
+}
+func (noStorage) Home(context.Context, uint64, PostlistOptions) ([]Message, error) {
+	return nil, ErrNoStore
+}
+func (noStorage) UserPostlist(context.Context, uint64, PostlistOptions) ([]UserPost, error) {
+	return nil, ErrNoStore
+}
+func (noStorage) ProjectPostlist(context.Context, uint64, uint64, PostlistOptions) ([]ProjectPost, error) {
+	return nil, ErrNoStore
+}
+func (noStorage) UserPostComments(context.Context, uint64, CommentlistOptions) ([]UserPostComment, error) {
+	return nil, ErrNoStore
+}
+func (noStorage) ProjectPostComments(context.Context, uint64, CommentlistOptions) ([]ProjectPostComment, error) {
+	return nil, ErrNoStore
+}
+func (noStorage) Pms(context.Context, uint64, uint64, PmsOptions) ([]PM, error) {
+	return nil, ErrNoStore
+}
+func (noStorage) Conversations(context.Context, uint64) ([]Conversation, error) {
+	return nil, ErrNoStore
+}
+func (noStorage) ReadPms(context.Context, uint64, uint64) error { return ErrNoStore }
+func (noStorage) Notifications(context.Context, uint64, NotificationsOptions) ([]Notification, error) {
+	return nil, ErrNoStore
+}
+func (noStorage) CountNotifications(context.Context, uint64, []NotificationType) (uint64, error) {
+	return 0, ErrNoStore
+}
+func (noStorage) Mentions(context.Context, *Mention, MentionsOptions) ([]Mention, error) {
+	return nil, ErrNoStore
+}
+func (noStorage) Tagged(context.Context, uint64, string, PostlistOptions) ([]Message, error) {
+	return nil, ErrNoStore
+}
+func (noStorage) Trending(context.Context, uint64, time.Time, uint8) ([]Trend, error) {
+	return nil, ErrNoStore
+}
+func (noStorage) OAuth2Family(context.Context, uint64) ([]uint64, error) {
+	return nil, ErrNoStore
+}
+func (noStorage) ExpiredOAuth2Authorize(context.Context, int) ([]uint64, error) {
+	return nil, ErrNoStore
+}
+func (noStorage) DeadOAuth2Families(context.Context, time.Duration, int) ([]uint64, error) {
+	return nil, ErrNoStore
+}
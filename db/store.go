@@ -0,0 +1,304 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/nerdzeu/nerdz-core/db/health"
+	"github.com/nerdzeu/nerdz-core/db/igor"
+)
+
+// Store is a handle to a NERDZ database, that carries its Storage.
+//
+// Every function and method of the package uses the Store carried by its context:
+// the operations performed on the values returned by a Store must receive a context
+// returned by Store.Context, or they use the default Store initialised by Init.
+// The operations whose context carries no Store fail with ErrNoStore if Init has not been called.
+type Store struct {
+	// db is the PostgreSQL database, nil if the Store has been created by NewStore or is a unit of work
+	db      *igor.Database
+	storage Storage
+	// cursorKey signs the pagination cursors
+	cursorKey []byte
+	// inTransaction is true if the store is the unit of work of a transaction
+	inTransaction bool
+	// logFile is the file of the query logger, if any
+	logFile io.Closer
+	// monitors check the health of the databases
+	monitors []*health.Monitor
+	// events delivers the events published on the storage to the subscriptions of the store
+	events *hub
+}
+
+// ErrNoStore is returned by the operations whose context carries no Store (see Store.Context),
+// when Init has not been called
+var ErrNoStore = errors.New("the context carries no Store")
+
+// storeKey is the key of the Store in a context
+type storeKey struct{}
+
+// readYourWritesKey is the key of the read-your-writes flag in a context
+type readYourWritesKey struct{}
+
+// ReadYourWrites returns a copy of ctx whose reads are served by the primary database,
+// so that they see the writes performed before. Otherwise the reads can be served
+// by a replica, that may lag behind the primary
+func ReadYourWrites(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readYourWritesKey{}, true)
+}
+
+// readYourWrites returns true if the reads of ctx must see its writes
+func readYourWrites(ctx context.Context) bool {
+	required, _ := ctx.Value(readYourWritesKey{}).(bool)
+	return required
+}
+
+// Open connects to the database described by config, and to its read replicas.
+// config.CursorSecret is required: the cursors must be signed with the same key by every process
+func Open(config Config) (store *Store, err error) {
+	if config.CursorSecret == "" {
+		return nil, invalidArgument("empty cursor secret")
+	}
+
+	replicas, err := config.replicas()
+	if err != nil {
+		return nil, err
+	}
+
+	logger, logFile, err := config.logger()
+	if err != nil {
+		return nil, err
+	}
+
+	store = &Store{cursorKey: []byte(config.CursorSecret), logFile: logFile}
+	defer func() {
+		if err != nil {
+			store.Close()
+			store = nil
+		}
+	}()
+
+	var breaker *health.Breaker
+	if store.db, breaker, err = config.connect(logger); err != nil {
+		return
+	}
+	store.watch(config, store.db, breaker)
+
+	storage := &postgres{db: store.db, backoff: config.backoff()}
+	store.storage, store.events = storage, newHub(storage)
+	for _, replicaConfig := range replicas {
+		database, breaker, err := replicaConfig.connect(logger)
+		if err != nil {
+			return nil, fmt.Errorf("unable to connect to the replica %s: %w", replicaConfig.Host, err)
+		}
+		store.watch(replicaConfig, database, breaker)
+		storage.replicas = append(storage.replicas, replica{db: database, breaker: breaker})
+	}
+	return store, nil
+}
+
+// watch checks the health of database as config describes, reporting to its breaker.
+// When a check fails, the idle connections are discarded, so that the next queries reconnect
+func (s *Store) watch(config Config, database *igor.Database, breaker *health.Breaker) {
+	if config.HealthInterval <= 0 {
+		return
+	}
+
+	pool := database.DB()
+	s.monitors = append(s.monitors, health.Watch(config.HealthInterval, breaker, check(database), func() {
+		pool.SetMaxIdleConns(0)
+		pool.SetMaxIdleConns(config.idleConns())
+	}))
+}
+
+// NewStore creates a Store that uses storage, e.g. an in-memory storage that is not shared with other processes.
+// Its cursors are signed with a random key, thus they are valid only until the Store is closed
+func NewStore(storage Storage) *Store {
+	return &Store{storage: storage, cursorKey: randomKey(), events: newHub(storage)}
+}
+
+// Storage returns the Storage of the store
+func (s *Store) Storage() Storage {
+	return s.storage
+}
+
+// Close closes the subscriptions to the events, stops the health checks and closes the connections
+// to the database and to its replicas, if any
+func (s *Store) Close() error {
+	if s.events != nil {
+		s.events.close()
+	}
+	for _, monitor := range s.monitors {
+		monitor.Stop()
+	}
+	s.monitors = nil
+
+	var err error
+	if s.db != nil {
+		s.db.StopListening()
+		err = s.db.DB().Close()
+	}
+
+	if storage, ok := s.storage.(*postgres); ok {
+		for _, replica := range storage.replicas {
+			if replicaErr := replica.db.DB().Close(); err == nil {
+				err = replicaErr
+			}
+		}
+	}
+
+	if s.logFile != nil {
+		if logErr := s.logFile.Close(); err == nil {
+			err = logErr
+		}
+	}
+	return err
+}
+
+// Transaction executes f as a unit of work: the operations performed with the context passed to f
+// are committed if f returns nil, and rolled back if f returns an error or panics.
+// f must use only the context it receives, whose Store is bound to the transaction:
+// the calls of Transaction with this context join the same unit of work
+func (s *Store) Transaction(ctx context.Context, f func(ctx context.Context) error) error {
+	if s.inTransaction {
+		return f(s.Context(ctx))
+	}
+
+	return s.storage.Transaction(ctx, func(storage Storage) error {
+		tx := &Store{storage: storage, cursorKey: s.cursorKey, inTransaction: true, events: s.events}
+		return f(tx.Context(ctx))
+	})
+}
+
+// Context returns a copy of ctx that carries the store
+func (s *Store) Context(ctx context.Context) context.Context {
+	return context.WithValue(ctx, storeKey{}, s)
+}
+
+// StoreFromContext returns the Store carried by ctx or, if there is none, the default Store.
+// Returns nil if ctx carries no Store and Init has not been called
+func StoreFromContext(ctx context.Context) *Store {
+	if store, ok := ctx.Value(storeKey{}).(*Store); ok {
+		return store
+	}
+	return defaultStore
+}
+
+// OAuth2Storage returns an OAuth2Storage that uses the store
+func (s *Store) OAuth2Storage() *OAuth2Storage {
+	return NewOAuth2Storage().WithContext(s.Context(context.Background()))
+}
+
+// NewUser returns the user with the specified id
+func (s *Store) NewUser(ctx context.Context, id uint64) (*User, error) {
+	return NewUser(s.Context(ctx), id)
+}
+
+// NewUserWhere returns the first user that matches the description
+func (s *Store) NewUserWhere(ctx context.Context, description *User) (*User, error) {
+	return NewUserWhere(s.Context(ctx), description)
+}
+
+// Login returns the user identified by username (or email or id) and password
+func (s *Store) Login(ctx context.Context, username, password string) (*User, error) {
+	return Login(s.Context(ctx), username, password)
+}
+
+// Users returns the users with the specified ids
+func (s *Store) Users(ctx context.Context, ids []uint64) ([]*User, error) {
+	return Users(s.Context(ctx), ids)
+}
+
+// LoadUsers returns the users with the specified ids, in the same order, and the ids of the missing users
+func (s *Store) LoadUsers(ctx context.Context, ids []uint64) ([]*User, []uint64, error) {
+	return LoadUsers(s.Context(ctx), ids)
+}
+
+// NewProject returns the project with the specified id
+func (s *Store) NewProject(ctx context.Context, id uint64) (*Project, error) {
+	return NewProject(s.Context(ctx), id)
+}
+
+// NewProjectWhere returns the first project that matches the description
+func (s *Store) NewProjectWhere(ctx context.Context, description *Project) (*Project, error) {
+	return NewProjectWhere(s.Context(ctx), description)
+}
+
+// Projects returns the projects with the specified ids
+func (s *Store) Projects(ctx context.Context, ids []uint64) ([]*Project, error) {
+	return Projects(s.Context(ctx), ids)
+}
+
+// LoadProjects returns the projects with the specified ids, in the same order, and the ids of the missing projects
+func (s *Store) LoadProjects(ctx context.Context, ids []uint64) ([]*Project, []uint64, error) {
+	return LoadProjects(s.Context(ctx), ids)
+}
+
+// NewUserPost returns the user post with the specified hpid
+func (s *Store) NewUserPost(ctx context.Context, hpid uint64) (*UserPost, error) {
+	return NewUserPost(s.Context(ctx), hpid)
+}
+
+// NewUserPostWhere returns the first user post that matches the description
+func (s *Store) NewUserPostWhere(ctx context.Context, description *UserPost) (*UserPost, error) {
+	return NewUserPostWhere(s.Context(ctx), description)
+}
+
+// NewProjectPost returns the project post with the specified hpid
+func (s *Store) NewProjectPost(ctx context.Context, hpid uint64) (*ProjectPost, error) {
+	return NewProjectPost(s.Context(ctx), hpid)
+}
+
+// NewProjectPostWhere returns the first project post that matches the description
+func (s *Store) NewProjectPostWhere(ctx context.Context, description *ProjectPost) (*ProjectPost, error) {
+	return NewProjectPostWhere(s.Context(ctx), description)
+}
+
+// NewUserPostComment returns the user post comment with the specified hcid
+func (s *Store) NewUserPostComment(ctx context.Context, hcid uint64) (*UserPostComment, error) {
+	return NewUserPostComment(s.Context(ctx), hcid)
+}
+
+// NewUserPostCommentWhere returns the first user post comment that matches the description
+func (s *Store) NewUserPostCommentWhere(ctx context.Context, description *UserPostComment) (*UserPostComment, error) {
+	return NewUserPostCommentWhere(s.Context(ctx), description)
+}
+
+// NewProjectPostComment returns the project post comment with the specified hcid
+func (s *Store) NewProjectPostComment(ctx context.Context, hcid uint64) (*ProjectPostComment, error) {
+	return NewProjectPostComment(s.Context(ctx), hcid)
+}
+
+// NewProjectPostCommentWhere returns the first project post comment that matches the description
+func (s *Store) NewProjectPostCommentWhere(ctx context.Context, description *ProjectPostComment) (*ProjectPostComment, error) {
+	return NewProjectPostCommentWhere(s.Context(ctx), description)
+}
+
+// NewPm returns the private message with the specified pmid
+func (s *Store) NewPm(ctx context.Context, pmid uint64) (*PM, error) {
+	return NewPm(s.Context(ctx), pmid)
+}
+
+// NewPmWhere returns the first private message that matches the description
+func (s *Store) NewPmWhere(ctx context.Context, description *PM) (*PM, error) {
+	return NewPmWhere(s.Context(ctx), description)
+}
@@ -0,0 +1,107 @@
+//go:build postgres
+// +build postgres
+
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nerdzeu/nerdz-core/db"
+)
+
+func TestStore(t *testing.T) {
+	store, err := db.Open(db.ConfigFromViper())
+	if err != nil {
+		t.Fatalf("No error should happen when opening a store, but got: %s", err)
+	}
+	defer store.Close()
+
+	ctx := store.Context(context.Background())
+	if db.StoreFromContext(ctx) != store {
+		t.Fatalf("The context should carry the store")
+	}
+
+	user, err := store.NewUser(ctx, 1)
+	if err != nil {
+		t.Fatalf("No error should happen when loading an existing user, but got: %s", err)
+	}
+
+	pms, err := user.Pms(ctx, other.ID(), db.PmsOptions{})
+	if err != nil {
+		t.Fatalf("No error should happen when loading the pms using the store, but got: %s", err)
+	}
+
+	if len(*pms) == 0 {
+		t.Errorf("The user should have private messages with the other user")
+	}
+
+	if _, err = db.Open(db.Config{Name: "test_db", CursorSecret: "secret"}); err == nil {
+		t.Errorf("Opening a store without username should fail")
+	}
+
+	config := db.ConfigFromViper()
+	config.CursorSecret = ""
+	if _, err = db.Open(config); !errors.Is(err, db.ErrInvalidArgument) {
+		t.Errorf("Opening a store without cursor secret should fail with ErrInvalidArgument, but got: %v", err)
+	}
+}
+
+func TestReplicas(t *testing.T) {
+	config := db.ConfigFromViper()
+	config.MaxOpenConns = 2
+	config.Replicas = []string{config.Host}
+
+	store, err := db.Open(config)
+	if err != nil {
+		t.Fatalf("No error should happen when opening a store with a replica, but got: %s", err)
+	}
+	defer store.Close()
+
+	ctx := store.Context(context.Background())
+	user, err := store.NewUser(ctx, 1)
+	if err != nil {
+		t.Fatalf("No error should happen when reading from a replica, but got: %s", err)
+	}
+
+	if user.Postlist(db.ReadYourWrites(ctx), db.PostlistOptions{}) == nil {
+		t.Errorf("The postlist read from the primary should not be nil")
+	}
+
+	config.Replicas = []string{"localhost:1"}
+	if _, err = db.Open(config); err == nil {
+		t.Errorf("Opening a store with an unreachable replica should fail")
+	}
+}
+
+func TestInit(t *testing.T) {
+	if err := db.Init(); err != nil {
+		t.Fatalf("No error should happen when initialising the default store, but got: %s", err)
+	}
+
+	if db.StoreFromContext(context.Background()) != db.DefaultStore() {
+		t.Fatalf("A context without a store should use the default store")
+	}
+
+	if _, err := db.NewUser(context.Background(), 1); err != nil {
+		t.Errorf("No error should happen when loading a user through the default store, but got: %s", err)
+	}
+}
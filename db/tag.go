@@ -0,0 +1,136 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	// MinTrending represents the minimum trending tags number that can be required
+	MinTrending uint64 = 1
+	// MaxTrending represents the maximum trending tags number that can be required
+	MaxTrending uint64 = 50
+	// maxTagLength is the maximum length of a tag, # included
+	maxTagLength = 45
+)
+
+// hashtag matches #tag, when the # is not part of a word, of an URL or of an encoded character (&#39;)
+var hashtag = regexp.MustCompile(`(?:^|[^\pL\pN_&/])#([\pL\pN_]+)`)
+
+// Trend is a tag, with the number of posts it classifies
+type Trend struct {
+	Tag   string
+	Count uint64
+}
+
+// NormalizeTag returns tag as it's stored: lower case and prefixed by #.
+// Returns an error if tag is not a valid tag: a word of letters, digits and underscores, not only digits
+func NormalizeTag(tag string) (string, error) {
+	tag = "#" + strings.ToLower(strings.TrimPrefix(tag, "#"))
+	if len(tag) > maxTagLength {
+		return "", invalidArgument("the tag %s is too long", tag)
+	}
+
+	letters := false
+	for _, r := range tag[1:] {
+		if !unicode.IsLetter(r) && !unicode.IsNumber(r) && r != '_' {
+			return "", invalidArgument("invalid tag %s", tag)
+		}
+		letters = letters || !unicode.IsDigit(r)
+	}
+	if !letters {
+		return "", invalidArgument("invalid tag %s", tag)
+	}
+	return tag, nil
+}
+
+// hashtags returns the valid tags of text, normalized and without duplicates, in order of appearance
+func hashtags(text string) []string {
+	var tags []string
+	seen := make(map[string]bool)
+	for _, match := range hashtag.FindAllStringSubmatch(text, -1) {
+		if tag, err := NormalizeTag(match[1]); err == nil && !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// classify stores the tags of message, if it's a post. The tags of an edited post replace the previous ones
+func classify(ctx context.Context, message Content, edited bool) error {
+	var description PostClassification
+	switch post := message.(type) {
+	case *UserPost:
+		description.UHpid = post.Hpid
+	case *ProjectPost:
+		description.GHpid = post.Hpid
+	default:
+		return nil
+	}
+
+	if edited {
+		if err := storage(ctx).Delete(ctx, &description); err != nil {
+			return err
+		}
+	}
+	for _, tag := range hashtags(message.Text()) {
+		classification := description
+		classification.Tag = tag
+		if err := storage(ctx).Create(ctx, &classification); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Tagged returns the user and project posts classified with tag, selected by options, sorted like the home.
+// The posts are the ones that user can see in the home: user is nil for the anonymous users,
+// that see the posts of the visible projects only. The following and the followers of options are the ones of user
+func Tagged(ctx context.Context, user *User, tag string, options PostlistOptions) (*[]Message, error) {
+	tag, err := NormalizeTag(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	var id uint64
+	if user != nil {
+		id = user.ID()
+	}
+	posts, err := storage(ctx).Tagged(ctx, id, tag, options)
+	return &posts, err
+}
+
+// Trending returns at most n tags of the posts created in the last window, most used first.
+// The posts are the ones that user, nil for the anonymous users, can see in the home
+func Trending(ctx context.Context, user *User, window time.Duration, n uint8) ([]Trend, error) {
+	if window <= 0 {
+		return nil, invalidArgument("the window of the trending tags must be positive")
+	}
+
+	var id uint64
+	if user != nil {
+		id = user.ID()
+	}
+	return storage(ctx).Trending(ctx, id, time.Now().UTC().Add(-window), AtMostTrending(uint64(n)))
+}
@@ -0,0 +1,102 @@
+//go:build postgres
+// +build postgres
+
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nerdzeu/nerdz-core/db"
+)
+
+// tagged returns true if posts contains the user post with the specified hpid
+func tagged(posts []db.Message, hpid uint64) bool {
+	for _, post := range posts {
+		if post.Type == 1 && post.Hpid == hpid {
+			return true
+		}
+	}
+	return false
+}
+
+func TestTagged(t *testing.T) {
+	post := db.UserPost{}
+	post.Message = "Testing #CoreTags, #coretags and issue #42"
+	if err := me.Submit(ctx, &post); err != nil {
+		t.Fatalf("No error should happen when posting, but got: %s", err)
+	}
+	defer me.Delete(ctx, &post)
+
+	for _, user := range []*db.User{me, nil} {
+		posts, err := db.Tagged(ctx, user, "#CORETAGS", db.PostlistOptions{})
+		if err != nil || !tagged(*posts, post.Hpid) {
+			t.Errorf("The post should be tagged #coretags for %+v, but got %+v (%v)", user, posts, err)
+		}
+	}
+	if _, err := db.Tagged(ctx, me, "42", db.PostlistOptions{}); err == nil {
+		t.Errorf("A tag made of digits only should be invalid")
+	}
+
+	post.Message = "Testing #coreedited"
+	if err := me.Edit(ctx, &post); err != nil {
+		t.Fatalf("No error should happen when editing a post, but got: %s", err)
+	}
+	if posts, err := db.Tagged(ctx, me, "coretags", db.PostlistOptions{}); err != nil || tagged(*posts, post.Hpid) {
+		t.Errorf("The tags of the edited post should be replaced, but got %+v (%v)", posts, err)
+	}
+	if posts, err := db.Tagged(ctx, me, "coreedited", db.PostlistOptions{}); err != nil || !tagged(*posts, post.Hpid) {
+		t.Errorf("The edited post should be tagged #coreedited, but got %+v (%v)", posts, err)
+	}
+}
+
+func TestTrending(t *testing.T) {
+	for _, message := range []string{"#coretrend #coretrend", "Still #coretrend"} {
+		post := db.UserPost{}
+		post.Message = message
+		if err := me.Submit(ctx, &post); err != nil {
+			t.Fatalf("No error should happen when posting, but got: %s", err)
+		}
+		defer me.Delete(ctx, &post)
+	}
+
+	trends, err := db.Trending(ctx, me, time.Hour, uint8(db.MaxTrending))
+	if err != nil {
+		t.Fatalf("No error should happen when loading the trending tags, but got: %s", err)
+	}
+	found := false
+	for _, trend := range trends {
+		if trend.Tag == "#coretrend" {
+			found = true
+			if trend.Count != 2 {
+				t.Errorf("The tags should be counted once per post, but got %+v", trend)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("The tags of the last hour should be trending, but got %+v", trends)
+	}
+
+	for _, window := range []time.Duration{0, -time.Hour} {
+		if _, err = db.Trending(ctx, me, window, 1); err == nil {
+			t.Errorf("The window %s should be invalid", window)
+		}
+	}
+}
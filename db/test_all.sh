@@ -11,11 +11,16 @@ CONT_NAME="nerdz-test-db"
 
 export NERDZ_DB_USER="test_db"
 export NERDZ_DB_NAME="test_db"
+export NERDZ_DB_CURSOR_SECRET="test_db"
 
 echo -n "Starting Docker container $CONT_NAME: " && \
 sudo docker run -d --rm --name "$CONT_NAME" -p 5432:5432 "$DOCKER_IMG" && \
 trap "echo -n 'Destroying Docker container: ' && sudo docker stop \"$CONT_NAME\"" INT TERM EXIT && \
 echo 'Letting PostgreSQL a few seconds to startup...' && \
 sleep 5 && \
+echo 'Applying the migrations...' && \
+for migration in migrations/*.sql; do
+    sudo docker exec -i "$CONT_NAME" psql -q -v ON_ERROR_STOP=1 -U "$NERDZ_DB_USER" "$NERDZ_DB_NAME" < "$migration" || exit 1
+done && \
 echo "Launching tests" && \
-go test "$@"
\ No newline at end of file
+go test -tags postgres "$@"
\ No newline at end of file
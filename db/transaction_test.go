@@ -0,0 +1,97 @@
+//go:build postgres
+// +build postgres
+
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nerdzeu/nerdz-core/db"
+)
+
+// boardPosts returns the number of posts on the board of me
+func boardPosts(t *testing.T) uint64 {
+	count, err := db.StoreFromContext(ctx).Storage().Count(ctx, &db.UserPost{Post: db.Post{To: me.ID()}})
+	if err != nil {
+		t.Fatalf("No error should happen when counting the posts, but got: %s", err)
+	}
+	return count
+}
+
+func TestTransaction(t *testing.T) {
+	post := db.UserPost{}
+	post.Message = "Lock me"
+	if err := me.Submit(ctx, &post); err != nil {
+		t.Fatalf("No error should happen when posting, but got: %s", err)
+	}
+	defer me.Delete(ctx, &post)
+
+	// The second lock refers to a missing user: the first one must be rolled back
+	if _, err := me.LockPost(ctx, &post, other, &db.User{Counter: 4242}); err == nil {
+		t.Fatalf("Locking a post for a missing user should fail")
+	}
+	if count, _ := db.StoreFromContext(ctx).Storage().Count(ctx, &db.UserPostUserLock{Hpid: post.Hpid}); count != 0 {
+		t.Errorf("A failed lock should leave no locks, but got %d", count)
+	}
+
+	posts := boardPosts(t)
+	failure := errors.New("failure")
+	err := db.Transaction(ctx, func(ctx context.Context) error {
+		return db.Transaction(ctx, func(ctx context.Context) error {
+			rolledBack := db.UserPost{}
+			rolledBack.To, rolledBack.Message = me.ID(), "Rolled back"
+			if err := other.Submit(ctx, &rolledBack); err != nil {
+				return err
+			}
+			return failure
+		})
+	})
+	if err != failure {
+		t.Errorf("The transaction should return the error of f, but got: %v", err)
+	}
+	if count := boardPosts(t); count != posts {
+		t.Errorf("The posts of a failed transaction should be rolled back, expected %d posts but got %d", posts, count)
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("The panic of f should be propagated")
+			}
+		}()
+		db.Transaction(ctx, func(ctx context.Context) error {
+			rolledBack := db.UserPost{}
+			rolledBack.To, rolledBack.Message = me.ID(), "Rolled back"
+			if err := other.Submit(ctx, &rolledBack); err != nil {
+				return err
+			}
+			panic("failure")
+		})
+	}()
+	if count := boardPosts(t); count != posts {
+		t.Errorf("The posts of a panicking transaction should be rolled back, expected %d posts but got %d", posts, count)
+	}
+
+	if err = db.Transaction(context.Background(), func(context.Context) error { return nil }); err != db.ErrNoStore {
+		t.Errorf("A transaction without a store should fail with ErrNoStore, but got: %v", err)
+	}
+}
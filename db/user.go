@@ -18,8 +18,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package db
 
 import (
-	"errors"
-	"fmt"
+	"context"
 	"net/mail"
 	"net/url"
 	"reflect"
@@ -27,133 +26,151 @@ import (
 	"strings"
 	"time"
 
+	"github.com/nerdzeu/nerdz-core/db/igor"
 	"github.com/nerdzeu/nerdz-core/utils"
 )
 
-// NewUser returns the user with the specified id
-func NewUser(id uint64) (*User, error) {
-	return NewUserWhere(&User{Counter: id})
+// NewUser returns the user with the specified id. The id 0 is never found
+func NewUser(ctx context.Context, id uint64) (*User, error) {
+	if id == 0 {
+		return nil, notFound("Requested User does not exist")
+	}
+	return NewUserWhere(ctx, &User{Counter: id})
 }
 
-// NewUserWhere returns the first user that matches the description
-func NewUserWhere(description *User) (user *User, e error) {
+// NewUserWhere returns the first user that matches the description.
+// An empty description, that would match any user, is rejected with ErrInvalidArgument
+func NewUserWhere(ctx context.Context, description *User) (user *User, e error) {
+	if emptyDescription(description) {
+		return nil, invalidArgument("the description of the User is empty")
+	}
 	user = new(User)
-	if e = db().Where(description).Scan(user); e != nil {
-		return
+	if e = storage(ctx).Find(ctx, description, user); e != nil {
+		return nil, e
+	}
+	if user.ID() == 0 {
+		return nil, notFound("Requested User does not exist")
 	}
 
-	if e = db().First(&user.Profile, user.ID()); e != nil {
-		return
+	if e = storage(ctx).Find(ctx, &Profile{Counter: user.ID()}, &user.Profile); e != nil {
+		return nil, e
 	}
 
 	return
 }
 
 // Login initializes a User struct if login (id | email | username) and password are correct
-func Login(login, password string) (*User, error) {
+func Login(ctx context.Context, login, password string) (*User, error) {
 	var email *mail.Address
 	var username string
 	var id uint64
 	var e error
 
 	if email, e = mail.ParseAddress(login); e == nil { // is a mail
-		if e = db().Model(User{}).Select("username").Where(&User{Email: email.Address}).Scan(&username); e != nil {
+		if username, e = loginUsername(ctx, &User{Email: email.Address}); e != nil {
 			return nil, e
 		}
 	} else if id, e = strconv.ParseUint(login, 10, 64); e == nil { // if login the user ID
-		if e = db().Model(User{}).Select("username").Where(&User{Counter: id}).Scan(&username); e != nil {
+		if username, e = loginUsername(ctx, &User{Counter: id}); e != nil {
 			return nil, e
 		}
 	} else { // otherwise is the username
 		username = login
 	}
 
-	var logged bool
 	var counter uint64
-
-	if e = db().Model(User{}).Select("login(?, ?) AS logged, counter", username, password).Where("LOWER(username) = ?", username).Scan(&logged, &counter); e != nil {
+	if counter, e = storage(ctx).Login(ctx, username, password); e != nil {
 		return nil, e
 	}
 
-	if !logged {
-		return nil, errors.New("wrong username or password")
+	if counter == 0 {
+		return nil, NewError(ErrAuthFailed, "wrong username or password")
 	}
 
-	return NewUser(counter)
+	return NewUser(ctx, counter)
+}
+
+// loginUsername returns the username of the user that matches the description
+func loginUsername(ctx context.Context, description *User) (string, error) {
+	var usernames []string
+	if e := storage(ctx).Pluck(ctx, description, "username", &usernames); e != nil || len(usernames) == 0 {
+		return "", e
+	}
+	return usernames[0], nil
 }
 
 // Begin *Numeric* Methods
 
 // NumericBlacklist returns a slice containing the counters (IDs) of blacklisted user
-func (user *User) NumericBlacklist() (blacklist []uint64) {
-	db().Model(Blacklist{}).Where(&Blacklist{From: user.ID()}).Pluck(`"to"`, &blacklist)
+func (user *User) NumericBlacklist(ctx context.Context) (blacklist []uint64) {
+	storage(ctx).Pluck(ctx, &Blacklist{From: user.ID()}, "to", &blacklist)
 	return
 }
 
 // NumericBlacklisting returns a slice  containing the IDs of users that puts user (*User) in their blacklist
-func (user *User) NumericBlacklisting() (blacklist []uint64) {
-	db().Model(Blacklist{}).Where(&Blacklist{To: user.ID()}).Pluck(`"from"`, &blacklist)
+func (user *User) NumericBlacklisting(ctx context.Context) (blacklist []uint64) {
+	storage(ctx).Pluck(ctx, &Blacklist{To: user.ID()}, "from", &blacklist)
 	return
 }
 
 // NumericFollowers returns a slice containing the IDs of User that are user's followers
-func (user *User) NumericFollowers() (followers []uint64) {
-	db().Model(UserFollower{}).Where(UserFollower{To: user.ID()}).Pluck(`"from"`, &followers)
+func (user *User) NumericFollowers(ctx context.Context) (followers []uint64) {
+	storage(ctx).Pluck(ctx, UserFollower{To: user.ID()}, "from", &followers)
 	return
 }
 
 // NumericUserFollowing returns a slice containing the IDs of User that user (User *) is following
-func (user *User) NumericUserFollowing() (following []uint64) {
-	db().Model(UserFollower{}).Where(&UserFollower{From: user.ID()}).Pluck(`"to"`, &following)
+func (user *User) NumericUserFollowing(ctx context.Context) (following []uint64) {
+	storage(ctx).Pluck(ctx, &UserFollower{From: user.ID()}, "to", &following)
 	return
 }
 
 // NumericProjectFollowing returns a slice containing the IDs of Project that user (User *) is following
-func (user *User) NumericProjectFollowing() (following []uint64) {
-	db().Model(ProjectFollower{}).Where(&ProjectFollower{From: user.ID()}).Pluck(`"to"`, &following)
+func (user *User) NumericProjectFollowing(ctx context.Context) (following []uint64) {
+	storage(ctx).Pluck(ctx, &ProjectFollower{From: user.ID()}, "to", &following)
 	return
 }
 
 // NumericFriends returns a slice containing the IDs of Users that are user's friends (follows each other)
-func (user *User) NumericFriends() (friends []uint64) {
-	db().Raw(`SELECT "to" FROM (
-		select "to" from followers where "from" = ?) as f
-		inner join
-		(select "from" from followers where "to" = ?) as e
-		on f.to = e.from
-		inner join users u on u.counter = f.to`, user.ID(), user.ID()).Scan(&friends)
+func (user *User) NumericFriends(ctx context.Context) (friends []uint64) {
+	followers := user.NumericFollowers(ctx)
+	for _, following := range user.NumericUserFollowing(ctx) {
+		if utils.InSlice(following, followers) {
+			friends = append(friends, following)
+		}
+	}
 	return
 }
 
 // NumericWhitelist returns a slice containing the IDs of users that are in user whitelist
-func (user *User) NumericWhitelist() []uint64 {
+func (user *User) NumericWhitelist(ctx context.Context) []uint64 {
 	var whitelist []uint64
-	db().Model(Whitelist{}).Where(Whitelist{From: user.ID()}).Pluck(`"to"`, &whitelist)
+	storage(ctx).Pluck(ctx, Whitelist{From: user.ID()}, "to", &whitelist)
 	return append(whitelist, user.ID())
 }
 
 // NumericWhitelisting returns a slice containing thr IDs of users that whitelisted the user
-func (user *User) NumericWhitelisting() (whitelisting []uint64) {
-	db().Model(Whitelist{}).Where(Whitelist{To: user.ID()}).Pluck(`"from"`, &whitelisting)
+func (user *User) NumericWhitelisting(ctx context.Context) (whitelisting []uint64) {
+	storage(ctx).Pluck(ctx, Whitelist{To: user.ID()}, "from", &whitelisting)
 	return
 }
 
 // NumericProjects returns a slice containing the IDs of the projects owned by user
-func (user *User) NumericProjects() (projects []uint64) {
-	db().Model(ProjectOwner{}).Where(ProjectOwner{From: user.ID()}).Pluck(`"to"`, &projects)
+func (user *User) NumericProjects(ctx context.Context) (projects []uint64) {
+	storage(ctx).Pluck(ctx, ProjectOwner{From: user.ID()}, "to", &projects)
 	return
 }
 
 // End *Numeric* Methods
 
 // Interests returns a []string of user interests
-func (user *User) Interests() (interests []string) {
-	db().Model(Interest{}).Where(Interest{From: user.ID()}).Pluck(`"value"`, &interests)
+func (user *User) Interests(ctx context.Context) (interests []string) {
+	storage(ctx).Pluck(ctx, Interest{From: user.ID()}, "value", &interests)
 	return
 }
 
 // PersonalInfo returns a *PersonalInfo struct
-func (user *User) PersonalInfo() *PersonalInfo {
+func (user *User) PersonalInfo(ctx context.Context) *PersonalInfo {
 	return &PersonalInfo{
 		Username:  user.Username,
 		IsOnline:  user.Viewonline && user.Last.Add(time.Duration(5)*time.Minute).After(time.Now()),
@@ -164,7 +181,7 @@ func (user *User) PersonalInfo() *PersonalInfo {
 		Gender:    user.Gender,
 		Birthday:  user.BirthDate,
 		Gravatar:  utils.Gravatar(user.Email),
-		Interests: user.Interests(),
+		Interests: user.Interests(ctx),
 		Quotes:    strings.Split(user.Profile.Quotes, "\n"),
 		Biography: user.Profile.Biography}
 }
@@ -197,206 +214,190 @@ func (user *User) ContactInfo() *ContactInfo {
 }
 
 // BoardInfo returns a *BoardInfo struct
-func (user *User) BoardInfo() *BoardInfo {
+func (user *User) BoardInfo(ctx context.Context) (*BoardInfo, error) {
+	whitelist, err := user.Whitelist(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	return &BoardInfo{
 		Language:  user.BoardLang,
 		IsClosed:  user.Profile.Closed,
 		Private:   user.Private,
-		Whitelist: user.Whitelist()}
+		Whitelist: whitelist}, nil
 }
 
 // Whitelist returns a slice of users that are in the user whitelist
-func (user *User) Whitelist() []*User {
-	return Users(user.NumericWhitelist())
+func (user *User) Whitelist(ctx context.Context) ([]*User, error) {
+	return Users(ctx, user.NumericWhitelist(ctx))
 }
 
 // Whitelisting returns a slice of users that whitelisted the user
-func (user *User) Whitelisting() []*User {
-	return Users(user.NumericWhitelisting())
+func (user *User) Whitelisting(ctx context.Context) ([]*User, error) {
+	return Users(ctx, user.NumericWhitelisting(ctx))
 }
 
 // Followers returns a slice of User that are user's followers
-func (user *User) Followers() []*User {
-	return Users(user.NumericFollowers())
+func (user *User) Followers(ctx context.Context) ([]*User, error) {
+	return Users(ctx, user.NumericFollowers(ctx))
 }
 
 // UserFollowing returns a slice of User that user (User *) is following
-func (user *User) UserFollowing() []*User {
-	return Users(user.NumericUserFollowing())
+func (user *User) UserFollowing(ctx context.Context) ([]*User, error) {
+	return Users(ctx, user.NumericUserFollowing(ctx))
 }
 
 // ProjectFollowing returns a slice of Project that user (User *) is following
-func (user *User) ProjectFollowing() []*Project {
-	return Projects(user.NumericProjectFollowing())
+func (user *User) ProjectFollowing(ctx context.Context) ([]*Project, error) {
+	return Projects(ctx, user.NumericProjectFollowing(ctx))
 }
 
-// Blacklist returns a slice of users that user (*Project) put in his blacklist
-func (user *User) Blacklist() []*User {
-	return Users(user.NumericBlacklist())
+// Blacklist returns a slice of users that user (*Project) put in their blacklist
+func (user *User) Blacklist(ctx context.Context) ([]*User, error) {
+	return Users(ctx, user.NumericBlacklist(ctx))
 }
 
 // Blacklisting returns a slice of users that puts user (*User) in their blacklist
-func (user *User) Blacklisting() []*User {
-	return Users(user.NumericBlacklisting())
+func (user *User) Blacklisting(ctx context.Context) ([]*User, error) {
+	return Users(ctx, user.NumericBlacklisting(ctx))
 }
 
 // Projects returns a slice of projects owned by the user
-func (user *User) Projects() []*Project {
-	return Projects(user.NumericProjects())
+func (user *User) Projects(ctx context.Context) ([]*Project, error) {
+	return Projects(ctx, user.NumericProjects(ctx))
 }
 
 // ProjectHome returns a slice of ProjectPost selected by options
-func (user *User) ProjectHome(options PostlistOptions) *[]ProjectPost {
-	var projectPost ProjectPost
-
-	query := db().Model(projectPost).Order("hpid DESC")
-	query = projectPostlistConditions(query, user)
-
-	options.Model = projectPost
-	query = postlistQueryBuilder(query, options, user)
-
-	var projectPosts []ProjectPost
-	query.Scan(&projectPosts)
-
+func (user *User) ProjectHome(ctx context.Context, options PostlistOptions) *[]ProjectPost {
+	projectPosts, _ := storage(ctx).ProjectHome(ctx, user.ID(), options)
 	return &projectPosts
 }
 
 // UserHome returns a slice of UserPost specified by options
-func (user *User) UserHome(options PostlistOptions) *[]UserPost {
-	var userPost UserPost
-
-	query := db().Model(userPost).Order("hpid DESC")
-	query = query.Where("("+UserPost{}.TableName()+`."to" NOT IN (SELECT "to" FROM blacklist WHERE "from" = ?))`, user.ID())
-
-	options.Model = userPost
-	query = postlistQueryBuilder(query, options, user)
-
-	var posts []UserPost
-	query.Scan(&posts)
+func (user *User) UserHome(ctx context.Context, options PostlistOptions) *[]UserPost {
+	posts, _ := storage(ctx).UserHome(ctx, user.ID(), options)
 	return &posts
 }
 
 // Home returns a slice of Post representing the user home. Posts are
 // filtered by specified options.
-func (user *User) Home(options PostlistOptions) *[]Message {
-	var message Message
-	query := db().
-		CTE(`WITH blist AS (SELECT "to" FROM blacklist WHERE "from" = ?)`, user.ID()). // WITH cte
-		Table(message.TableName()).                                                    // select * from messages
-		Where(`"from" NOT IN (SELECT * FROM blist) AND
-		CASE type
-		WHEN 1 THEN "to" NOT IN (SELECT * FROM blist)
-		ELSE ( -- groups conditions
-			TRUE IN (SELECT visible FROM groups g WHERE g.counter = "to")
-			OR
-			(? IN (
-				SELECT "from" FROM groups_members gm WHERE gm."to" = "to"
-				UNION ALL
-				SELECT "from" FROM groups_owners go WHERE go."to" = "to")
-			)
-		)
-		END`, user.ID()).
-		Order("time DESC")
-
-	options.Model = message
-	query = postlistQueryBuilder(query, options, user) // handle following, followers, language, newer, older, between...
-	var posts []Message
-	query.Scan(&posts)
+func (user *User) Home(ctx context.Context, options PostlistOptions) *[]Message {
+	posts, _ := storage(ctx).Home(ctx, user.ID(), options)
 	return &posts
 }
 
 // Pms returns a slice of Pm, representing the list of the last messages exchanged with other users
-func (user *User) Pms(otherUser uint64, options PmsOptions) (*[]PM, error) {
-	var pms []PM
+func (user *User) Pms(ctx context.Context, otherUser uint64, options PmsOptions) (*[]PM, error) {
+	pms, e := storage(ctx).Pms(ctx, user.ID(), otherUser, options)
+	return &pms, e
+}
 
-	query := db().Model(PM{}).Where(
-		`("from" = ? AND "to" = ?) OR ("from" = ? AND "to" = ?)`,
-		user.ID(), otherUser, otherUser, user.ID())
-	// build query in function of parameters
-	query = pmsQueryBuilder(query, options)
+// Typing tells other that user is typing a private message to them.
+// Returns an error if one of the users blacklisted the other
+func (user *User) Typing(ctx context.Context, other uint64) error {
+	if other == user.ID() {
+		return invalidArgument("you can't write to yourself")
+	}
+	if user.blacklisted(ctx, other) {
+		return permissionDenied("you can't write to user %d", other)
+	}
+	return publish(ctx, Event{Type: EventTyping, From: user.ID(), To: other})
+}
 
-	e := query.Scan(&pms)
-	return &pms, e
+// ReadPms marks as read the private messages that other sent to user, and tells other that they have been read,
+// unless one of the users blacklisted the other
+func (user *User) ReadPms(ctx context.Context, other uint64) error {
+	return Transaction(ctx, func(ctx context.Context) error {
+		if err := storage(ctx).ReadPms(ctx, user.ID(), other); err != nil {
+			return err
+		}
+		if user.blacklisted(ctx, other) {
+			return nil
+		}
+		return publish(ctx, Event{Type: EventRead, From: user.ID(), To: other})
+	})
+}
+
+// blacklisted returns true if user blacklisted other, or other blacklisted user
+func (user *User) blacklisted(ctx context.Context, other uint64) bool {
+	return utils.InSlice(other, user.NumericBlacklist(ctx)) || utils.InSlice(other, user.NumericBlacklisting(ctx))
 }
 
 // Vote express a positive/negative preference for a post or comment.
 // Returns the vote if everything went ok
-func (user *User) Vote(message Content, vote int8) (Vote, error) {
-	method := db().Create
+func (user *User) Vote(ctx context.Context, message Content, vote int8) (Vote, error) {
 	if vote > 0 {
 		vote = 1
-	} else if vote == 0 {
-		vote = 0
-		method = db().Delete
-	} else {
+	} else if vote < 0 {
 		vote = -1
 	}
 
-	var err error
+	// the new votes are published to the author of the content
+	event := Event{Type: EventVote, From: user.ID(), To: message.NumericSender(), Vote: vote}
+	express := func(model igor.DBModel) error {
+		if vote == 0 {
+			return storage(ctx).Delete(ctx, model)
+		}
+		return Transaction(ctx, func(ctx context.Context) error {
+			if err := storage(ctx).Create(ctx, model); err != nil {
+				return err
+			}
+			return publish(ctx, event)
+		})
+	}
+
 	switch message.(type) {
 	case *UserPost:
 		post := message.(*UserPost)
 		dbVote := UserPostVote{Hpid: post.ID(), From: user.ID(), To: post.To, Vote: vote}
-		err = method(&dbVote)
-		return &dbVote, err
+		event.Board, event.Hpid = post.To, post.Hpid
+		return &dbVote, express(&dbVote)
 
 	case *ProjectPost:
 		post := message.(*ProjectPost)
 		dbVote := ProjectPostVote{Hpid: post.ID(), From: user.ID(), To: post.To, Vote: vote}
-		err = method(&dbVote)
-		return &dbVote, err
+		event.Board, event.Project, event.Hpid = post.To, true, post.Hpid
+		return &dbVote, express(&dbVote)
 
 	case *UserPostComment:
 		comment := message.(*UserPostComment)
 		dbVote := UserPostCommentVote{Hcid: comment.Hcid, From: user.ID(), Vote: vote}
-		err = method(&dbVote)
-		return &dbVote, err
+		event.Board, event.Hpid, event.Hcid = comment.To, comment.Hpid, comment.Hcid
+		return &dbVote, express(&dbVote)
 
 	case *ProjectPostComment:
 		comment := message.(*ProjectPostComment)
 		dbVote := ProjectPostCommentVote{Hcid: comment.Hcid, From: user.ID(), To: comment.To, Vote: vote}
-		err = method(&dbVote)
-		return &dbVote, err
+		event.Board, event.Project, event.Hpid, event.Hcid = comment.To, true, comment.Hpid, comment.Hcid
+		return &dbVote, express(&dbVote)
 
 	case *PM:
-		return nil, fmt.Errorf("TODO(galeone): No preference for private message")
+		return nil, invalidArgument("TODO(galeone): No preference for private message")
 	}
 
-	return nil, fmt.Errorf("invalid parameter type: %s", reflect.TypeOf(message))
+	return nil, invalidArgument("invalid parameter type: %s", reflect.TypeOf(message))
 }
 
 // Conversations returns all the private conversations done by the user
-func (user *User) Conversations() (*[]Conversation, error) {
-	var convList []Conversation
-	err := db().Raw(`WITH conversations_with_duplicates AS (
-		SELECT DISTINCT ?::bigint AS me, otherid, MAX(times) as "time", to_read FROM (
-			SELECT MAX("time") AS times, "from" as otherid, to_read FROM pms WHERE "to" = ? GROUP BY "from", to_read
-			UNION
-			SELECT MAX("time") AS times, "to" as otherid, FALSE AS to_read FROM pms WHERE "from" = ? GROUP BY "to", to_read
-		) AS tmp GROUP BY otherid, to_read
-	)
-	SELECT c.me, c.otherid, p.message, MAX(c."time") AS t, c.to_read
-	FROM conversations_with_duplicates c
-	INNER JOIN pms p
-	ON c."time" = p."time" AND (
-		(c.me = p."from" AND c.otherid = p."to")
-		OR
-		(c.me = p."to" AND c.otherid = p."from")
-	)
-	GROUP BY c.me, c.otherid, p.message, c.to_read
-	ORDER BY to_read DESC, t DESC`, user.ID(), user.ID(), user.ID()).Scan(&convList)
+func (user *User) Conversations(ctx context.Context) (*[]Conversation, error) {
+	convList, err := storage(ctx).Conversations(ctx, user.ID())
 	return &convList, err
 }
 
 // DeleteConversation deletes the conversation of user with other user
-func (user *User) DeleteConversation(other uint64) error {
-	return db().Where(`("from" = ? AND "to" = ?) OR ("from" = ? AND "to" = ?)`, user.ID(), other, other, user.ID()).Delete(&PM{})
+func (user *User) DeleteConversation(ctx context.Context, other uint64) error {
+	return Transaction(ctx, func(ctx context.Context) error {
+		if err := storage(ctx).Delete(ctx, &PM{From: user.ID(), To: other}); err != nil {
+			return err
+		}
+		return storage(ctx).Delete(ctx, &PM{From: other, To: user.ID()})
+	})
 }
 
 //Implements Board interface
 
 //Info returns a *info struct
-func (user *User) Info() *Info {
+func (user *User) Info(ctx context.Context) *Info {
 	website, _ := url.Parse(user.Profile.Website)
 	gravaURL := utils.Gravatar(user.Email)
 
@@ -412,19 +413,8 @@ func (user *User) Info() *Info {
 }
 
 //Postlist returns the specified slice of post on the user board
-func (user *User) Postlist(options PostlistOptions) *[]ExistingPost {
-	users := User{}.TableName()
-	var post UserPost
-
-	query := db().Model(UserPost{}).Order("hpid DESC").
-		Joins("JOIN "+users+" ON "+users+".counter = "+post.TableName()+".to").
-		Where(`"to" = ?`, user.ID())
-
-	options.Model = post
-
-	var userPosts []UserPost
-	query = postlistQueryBuilder(query, options, user)
-	query.Scan(&userPosts)
+func (user *User) Postlist(ctx context.Context, options PostlistOptions) *[]ExistingPost {
+	userPosts, _ := storage(ctx).UserPostlist(ctx, user.ID(), options)
 
 	var retPosts []ExistingPost
 	for _, p := range userPosts {
@@ -18,228 +18,258 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package db
 
 import (
-	"errors"
+	"context"
 	"reflect"
 
 	"html"
 
-	"github.com/galeone/igor"
+	"github.com/nerdzeu/nerdz-core/db/igor"
 	"github.com/nerdzeu/nerdz-core/utils"
 )
 
 // User actions
 
 // Delete an existing message
-func (user *User) Delete(message Content) error {
-	if user.CanDelete(message) {
-		return db().Delete(message)
+func (user *User) Delete(ctx context.Context, message Content) error {
+	if user.CanDelete(ctx, message) {
+		return storage(ctx).Delete(ctx, message)
 	}
-	return errors.New("you can't delete this message")
+	return permissionDenied("you can't delete this message")
 }
 
 // Edit an existing message
-func (user *User) Edit(message Content) error {
-	if user.CanEdit(message) {
-		rollBackText := message.Text() //unencoded
+func (user *User) Edit(ctx context.Context, message Content) error {
+	rollBackText := message.Text() //unencoded
 
-		if err := populateContent(message, user); err != nil {
-			message.SetText(rollBackText)
+	// the check, the update, the revision of the previous message, recorded by the update, the new mentions and tags are a unit
+	err := Transaction(ctx, func(ctx context.Context) error {
+		if !user.CanEdit(ctx, message) {
+			return permissionDenied("editing of this message is not allowed")
+		}
+
+		if err := populateContent(ctx, message, user); err != nil {
 			return err
 		}
 
-		if err := db().Updates(message); err != nil {
-			message.SetText(rollBackText)
+		if err := storage(ctx).Updates(ctx, message); err != nil {
 			return err
 		}
+		if err := classify(ctx, message, true); err != nil {
+			return err
+		}
+		return user.mention(ctx, message, true)
+	})
 
-		return nil
+	if err != nil {
+		message.SetText(rollBackText)
 	}
-
-	return errors.New("editing of this message is not allowed")
+	return err
 }
 
 // Follow creates a new "follow" relationship between the current user
 // and another NERDZ board. The board could represent a NERDZ's project
 // or another NERDZ's user.
-func (user *User) Follow(board Board) error {
+func (user *User) Follow(ctx context.Context, board Board) error {
 	if board == nil {
-		return errors.New("unable to follow an undefined board")
+		return invalidArgument("unable to follow an undefined board")
 	}
 
 	switch board.(type) {
 	case *User:
 		otherUser := board.(*User)
-		return db().Create(&UserFollower{From: user.ID(), To: otherUser.ID()})
+		return Transaction(ctx, func(ctx context.Context) error {
+			if err := storage(ctx).Create(ctx, &UserFollower{From: user.ID(), To: otherUser.ID()}); err != nil {
+				return err
+			}
+			return publish(ctx, Event{Type: EventFollow, From: user.ID(), To: otherUser.ID(), Board: otherUser.ID()})
+		})
 
 	case *Project:
 		otherProj := board.(*Project)
-		return db().Create(&ProjectFollower{From: user.ID(), To: otherProj.ID()})
+		return Transaction(ctx, func(ctx context.Context) error {
+			if err := storage(ctx).Create(ctx, &ProjectFollower{From: user.ID(), To: otherProj.ID()}); err != nil {
+				return err
+			}
+			return publish(ctx, Event{Type: EventFollow, From: user.ID(), To: otherProj.NumericOwner(ctx), Board: otherProj.ID(), Project: true})
+		})
 
 	}
 
-	return errors.New("invalid follower type " + reflect.TypeOf(board).String())
+	return invalidArgument("invalid follower type %s", reflect.TypeOf(board))
 }
 
 // Submit submits a Message
-func (user *User) Submit(message Content) error {
-	if err := populateContent(message, user); err != nil {
+func (user *User) Submit(ctx context.Context, message Content) error {
+	if err := populateContent(ctx, message, user); err != nil {
 		return err
 	}
 
-	return db().Create(message.(igor.DBModel))
+	// the message is classified, and it's published with its mentions as soon as they're stored
+	return Transaction(ctx, func(ctx context.Context) error {
+		if err := storage(ctx).Create(ctx, message.(igor.DBModel)); err != nil {
+			return err
+		}
+		if err := classify(ctx, message, false); err != nil {
+			return err
+		}
+		if err := publish(ctx, contentEvent(message)); err != nil {
+			return err
+		}
+		return user.mention(ctx, message, false)
+	})
 }
 
 // WhitelistUser add other user to the user whitelist
-func (user *User) WhitelistUser(other *User) error {
+func (user *User) WhitelistUser(ctx context.Context, other *User) error {
 	if other == nil {
-		return errors.New("Other user should be a vaid user")
+		return invalidArgument("Other user should be a vaid user")
 	}
 
-	return db().Create(&Whitelist{From: user.ID(), To: other.ID()})
+	return storage(ctx).Create(ctx, &Whitelist{From: user.ID(), To: other.ID()})
 }
 
 // UnwhitelistUser removes other user to the user whitelist
-func (user *User) UnwhitelistUser(other *User) error {
+func (user *User) UnwhitelistUser(ctx context.Context, other *User) error {
 	if other == nil {
-		return errors.New("Other user should be a vaid user")
+		return invalidArgument("Other user should be a vaid user")
 	}
 
-	return db().Where(&Whitelist{From: user.ID(), To: other.ID()}).Delete(Whitelist{})
+	return storage(ctx).Delete(ctx, &Whitelist{From: user.ID(), To: other.ID()})
 }
 
 // BlacklistUser add other user to the user blacklist
-func (user *User) BlacklistUser(other *User, motivation string) error {
+func (user *User) BlacklistUser(ctx context.Context, other *User, motivation string) error {
 	if other == nil {
-		return errors.New("Other user should be a vaid user")
+		return invalidArgument("Other user should be a vaid user")
 	}
-	return db().Create(&Blacklist{From: user.ID(), To: other.ID(), Motivation: motivation})
+	return storage(ctx).Create(ctx, &Blacklist{From: user.ID(), To: other.ID(), Motivation: motivation})
 }
 
 // UnblacklistUser removes other user to the user blacklist
-func (user *User) UnblacklistUser(other *User) error {
+func (user *User) UnblacklistUser(ctx context.Context, other *User) error {
 	if other == nil {
-		return errors.New("Other user should be a vaid user")
+		return invalidArgument("Other user should be a vaid user")
 	}
-	return db().Where(&Blacklist{From: user.ID(), To: other.ID()}).Delete(Blacklist{})
+	return storage(ctx).Delete(ctx, &Blacklist{From: user.ID(), To: other.ID()})
 }
 
 // Unfollow delete a "follow" relationship between the current user
 // and another NERDZ board. The board could represent a NERDZ's project
 // or another NERDZ's user.
-func (user *User) Unfollow(board Board) error {
+func (user *User) Unfollow(ctx context.Context, board Board) error {
 	if board == nil {
-		return errors.New("unable to unfollow an undefined board")
+		return invalidArgument("unable to unfollow an undefined board")
 	}
 
 	switch board.(type) {
 	case *User:
 		otherUser := board.(*User)
-		return db().Where(&UserFollower{From: user.ID(), To: otherUser.ID()}).Delete(UserFollower{})
+		return storage(ctx).Delete(ctx, &UserFollower{From: user.ID(), To: otherUser.ID()})
 
 	case *Project:
 		otherProj := board.(*Project)
-		return db().Where(&ProjectFollower{From: user.ID(), To: otherProj.ID()}).Delete(ProjectFollower{})
+		return storage(ctx).Delete(ctx, &ProjectFollower{From: user.ID(), To: otherProj.ID()})
 
 	}
 
-	return errors.New("invalid follower type " + reflect.TypeOf(board).String())
+	return invalidArgument("invalid follower type %s", reflect.TypeOf(board))
 }
 
 // Bookmark bookmarks the specified post by a specific user. An error is returned if the
 // post isn't defined or if there are other errors returned by the
 // DBMS
-func (user *User) Bookmark(post ExistingPost) (Bookmark, error) {
+func (user *User) Bookmark(ctx context.Context, post ExistingPost) (Bookmark, error) {
 	if post == nil {
-		return nil, errors.New("unable to bookmark undefined post")
+		return nil, invalidArgument("unable to bookmark undefined post")
 	}
 
 	switch post.(type) {
 	case *UserPost:
 		userPost := post.(*UserPost)
 		bookmark := UserPostBookmark{From: user.ID(), Hpid: userPost.ID()}
-		err := db().Create(&bookmark)
+		err := storage(ctx).Create(ctx, &bookmark)
 		return &bookmark, err
 
 	case *ProjectPost:
 		projectPost := post.(*ProjectPost)
 		bookmark := ProjectPostBookmark{From: user.ID(), Hpid: projectPost.ID()}
-		err := db().Create(&bookmark)
+		err := storage(ctx).Create(ctx, &bookmark)
 		return &bookmark, err
 	}
 
-	return nil, errors.New("invalid post type " + reflect.TypeOf(post).String())
+	return nil, invalidArgument("invalid post type %s", reflect.TypeOf(post))
 }
 
 // Unbookmark the specified post by a specific user. An error is returned if the
 // post isn't defined or if there are other errors returned by the DBMS
-func (user *User) Unbookmark(post ExistingPost) error {
+func (user *User) Unbookmark(ctx context.Context, post ExistingPost) error {
 	if post == nil {
-		return errors.New("unable to unbookmark undefined post")
+		return invalidArgument("unable to unbookmark undefined post")
 	}
 
 	switch post.(type) {
 	case *UserPost:
 		userPost := post.(*UserPost)
-		return db().Where(&UserPostBookmark{From: user.ID(), Hpid: userPost.ID()}).Delete(UserPostBookmark{})
+		return storage(ctx).Delete(ctx, &UserPostBookmark{From: user.ID(), Hpid: userPost.ID()})
 
 	case *ProjectPost:
 		projectPost := post.(*ProjectPost)
-		return db().Where(&ProjectPostBookmark{From: user.ID(), Hpid: projectPost.ID()}).Delete(ProjectPostBookmark{})
+		return storage(ctx).Delete(ctx, &ProjectPostBookmark{From: user.ID(), Hpid: projectPost.ID()})
 	}
 
-	return errors.New("invalid post type " + reflect.TypeOf(post).String())
+	return invalidArgument("invalid post type %s", reflect.TypeOf(post))
 }
 
 // Lurk lurkes the specified post by a specific user. An error is returned if the
 // post isn't defined or if there are other errors returned by the
 // DBMS
-func (user *User) Lurk(post ExistingPost) (Lurk, error) {
+func (user *User) Lurk(ctx context.Context, post ExistingPost) (Lurk, error) {
 	if post == nil {
-		return nil, errors.New("unable to lurk undefined post")
+		return nil, invalidArgument("unable to lurk undefined post")
 	}
 
 	switch post.(type) {
 	case *UserPost:
 		userPost := post.(*UserPost)
 		lurk := UserPostLurk{From: user.ID(), Hpid: userPost.ID()}
-		err := db().Create(&lurk)
+		err := storage(ctx).Create(ctx, &lurk)
 		return &lurk, err
 
 	case *ProjectPost:
 		projectPost := post.(*ProjectPost)
 		lurk := ProjectPostLurk{From: user.ID(), Hpid: projectPost.ID()}
-		err := db().Create(&lurk)
+		err := storage(ctx).Create(ctx, &lurk)
 		return &lurk, err
 	}
 
-	return nil, errors.New("invalid post type " + reflect.TypeOf(post).String())
+	return nil, invalidArgument("invalid post type %s", reflect.TypeOf(post))
 }
 
 // Unlurk the specified post by a specific user. An error is returned if the
 // post isn't defined or if there are other errors returned by the DBMS
-func (user *User) Unlurk(post ExistingPost) error {
+func (user *User) Unlurk(ctx context.Context, post ExistingPost) error {
 	if post == nil {
-		return errors.New("unable to unlurk undefined post")
+		return invalidArgument("unable to unlurk undefined post")
 	}
 
 	switch post.(type) {
 	case *UserPost:
 		userPost := post.(*UserPost)
-		return db().Where(&UserPostLurk{From: user.ID(), Hpid: userPost.ID()}).Delete(UserPostLurk{})
+		return storage(ctx).Delete(ctx, &UserPostLurk{From: user.ID(), Hpid: userPost.ID()})
 
 	case *ProjectPost:
 		projectPost := post.(*ProjectPost)
-		return db().Where(&ProjectPostLurk{From: user.ID(), Hpid: projectPost.ID()}).Delete(ProjectPostLurk{})
+		return storage(ctx).Delete(ctx, &ProjectPostLurk{From: user.ID(), Hpid: projectPost.ID()})
 	}
 
-	return errors.New("invalid post type " + reflect.TypeOf(post).String())
+	return invalidArgument("invalid post type %s", reflect.TypeOf(post))
 }
 
 // LockPost lockes the specified post. If users are present, indiidual notifications
 // are disabled from the user presents in the users list.
-func (user *User) LockPost(post ExistingPost, users ...*User) (*[]Lock, error) {
+func (user *User) LockPost(ctx context.Context, post ExistingPost, users ...*User) (*[]Lock, error) {
 	if post == nil {
-		return nil, errors.New("unable to lurk undefined post")
+		return nil, invalidArgument("unable to lurk undefined post")
 	}
 
 	switch post.(type) {
@@ -247,16 +277,22 @@ func (user *User) LockPost(post ExistingPost, users ...*User) (*[]Lock, error) {
 		userPost := post.(*UserPost)
 		if len(users) == 0 {
 			lock := UserPostLock{User: user.ID(), Hpid: userPost.ID()}
-			err := db().Create(&lock)
+			err := storage(ctx).Create(ctx, &lock)
 			return &[]Lock{&lock}, err
 		}
 		var locks []Lock
-		for _, other := range users {
-			lock := UserPostUserLock{From: user.ID(), To: other.ID(), Hpid: userPost.ID()}
-			if err := db().Create(&lock); err != nil {
-				return nil, err
+		err := Transaction(ctx, func(ctx context.Context) error {
+			for _, other := range users {
+				lock := UserPostUserLock{From: user.ID(), To: other.ID(), Hpid: userPost.ID()}
+				if err := storage(ctx).Create(ctx, &lock); err != nil {
+					return err
+				}
+				locks = append(locks, Lock(&lock))
 			}
-			locks = append(locks, Lock(&lock))
+			return nil
+		})
+		if err != nil {
+			return nil, err
 		}
 		return &locks, nil
 
@@ -265,77 +301,87 @@ func (user *User) LockPost(post ExistingPost, users ...*User) (*[]Lock, error) {
 		if len(users) == 0 {
 			projectPost := post.(*ProjectPost)
 			lock := ProjectPostLock{User: user.ID(), Hpid: projectPost.ID()}
-			err := db().Create(&lock)
+			err := storage(ctx).Create(ctx, &lock)
 			return &[]Lock{&lock}, err
 		}
 		var locks []Lock
-		for _, other := range users {
-			lock := ProjectPostUserLock{From: user.ID(), To: other.ID(), Hpid: projectPost.ID()}
-			if err := db().Create(&lock); err != nil {
-				return nil, err
+		err := Transaction(ctx, func(ctx context.Context) error {
+			for _, other := range users {
+				lock := ProjectPostUserLock{From: user.ID(), To: other.ID(), Hpid: projectPost.ID()}
+				if err := storage(ctx).Create(ctx, &lock); err != nil {
+					return err
+				}
+				locks = append(locks, Lock(&lock))
 			}
-			locks = append(locks, Lock(&lock))
+			return nil
+		})
+		if err != nil {
+			return nil, err
 		}
 		return &locks, nil
 	}
 
-	return nil, errors.New("invalid post type " + reflect.TypeOf(post).String())
+	return nil, invalidArgument("invalid post type %s", reflect.TypeOf(post))
 }
 
 // Unlock the specified post by a specific user. An error is returned if the
 // post isn't defined or if there are other errors returned by the DBMS
-func (user *User) Unlock(post ExistingPost, users ...*User) error {
+func (user *User) Unlock(ctx context.Context, post ExistingPost, users ...*User) error {
 	if post == nil {
-		return errors.New("unable to unlock undefined post")
+		return invalidArgument("unable to unlock undefined post")
 	}
 
 	switch post.(type) {
 	case *UserPost:
 		userPost := post.(*UserPost)
 		if len(users) == 0 {
-			return db().Where(&UserPostLock{User: user.ID(), Hpid: userPost.ID()}).Delete(UserPostLock{})
+			return storage(ctx).Delete(ctx, &UserPostLock{User: user.ID(), Hpid: userPost.ID()})
 		}
-		for _, other := range users {
-			err := db().Where(&UserPostUserLock{From: user.ID(), To: other.ID(), Hpid: userPost.ID()}).Delete(UserPostUserLock{})
-			if err != nil {
-				return err
+		return Transaction(ctx, func(ctx context.Context) error {
+			for _, other := range users {
+				err := storage(ctx).Delete(ctx, &UserPostUserLock{From: user.ID(), To: other.ID(), Hpid: userPost.ID()})
+				if err != nil {
+					return err
+				}
 			}
-		}
-		return nil
+			return nil
+		})
 
 	case *ProjectPost:
 		projectPost := post.(*ProjectPost)
 		if len(users) == 0 {
-			return db().Where(&ProjectPostLock{User: user.ID(), Hpid: projectPost.ID()}).Delete(ProjectPostLock{})
+			return storage(ctx).Delete(ctx, &ProjectPostLock{User: user.ID(), Hpid: projectPost.ID()})
 		}
-		for _, other := range users {
-			err := db().Where(&ProjectPostUserLock{From: user.ID(), To: other.ID(), Hpid: projectPost.ID()}).Delete(ProjectPostUserLock{})
-			if err != nil {
-				return err
+		return Transaction(ctx, func(ctx context.Context) error {
+			for _, other := range users {
+				err := storage(ctx).Delete(ctx, &ProjectPostUserLock{From: user.ID(), To: other.ID(), Hpid: projectPost.ID()})
+				if err != nil {
+					return err
+				}
 			}
-		}
-		return nil
+			return nil
+		})
 	}
 
-	return errors.New("invalid post type " + reflect.TypeOf(post).String())
+	return invalidArgument("invalid post type %s", reflect.TypeOf(post))
 }
 
 // AddInterest adds the specified interest. An error is returned if the
 // interests already exists or some DBMS contraint is violated
-func (user *User) AddInterest(interest *Interest) error {
+func (user *User) AddInterest(ctx context.Context, interest *Interest) error {
 	interest.From = user.ID()
 	if interest.Value == "" {
-		return errors.New("invalid interest value: (empty)")
+		return invalidArgument("invalid interest value: (empty)")
 	}
-	return db().Create(interest)
+	return storage(ctx).Create(ctx, interest)
 }
 
 // DeleteInterest removes the specified interest (by its ID or its Value).
-func (user *User) DeleteInterest(interest *Interest) error {
+func (user *User) DeleteInterest(ctx context.Context, interest *Interest) error {
 	var toDelete Interest
 	if interest.ID <= 0 {
 		if interest.Value == "" {
-			return errors.New("invalid interest ID and empty interest")
+			return invalidArgument("invalid interest ID and empty interest")
 		}
 		toDelete.Value = interest.Value
 	} else {
@@ -343,17 +389,17 @@ func (user *User) DeleteInterest(interest *Interest) error {
 	}
 
 	if interest.From != user.ID() {
-		return errors.New("you can't remove other user interests")
+		return permissionDenied("you can't remove other user interests")
 	}
 
 	toDelete.From = interest.From
 
-	return db().Where(&toDelete).Delete(Interest{})
+	return storage(ctx).Delete(ctx, &toDelete)
 }
 
 // Friends returns the current user's friends
-func (user *User) Friends() []*User {
-	return Users(user.NumericFriends())
+func (user *User) Friends(ctx context.Context) ([]*User, error) {
+	return Users(ctx, user.NumericFriends(ctx))
 }
 
 // Implements Reference interface
@@ -371,35 +417,36 @@ func (user *User) Language() string {
 // Can* methods
 
 // CanEdit returns true if user can edit the Message
-func (user *User) CanEdit(message Content) bool {
-	return message.ID() > 0 && message.IsEditable() && utils.InSlice(user.ID(), message.NumericOwners())
+func (user *User) CanEdit(ctx context.Context, message Content) bool {
+	return message.ID() > 0 && message.IsEditable() && utils.InSlice(user.ID(), message.NumericOwners(ctx))
 }
 
 // CanDelete returns true if user can delete the Message
-func (user *User) CanDelete(message Content) bool {
-	return message.ID() > 0 && utils.InSlice(user.ID(), message.NumericOwners())
+func (user *User) CanDelete(ctx context.Context, message Content) bool {
+	return message.ID() > 0 && utils.InSlice(user.ID(), message.NumericOwners(ctx))
 }
 
 // CanBookmark returns true if user haven't bookamrked to existingPost yet
-func (user *User) CanBookmark(message ExistingPost) bool {
-	return message.ID() > 0 && !utils.InSlice(user.ID(), message.NumericBookmarkers())
+func (user *User) CanBookmark(ctx context.Context, message ExistingPost) bool {
+	return message.ID() > 0 && !utils.InSlice(user.ID(), message.NumericBookmarkers(ctx))
 }
 
 // CanLurk returns true if the user haven't lurked the existingPost yet
-func (user *User) CanLurk(message ExistingPost) bool {
-	return message.ID() > 0 && !utils.InSlice(user.ID(), message.NumericLurkers())
+func (user *User) CanLurk(ctx context.Context, message ExistingPost) bool {
+	return message.ID() > 0 && !utils.InSlice(user.ID(), message.NumericLurkers(ctx))
 }
 
 // CanComment returns true if the user can comment to the existingPost
-func (user *User) CanComment(message ExistingPost) bool {
-	return !utils.InSlice(user.ID(), message.Sender().NumericBlacklist()) && message.ID() > 0 && !message.IsClosed()
+func (user *User) CanComment(ctx context.Context, message ExistingPost) bool {
+	sender := message.Sender(ctx)
+	return sender != nil && !utils.InSlice(user.ID(), sender.NumericBlacklist(ctx)) && message.ID() > 0 && !message.IsClosed()
 }
 
 // CanSee returns true if the user can see the Board content
-func (user *User) CanSee(board Board) bool {
+func (user *User) CanSee(ctx context.Context, board Board) bool {
 	switch board.(type) {
 	case *User:
-		return !utils.InSlice(user.ID(), board.(*User).NumericBlacklist())
+		return !utils.InSlice(user.ID(), board.(*User).NumericBlacklist(ctx))
 
 	case *Project:
 		project := board.(*Project)
@@ -407,21 +454,61 @@ func (user *User) CanSee(board Board) bool {
 			return true
 		}
 
-		return user.ID() == project.NumericOwner() || utils.InSlice(user.ID(), project.NumericMembers())
+		return user.ID() == project.NumericOwner(ctx) || utils.InSlice(user.ID(), project.NumericMembers(ctx))
 	}
 	return false
 }
 
+// CanRead returns an error if user, nil for the anonymous users, can't read content.
+// Private messages are readable only by the users of the conversation, posts only on the boards that
+// user can see and comments only on the posts that user can read. Like in the lists of posts, the contents
+// sent by the users that user blacklisted are not readable
+func CanRead(ctx context.Context, user *User, content Content) error {
+	if user != nil && utils.InSlice(content.NumericSender(), user.NumericBlacklist(ctx)) {
+		return permissionDenied("the sender of the content is in your blacklist")
+	}
+
+	switch content := content.(type) {
+	case *PM:
+		if user == nil || !utils.InSlice(user.ID(), content.NumericOwners(ctx)) {
+			return notFound("pm %d does not exist", content.ID())
+		}
+	case ExistingComment:
+		post, err := content.Post(ctx)
+		if err != nil {
+			return err
+		}
+		return CanRead(ctx, user, post)
+	case *UserPost:
+		board, err := NewUser(ctx, content.NumericReference())
+		if err != nil {
+			return err
+		}
+		if user != nil && !user.CanSee(ctx, board) {
+			return permissionDenied("you are in the blacklist of user %d", board.ID())
+		}
+	case *ProjectPost:
+		project, err := NewProject(ctx, content.NumericReference())
+		if err != nil {
+			return err
+		}
+		if !project.Visible && (user == nil || !user.CanSee(ctx, project)) {
+			return notFound("project post %d does not exist", content.ID())
+		}
+	}
+	return nil
+}
+
 // populate functions
 
-func populateContent(message Content, user *User) error {
+func populateContent(ctx context.Context, message Content, user *User) error {
 	message.ClearDefaults()
 
 	if post, ok := message.(*UserPost); ok && post.To == 0 {
 		post.To = user.ID()
 	}
 
-	lang, err := sanitiseLanguage(message.Language(), message.Sender().Language())
+	lang, err := sanitiseLanguage(message.Language(), user.Language())
 	if err != nil {
 		return err
 	}
@@ -18,25 +18,31 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package db
 
 import (
-	"fmt"
+	"context"
 	"time"
 
 	"github.com/nerdzeu/nerdz-core/utils"
 )
 
 // NewUserPost returns the *UserPost with id hpid if exists. Returns error otherwise
-func NewUserPost(hpid uint64) (*UserPost, error) {
-	return NewUserPostWhere(&UserPost{Post{Hpid: hpid}})
+func NewUserPost(ctx context.Context, hpid uint64) (*UserPost, error) {
+	if hpid == 0 {
+		return nil, notFound("Requested UserPost does not exist")
+	}
+	return NewUserPostWhere(ctx, &UserPost{Post{Hpid: hpid}})
 }
 
 // NewUserPostWhere returns the *UserPost fetching the first one that matches the description
-func NewUserPostWhere(description *UserPost) (post *UserPost, e error) {
+func NewUserPostWhere(ctx context.Context, description *UserPost) (post *UserPost, e error) {
+	if emptyDescription(description) {
+		return nil, invalidArgument("the description of the UserPost is empty")
+	}
 	post = new(UserPost)
-	if e = db().Model(UserPost{}).Where(description).Scan(post); e != nil {
+	if e = storage(ctx).Find(ctx, description, post); e != nil {
 		return nil, e
 	}
 	if post.ID() == 0 {
-		return nil, fmt.Errorf("Requested UserPost does not exist")
+		return nil, notFound("Requested UserPost does not exist")
 	}
 	return
 }
@@ -76,8 +82,8 @@ func (post *UserPost) NumericSender() uint64 {
 }
 
 // Sender returns the sender *User
-func (post *UserPost) Sender() *User {
-	user, _ := NewUser(post.NumericSender())
+func (post *UserPost) Sender(ctx context.Context) *User {
+	user, _ := NewUser(ctx, post.NumericSender())
 	return user
 }
 
@@ -87,8 +93,8 @@ func (post *UserPost) NumericReference() uint64 {
 }
 
 // Reference returns the recipient *User
-func (post *UserPost) Reference() Reference {
-	user, _ := NewUser(post.NumericReference())
+func (post *UserPost) Reference(ctx context.Context) Reference {
+	user, _ := NewUser(ctx, post.NumericReference())
 	return user
 }
 
@@ -108,7 +114,7 @@ func (post *UserPost) IsClosed() bool {
 }
 
 // NumericOwners returns a slice of ids of the owner of the posts (the ones that can perform actions)
-func (post *UserPost) NumericOwners() []uint64 {
+func (post *UserPost) NumericOwners(ctx context.Context) []uint64 {
 	if post.To != post.From {
 		return []uint64{post.To, post.From}
 	}
@@ -116,20 +122,19 @@ func (post *UserPost) NumericOwners() []uint64 {
 }
 
 // Owners returns a slice of *User representing the users who own the post
-func (post *UserPost) Owners() (ret []*User) {
-	return Users(post.NumericOwners())
+func (post *UserPost) Owners(ctx context.Context) ([]*User, error) {
+	return Users(ctx, post.NumericOwners(ctx))
 }
 
 // VotesCount returns the post's votes value
-func (post *UserPost) VotesCount() (sum int) {
-	db().Model(UserPostVote{}).Select("COALESCE(sum(vote), 0)").Where(&UserPostVote{Hpid: post.ID()}).Scan(&sum)
-	return
+func (post *UserPost) VotesCount(ctx context.Context) (sum int) {
+	return votesSum(ctx, &UserPostVote{Hpid: post.ID()})
 }
 
 // Votes returns a pointer to a slice of Vote
-func (post *UserPost) Votes() *[]Vote {
+func (post *UserPost) Votes(ctx context.Context) *[]Vote {
 	ret := []UserPostVote{}
-	db().Model(UserPostVote{}).Where(&UserPostVote{Hpid: post.ID()}).Scan(&ret)
+	storage(ctx).Find(ctx, &UserPostVote{Hpid: post.ID()}, &ret)
 	var retVotes []Vote
 	for _, v := range ret {
 		vote := v
@@ -140,9 +145,9 @@ func (post *UserPost) Votes() *[]Vote {
 }
 
 // Bookmarks returns a pointer to a slice of Bookmark
-func (post *UserPost) Bookmarks() *[]Bookmark {
+func (post *UserPost) Bookmarks(ctx context.Context) *[]Bookmark {
 	ret := []UserPostBookmark{}
-	db().Model(UserPostBookmark{}).Where(&UserPostBookmark{Hpid: post.ID()}).Scan(&ret)
+	storage(ctx).Find(ctx, &UserPostBookmark{Hpid: post.ID()}, &ret)
 	var retBookmarks []Bookmark
 	for _, b := range ret {
 		bookmark := b
@@ -153,9 +158,9 @@ func (post *UserPost) Bookmarks() *[]Bookmark {
 }
 
 // Lurks returns a pointer to a slice of Lurk
-func (post *UserPost) Lurks() *[]Lurk {
+func (post *UserPost) Lurks(ctx context.Context) *[]Lurk {
 	ret := []UserPostLurk{}
-	db().Model(UserPostLurk{}).Where(&UserPostLurk{Hpid: post.ID()}).Scan(&ret)
+	storage(ctx).Find(ctx, &UserPostLurk{Hpid: post.ID()}, &ret)
 	var retLurkers []Lurk
 	for _, l := range ret {
 		lurker := l
@@ -165,10 +170,16 @@ func (post *UserPost) Lurks() *[]Lurk {
 	return &retLurkers
 }
 
+// Mentions returns the mentions in the post and in its comments, selected by options, from the newest to the oldest
+func (post *UserPost) Mentions(ctx context.Context, options MentionsOptions) (*[]Mention, error) {
+	mentions, e := storage(ctx).Mentions(ctx, &Mention{UHpid: post.ID()}, options)
+	return &mentions, e
+}
+
 // Locks returns a pointer to a slice of Lock
-func (post *UserPost) Locks() *[]Lock {
+func (post *UserPost) Locks(ctx context.Context) *[]Lock {
 	ret := []UserPostLock{}
-	db().Model(UserPostLock{}).Where(&UserPostLock{Hpid: post.ID()}).Scan(&ret)
+	storage(ctx).Find(ctx, &UserPostLock{Hpid: post.ID()}, &ret)
 	var retLockers []Lock
 	for _, l := range ret {
 		locker := l
@@ -191,25 +202,20 @@ func (post *UserPost) Language() string {
 }
 
 // Revisions returns all the revisions of the message
-func (post *UserPost) Revisions() (modifications []string) {
-	db().Model(UserPostRevision{}).Where(&UserPostRevision{Hpid: post.ID()}).Pluck("message", &modifications)
+func (post *UserPost) Revisions(ctx context.Context) (modifications []string) {
+	storage(ctx).Pluck(ctx, &UserPostRevision{Hpid: post.ID()}, "message", &modifications)
 	return
 }
 
 // RevisionsNumber returns the number of the revisions
-func (post *UserPost) RevisionsNumber() (count uint8) {
-	db().Model(UserPostRevision{}).Where(&UserPostRevision{Hpid: post.ID()}).Count(&count)
-	return
+func (post *UserPost) RevisionsNumber(ctx context.Context) (count uint8) {
+	return countWhere(ctx, &UserPostRevision{Hpid: post.ID()})
 }
 
 // Comments returns the full comments list, or the selected range of comments
 // Comments(options)  returns the comment list, using selected options
-func (post *UserPost) Comments(options CommentlistOptions) *[]ExistingComment {
-	var comments []UserPostComment
-
-	query := db().Where(&UserPostComment{Hpid: post.ID()})
-	query = commentlistQueryBuilder(query, options)
-	query.Scan(&comments)
+func (post *UserPost) Comments(ctx context.Context, options CommentlistOptions) *[]ExistingComment {
+	comments, _ := storage(ctx).UserPostComments(ctx, post.ID(), options)
 
 	comments = utils.ReverseSlice(comments).([]UserPostComment)
 
@@ -222,9 +228,8 @@ func (post *UserPost) Comments(options CommentlistOptions) *[]ExistingComment {
 }
 
 // CommentsCount returns the number of comment's post
-func (post *UserPost) CommentsCount() (count uint8) {
-	db().Model(UserPostComment{}).Where(&UserPostComment{Hpid: post.ID()}).Count(&count)
-	return
+func (post *UserPost) CommentsCount(ctx context.Context) (count uint8) {
+	return countWhere(ctx, &UserPostComment{Hpid: post.ID()})
 }
 
 // NumericType returns the numeric type of the post
@@ -238,35 +243,33 @@ func (*UserPost) Type() string {
 }
 
 // NumericBookmarkers returns a slice of uint64 representing the ids of the users that bookmarked the post
-func (post *UserPost) NumericBookmarkers() (bookmarkers []uint64) {
-	db().Model(UserPostBookmark{}).Where(&UserPostBookmark{Hpid: post.ID()}).Pluck(`"from"`, &bookmarkers)
+func (post *UserPost) NumericBookmarkers(ctx context.Context) (bookmarkers []uint64) {
+	storage(ctx).Pluck(ctx, &UserPostBookmark{Hpid: post.ID()}, "from", &bookmarkers)
 	return
 }
 
 // Bookmarkers returns a slice of users that bookmarked the post
-func (post *UserPost) Bookmarkers() []*User {
-	return Users(post.NumericBookmarkers())
+func (post *UserPost) Bookmarkers(ctx context.Context) ([]*User, error) {
+	return Users(ctx, post.NumericBookmarkers(ctx))
 }
 
 // BookmarksCount returns the number of users that bookmarked the post
-func (post *UserPost) BookmarksCount() (count uint8) {
-	db().Model(UserPostBookmark{}).Where(&UserPostBookmark{Hpid: post.ID()}).Count(&count)
-	return
+func (post *UserPost) BookmarksCount(ctx context.Context) (count uint8) {
+	return countWhere(ctx, &UserPostBookmark{Hpid: post.ID()})
 }
 
 // NumericLurkers returns a slice of uint64 representing the ids of the users that lurked the post
-func (post *UserPost) NumericLurkers() (lurkers []uint64) {
-	db().Model(UserPostLurk{}).Where(&UserPostLurk{Hpid: post.ID()}).Pluck(`"from"`, &lurkers)
+func (post *UserPost) NumericLurkers(ctx context.Context) (lurkers []uint64) {
+	storage(ctx).Pluck(ctx, &UserPostLurk{Hpid: post.ID()}, "from", &lurkers)
 	return
 }
 
 // Lurkers returns a slice of users that are lurking the post
-func (post *UserPost) Lurkers() []*User {
-	return Users(post.NumericLurkers())
+func (post *UserPost) Lurkers(ctx context.Context) ([]*User, error) {
+	return Users(ctx, post.NumericLurkers(ctx))
 }
 
 // LurkersCount returns the number of users that are lurking the post
-func (post *UserPost) LurkersCount() (count uint8) {
-	db().Model(UserPostLurk{}).Where(&UserPostLurk{Hpid: post.ID()}).Count(&count)
-	return
+func (post *UserPost) LurkersCount(ctx context.Context) (count uint8) {
+	return countWhere(ctx, &UserPostLurk{Hpid: post.ID()})
 }
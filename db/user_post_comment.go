@@ -18,23 +18,29 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package db
 
 import (
-	"fmt"
+	"context"
 	"time"
 )
 
 // NewUserPostComment initializes a UserPostComment struct
-func NewUserPostComment(hcid uint64) (comment *UserPostComment, e error) {
-	return NewUserPostCommentWhere(&UserPostComment{Hcid: hcid})
+func NewUserPostComment(ctx context.Context, hcid uint64) (comment *UserPostComment, e error) {
+	if hcid == 0 {
+		return nil, notFound("Requested UserPostComment does not exist")
+	}
+	return NewUserPostCommentWhere(ctx, &UserPostComment{Hcid: hcid})
 }
 
 // NewUserPostCommentWhere returns the *UserPostComment fetching the first one that matches the description
-func NewUserPostCommentWhere(description *UserPostComment) (comment *UserPostComment, e error) {
+func NewUserPostCommentWhere(ctx context.Context, description *UserPostComment) (comment *UserPostComment, e error) {
+	if emptyDescription(description) {
+		return nil, invalidArgument("the description of the UserPostComment is empty")
+	}
 	comment = new(UserPostComment)
-	if e = db().Model(UserPostComment{}).Where(description).Scan(comment); e != nil {
+	if e = storage(ctx).Find(ctx, description, comment); e != nil {
 		return nil, e
 	}
 	if comment.Hcid == 0 {
-		return nil, fmt.Errorf("Requested UserPostComment does not exist")
+		return nil, notFound("Requested UserPostComment does not exist")
 	}
 	return
 }
@@ -47,8 +53,8 @@ func (comment *UserPostComment) NumericSender() uint64 {
 }
 
 // Sender returns the sender *User
-func (comment *UserPostComment) Sender() *User {
-	user, _ := NewUser(comment.NumericSender())
+func (comment *UserPostComment) Sender(ctx context.Context) *User {
+	user, _ := NewUser(ctx, comment.NumericSender())
 	return user
 }
 
@@ -58,21 +64,20 @@ func (comment *UserPostComment) NumericReference() uint64 {
 }
 
 // Reference returns the recipient *Post
-func (comment *UserPostComment) Reference() Reference {
-	post, _ := NewUserPost(comment.NumericReference())
+func (comment *UserPostComment) Reference(ctx context.Context) Reference {
+	post, _ := NewUserPost(ctx, comment.NumericReference())
 	return post
 }
 
 // Votes returns the post's votes value
-func (comment *UserPostComment) VotesCount() (sum int) {
-	db().Model(UserPostCommentVote{}).Select("COALESCE(sum(vote), 0)").Where(&UserPostCommentVote{Hcid: comment.Hcid}).Scan(&sum)
-	return
+func (comment *UserPostComment) VotesCount(ctx context.Context) (sum int) {
+	return votesSum(ctx, &UserPostCommentVote{Hcid: comment.Hcid})
 }
 
 // Votes returns a pointer to a slice of Vote
-func (comment *UserPostComment) Votes() *[]Vote {
+func (comment *UserPostComment) Votes(ctx context.Context) *[]Vote {
 	ret := []UserPostCommentVote{}
-	db().Model(UserPostCommentVote{}).Where(&UserPostCommentVote{Hcid: comment.Hcid}).Scan(&ret)
+	storage(ctx).Find(ctx, &UserPostCommentVote{Hcid: comment.Hcid}, &ret)
 	var retVotes []Vote
 	for _, v := range ret {
 		vote := v
@@ -83,10 +88,10 @@ func (comment *UserPostComment) Votes() *[]Vote {
 }
 
 // Post returns the ExistingPost struct to which the comment is related
-func (comment *UserPostComment) Post() (ExistingPost, error) {
+func (comment *UserPostComment) Post(ctx context.Context) (ExistingPost, error) {
 	var post *UserPost
 	var err error
-	if post, err = NewUserPost(comment.Hpid); err != nil {
+	if post, err = NewUserPost(ctx, comment.Hpid); err != nil {
 		return nil, err
 	}
 	return ExistingPost(post), nil
@@ -142,23 +147,22 @@ func (comment *UserPostComment) IsEditable() bool {
 }
 
 // NumericOwners returns a slice of ids of the owner of the comment (the ones that can perform actions)
-func (comment *UserPostComment) NumericOwners() []uint64 {
+func (comment *UserPostComment) NumericOwners(ctx context.Context) []uint64 {
 	return []uint64{comment.From, comment.To}
 }
 
 // Owners returns a slice of *User representing the users who own the comment
-func (comment *UserPostComment) Owners() []*User {
-	return Users(comment.NumericOwners())
+func (comment *UserPostComment) Owners(ctx context.Context) ([]*User, error) {
+	return Users(ctx, comment.NumericOwners(ctx))
 }
 
 // Revisions returns all the revisions of the message
-func (comment *UserPostComment) Revisions() (modifications []string) {
-	db().Model(UserPostCommentRevision{}).Where(&UserPostCommentRevision{Hcid: comment.Hcid}).Pluck("message", &modifications)
+func (comment *UserPostComment) Revisions(ctx context.Context) (modifications []string) {
+	storage(ctx).Pluck(ctx, &UserPostCommentRevision{Hcid: comment.Hcid}, "message", &modifications)
 	return
 }
 
 // RevisionsNumber returns the number of the revisions
-func (comment *UserPostComment) RevisionsNumber() (count uint8) {
-	db().Model(UserPostCommentRevision{}).Where(&UserPostCommentRevision{Hcid: comment.Hcid}).Count(&count)
-	return
+func (comment *UserPostComment) RevisionsNumber(ctx context.Context) (count uint8) {
+	return countWhere(ctx, &UserPostCommentRevision{Hcid: comment.Hcid})
 }
@@ -17,6 +17,8 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 
 package db
 
+import "context"
+
 // Implementing Vote interface
 
 // Value returns the vote's value
@@ -25,8 +27,8 @@ func (vote *UserPostCommentVote) Value() int8 {
 }
 
 // Sender returns the User that casted the vote
-func (vote *UserPostCommentVote) Sender() (user *User) {
-	user, _ = NewUser(vote.From)
+func (vote *UserPostCommentVote) Sender(ctx context.Context) (user *User) {
+	user, _ = NewUser(ctx, vote.From)
 	return
 }
 
@@ -36,8 +38,8 @@ func (vote *UserPostCommentVote) NumericSender() uint64 {
 }
 
 // Reference returns the reference of the vote
-func (vote *UserPostCommentVote) Reference() Reference {
-	post, _ := NewUserPostComment(vote.Hcid)
+func (vote *UserPostCommentVote) Reference(ctx context.Context) Reference {
+	post, _ := NewUserPostComment(ctx, vote.Hcid)
 	return post
 }
 
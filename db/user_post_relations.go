@@ -17,6 +17,8 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 
 package db
 
+import "context"
+
 // UserPostVote: implementing Vote interface
 
 // Value returns the vote's value
@@ -25,8 +27,8 @@ func (vote *UserPostVote) Value() int8 {
 }
 
 // Sender returns the User that casted the vote
-func (vote *UserPostVote) Sender() (user *User) {
-	user, _ = NewUser(vote.From)
+func (vote *UserPostVote) Sender(ctx context.Context) (user *User) {
+	user, _ = NewUser(ctx, vote.From)
 	return
 }
 
@@ -36,8 +38,8 @@ func (vote *UserPostVote) NumericSender() uint64 {
 }
 
 // Reference returns the reference of the vote
-func (vote *UserPostVote) Reference() Reference {
-	post, _ := NewUserPost(vote.Hpid)
+func (vote *UserPostVote) Reference(ctx context.Context) Reference {
+	post, _ := NewUserPost(ctx, vote.Hpid)
 	return post
 }
 
@@ -49,8 +51,8 @@ func (vote *UserPostVote) NumericReference() uint64 {
 // UserPostBookmark: implementing Bookmark interface
 
 // Sender returns the User that casted the bookmark
-func (bookmark *UserPostBookmark) Sender() (user *User) {
-	user, _ = NewUser(bookmark.From)
+func (bookmark *UserPostBookmark) Sender(ctx context.Context) (user *User) {
+	user, _ = NewUser(ctx, bookmark.From)
 	return
 }
 
@@ -60,8 +62,8 @@ func (bookmark *UserPostBookmark) NumericSender() uint64 {
 }
 
 // Reference returns the reference of the bookmark
-func (bookmark *UserPostBookmark) Reference() Reference {
-	post, _ := NewUserPost(bookmark.Hpid)
+func (bookmark *UserPostBookmark) Reference(ctx context.Context) Reference {
+	post, _ := NewUserPost(ctx, bookmark.Hpid)
 	return post
 }
 
@@ -73,8 +75,8 @@ func (bookmark *UserPostBookmark) NumericReference() uint64 {
 // UserPostLurk: implementing Lurk interface
 
 // Sender returns the User that casted the lurk
-func (lurk *UserPostLurk) Sender() (user *User) {
-	user, _ = NewUser(lurk.From)
+func (lurk *UserPostLurk) Sender(ctx context.Context) (user *User) {
+	user, _ = NewUser(ctx, lurk.From)
 	return
 }
 
@@ -84,8 +86,8 @@ func (lurk *UserPostLurk) NumericSender() uint64 {
 }
 
 // Reference returns the reference of the lurk
-func (lurk *UserPostLurk) Reference() Reference {
-	post, _ := NewUserPost(lurk.Hpid)
+func (lurk *UserPostLurk) Reference(ctx context.Context) Reference {
+	post, _ := NewUserPost(ctx, lurk.Hpid)
 	return post
 }
 
@@ -97,8 +99,8 @@ func (lurk *UserPostLurk) NumericReference() uint64 {
 // UserPostLock: implementing Lock interface
 
 // Sender returns the User that casted the lock
-func (lock *UserPostLock) Sender() (user *User) {
-	user, _ = NewUser(lock.User)
+func (lock *UserPostLock) Sender(ctx context.Context) (user *User) {
+	user, _ = NewUser(ctx, lock.User)
 	return
 }
 
@@ -108,8 +110,8 @@ func (lock *UserPostLock) NumericSender() uint64 {
 }
 
 // Reference returns the reference of the lurk
-func (lock *UserPostLock) Reference() Reference {
-	post, _ := NewUserPost(lock.Hpid)
+func (lock *UserPostLock) Reference(ctx context.Context) Reference {
+	post, _ := NewUserPost(ctx, lock.Hpid)
 	return post
 }
 
@@ -121,8 +123,8 @@ func (lock *UserPostLock) NumericReference() uint64 {
 // UserPostUserLock: implementing Lock interface
 
 // Sender returns the User that casted the lock
-func (lock *UserPostUserLock) Sender() (user *User) {
-	user, _ = NewUser(lock.From)
+func (lock *UserPostUserLock) Sender(ctx context.Context) (user *User) {
+	user, _ = NewUser(ctx, lock.From)
 	return
 }
 
@@ -132,8 +134,8 @@ func (lock *UserPostUserLock) NumericSender() uint64 {
 }
 
 // Reference returns the reference of the lurk
-func (lock *UserPostUserLock) Reference() Reference {
-	post, _ := NewUserPost(lock.Hpid)
+func (lock *UserPostUserLock) Reference(ctx context.Context) Reference {
+	post, _ := NewUserPost(ctx, lock.Hpid)
 	return post
 }
 
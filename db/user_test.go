@@ -1,3 +1,6 @@
+//go:build postgres
+// +build postgres
+
 /*
 Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
 
@@ -18,6 +21,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package db_test
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"testing"
@@ -28,40 +32,46 @@ import (
 
 var me, other, blacklisted, withClosedProfile *db.User
 
-func init() {
-	err := db.Init()
+// ctx is the context of every database call of the tests: it carries the Store
+// opened with the configuration loaded in viper
+var ctx = func() context.Context {
+	store, err := db.Open(db.ConfigFromViper())
 	if err != nil {
 		panic(err)
 	}
+	return store.Context(context.Background())
+}()
 
-	me, err = db.NewUser(1)
+func init() {
+	var err error
+	me, err = db.NewUser(ctx, 1)
 	if err != nil {
 		panic(fmt.Sprintf("No error should happen when create existing user, but got: %+v", err))
 	}
 
-	other, err = db.NewUser(2)
+	other, err = db.NewUser(ctx, 2)
 	if err != nil {
 		panic(fmt.Sprintf("No error should happen when create existing user, but got: %+v", err))
 	}
 
-	blacklisted, _ = db.NewUser(5)
-	withClosedProfile, _ = db.NewUser(7)
+	blacklisted, _ = db.NewUser(ctx, 5)
+	withClosedProfile, _ = db.NewUser(ctx, 7)
 }
 
 func TestLogin(t *testing.T) {
-	if _, e := db.Login("1", "adminadmin"); e != nil {
+	if _, e := db.Login(ctx, "1", "adminadmin"); e != nil {
 		t.Fatalf("Login using ID and password shold work but got: %s", e.Error())
 	}
 
-	if _, e := db.Login("admin@admin.net", "adminadmin"); e != nil {
+	if _, e := db.Login(ctx, "admin@admin.net", "adminadmin"); e != nil {
 		t.Fatalf("Login using email and password shold work but got: %s", e.Error())
 	}
 
-	if _, e := db.Login("admin", "adminadmin"); e != nil {
+	if _, e := db.Login(ctx, "admin", "adminadmin"); e != nil {
 		t.Fatalf("Login using username and password shold work but got: %s", e.Error())
 	}
 
-	if _, e := db.Login("BANANA", "adminadmin"); e == nil {
+	if _, e := db.Login(ctx, "BANANA", "adminadmin"); e == nil {
 		t.Fatalf("Login using a wrong username and passowrd shold fail. But it worked")
 	}
 }
@@ -74,7 +84,7 @@ func TestContactInfo(t *testing.T) {
 }
 
 func TestPersonalInfo(t *testing.T) {
-	info := me.PersonalInfo()
+	info := me.PersonalInfo(ctx)
 	if info == nil {
 		t.Error("null info")
 	}
@@ -91,9 +101,9 @@ func TestPersonalInfo(t *testing.T) {
 }
 
 func TestBoardInfo(t *testing.T) {
-	info := me.BoardInfo()
-	if info == nil {
-		t.Error("null info")
+	info, err := me.BoardInfo(ctx)
+	if err != nil {
+		t.Fatalf("No error should happen when loading the board info, but got: %s", err)
 	}
 
 	// If whitelist is not empty, the output will be huge (if tested with -v flag)
@@ -101,15 +111,15 @@ func TestBoardInfo(t *testing.T) {
 }
 
 func TestBlackList(t *testing.T) {
-	bl := me.Blacklist()
-	if len(bl) != 1 {
+	bl, err := me.Blacklist(ctx)
+	if err != nil || len(bl) != 1 {
 		t.Fatalf("Expected 1 user in blacklist, but got: %v\n", len(bl))
 	}
 }
 
 func TestHome(t *testing.T) {
 	// At most the last 10 posts from italian users
-	userHome := me.UserHome(db.PostlistOptions{Following: false, Language: "it", N: 10})
+	userHome := me.UserHome(ctx, db.PostlistOptions{Following: false, Language: "it", N: 10})
 	if len(*userHome) != 10 {
 		t.Fatalf("Expected 10 posts, but got: %+v\n", len(*userHome))
 	}
@@ -117,7 +127,7 @@ func TestHome(t *testing.T) {
 	t.Logf("%+v\n", *userHome)
 
 	// At most the last 10 project posts from italian users
-	projectHome := me.ProjectHome(db.PostlistOptions{Following: false, Language: "it", N: 10})
+	projectHome := me.ProjectHome(ctx, db.PostlistOptions{Following: false, Language: "it", N: 10})
 	if len(*projectHome) != 10 {
 		t.Fatalf("Expected 10 posts, but got: %+v\n", len(*projectHome))
 	}
@@ -125,13 +135,13 @@ func TestHome(t *testing.T) {
 	t.Logf("%+v\n", *projectHome)
 
 	// At most the last 10 posts from German users
-	userHome = me.UserHome(db.PostlistOptions{Following: false, Language: "de", N: 10})
+	userHome = me.UserHome(ctx, db.PostlistOptions{Following: false, Language: "de", N: 10})
 	if len(*userHome) != 0 {
 		t.Fatalf("Expected 0 posts, but got: %+v\n", len(*userHome))
 	}
 
 	// At most the last 10 posts to English users from users that "user" is following
-	userHome = me.UserHome(db.PostlistOptions{Following: true, Language: "en", N: 10})
+	userHome = me.UserHome(ctx, db.PostlistOptions{Following: true, Language: "en", N: 10})
 
 	if len(*userHome) == 0 {
 		t.Error("Expected at least 1 post from an english user the 'user' is following. But 0 found")
@@ -140,7 +150,7 @@ func TestHome(t *testing.T) {
 	t.Logf("%+v\n", *userHome)
 
 	// The single post older (created before) the one with hpid 1000, from some user that 'user' follow and to an english speaking one
-	userHome = me.UserHome(db.PostlistOptions{Following: true, Language: "en", N: 1, Older: 1000})
+	userHome = me.UserHome(ctx, db.PostlistOptions{Following: true, Language: "en", N: 1, Older: &db.Cursor{Table: db.UserPost{}.TableName(), ID: 1000}})
 
 	if len(*userHome) != 1 {
 		t.Fatalf("Expeted 1 post, but got: %d", len(*userHome))
@@ -153,7 +163,7 @@ func TestHome(t *testing.T) {
 	}
 
 	// At most 2 posts in the Homepage formed by my posts and my friends posts
-	userHome = me.UserHome(db.PostlistOptions{Following: true, Followers: true, N: 2})
+	userHome = me.UserHome(ctx, db.PostlistOptions{Following: true, Followers: true, N: 2})
 
 	if len(*userHome) != 2 {
 		t.Fatalf("Expeted 2 posts, but got: %d", len(*userHome))
@@ -164,10 +174,10 @@ func TestHome(t *testing.T) {
 	lastFriendPost := (*userHome)[0]
 
 	// Get the (at max 20, in this case only 1) newer posts than the one with the "Newer" from friends
-	userHome = me.UserHome(db.PostlistOptions{
+	userHome = me.UserHome(ctx, db.PostlistOptions{
 		Following: true,
 		Followers: true,
-		Newer:     (*userHome)[1].Hpid})
+		Newer:     db.NewCursor(&(*userHome)[1])})
 
 	if len(*userHome) > 1 || (*userHome)[0].Hpid != lastFriendPost.Hpid {
 		t.Fatalf("Expected 1 post with hpid %d, but got %d posts and the first post has hpid = %d", lastFriendPost.Hpid, len(*userHome), (*userHome)[0].Hpid)
@@ -175,24 +185,24 @@ func TestHome(t *testing.T) {
 }
 
 func TestUserPostlist(t *testing.T) {
-	postList := me.Postlist(db.PostlistOptions{})
+	postList := me.Postlist(ctx, db.PostlistOptions{})
 	if len(*postList) != 20 {
 		t.Fatalf("Expected 20  posts, but got: %+v\n", len(*postList))
 	}
 
 	// Older than 1 (all) and newer than 8000 (no one) -> empty
-	postList = me.Postlist(db.PostlistOptions{
-		Older: 1,
-		Newer: 80000})
+	postList = me.Postlist(ctx, db.PostlistOptions{
+		Older: &db.Cursor{Table: db.UserPost{}.TableName(), ID: 1},
+		Newer: &db.Cursor{Table: db.UserPost{}.TableName(), ID: 80000}})
 
 	if len(*postList) != 0 {
 		t.Fatalf("Expected 0 posts. But got: %d", len(*postList))
 	}
 
 	// Find posts between 103 and 97 inclusive, in user profile, from everybody.
-	postList = me.Postlist(db.PostlistOptions{
-		Older: 103,
-		Newer: 97,
+	postList = me.Postlist(ctx, db.PostlistOptions{
+		Older: &db.Cursor{Table: db.UserPost{}.TableName(), ID: 104},
+		Newer: &db.Cursor{Table: db.UserPost{}.TableName(), ID: 96},
 	})
 
 	if len(*postList) != 4 {
@@ -205,7 +215,7 @@ func TestAddEditDeleteUserPost(t *testing.T) {
 
 	// New post on my board (To = 0)
 	post.Message = "All right"
-	if err := me.Submit(&post); err != nil {
+	if err := me.Submit(ctx, &post); err != nil {
 		t.Fatalf("Add user post should work but, got: %v", err)
 	}
 
@@ -213,68 +223,68 @@ func TestAddEditDeleteUserPost(t *testing.T) {
 		t.Fatalf("Post-User language mismatch: got %+v, expected %+v", post.Language(), me.Language())
 	}
 
-	if err := me.Delete(&post); err != nil {
+	if err := me.Delete(ctx, &post); err != nil {
 		t.Fatalf("Delete with hpid %v shoud work, but got error: %v", post.Hpid, err)
 	}
 
 	post.Message = "All right2"
 	post.Lang = "en"
 
-	if err := me.Submit(&post); err != nil {
+	if err := me.Submit(ctx, &post); err != nil {
 		t.Fatalf("Add with ID should work but, got: %v", err)
 	}
 
 	post.Message = "Post updated -> :D\nwow JA JA JA"
 	post.Lang = "fu"
 	// Language "fu" does not exists, this edit should fail
-	if err := me.Edit(&post); err == nil {
+	if err := me.Edit(ctx, &post); err == nil {
 		t.Fatalf("Edit post language and message not failed! - %v", err)
 	}
 
 	post.Lang = "de"
-	if err := me.Edit(&post); err != nil {
+	if err := me.Edit(ctx, &post); err != nil {
 		t.Fatalf("This edit shold work but got %s", err)
 	}
 
 	oldHpid := post.Hpid
 	post.Hpid = 0 //default value for uint64
-	if err := me.Delete(&post); err == nil {
+	if err := me.Delete(ctx, &post); err == nil {
 		t.Fatalf("Delete with hpid 0 should fail")
 	}
 
 	post.Hpid = oldHpid
-	if err := me.Delete(&post); err != nil {
+	if err := me.Delete(ctx, &post); err != nil {
 		t.Fatalf("Delete a valid post should work")
 	}
 
 }
 
 func TestAddEditDeleteUserPostComment(t *testing.T) {
-	postList := *me.Postlist(db.PostlistOptions{N: 1})
+	postList := *me.Postlist(ctx, db.PostlistOptions{N: 1})
 	existingPost := postList[0].(*db.UserPost)
 
 	var comment db.UserPostComment
 	comment.Message = "Nice <html>"
 	comment.Hpid = existingPost.Hpid
 
-	if err := me.Submit(&comment); err != nil {
+	if err := me.Submit(ctx, &comment); err != nil {
 		t.Fatalf("Add failed: %s", err)
 	}
 
 	comment.Message = "LOL EDIT"
 
 	// Should fail, because of flood limits
-	if err := me.Edit(&comment); err == nil {
+	if err := me.Edit(ctx, &comment); err == nil {
 		t.Fatalf("Edit should fail, but succeded")
 	}
 
 	// Wait 5 second to avoid flood limit (db side)
 	time.Sleep(6000 * time.Millisecond)
-	if err := me.Edit(&comment); err != nil {
+	if err := me.Edit(ctx, &comment); err != nil {
 		t.Fatalf("Edit comment failed with error: %s", err)
 	}
 
-	if err := me.Delete(&comment); err != nil {
+	if err := me.Delete(ctx, &comment); err != nil {
 		t.Fatalf("Delete comment with hcid %v shoud work, but got error: %v", comment.Hcid, err)
 	}
 }
@@ -282,28 +292,30 @@ func TestAddEditDeleteUserPostComment(t *testing.T) {
 func TestAddEditDeleteProjectPost(t *testing.T) {
 	var post db.ProjectPost
 
-	myProject := me.Projects()[0]
+	projects, _ := me.Projects(ctx)
+	myProject := projects[0]
 	post.To = myProject.Counter
 	post.Message = "BEST ADMIN EVER :>\nHello!"
 	post.Lang = "en"
 
-	if err := me.Submit(&post); err != nil {
+	if err := me.Submit(ctx, &post); err != nil {
 		t.Fatalf("No errors should occur whie adding a post to a project of mine, but got: %v", err)
 	}
 
 	post.Message = "WORST ADMIN EVER :<\a <- some random character"
-	if err := me.Edit(&post); err != nil {
+	if err := me.Edit(ctx, &post); err != nil {
 		t.Fatalf("Project Post edit should work, but failed with error: %s\n", err)
 	}
 
-	if err := me.Delete(&post); err != nil {
+	if err := me.Delete(ctx, &post); err != nil {
 		t.Fatalf("Delete failed with error: %s", err.Error())
 	}
 }
 
 func TestAddEditDeleteProjectPostComment(t *testing.T) {
-	myProject := me.Projects()[0]
-	projectPostList := *myProject.Postlist(db.PostlistOptions{N: 1})
+	projects, _ := me.Projects(ctx)
+	myProject := projects[0]
+	projectPostList := *myProject.Postlist(ctx, db.PostlistOptions{N: 1})
 
 	projectPost := projectPostList[0].(*db.ProjectPost)
 
@@ -311,18 +323,18 @@ func TestAddEditDeleteProjectPostComment(t *testing.T) {
 	projectPostComment.Hpid = projectPost.Hpid
 	projectPostComment.Message = "lol k"
 
-	if err := me.Submit(&projectPostComment); err != nil {
+	if err := me.Submit(ctx, &projectPostComment); err != nil {
 		t.Fatalf("Add comment on an existing project post sould work but failed with error: %s", err.Error())
 	}
 
 	projectPostComment.Message = "lol, k"
 	// Wait 5 second to avoid flood limit (db side)
 	time.Sleep(5000 * time.Millisecond)
-	if err := me.Edit(&projectPostComment); err != nil {
+	if err := me.Edit(ctx, &projectPostComment); err != nil {
 		t.Fatalf("Edit project post comment failed with error: %s", err)
 	}
 
-	if err := me.Delete(&projectPostComment); err != nil {
+	if err := me.Delete(ctx, &projectPostComment); err != nil {
 		t.Fatalf("Delete with hcid %v shoud work, but got error: %v", projectPostComment.Hcid, err)
 	}
 }
@@ -333,73 +345,73 @@ func TestAddEditDeletePm(t *testing.T) {
 	pm.Message = "Hi bro. Join telegram now"
 	pm.To = withClosedProfile.Counter
 
-	if err := me.Submit(&pm); err != nil {
+	if err := me.Submit(ctx, &pm); err != nil {
 		t.Fatalf("No errors should occur while adding a new pm to a non blacklisted user, but got %v", err)
 	}
 
 	pm.Message = "Pm edit is impossible (since in IM messages are not editable)"
-	if err := me.Edit(&pm); err == nil {
+	if err := me.Edit(ctx, &pm); err == nil {
 		t.Fatalf("Pm edit shouldn't work")
 	}
 
-	if err := me.Delete(&pm); err != nil {
+	if err := me.Delete(ctx, &pm); err != nil {
 		t.Fatalf("Pm delete failed with error: %s", err.Error())
 	}
 }
 
 func TestFollowUser(t *testing.T) {
-	other, _ = db.NewUser(3)
+	other, _ = db.NewUser(ctx, 3)
 
 	t.Logf("User(%d) follows User(%d)", me.Counter, other.Counter)
 
-	oldNumFollowers := len(other.NumericFollowers())
+	oldNumFollowers := len(other.NumericFollowers(ctx))
 
-	if err := me.Follow(other); err != nil {
+	if err := me.Follow(ctx, other); err != nil {
 		t.Log("The user should correctly follow the other user but: ")
 		t.Error(err)
 	}
 
-	if len(other.NumericFollowers()) != oldNumFollowers+1 {
+	if len(other.NumericFollowers(ctx)) != oldNumFollowers+1 {
 		t.Log("There isn't a new follower for the user!")
 		t.Error("No new follower")
 	}
 }
 
 func TestFriends(t *testing.T) {
-	f := me.Friends()
-	if len(f) != 3 {
+	f, err := me.Friends(ctx)
+	if err != nil || len(f) != 3 {
 		t.Fatalf("Expected 3 friends but got: %d", len(f))
 	}
 }
 
 func TestFollowProject(t *testing.T) {
-	project, _ := db.NewProject(1)
+	project, _ := db.NewProject(ctx, 1)
 
 	t.Log("I want to follow a fantastic project whose name is: ", project.Name)
-	oldNumFollowers := len(project.NumericFollowers())
+	oldNumFollowers := len(project.NumericFollowers(ctx))
 
-	if err := me.Follow(project); err != nil {
+	if err := me.Follow(ctx, project); err != nil {
 		t.Log("The user should correctly follow the project but: ")
 		t.Error(err)
 	}
 
-	if len(project.NumericFollowers()) != oldNumFollowers+1 {
+	if len(project.NumericFollowers(ctx)) != oldNumFollowers+1 {
 		t.Log("There isn't a new follower for the project!")
 		t.Error("No new follower")
 	}
 }
 
 func TestUnfollowUser(t *testing.T) {
-	other, _ = db.NewUser(3)
+	other, _ = db.NewUser(ctx, 3)
 	t.Logf("User(%d) unfollows User(%d)", me.Counter, other.Counter)
 
-	oldNumFollowers := len(other.NumericFollowers())
+	oldNumFollowers := len(other.NumericFollowers(ctx))
 
-	if err := me.Unfollow(other); err != nil {
+	if err := me.Unfollow(ctx, other); err != nil {
 		t.Error(err)
 	}
 
-	newNumFollowers := len(other.NumericFollowers())
+	newNumFollowers := len(other.NumericFollowers(ctx))
 
 	if newNumFollowers != oldNumFollowers-1 {
 		t.Fatalf("The follower isn't removed from the followers list! (old %d, new %d)", oldNumFollowers, newNumFollowers)
@@ -407,16 +419,17 @@ func TestUnfollowUser(t *testing.T) {
 }
 
 func TestUnfollowProject(t *testing.T) {
-	project, _ := db.NewProject(2)
+	project, _ := db.NewProject(ctx, 2)
 
 	t.Log("I want to unfollow a useless project whose name is: ", project.Name)
-	oldNumFollowers := len(project.Followers())
+	followers, _ := project.Followers(ctx)
+	oldNumFollowers := len(followers)
 
-	if err := me.Unfollow(project); err != nil {
+	if err := me.Unfollow(ctx, project); err != nil {
 		t.Error(err)
 	}
 
-	if len(project.Followers()) != oldNumFollowers-1 {
+	if followers, _ = project.Followers(ctx); len(followers) != oldNumFollowers-1 {
 		t.Error("The follower isn't removed from the project's followers!")
 	}
 }
@@ -424,11 +437,11 @@ func TestUnfollowProject(t *testing.T) {
 func TestNewUserPost(t *testing.T) {
 	var e error
 	var postA, postB *db.UserPost
-	if postA, e = db.NewUserPost(13); e != nil {
+	if postA, e = db.NewUserPost(ctx, 13); e != nil {
 		t.Fatalf("NewUserPost(13) shouldn't fail, but got: %s\n", e.Error())
 	}
 
-	if postB, e = db.NewUserPostWhere(&db.UserPost{db.Post{To: 3, Pid: 2}}); e != nil {
+	if postB, e = db.NewUserPostWhere(ctx, &db.UserPost{db.Post{To: 3, Pid: 2}}); e != nil {
 		t.Fatalf("NewUserPostWhere To:3 and Pid:2 shouldn't fail, but got: %s\n", e.Error())
 	}
 
@@ -438,73 +451,73 @@ func TestNewUserPost(t *testing.T) {
 }
 
 func TestUserPostBookmark(t *testing.T) {
-	post, _ := db.NewUserPost(13)
+	post, _ := db.NewUserPost(ctx, 13)
 
 	t.Logf("User(%d) bookmarkers the user's post(%d) ", me.Counter, post.Hpid)
 
-	oldNumBookmarks := len(post.NumericBookmarkers())
+	oldNumBookmarks := len(post.NumericBookmarkers(ctx))
 
-	if _, err := me.Bookmark(post); err != nil {
+	if _, err := me.Bookmark(ctx, post); err != nil {
 		t.Error(err)
 	}
 
-	if len(post.NumericBookmarkers()) != oldNumBookmarks+1 {
+	if len(post.NumericBookmarkers(ctx)) != oldNumBookmarks+1 {
 		t.Error("There isn't a new bookmark for the user's post ", post.Hpid)
 	}
 }
 
 func TestUserPostUnbookmark(t *testing.T) {
-	post, _ := db.NewUserPost(13)
+	post, _ := db.NewUserPost(ctx, 13)
 
 	t.Logf("User(%d) unbookmarkers the user's post(%d) ", me.Counter, post.Hpid)
 
-	oldNumBookmarks := len(post.NumericBookmarkers())
+	oldNumBookmarks := len(post.NumericBookmarkers(ctx))
 
-	if err := me.Unbookmark(post); err != nil {
+	if err := me.Unbookmark(ctx, post); err != nil {
 		t.Error(err)
 	}
 
-	if len(post.NumericBookmarkers()) != oldNumBookmarks-1 {
+	if len(post.NumericBookmarkers(ctx)) != oldNumBookmarks-1 {
 		t.Error("Bookmark isn't removed for the user's post ", post.Hpid)
 	}
 }
 
 func TestProjectPostBookmark(t *testing.T) {
-	post, _ := db.NewProjectPost(2)
+	post, _ := db.NewProjectPost(ctx, 2)
 
 	t.Logf("User(%d) bookmarkers the project's post(%d) ", me.Counter, post.Hpid)
 
-	oldNumBookmarks := len(post.NumericBookmarkers())
+	oldNumBookmarks := len(post.NumericBookmarkers(ctx))
 
-	if _, err := me.Bookmark(post); err != nil {
+	if _, err := me.Bookmark(ctx, post); err != nil {
 		t.Error(err)
 	}
 
-	if len(post.NumericBookmarkers()) != oldNumBookmarks+1 {
+	if len(post.NumericBookmarkers(ctx)) != oldNumBookmarks+1 {
 		t.Error("There isn't a new bookmark for the project's post ", post.Hpid)
 	}
 }
 
 func TestProjectPostUnbookmark(t *testing.T) {
-	post, _ := db.NewProjectPost(2)
+	post, _ := db.NewProjectPost(ctx, 2)
 
 	t.Logf("User(%d) unbookmarkers the project's post(%d) ", me.Counter, post.Hpid)
 
-	oldNumBookmarks := len(post.NumericBookmarkers())
+	oldNumBookmarks := len(post.NumericBookmarkers(ctx))
 
-	if err := me.Unbookmark(post); err != nil {
+	if err := me.Unbookmark(ctx, post); err != nil {
 		t.Error(err)
 	}
 
-	if len(post.NumericBookmarkers()) != oldNumBookmarks-1 {
+	if len(post.NumericBookmarkers(ctx)) != oldNumBookmarks-1 {
 		t.Error("Bookmark isn't removed for the project ", post.Hpid)
 	}
 }
 
 func TestPms(t *testing.T) {
-	other, _ = db.NewUser(2)
+	other, _ = db.NewUser(ctx, 2)
 	t.Logf("User(%d) pm-> User(%d)", me.Counter, other.Counter)
-	pmList, err := me.Pms(other.Counter, db.PmsOptions{})
+	pmList, err := me.Pms(ctx, other.Counter, db.PmsOptions{})
 
 	if err != nil {
 		t.Fatalf("Error trying to get pms between user(%d) and user(%d) - %v", me.ID(), other.ID(), err)
@@ -515,11 +528,11 @@ func TestPms(t *testing.T) {
 	}
 
 	// Delete
-	if err = me.DeleteConversation(other.ID()); err != nil {
+	if err = me.DeleteConversation(ctx, other.ID()); err != nil {
 		t.Fatalf("Conversation between me and other should be removed, but got: %s", err.Error())
 	}
 
-	pmList, err = me.Pms(other.ID(), db.PmsOptions{})
+	pmList, err = me.Pms(ctx, other.ID(), db.PmsOptions{})
 	if len(*pmList) != 0 {
 		t.Fatalf("Conversation between me and other should be removed, but %d messages got instead", len(*pmList))
 	}
@@ -528,7 +541,7 @@ func TestPms(t *testing.T) {
 func TestConversation(t *testing.T) {
 	t.Logf("Looking for conversation for user(%d)", me.Counter)
 
-	convList, err := me.Conversations()
+	convList, err := me.Conversations(ctx)
 
 	if err != nil {
 		t.Fatalf("No private conversations available for user(%d)", me.Counter)
@@ -543,36 +556,36 @@ func TestConversation(t *testing.T) {
 }
 
 func TestDoVotes(t *testing.T) {
-	userPost, _ := db.NewUserPost(13)
-	votesCount := userPost.VotesCount()
-	votes := *userPost.Votes()
+	userPost, _ := db.NewUserPost(ctx, 13)
+	votesCount := userPost.VotesCount(ctx)
+	votes := *userPost.Votes(ctx)
 
 	t.Logf("user(%d) likes user post(%d)", me.Counter, userPost.Hpid)
 
-	if _, err := me.Vote(userPost, 1); err != nil {
-		t.Fatalf("User is unable to like user post - %v. %d <= %d", err, votesCount, userPost.VotesCount())
+	if _, err := me.Vote(ctx, userPost, 1); err != nil {
+		t.Fatalf("User is unable to like user post - %v. %d <= %d", err, votesCount, userPost.VotesCount(ctx))
 	}
 
-	newVotes := *userPost.Votes()
+	newVotes := *userPost.Votes(ctx)
 	if len(newVotes) <= len(votes) {
 		t.Fatalf("Vote has not beed added, because %d <= %d", len(newVotes), len(votes))
 	}
 
-	if _, err := me.Vote(userPost, 0); err != nil || votesCount != userPost.VotesCount() {
-		t.Fatalf("User is unable to remove preference from user post - %v. %d != %d", err, votesCount, userPost.VotesCount())
+	if _, err := me.Vote(ctx, userPost, 0); err != nil || votesCount != userPost.VotesCount(ctx) {
+		t.Fatalf("User is unable to remove preference from user post - %v. %d != %d", err, votesCount, userPost.VotesCount(ctx))
 	}
 
-	projPost, _ := db.NewProjectPost(2)
+	projPost, _ := db.NewProjectPost(ctx, 2)
 
 	t.Logf("user(%d) likes project post(%d)", me.Counter, projPost.Hpid)
 
-	if _, err := me.Vote(projPost, 1); err != nil {
+	if _, err := me.Vote(ctx, projPost, 1); err != nil {
 		t.Fatalf("User is unable to like project post - %v", err)
 	}
 }
 
 func TestInterests(t *testing.T) {
-	interests := me.Interests()
+	interests := me.Interests(ctx)
 	if len(interests) != 1 {
 		t.Fatalf("Failed to fetch interests (fetched only %d)", len(interests))
 	}
@@ -584,16 +597,16 @@ func TestInterests(t *testing.T) {
 		Value: "awsome interest",
 	}
 
-	if err := me.AddInterest(&newIn); err != nil {
+	if err := me.AddInterest(ctx, &newIn); err != nil {
 		t.Fatalf("AddInterest shoud not fail, but got: %v", err)
 	}
 
-	interests = me.Interests()
+	interests = me.Interests(ctx)
 	if len(interests) != 2 {
 		t.Fatalf("Failed to fetch interests after insert (fetched only %d)", len(interests))
 	}
 
-	if err := me.DeleteInterest(&newIn); err != nil {
+	if err := me.DeleteInterest(ctx, &newIn); err != nil {
 		t.Fatalf("DeleteInterest shoud not fail, but got: %v", err)
 	}
 }
@@ -18,46 +18,147 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package db
 
 import (
+	"context"
+	"math"
+	"reflect"
+
+	"github.com/nerdzeu/nerdz-core/db/igor"
 	"github.com/nerdzeu/nerdz-core/utils"
 )
 
-// Users returns a slice of pointer to User, fetched from its Ids
-func Users(ids []uint64) []*User {
-	var users []*User
-	for _, elem := range ids {
-		user, _ := NewUser(elem)
-		users = append(users, user)
+// Users returns a slice of pointer to User, fetched from its Ids.
+// The users that don't exist are skipped: use LoadUsers to know which ones
+func Users(ctx context.Context, ids []uint64) ([]*User, error) {
+	users, _, err := LoadUsers(ctx, ids)
+	return users, err
+}
+
+// LoadUsers returns the users with the specified ids, in the same order, loading them
+// together with their profiles in a single query. missing contains the ids of the users that don't exist
+func LoadUsers(ctx context.Context, ids []uint64) (users []*User, missing []uint64, e error) {
+	var found []User
+	if found, e = storage(ctx).UsersIn(ctx, ids); e != nil {
+		return
+	}
+
+	byID := make(map[uint64]*User, len(found))
+	for i := range found {
+		byID[found[i].Counter] = &found[i]
 	}
-	return users
+
+	reported := make(map[uint64]bool)
+	for _, id := range ids {
+		if user, ok := byID[id]; ok {
+			loaded := *user
+			users = append(users, &loaded)
+		} else if !reported[id] {
+			reported[id] = true
+			missing = append(missing, id)
+		}
+	}
+	return
+}
+
+// Projects returns a slice of pointer to Project, fetched from its Ids.
+// The projects that don't exist are skipped: use LoadProjects to know which ones
+func Projects(ctx context.Context, ids []uint64) ([]*Project, error) {
+	projects, _, err := LoadProjects(ctx, ids)
+	return projects, err
 }
 
-// Projects returns a slice of pointer to Project, fetched from its Ids
-func Projects(ids []uint64) []*Project {
-	var projects []*Project
-	for _, elem := range ids {
-		project, _ := NewProject(elem)
-		projects = append(projects, project)
+// LoadProjects returns the projects with the specified ids, in the same order, loading them
+// with a single query. missing contains the ids of the projects that don't exist
+func LoadProjects(ctx context.Context, ids []uint64) (projects []*Project, missing []uint64, e error) {
+	var found []Project
+	if e = storage(ctx).FindIn(ctx, Project{}, "counter", ids, &found); e != nil {
+		return
 	}
-	return projects
+
+	byID := make(map[uint64]*Project, len(found))
+	for i := range found {
+		byID[found[i].Counter] = &found[i]
+	}
+
+	reported := make(map[uint64]bool)
+	for _, id := range ids {
+		if project, ok := byID[id]; ok {
+			loaded := *project
+			projects = append(projects, &loaded)
+		} else if !reported[id] {
+			reported[id] = true
+			missing = append(missing, id)
+		}
+	}
+	return
 }
 
-// Infos returns a slice of pointer to Info
-func Infos(slice interface{}) []*Info {
+// Infos returns a slice of pointer to Info.
+// The owners of the projects are loaded with a query for every kind of data
+func Infos(ctx context.Context, slice interface{}) ([]*Info, error) {
 	var infos []*Info
 
 	switch slice.(type) {
 	case []*User:
 		boards := slice.([]*User)
 		for _, elem := range boards {
-			infos = append(infos, elem.Info())
+			infos = append(infos, elem.Info(ctx))
 		}
 	case []*Project:
 		boards := slice.([]*Project)
+		ids := make([]uint64, len(boards))
+		for i, elem := range boards {
+			ids[i] = elem.ID()
+		}
+
+		var ownerships []ProjectOwner
+		if err := storage(ctx).FindIn(ctx, ProjectOwner{}, "to", ids, &ownerships); err != nil {
+			return nil, err
+		}
+		ownerOf := make(map[uint64]uint64, len(ownerships))
+		var ownerIDs []uint64
+		for _, ownership := range ownerships {
+			ownerOf[ownership.To] = ownership.From
+			ownerIDs = append(ownerIDs, ownership.From)
+		}
+
+		owners, err := Users(ctx, ownerIDs)
+		if err != nil {
+			return nil, err
+		}
+		ownerInfos := make(map[uint64]*Info, len(owners))
+		for _, owner := range owners {
+			ownerInfos[owner.ID()] = owner.Info(ctx)
+		}
+
 		for _, elem := range boards {
-			infos = append(infos, elem.Info())
+			infos = append(infos, elem.info(ownerInfos[ownerOf[elem.ID()]]))
 		}
 	}
-	return infos
+	return infos, nil
+}
+
+// emptyDescription returns true if description is nil or has no field set, so that it would match any record
+func emptyDescription(description igor.DBModel) bool {
+	value := reflect.ValueOf(description)
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return true
+		}
+		value = value.Elem()
+	}
+	return !value.IsValid() || value.IsZero()
+}
+
+// countWhere returns the number of records that match description, at most math.MaxUint8
+func countWhere(ctx context.Context, description igor.DBModel) uint8 {
+	count, _ := storage(ctx).Count(ctx, description)
+	return uint8(utils.AtMost(count, 0, math.MaxUint8))
+}
+
+// votesSum returns the sum of the votes that match description
+func votesSum(ctx context.Context, description igor.DBModel) int {
+	sum, _ := storage(ctx).Sum(ctx, description, "vote")
+	return int(sum)
 }
 
 // AtMostPosts returns a uint8 that's the number of posts to be retrieved
@@ -74,3 +175,18 @@ func AtMostComments(n uint64) uint8 {
 func AtMostPms(n uint64) uint8 {
 	return uint8(utils.AtMost(n, MinPms, MaxPms))
 }
+
+// AtMostMentions returns a uint8 that's the number of mentions to be retrieved
+func AtMostMentions(n uint64) uint8 {
+	return uint8(utils.AtMost(n, MinMentions, MaxMentions))
+}
+
+// AtMostTrending returns a uint8 that's the number of trending tags to be retrieved
+func AtMostTrending(n uint64) uint8 {
+	return uint8(utils.AtMost(n, MinTrending, MaxTrending))
+}
+
+// AtMostNotifications returns a uint8 that's the number of notifications to be retrieved
+func AtMostNotifications(n uint64) uint8 {
+	return uint8(utils.AtMost(n, MinNotifications, MaxNotifications))
+}
@@ -0,0 +1,78 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/nerdzeu/nerdz-core/db"
+	"github.com/nerdzeu/nerdz-core/server"
+	"github.com/spf13/viper"
+)
+
+func main() {
+	configFile := flag.String("config", "", "path of the configuration file. If empty, config.{json,toml,yaml} is searched in the working directory and in /etc/nerdz-core")
+	flag.Parse()
+
+	if *configFile != "" {
+		viper.SetConfigFile(*configFile)
+	} else {
+		viper.SetConfigName("config")
+		viper.AddConfigPath(".")
+		viper.AddConfigPath("/etc/nerdz-core")
+	}
+
+	if err := viper.ReadInConfig(); err != nil {
+		// Without a configuration file, the configuration is read from the environment
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok || *configFile != "" {
+			log.Fatalf("unable to read the configuration: %s", err)
+		}
+	}
+
+	if err := db.Init(); err != nil {
+		log.Fatalf("unable to connect to the database: %s", err)
+	}
+	store := db.DefaultStore()
+	defer store.Close()
+
+	srv, err := server.New(store)
+	if err != nil {
+		log.Fatalf("unable to create the server: %s", err)
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		log.Printf("listening on %s", srv.Address())
+		errs <- srv.Serve()
+	}()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
+
+	select {
+	case err = <-errs:
+		log.Fatalf("unable to serve: %s", err)
+	case sig := <-signals:
+		log.Printf("received %s, draining the pending requests", sig)
+		srv.Shutdown()
+	}
+}
@@ -9,12 +9,95 @@ It is generated from these files:
 	nerdz.proto
 
 It has these top-level messages:
+	Profile
+	User
+	PersonalInfo
+	ContactInfo
+	BoardInfo
+	Info
+	Project
+	ProjectInfo
+	UserPost
+	ProjectPost
+	UserPostComment
+	ProjectPostComment
+	PM
+	Conversation
+	UserPostVote
+	ProjectPostVote
+	UserPostCommentVote
+	ProjectPostCommentVote
+	UserPostBookmark
+	ProjectPostBookmark
+	UserPostLurk
+	ProjectPostLurk
+	UserPostLock
+	UserPostUserLock
+	ProjectPostLock
+	ProjectPostUserLock
+	Application
+	Message
+	Content
+	ContentID
+	Event
+	Notification
+	Mention
+	Trend
+	UserList
+	ProjectList
+	MessageList
+	ContentList
+	ConversationList
+	NotificationList
+	MentionList
+	TrendList
+	ApplicationList
+	PostlistOptions
+	CommentlistOptions
+	PmsOptions
+	NotificationsOptions
+	MentionsOptions
+	UserRequest
+	ProjectRequest
+	PostlistRequest
+	HomeRequest
+	CommentsRequest
+	MentionsRequest
+	TaggedRequest
+	TrendingRequest
+	BoardRequest
+	UserActionRequest
+	SubmitRequest
+	EditRequest
+	ContentRequest
+	VoteRequest
+	LockRequest
+	PmsRequest
+	ConversationRequest
+	ChatRequest
+	ChatError
+	ChatEvent
+	NotificationsRequest
+	NotificationsCount
+	RevokeTokenRequest
+	CreateClientRequest
+	UpdateClientRequest
+	ClientRequest
+	ClientSecret
 */
 package proto
 
 import proto1 "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
+import google_protobuf "github.com/golang/protobuf/ptypes/duration"
+import google_protobuf1 "github.com/golang/protobuf/ptypes/empty"
+import google_protobuf2 "github.com/golang/protobuf/ptypes/timestamp"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto1.Marshal
@@ -63,22 +146,6435 @@ func (x Language) String() string {
 }
 func (Language) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
 
-func init() {
-	proto1.RegisterEnum("nerdz.Language", Language_name, Language_value)
+// ContentType identifies the kind of a Content
+type ContentType int32
+
+const (
+	ContentType_USER_POST            ContentType = 0
+	ContentType_PROJECT_POST         ContentType = 1
+	ContentType_USER_POST_COMMENT    ContentType = 2
+	ContentType_PROJECT_POST_COMMENT ContentType = 3
+	ContentType_PRIVATE_MESSAGE      ContentType = 4
+)
+
+var ContentType_name = map[int32]string{
+	0: "USER_POST",
+	1: "PROJECT_POST",
+	2: "USER_POST_COMMENT",
+	3: "PROJECT_POST_COMMENT",
+	4: "PRIVATE_MESSAGE",
+}
+var ContentType_value = map[string]int32{
+	"USER_POST":            0,
+	"PROJECT_POST":         1,
+	"USER_POST_COMMENT":    2,
+	"PROJECT_POST_COMMENT": 3,
+	"PRIVATE_MESSAGE":      4,
+}
+
+func (x ContentType) String() string {
+	return proto1.EnumName(ContentType_name, int32(x))
+}
+func (ContentType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+// BoardType makes possible to distinguish a User board from a Project board
+type BoardType int32
+
+const (
+	BoardType_USER    BoardType = 0
+	BoardType_PROJECT BoardType = 1
+)
+
+var BoardType_name = map[int32]string{
+	0: "USER",
+	1: "PROJECT",
+}
+var BoardType_value = map[string]int32{
+	"USER":    0,
+	"PROJECT": 1,
+}
+
+func (x BoardType) String() string {
+	return proto1.EnumName(BoardType_name, int32(x))
+}
+func (BoardType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+// EventType identifies the kind of an Event
+type EventType int32
+
+const (
+	EventType_NEW_POST     EventType = 0
+	EventType_NEW_COMMENT  EventType = 1
+	EventType_NEW_PM       EventType = 2
+	EventType_NEW_VOTE     EventType = 3
+	EventType_NEW_MENTION  EventType = 4
+	EventType_NEW_FOLLOWER EventType = 5
+	EventType_TYPING       EventType = 6
+	EventType_PMS_READ     EventType = 7
+)
+
+var EventType_name = map[int32]string{
+	0: "NEW_POST",
+	1: "NEW_COMMENT",
+	2: "NEW_PM",
+	3: "NEW_VOTE",
+	4: "NEW_MENTION",
+	5: "NEW_FOLLOWER",
+	6: "TYPING",
+	7: "PMS_READ",
+}
+var EventType_value = map[string]int32{
+	"NEW_POST":     0,
+	"NEW_COMMENT":  1,
+	"NEW_PM":       2,
+	"NEW_VOTE":     3,
+	"NEW_MENTION":  4,
+	"NEW_FOLLOWER": 5,
+	"TYPING":       6,
+	"PMS_READ":     7,
+}
+
+func (x EventType) String() string {
+	return proto1.EnumName(EventType_name, int32(x))
+}
+func (EventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+// NotificationType identifies the kind of a Notification
+type NotificationType int32
+
+const (
+	NotificationType_NOTIFICATION_COMMENT          NotificationType = 0
+	NotificationType_NOTIFICATION_PROJECT_COMMENT  NotificationType = 1
+	NotificationType_NOTIFICATION_PROJECT_POST     NotificationType = 2
+	NotificationType_NOTIFICATION_MENTION          NotificationType = 3
+	NotificationType_NOTIFICATION_FOLLOWER         NotificationType = 4
+	NotificationType_NOTIFICATION_PROJECT_FOLLOWER NotificationType = 5
+	NotificationType_NOTIFICATION_MEMBER           NotificationType = 6
+)
+
+var NotificationType_name = map[int32]string{
+	0: "NOTIFICATION_COMMENT",
+	1: "NOTIFICATION_PROJECT_COMMENT",
+	2: "NOTIFICATION_PROJECT_POST",
+	3: "NOTIFICATION_MENTION",
+	4: "NOTIFICATION_FOLLOWER",
+	5: "NOTIFICATION_PROJECT_FOLLOWER",
+	6: "NOTIFICATION_MEMBER",
+}
+var NotificationType_value = map[string]int32{
+	"NOTIFICATION_COMMENT":          0,
+	"NOTIFICATION_PROJECT_COMMENT":  1,
+	"NOTIFICATION_PROJECT_POST":     2,
+	"NOTIFICATION_MENTION":          3,
+	"NOTIFICATION_FOLLOWER":         4,
+	"NOTIFICATION_PROJECT_FOLLOWER": 5,
+	"NOTIFICATION_MEMBER":           6,
+}
+
+func (x NotificationType) String() string {
+	return proto1.EnumName(NotificationType_name, int32(x))
+}
+func (NotificationType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+// Profile contains the profile of an user
+type Profile struct {
+	Counter        uint64                      `protobuf:"varint,1,opt,name=counter" json:"counter,omitempty"`
+	Website        string                      `protobuf:"bytes,2,opt,name=website" json:"website,omitempty"`
+	Quotes         string                      `protobuf:"bytes,3,opt,name=quotes" json:"quotes,omitempty"`
+	Biography      string                      `protobuf:"bytes,4,opt,name=biography" json:"biography,omitempty"`
+	Github         string                      `protobuf:"bytes,5,opt,name=github" json:"github,omitempty"`
+	Skype          string                      `protobuf:"bytes,6,opt,name=skype" json:"skype,omitempty"`
+	Jabber         string                      `protobuf:"bytes,7,opt,name=jabber" json:"jabber,omitempty"`
+	Yahoo          string                      `protobuf:"bytes,8,opt,name=yahoo" json:"yahoo,omitempty"`
+	Userscript     string                      `protobuf:"bytes,9,opt,name=userscript" json:"userscript,omitempty"`
+	Template       uint32                      `protobuf:"varint,10,opt,name=template" json:"template,omitempty"`
+	MobileTemplate uint32                      `protobuf:"varint,11,opt,name=mobile_template,json=mobileTemplate" json:"mobile_template,omitempty"`
+	Dateformat     string                      `protobuf:"bytes,12,opt,name=dateformat" json:"dateformat,omitempty"`
+	Facebook       string                      `protobuf:"bytes,13,opt,name=facebook" json:"facebook,omitempty"`
+	Twitter        string                      `protobuf:"bytes,14,opt,name=twitter" json:"twitter,omitempty"`
+	Steam          string                      `protobuf:"bytes,15,opt,name=steam" json:"steam,omitempty"`
+	Push           bool                        `protobuf:"varint,16,opt,name=push" json:"push,omitempty"`
+	Pushregtime    *google_protobuf2.Timestamp `protobuf:"bytes,17,opt,name=pushregtime" json:"pushregtime,omitempty"`
+	Closed         bool                        `protobuf:"varint,18,opt,name=closed" json:"closed,omitempty"`
+}
+
+func (m *Profile) Reset()                    { *m = Profile{} }
+func (m *Profile) String() string            { return proto1.CompactTextString(m) }
+func (*Profile) ProtoMessage()               {}
+func (*Profile) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *Profile) GetCounter() uint64 {
+	if m != nil {
+		return m.Counter
+	}
+	return 0
+}
+
+func (m *Profile) GetWebsite() string {
+	if m != nil {
+		return m.Website
+	}
+	return ""
+}
+
+func (m *Profile) GetQuotes() string {
+	if m != nil {
+		return m.Quotes
+	}
+	return ""
+}
+
+func (m *Profile) GetBiography() string {
+	if m != nil {
+		return m.Biography
+	}
+	return ""
+}
+
+func (m *Profile) GetGithub() string {
+	if m != nil {
+		return m.Github
+	}
+	return ""
+}
+
+func (m *Profile) GetSkype() string {
+	if m != nil {
+		return m.Skype
+	}
+	return ""
+}
+
+func (m *Profile) GetJabber() string {
+	if m != nil {
+		return m.Jabber
+	}
+	return ""
+}
+
+func (m *Profile) GetYahoo() string {
+	if m != nil {
+		return m.Yahoo
+	}
+	return ""
+}
+
+func (m *Profile) GetUserscript() string {
+	if m != nil {
+		return m.Userscript
+	}
+	return ""
+}
+
+func (m *Profile) GetTemplate() uint32 {
+	if m != nil {
+		return m.Template
+	}
+	return 0
+}
+
+func (m *Profile) GetMobileTemplate() uint32 {
+	if m != nil {
+		return m.MobileTemplate
+	}
+	return 0
+}
+
+func (m *Profile) GetDateformat() string {
+	if m != nil {
+		return m.Dateformat
+	}
+	return ""
+}
+
+func (m *Profile) GetFacebook() string {
+	if m != nil {
+		return m.Facebook
+	}
+	return ""
+}
+
+func (m *Profile) GetTwitter() string {
+	if m != nil {
+		return m.Twitter
+	}
+	return ""
+}
+
+func (m *Profile) GetSteam() string {
+	if m != nil {
+		return m.Steam
+	}
+	return ""
+}
+
+func (m *Profile) GetPush() bool {
+	if m != nil {
+		return m.Push
+	}
+	return false
+}
+
+func (m *Profile) GetPushregtime() *google_protobuf2.Timestamp {
+	if m != nil {
+		return m.Pushregtime
+	}
+	return nil
+}
+
+func (m *Profile) GetClosed() bool {
+	if m != nil {
+		return m.Closed
+	}
+	return false
+}
+
+// User is the transfer object of an user.
+// Credentials and connection details (password, remote address, user agent) are never transferred
+type User struct {
+	Counter uint64                      `protobuf:"varint,1,opt,name=counter" json:"counter,omitempty"`
+	Last    *google_protobuf2.Timestamp `protobuf:"bytes,2,opt,name=last" json:"last,omitempty"`
+	// notify_story is the JSON encoded notification story
+	NotifyStory      string                      `protobuf:"bytes,3,opt,name=notify_story,json=notifyStory" json:"notify_story,omitempty"`
+	Private          bool                        `protobuf:"varint,4,opt,name=private" json:"private,omitempty"`
+	Lang             Language                    `protobuf:"varint,5,opt,name=lang,enum=nerdz.Language" json:"lang,omitempty"`
+	Username         string                      `protobuf:"bytes,6,opt,name=username" json:"username,omitempty"`
+	Email            string                      `protobuf:"bytes,7,opt,name=email" json:"email,omitempty"`
+	Name             string                      `protobuf:"bytes,8,opt,name=name" json:"name,omitempty"`
+	Surname          string                      `protobuf:"bytes,9,opt,name=surname" json:"surname,omitempty"`
+	Gender           bool                        `protobuf:"varint,10,opt,name=gender" json:"gender,omitempty"`
+	BirthDate        *google_protobuf2.Timestamp `protobuf:"bytes,11,opt,name=birth_date,json=birthDate" json:"birth_date,omitempty"`
+	BoardLang        Language                    `protobuf:"varint,12,opt,name=board_lang,json=boardLang,enum=nerdz.Language" json:"board_lang,omitempty"`
+	Timezone         string                      `protobuf:"bytes,13,opt,name=timezone" json:"timezone,omitempty"`
+	Viewonline       bool                        `protobuf:"varint,14,opt,name=viewonline" json:"viewonline,omitempty"`
+	RegistrationTime *google_protobuf2.Timestamp `protobuf:"bytes,15,opt,name=registration_time,json=registrationTime" json:"registration_time,omitempty"`
+	Profile          *Profile                    `protobuf:"bytes,16,opt,name=profile" json:"profile,omitempty"`
+}
+
+func (m *User) Reset()                    { *m = User{} }
+func (m *User) String() string            { return proto1.CompactTextString(m) }
+func (*User) ProtoMessage()               {}
+func (*User) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *User) GetCounter() uint64 {
+	if m != nil {
+		return m.Counter
+	}
+	return 0
+}
+
+func (m *User) GetLast() *google_protobuf2.Timestamp {
+	if m != nil {
+		return m.Last
+	}
+	return nil
+}
+
+func (m *User) GetNotifyStory() string {
+	if m != nil {
+		return m.NotifyStory
+	}
+	return ""
+}
+
+func (m *User) GetPrivate() bool {
+	if m != nil {
+		return m.Private
+	}
+	return false
+}
+
+func (m *User) GetLang() Language {
+	if m != nil {
+		return m.Lang
+	}
+	return Language_INVALID
+}
+
+func (m *User) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+func (m *User) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
+}
+
+func (m *User) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *User) GetSurname() string {
+	if m != nil {
+		return m.Surname
+	}
+	return ""
+}
+
+func (m *User) GetGender() bool {
+	if m != nil {
+		return m.Gender
+	}
+	return false
+}
+
+func (m *User) GetBirthDate() *google_protobuf2.Timestamp {
+	if m != nil {
+		return m.BirthDate
+	}
+	return nil
+}
+
+func (m *User) GetBoardLang() Language {
+	if m != nil {
+		return m.BoardLang
+	}
+	return Language_INVALID
+}
+
+func (m *User) GetTimezone() string {
+	if m != nil {
+		return m.Timezone
+	}
+	return ""
+}
+
+func (m *User) GetViewonline() bool {
+	if m != nil {
+		return m.Viewonline
+	}
+	return false
+}
+
+func (m *User) GetRegistrationTime() *google_protobuf2.Timestamp {
+	if m != nil {
+		return m.RegistrationTime
+	}
+	return nil
+}
+
+func (m *User) GetProfile() *Profile {
+	if m != nil {
+		return m.Profile
+	}
+	return nil
+}
+
+// PersonalInfo contains all the personal info of an user
+type PersonalInfo struct {
+	IsOnline  bool                        `protobuf:"varint,1,opt,name=is_online,json=isOnline" json:"is_online,omitempty"`
+	Nation    string                      `protobuf:"bytes,2,opt,name=nation" json:"nation,omitempty"`
+	Timezone  string                      `protobuf:"bytes,3,opt,name=timezone" json:"timezone,omitempty"`
+	Username  string                      `protobuf:"bytes,4,opt,name=username" json:"username,omitempty"`
+	Name      string                      `protobuf:"bytes,5,opt,name=name" json:"name,omitempty"`
+	Surname   string                      `protobuf:"bytes,6,opt,name=surname" json:"surname,omitempty"`
+	Gender    bool                        `protobuf:"varint,7,opt,name=gender" json:"gender,omitempty"`
+	Birthday  *google_protobuf2.Timestamp `protobuf:"bytes,8,opt,name=birthday" json:"birthday,omitempty"`
+	Gravatar  string                      `protobuf:"bytes,9,opt,name=gravatar" json:"gravatar,omitempty"`
+	Interests []string                    `protobuf:"bytes,10,rep,name=interests" json:"interests,omitempty"`
+	Quotes    []string                    `protobuf:"bytes,11,rep,name=quotes" json:"quotes,omitempty"`
+	Biography string                      `protobuf:"bytes,12,opt,name=biography" json:"biography,omitempty"`
+}
+
+func (m *PersonalInfo) Reset()                    { *m = PersonalInfo{} }
+func (m *PersonalInfo) String() string            { return proto1.CompactTextString(m) }
+func (*PersonalInfo) ProtoMessage()               {}
+func (*PersonalInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *PersonalInfo) GetIsOnline() bool {
+	if m != nil {
+		return m.IsOnline
+	}
+	return false
+}
+
+func (m *PersonalInfo) GetNation() string {
+	if m != nil {
+		return m.Nation
+	}
+	return ""
+}
+
+func (m *PersonalInfo) GetTimezone() string {
+	if m != nil {
+		return m.Timezone
+	}
+	return ""
+}
+
+func (m *PersonalInfo) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+func (m *PersonalInfo) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PersonalInfo) GetSurname() string {
+	if m != nil {
+		return m.Surname
+	}
+	return ""
+}
+
+func (m *PersonalInfo) GetGender() bool {
+	if m != nil {
+		return m.Gender
+	}
+	return false
+}
+
+func (m *PersonalInfo) GetBirthday() *google_protobuf2.Timestamp {
+	if m != nil {
+		return m.Birthday
+	}
+	return nil
+}
+
+func (m *PersonalInfo) GetGravatar() string {
+	if m != nil {
+		return m.Gravatar
+	}
+	return ""
+}
+
+func (m *PersonalInfo) GetInterests() []string {
+	if m != nil {
+		return m.Interests
+	}
+	return nil
+}
+
+func (m *PersonalInfo) GetQuotes() []string {
+	if m != nil {
+		return m.Quotes
+	}
+	return nil
+}
+
+func (m *PersonalInfo) GetBiography() string {
+	if m != nil {
+		return m.Biography
+	}
+	return ""
+}
+
+// ContactInfo contains all the contact info of an user
+type ContactInfo struct {
+	Website  string `protobuf:"bytes,1,opt,name=website" json:"website,omitempty"`
+	Github   string `protobuf:"bytes,2,opt,name=github" json:"github,omitempty"`
+	Skype    string `protobuf:"bytes,3,opt,name=skype" json:"skype,omitempty"`
+	Jabber   string `protobuf:"bytes,4,opt,name=jabber" json:"jabber,omitempty"`
+	Yahoo    string `protobuf:"bytes,5,opt,name=yahoo" json:"yahoo,omitempty"`
+	Facebook string `protobuf:"bytes,6,opt,name=facebook" json:"facebook,omitempty"`
+	Twitter  string `protobuf:"bytes,7,opt,name=twitter" json:"twitter,omitempty"`
+	Steam    string `protobuf:"bytes,8,opt,name=steam" json:"steam,omitempty"`
+}
+
+func (m *ContactInfo) Reset()                    { *m = ContactInfo{} }
+func (m *ContactInfo) String() string            { return proto1.CompactTextString(m) }
+func (*ContactInfo) ProtoMessage()               {}
+func (*ContactInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *ContactInfo) GetWebsite() string {
+	if m != nil {
+		return m.Website
+	}
+	return ""
+}
+
+func (m *ContactInfo) GetGithub() string {
+	if m != nil {
+		return m.Github
+	}
+	return ""
+}
+
+func (m *ContactInfo) GetSkype() string {
+	if m != nil {
+		return m.Skype
+	}
+	return ""
+}
+
+func (m *ContactInfo) GetJabber() string {
+	if m != nil {
+		return m.Jabber
+	}
+	return ""
+}
+
+func (m *ContactInfo) GetYahoo() string {
+	if m != nil {
+		return m.Yahoo
+	}
+	return ""
+}
+
+func (m *ContactInfo) GetFacebook() string {
+	if m != nil {
+		return m.Facebook
+	}
+	return ""
+}
+
+func (m *ContactInfo) GetTwitter() string {
+	if m != nil {
+		return m.Twitter
+	}
+	return ""
+}
+
+func (m *ContactInfo) GetSteam() string {
+	if m != nil {
+		return m.Steam
+	}
+	return ""
+}
+
+// BoardInfo contains all the informations related to the user's board
+type BoardInfo struct {
+	Language   Language `protobuf:"varint,1,opt,name=language,enum=nerdz.Language" json:"language,omitempty"`
+	IsClosed   bool     `protobuf:"varint,2,opt,name=is_closed,json=isClosed" json:"is_closed,omitempty"`
+	Private    bool     `protobuf:"varint,3,opt,name=private" json:"private,omitempty"`
+	Whitelist  []*User  `protobuf:"bytes,4,rep,name=whitelist" json:"whitelist,omitempty"`
+	UserScript string   `protobuf:"bytes,5,opt,name=user_script,json=userScript" json:"user_script,omitempty"`
+}
+
+func (m *BoardInfo) Reset()                    { *m = BoardInfo{} }
+func (m *BoardInfo) String() string            { return proto1.CompactTextString(m) }
+func (*BoardInfo) ProtoMessage()               {}
+func (*BoardInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *BoardInfo) GetLanguage() Language {
+	if m != nil {
+		return m.Language
+	}
+	return Language_INVALID
+}
+
+func (m *BoardInfo) GetIsClosed() bool {
+	if m != nil {
+		return m.IsClosed
+	}
+	return false
+}
+
+func (m *BoardInfo) GetPrivate() bool {
+	if m != nil {
+		return m.Private
+	}
+	return false
+}
+
+func (m *BoardInfo) GetWhitelist() []*User {
+	if m != nil {
+		return m.Whitelist
+	}
+	return nil
+}
+
+func (m *BoardInfo) GetUserScript() string {
+	if m != nil {
+		return m.UserScript
+	}
+	return ""
+}
+
+// Info contains the informations common to every board
+type Info struct {
+	Id       uint64    `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	Owner    *Info     `protobuf:"bytes,2,opt,name=owner" json:"owner,omitempty"`
+	Name     string    `protobuf:"bytes,3,opt,name=name" json:"name,omitempty"`
+	Username string    `protobuf:"bytes,4,opt,name=username" json:"username,omitempty"`
+	Website  string    `protobuf:"bytes,5,opt,name=website" json:"website,omitempty"`
+	Image    string    `protobuf:"bytes,6,opt,name=image" json:"image,omitempty"`
+	Closed   bool      `protobuf:"varint,7,opt,name=closed" json:"closed,omitempty"`
+	Type     BoardType `protobuf:"varint,8,opt,name=type,enum=nerdz.BoardType" json:"type,omitempty"`
+}
+
+func (m *Info) Reset()                    { *m = Info{} }
+func (m *Info) String() string            { return proto1.CompactTextString(m) }
+func (*Info) ProtoMessage()               {}
+func (*Info) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *Info) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Info) GetOwner() *Info {
+	if m != nil {
+		return m.Owner
+	}
+	return nil
+}
+
+func (m *Info) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Info) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+func (m *Info) GetWebsite() string {
+	if m != nil {
+		return m.Website
+	}
+	return ""
+}
+
+func (m *Info) GetImage() string {
+	if m != nil {
+		return m.Image
+	}
+	return ""
+}
+
+func (m *Info) GetClosed() bool {
+	if m != nil {
+		return m.Closed
+	}
+	return false
+}
+
+func (m *Info) GetType() BoardType {
+	if m != nil {
+		return m.Type
+	}
+	return BoardType_USER
+}
+
+// Project is the transfer object of a project
+type Project struct {
+	Counter      uint64                      `protobuf:"varint,1,opt,name=counter" json:"counter,omitempty"`
+	Description  string                      `protobuf:"bytes,2,opt,name=description" json:"description,omitempty"`
+	Name         string                      `protobuf:"bytes,3,opt,name=name" json:"name,omitempty"`
+	Private      bool                        `protobuf:"varint,4,opt,name=private" json:"private,omitempty"`
+	Photo        string                      `protobuf:"bytes,5,opt,name=photo" json:"photo,omitempty"`
+	Website      string                      `protobuf:"bytes,6,opt,name=website" json:"website,omitempty"`
+	Goal         string                      `protobuf:"bytes,7,opt,name=goal" json:"goal,omitempty"`
+	Visible      bool                        `protobuf:"varint,8,opt,name=visible" json:"visible,omitempty"`
+	Open         bool                        `protobuf:"varint,9,opt,name=open" json:"open,omitempty"`
+	CreationTime *google_protobuf2.Timestamp `protobuf:"bytes,10,opt,name=creation_time,json=creationTime" json:"creation_time,omitempty"`
+}
+
+func (m *Project) Reset()                    { *m = Project{} }
+func (m *Project) String() string            { return proto1.CompactTextString(m) }
+func (*Project) ProtoMessage()               {}
+func (*Project) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *Project) GetCounter() uint64 {
+	if m != nil {
+		return m.Counter
+	}
+	return 0
+}
+
+func (m *Project) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *Project) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Project) GetPrivate() bool {
+	if m != nil {
+		return m.Private
+	}
+	return false
+}
+
+func (m *Project) GetPhoto() string {
+	if m != nil {
+		return m.Photo
+	}
+	return ""
+}
+
+func (m *Project) GetWebsite() string {
+	if m != nil {
+		return m.Website
+	}
+	return ""
+}
+
+func (m *Project) GetGoal() string {
+	if m != nil {
+		return m.Goal
+	}
+	return ""
+}
+
+func (m *Project) GetVisible() bool {
+	if m != nil {
+		return m.Visible
+	}
+	return false
+}
+
+func (m *Project) GetOpen() bool {
+	if m != nil {
+		return m.Open
+	}
+	return false
+}
+
+func (m *Project) GetCreationTime() *google_protobuf2.Timestamp {
+	if m != nil {
+		return m.CreationTime
+	}
+	return nil
+}
+
+// ProjectInfo contains all the project's informations
+type ProjectInfo struct {
+	Id               uint64   `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	Owner            *User    `protobuf:"bytes,2,opt,name=owner" json:"owner,omitempty"`
+	Members          []*User  `protobuf:"bytes,3,rep,name=members" json:"members,omitempty"`
+	NumericMembers   []uint64 `protobuf:"varint,4,rep,packed,name=numeric_members,json=numericMembers" json:"numeric_members,omitempty"`
+	Followers        []*User  `protobuf:"bytes,5,rep,name=followers" json:"followers,omitempty"`
+	NumericFollowers []uint64 `protobuf:"varint,6,rep,packed,name=numeric_followers,json=numericFollowers" json:"numeric_followers,omitempty"`
+	Description      string   `protobuf:"bytes,7,opt,name=description" json:"description,omitempty"`
+	Name             string   `protobuf:"bytes,8,opt,name=name" json:"name,omitempty"`
+	Photo            string   `protobuf:"bytes,9,opt,name=photo" json:"photo,omitempty"`
+	Website          string   `protobuf:"bytes,10,opt,name=website" json:"website,omitempty"`
+	Goal             string   `protobuf:"bytes,11,opt,name=goal" json:"goal,omitempty"`
+	Visible          bool     `protobuf:"varint,12,opt,name=visible" json:"visible,omitempty"`
+	Private          bool     `protobuf:"varint,13,opt,name=private" json:"private,omitempty"`
+	Open             bool     `protobuf:"varint,14,opt,name=open" json:"open,omitempty"`
+}
+
+func (m *ProjectInfo) Reset()                    { *m = ProjectInfo{} }
+func (m *ProjectInfo) String() string            { return proto1.CompactTextString(m) }
+func (*ProjectInfo) ProtoMessage()               {}
+func (*ProjectInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *ProjectInfo) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *ProjectInfo) GetOwner() *User {
+	if m != nil {
+		return m.Owner
+	}
+	return nil
+}
+
+func (m *ProjectInfo) GetMembers() []*User {
+	if m != nil {
+		return m.Members
+	}
+	return nil
+}
+
+func (m *ProjectInfo) GetNumericMembers() []uint64 {
+	if m != nil {
+		return m.NumericMembers
+	}
+	return nil
+}
+
+func (m *ProjectInfo) GetFollowers() []*User {
+	if m != nil {
+		return m.Followers
+	}
+	return nil
+}
+
+func (m *ProjectInfo) GetNumericFollowers() []uint64 {
+	if m != nil {
+		return m.NumericFollowers
+	}
+	return nil
+}
+
+func (m *ProjectInfo) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *ProjectInfo) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ProjectInfo) GetPhoto() string {
+	if m != nil {
+		return m.Photo
+	}
+	return ""
+}
+
+func (m *ProjectInfo) GetWebsite() string {
+	if m != nil {
+		return m.Website
+	}
+	return ""
+}
+
+func (m *ProjectInfo) GetGoal() string {
+	if m != nil {
+		return m.Goal
+	}
+	return ""
+}
+
+func (m *ProjectInfo) GetVisible() bool {
+	if m != nil {
+		return m.Visible
+	}
+	return false
+}
+
+func (m *ProjectInfo) GetPrivate() bool {
+	if m != nil {
+		return m.Private
+	}
+	return false
+}
+
+func (m *ProjectInfo) GetOpen() bool {
+	if m != nil {
+		return m.Open
+	}
+	return false
+}
+
+// UserPost is a post on an user board
+type UserPost struct {
+	Hpid    uint64                      `protobuf:"varint,1,opt,name=hpid" json:"hpid,omitempty"`
+	From    uint64                      `protobuf:"varint,2,opt,name=from" json:"from,omitempty"`
+	To      uint64                      `protobuf:"varint,3,opt,name=to" json:"to,omitempty"`
+	Pid     uint64                      `protobuf:"varint,4,opt,name=pid" json:"pid,omitempty"`
+	Message string                      `protobuf:"bytes,5,opt,name=message" json:"message,omitempty"`
+	Time    *google_protobuf2.Timestamp `protobuf:"bytes,6,opt,name=time" json:"time,omitempty"`
+	Lang    Language                    `protobuf:"varint,7,opt,name=lang,enum=nerdz.Language" json:"lang,omitempty"`
+	News    bool                        `protobuf:"varint,8,opt,name=news" json:"news,omitempty"`
+	Closed  bool                        `protobuf:"varint,9,opt,name=closed" json:"closed,omitempty"`
+}
+
+func (m *UserPost) Reset()                    { *m = UserPost{} }
+func (m *UserPost) String() string            { return proto1.CompactTextString(m) }
+func (*UserPost) ProtoMessage()               {}
+func (*UserPost) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *UserPost) GetHpid() uint64 {
+	if m != nil {
+		return m.Hpid
+	}
+	return 0
+}
+
+func (m *UserPost) GetFrom() uint64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *UserPost) GetTo() uint64 {
+	if m != nil {
+		return m.To
+	}
+	return 0
+}
+
+func (m *UserPost) GetPid() uint64 {
+	if m != nil {
+		return m.Pid
+	}
+	return 0
+}
+
+func (m *UserPost) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *UserPost) GetTime() *google_protobuf2.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+func (m *UserPost) GetLang() Language {
+	if m != nil {
+		return m.Lang
+	}
+	return Language_INVALID
+}
+
+func (m *UserPost) GetNews() bool {
+	if m != nil {
+		return m.News
+	}
+	return false
+}
+
+func (m *UserPost) GetClosed() bool {
+	if m != nil {
+		return m.Closed
+	}
+	return false
+}
+
+// ProjectPost is a post on a project board
+type ProjectPost struct {
+	Hpid    uint64                      `protobuf:"varint,1,opt,name=hpid" json:"hpid,omitempty"`
+	From    uint64                      `protobuf:"varint,2,opt,name=from" json:"from,omitempty"`
+	To      uint64                      `protobuf:"varint,3,opt,name=to" json:"to,omitempty"`
+	Pid     uint64                      `protobuf:"varint,4,opt,name=pid" json:"pid,omitempty"`
+	Message string                      `protobuf:"bytes,5,opt,name=message" json:"message,omitempty"`
+	Time    *google_protobuf2.Timestamp `protobuf:"bytes,6,opt,name=time" json:"time,omitempty"`
+	Lang    Language                    `protobuf:"varint,7,opt,name=lang,enum=nerdz.Language" json:"lang,omitempty"`
+	News    bool                        `protobuf:"varint,8,opt,name=news" json:"news,omitempty"`
+	Closed  bool                        `protobuf:"varint,9,opt,name=closed" json:"closed,omitempty"`
+}
+
+func (m *ProjectPost) Reset()                    { *m = ProjectPost{} }
+func (m *ProjectPost) String() string            { return proto1.CompactTextString(m) }
+func (*ProjectPost) ProtoMessage()               {}
+func (*ProjectPost) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *ProjectPost) GetHpid() uint64 {
+	if m != nil {
+		return m.Hpid
+	}
+	return 0
+}
+
+func (m *ProjectPost) GetFrom() uint64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *ProjectPost) GetTo() uint64 {
+	if m != nil {
+		return m.To
+	}
+	return 0
+}
+
+func (m *ProjectPost) GetPid() uint64 {
+	if m != nil {
+		return m.Pid
+	}
+	return 0
+}
+
+func (m *ProjectPost) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *ProjectPost) GetTime() *google_protobuf2.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+func (m *ProjectPost) GetLang() Language {
+	if m != nil {
+		return m.Lang
+	}
+	return Language_INVALID
+}
+
+func (m *ProjectPost) GetNews() bool {
+	if m != nil {
+		return m.News
+	}
+	return false
+}
+
+func (m *ProjectPost) GetClosed() bool {
+	if m != nil {
+		return m.Closed
+	}
+	return false
+}
+
+// UserPostComment is a comment on a UserPost
+type UserPostComment struct {
+	Hcid     uint64                      `protobuf:"varint,1,opt,name=hcid" json:"hcid,omitempty"`
+	Hpid     uint64                      `protobuf:"varint,2,opt,name=hpid" json:"hpid,omitempty"`
+	From     uint64                      `protobuf:"varint,3,opt,name=from" json:"from,omitempty"`
+	To       uint64                      `protobuf:"varint,4,opt,name=to" json:"to,omitempty"`
+	Message  string                      `protobuf:"bytes,5,opt,name=message" json:"message,omitempty"`
+	Lang     Language                    `protobuf:"varint,6,opt,name=lang,enum=nerdz.Language" json:"lang,omitempty"`
+	Time     *google_protobuf2.Timestamp `protobuf:"bytes,7,opt,name=time" json:"time,omitempty"`
+	Editable bool                        `protobuf:"varint,8,opt,name=editable" json:"editable,omitempty"`
+}
+
+func (m *UserPostComment) Reset()                    { *m = UserPostComment{} }
+func (m *UserPostComment) String() string            { return proto1.CompactTextString(m) }
+func (*UserPostComment) ProtoMessage()               {}
+func (*UserPostComment) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *UserPostComment) GetHcid() uint64 {
+	if m != nil {
+		return m.Hcid
+	}
+	return 0
+}
+
+func (m *UserPostComment) GetHpid() uint64 {
+	if m != nil {
+		return m.Hpid
+	}
+	return 0
+}
+
+func (m *UserPostComment) GetFrom() uint64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *UserPostComment) GetTo() uint64 {
+	if m != nil {
+		return m.To
+	}
+	return 0
+}
+
+func (m *UserPostComment) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *UserPostComment) GetLang() Language {
+	if m != nil {
+		return m.Lang
+	}
+	return Language_INVALID
+}
+
+func (m *UserPostComment) GetTime() *google_protobuf2.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+func (m *UserPostComment) GetEditable() bool {
+	if m != nil {
+		return m.Editable
+	}
+	return false
+}
+
+// ProjectPostComment is a comment on a ProjectPost
+type ProjectPostComment struct {
+	Hcid     uint64                      `protobuf:"varint,1,opt,name=hcid" json:"hcid,omitempty"`
+	Hpid     uint64                      `protobuf:"varint,2,opt,name=hpid" json:"hpid,omitempty"`
+	From     uint64                      `protobuf:"varint,3,opt,name=from" json:"from,omitempty"`
+	To       uint64                      `protobuf:"varint,4,opt,name=to" json:"to,omitempty"`
+	Message  string                      `protobuf:"bytes,5,opt,name=message" json:"message,omitempty"`
+	Lang     Language                    `protobuf:"varint,6,opt,name=lang,enum=nerdz.Language" json:"lang,omitempty"`
+	Time     *google_protobuf2.Timestamp `protobuf:"bytes,7,opt,name=time" json:"time,omitempty"`
+	Editable bool                        `protobuf:"varint,8,opt,name=editable" json:"editable,omitempty"`
+}
+
+func (m *ProjectPostComment) Reset()                    { *m = ProjectPostComment{} }
+func (m *ProjectPostComment) String() string            { return proto1.CompactTextString(m) }
+func (*ProjectPostComment) ProtoMessage()               {}
+func (*ProjectPostComment) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *ProjectPostComment) GetHcid() uint64 {
+	if m != nil {
+		return m.Hcid
+	}
+	return 0
+}
+
+func (m *ProjectPostComment) GetHpid() uint64 {
+	if m != nil {
+		return m.Hpid
+	}
+	return 0
+}
+
+func (m *ProjectPostComment) GetFrom() uint64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *ProjectPostComment) GetTo() uint64 {
+	if m != nil {
+		return m.To
+	}
+	return 0
+}
+
+func (m *ProjectPostComment) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *ProjectPostComment) GetLang() Language {
+	if m != nil {
+		return m.Lang
+	}
+	return Language_INVALID
+}
+
+func (m *ProjectPostComment) GetTime() *google_protobuf2.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+func (m *ProjectPostComment) GetEditable() bool {
+	if m != nil {
+		return m.Editable
+	}
+	return false
+}
+
+// PM is a private message
+type PM struct {
+	Pmid    uint64                      `protobuf:"varint,1,opt,name=pmid" json:"pmid,omitempty"`
+	From    uint64                      `protobuf:"varint,2,opt,name=from" json:"from,omitempty"`
+	To      uint64                      `protobuf:"varint,3,opt,name=to" json:"to,omitempty"`
+	Message string                      `protobuf:"bytes,4,opt,name=message" json:"message,omitempty"`
+	Lang    Language                    `protobuf:"varint,5,opt,name=lang,enum=nerdz.Language" json:"lang,omitempty"`
+	ToRead  bool                        `protobuf:"varint,6,opt,name=to_read,json=toRead" json:"to_read,omitempty"`
+	Time    *google_protobuf2.Timestamp `protobuf:"bytes,7,opt,name=time" json:"time,omitempty"`
+}
+
+func (m *PM) Reset()                    { *m = PM{} }
+func (m *PM) String() string            { return proto1.CompactTextString(m) }
+func (*PM) ProtoMessage()               {}
+func (*PM) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *PM) GetPmid() uint64 {
+	if m != nil {
+		return m.Pmid
+	}
+	return 0
+}
+
+func (m *PM) GetFrom() uint64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *PM) GetTo() uint64 {
+	if m != nil {
+		return m.To
+	}
+	return 0
+}
+
+func (m *PM) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *PM) GetLang() Language {
+	if m != nil {
+		return m.Lang
+	}
+	return Language_INVALID
+}
+
+func (m *PM) GetToRead() bool {
+	if m != nil {
+		return m.ToRead
+	}
+	return false
+}
+
+func (m *PM) GetTime() *google_protobuf2.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+// Conversation contains the details about a single private conversation between two users
+type Conversation struct {
+	From        uint64                      `protobuf:"varint,1,opt,name=from" json:"from,omitempty"`
+	To          uint64                      `protobuf:"varint,2,opt,name=to" json:"to,omitempty"`
+	LastMessage string                      `protobuf:"bytes,3,opt,name=last_message,json=lastMessage" json:"last_message,omitempty"`
+	Time        *google_protobuf2.Timestamp `protobuf:"bytes,4,opt,name=time" json:"time,omitempty"`
+	ToRead      bool                        `protobuf:"varint,5,opt,name=to_read,json=toRead" json:"to_read,omitempty"`
+}
+
+func (m *Conversation) Reset()                    { *m = Conversation{} }
+func (m *Conversation) String() string            { return proto1.CompactTextString(m) }
+func (*Conversation) ProtoMessage()               {}
+func (*Conversation) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *Conversation) GetFrom() uint64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *Conversation) GetTo() uint64 {
+	if m != nil {
+		return m.To
+	}
+	return 0
+}
+
+func (m *Conversation) GetLastMessage() string {
+	if m != nil {
+		return m.LastMessage
+	}
+	return ""
+}
+
+func (m *Conversation) GetTime() *google_protobuf2.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+func (m *Conversation) GetToRead() bool {
+	if m != nil {
+		return m.ToRead
+	}
+	return false
+}
+
+// UserPostVote is a vote on a UserPost
+type UserPostVote struct {
+	Counter uint64                      `protobuf:"varint,1,opt,name=counter" json:"counter,omitempty"`
+	Hpid    uint64                      `protobuf:"varint,2,opt,name=hpid" json:"hpid,omitempty"`
+	From    uint64                      `protobuf:"varint,3,opt,name=from" json:"from,omitempty"`
+	To      uint64                      `protobuf:"varint,4,opt,name=to" json:"to,omitempty"`
+	Vote    int32                       `protobuf:"varint,5,opt,name=vote" json:"vote,omitempty"`
+	Time    *google_protobuf2.Timestamp `protobuf:"bytes,6,opt,name=time" json:"time,omitempty"`
+}
+
+func (m *UserPostVote) Reset()                    { *m = UserPostVote{} }
+func (m *UserPostVote) String() string            { return proto1.CompactTextString(m) }
+func (*UserPostVote) ProtoMessage()               {}
+func (*UserPostVote) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *UserPostVote) GetCounter() uint64 {
+	if m != nil {
+		return m.Counter
+	}
+	return 0
+}
+
+func (m *UserPostVote) GetHpid() uint64 {
+	if m != nil {
+		return m.Hpid
+	}
+	return 0
+}
+
+func (m *UserPostVote) GetFrom() uint64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *UserPostVote) GetTo() uint64 {
+	if m != nil {
+		return m.To
+	}
+	return 0
+}
+
+func (m *UserPostVote) GetVote() int32 {
+	if m != nil {
+		return m.Vote
+	}
+	return 0
+}
+
+func (m *UserPostVote) GetTime() *google_protobuf2.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+// ProjectPostVote is a vote on a ProjectPost
+type ProjectPostVote struct {
+	Counter uint64                      `protobuf:"varint,1,opt,name=counter" json:"counter,omitempty"`
+	Hpid    uint64                      `protobuf:"varint,2,opt,name=hpid" json:"hpid,omitempty"`
+	From    uint64                      `protobuf:"varint,3,opt,name=from" json:"from,omitempty"`
+	To      uint64                      `protobuf:"varint,4,opt,name=to" json:"to,omitempty"`
+	Vote    int32                       `protobuf:"varint,5,opt,name=vote" json:"vote,omitempty"`
+	Time    *google_protobuf2.Timestamp `protobuf:"bytes,6,opt,name=time" json:"time,omitempty"`
+}
+
+func (m *ProjectPostVote) Reset()                    { *m = ProjectPostVote{} }
+func (m *ProjectPostVote) String() string            { return proto1.CompactTextString(m) }
+func (*ProjectPostVote) ProtoMessage()               {}
+func (*ProjectPostVote) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *ProjectPostVote) GetCounter() uint64 {
+	if m != nil {
+		return m.Counter
+	}
+	return 0
+}
+
+func (m *ProjectPostVote) GetHpid() uint64 {
+	if m != nil {
+		return m.Hpid
+	}
+	return 0
+}
+
+func (m *ProjectPostVote) GetFrom() uint64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *ProjectPostVote) GetTo() uint64 {
+	if m != nil {
+		return m.To
+	}
+	return 0
+}
+
+func (m *ProjectPostVote) GetVote() int32 {
+	if m != nil {
+		return m.Vote
+	}
+	return 0
+}
+
+func (m *ProjectPostVote) GetTime() *google_protobuf2.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+// UserPostCommentVote is a vote on a UserPostComment
+type UserPostCommentVote struct {
+	Counter uint64 `protobuf:"varint,1,opt,name=counter" json:"counter,omitempty"`
+	Hcid    uint64 `protobuf:"varint,2,opt,name=hcid" json:"hcid,omitempty"`
+	From    uint64 `protobuf:"varint,3,opt,name=from" json:"from,omitempty"`
+	Vote    int32  `protobuf:"varint,4,opt,name=vote" json:"vote,omitempty"`
+}
+
+func (m *UserPostCommentVote) Reset()                    { *m = UserPostCommentVote{} }
+func (m *UserPostCommentVote) String() string            { return proto1.CompactTextString(m) }
+func (*UserPostCommentVote) ProtoMessage()               {}
+func (*UserPostCommentVote) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *UserPostCommentVote) GetCounter() uint64 {
+	if m != nil {
+		return m.Counter
+	}
+	return 0
+}
+
+func (m *UserPostCommentVote) GetHcid() uint64 {
+	if m != nil {
+		return m.Hcid
+	}
+	return 0
+}
+
+func (m *UserPostCommentVote) GetFrom() uint64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *UserPostCommentVote) GetVote() int32 {
+	if m != nil {
+		return m.Vote
+	}
+	return 0
+}
+
+// ProjectPostCommentVote is a vote on a ProjectPostComment
+type ProjectPostCommentVote struct {
+	Counter uint64                      `protobuf:"varint,1,opt,name=counter" json:"counter,omitempty"`
+	Hcid    uint64                      `protobuf:"varint,2,opt,name=hcid" json:"hcid,omitempty"`
+	From    uint64                      `protobuf:"varint,3,opt,name=from" json:"from,omitempty"`
+	To      uint64                      `protobuf:"varint,4,opt,name=to" json:"to,omitempty"`
+	Vote    int32                       `protobuf:"varint,5,opt,name=vote" json:"vote,omitempty"`
+	Time    *google_protobuf2.Timestamp `protobuf:"bytes,6,opt,name=time" json:"time,omitempty"`
+}
+
+func (m *ProjectPostCommentVote) Reset()                    { *m = ProjectPostCommentVote{} }
+func (m *ProjectPostCommentVote) String() string            { return proto1.CompactTextString(m) }
+func (*ProjectPostCommentVote) ProtoMessage()               {}
+func (*ProjectPostCommentVote) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *ProjectPostCommentVote) GetCounter() uint64 {
+	if m != nil {
+		return m.Counter
+	}
+	return 0
+}
+
+func (m *ProjectPostCommentVote) GetHcid() uint64 {
+	if m != nil {
+		return m.Hcid
+	}
+	return 0
+}
+
+func (m *ProjectPostCommentVote) GetFrom() uint64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *ProjectPostCommentVote) GetTo() uint64 {
+	if m != nil {
+		return m.To
+	}
+	return 0
+}
+
+func (m *ProjectPostCommentVote) GetVote() int32 {
+	if m != nil {
+		return m.Vote
+	}
+	return 0
+}
+
+func (m *ProjectPostCommentVote) GetTime() *google_protobuf2.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+// UserPostBookmark is a bookmark of a UserPost
+type UserPostBookmark struct {
+	Counter uint64                      `protobuf:"varint,1,opt,name=counter" json:"counter,omitempty"`
+	Hpid    uint64                      `protobuf:"varint,2,opt,name=hpid" json:"hpid,omitempty"`
+	From    uint64                      `protobuf:"varint,3,opt,name=from" json:"from,omitempty"`
+	Time    *google_protobuf2.Timestamp `protobuf:"bytes,4,opt,name=time" json:"time,omitempty"`
+}
+
+func (m *UserPostBookmark) Reset()                    { *m = UserPostBookmark{} }
+func (m *UserPostBookmark) String() string            { return proto1.CompactTextString(m) }
+func (*UserPostBookmark) ProtoMessage()               {}
+func (*UserPostBookmark) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+func (m *UserPostBookmark) GetCounter() uint64 {
+	if m != nil {
+		return m.Counter
+	}
+	return 0
+}
+
+func (m *UserPostBookmark) GetHpid() uint64 {
+	if m != nil {
+		return m.Hpid
+	}
+	return 0
+}
+
+func (m *UserPostBookmark) GetFrom() uint64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *UserPostBookmark) GetTime() *google_protobuf2.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+// ProjectPostBookmark is a bookmark of a ProjectPost
+type ProjectPostBookmark struct {
+	Counter uint64                      `protobuf:"varint,1,opt,name=counter" json:"counter,omitempty"`
+	Hpid    uint64                      `protobuf:"varint,2,opt,name=hpid" json:"hpid,omitempty"`
+	From    uint64                      `protobuf:"varint,3,opt,name=from" json:"from,omitempty"`
+	Time    *google_protobuf2.Timestamp `protobuf:"bytes,4,opt,name=time" json:"time,omitempty"`
+}
+
+func (m *ProjectPostBookmark) Reset()                    { *m = ProjectPostBookmark{} }
+func (m *ProjectPostBookmark) String() string            { return proto1.CompactTextString(m) }
+func (*ProjectPostBookmark) ProtoMessage()               {}
+func (*ProjectPostBookmark) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+func (m *ProjectPostBookmark) GetCounter() uint64 {
+	if m != nil {
+		return m.Counter
+	}
+	return 0
+}
+
+func (m *ProjectPostBookmark) GetHpid() uint64 {
+	if m != nil {
+		return m.Hpid
+	}
+	return 0
+}
+
+func (m *ProjectPostBookmark) GetFrom() uint64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *ProjectPostBookmark) GetTime() *google_protobuf2.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+// UserPostLurk is a lurk of a UserPost
+type UserPostLurk struct {
+	Counter uint64                      `protobuf:"varint,1,opt,name=counter" json:"counter,omitempty"`
+	Hpid    uint64                      `protobuf:"varint,2,opt,name=hpid" json:"hpid,omitempty"`
+	From    uint64                      `protobuf:"varint,3,opt,name=from" json:"from,omitempty"`
+	To      uint64                      `protobuf:"varint,4,opt,name=to" json:"to,omitempty"`
+	Time    *google_protobuf2.Timestamp `protobuf:"bytes,5,opt,name=time" json:"time,omitempty"`
+}
+
+func (m *UserPostLurk) Reset()                    { *m = UserPostLurk{} }
+func (m *UserPostLurk) String() string            { return proto1.CompactTextString(m) }
+func (*UserPostLurk) ProtoMessage()               {}
+func (*UserPostLurk) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+func (m *UserPostLurk) GetCounter() uint64 {
+	if m != nil {
+		return m.Counter
+	}
+	return 0
+}
+
+func (m *UserPostLurk) GetHpid() uint64 {
+	if m != nil {
+		return m.Hpid
+	}
+	return 0
+}
+
+func (m *UserPostLurk) GetFrom() uint64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *UserPostLurk) GetTo() uint64 {
+	if m != nil {
+		return m.To
+	}
+	return 0
+}
+
+func (m *UserPostLurk) GetTime() *google_protobuf2.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+// ProjectPostLurk is a lurk of a ProjectPost
+type ProjectPostLurk struct {
+	Counter uint64                      `protobuf:"varint,1,opt,name=counter" json:"counter,omitempty"`
+	Hpid    uint64                      `protobuf:"varint,2,opt,name=hpid" json:"hpid,omitempty"`
+	From    uint64                      `protobuf:"varint,3,opt,name=from" json:"from,omitempty"`
+	To      uint64                      `protobuf:"varint,4,opt,name=to" json:"to,omitempty"`
+	Time    *google_protobuf2.Timestamp `protobuf:"bytes,5,opt,name=time" json:"time,omitempty"`
+}
+
+func (m *ProjectPostLurk) Reset()                    { *m = ProjectPostLurk{} }
+func (m *ProjectPostLurk) String() string            { return proto1.CompactTextString(m) }
+func (*ProjectPostLurk) ProtoMessage()               {}
+func (*ProjectPostLurk) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *ProjectPostLurk) GetCounter() uint64 {
+	if m != nil {
+		return m.Counter
+	}
+	return 0
+}
+
+func (m *ProjectPostLurk) GetHpid() uint64 {
+	if m != nil {
+		return m.Hpid
+	}
+	return 0
+}
+
+func (m *ProjectPostLurk) GetFrom() uint64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *ProjectPostLurk) GetTo() uint64 {
+	if m != nil {
+		return m.To
+	}
+	return 0
+}
+
+func (m *ProjectPostLurk) GetTime() *google_protobuf2.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+// UserPostLock disables every notification of a UserPost for an user
+type UserPostLock struct {
+	Counter uint64                      `protobuf:"varint,1,opt,name=counter" json:"counter,omitempty"`
+	User    uint64                      `protobuf:"varint,2,opt,name=user" json:"user,omitempty"`
+	Hpid    uint64                      `protobuf:"varint,3,opt,name=hpid" json:"hpid,omitempty"`
+	Time    *google_protobuf2.Timestamp `protobuf:"bytes,4,opt,name=time" json:"time,omitempty"`
+}
+
+func (m *UserPostLock) Reset()                    { *m = UserPostLock{} }
+func (m *UserPostLock) String() string            { return proto1.CompactTextString(m) }
+func (*UserPostLock) ProtoMessage()               {}
+func (*UserPostLock) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+func (m *UserPostLock) GetCounter() uint64 {
+	if m != nil {
+		return m.Counter
+	}
+	return 0
+}
+
+func (m *UserPostLock) GetUser() uint64 {
+	if m != nil {
+		return m.User
+	}
+	return 0
+}
+
+func (m *UserPostLock) GetHpid() uint64 {
+	if m != nil {
+		return m.Hpid
+	}
+	return 0
+}
+
+func (m *UserPostLock) GetTime() *google_protobuf2.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+// UserPostUserLock disables the notifications of a UserPost caused by a single user
+type UserPostUserLock struct {
+	Counter uint64                      `protobuf:"varint,1,opt,name=counter" json:"counter,omitempty"`
+	From    uint64                      `protobuf:"varint,2,opt,name=from" json:"from,omitempty"`
+	To      uint64                      `protobuf:"varint,3,opt,name=to" json:"to,omitempty"`
+	Hpid    uint64                      `protobuf:"varint,4,opt,name=hpid" json:"hpid,omitempty"`
+	Time    *google_protobuf2.Timestamp `protobuf:"bytes,5,opt,name=time" json:"time,omitempty"`
+}
+
+func (m *UserPostUserLock) Reset()                    { *m = UserPostUserLock{} }
+func (m *UserPostUserLock) String() string            { return proto1.CompactTextString(m) }
+func (*UserPostUserLock) ProtoMessage()               {}
+func (*UserPostUserLock) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *UserPostUserLock) GetCounter() uint64 {
+	if m != nil {
+		return m.Counter
+	}
+	return 0
+}
+
+func (m *UserPostUserLock) GetFrom() uint64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *UserPostUserLock) GetTo() uint64 {
+	if m != nil {
+		return m.To
+	}
+	return 0
+}
+
+func (m *UserPostUserLock) GetHpid() uint64 {
+	if m != nil {
+		return m.Hpid
+	}
+	return 0
+}
+
+func (m *UserPostUserLock) GetTime() *google_protobuf2.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+// ProjectPostLock disables every notification of a ProjectPost for an user
+type ProjectPostLock struct {
+	Counter uint64                      `protobuf:"varint,1,opt,name=counter" json:"counter,omitempty"`
+	User    uint64                      `protobuf:"varint,2,opt,name=user" json:"user,omitempty"`
+	Hpid    uint64                      `protobuf:"varint,3,opt,name=hpid" json:"hpid,omitempty"`
+	Time    *google_protobuf2.Timestamp `protobuf:"bytes,4,opt,name=time" json:"time,omitempty"`
+}
+
+func (m *ProjectPostLock) Reset()                    { *m = ProjectPostLock{} }
+func (m *ProjectPostLock) String() string            { return proto1.CompactTextString(m) }
+func (*ProjectPostLock) ProtoMessage()               {}
+func (*ProjectPostLock) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+func (m *ProjectPostLock) GetCounter() uint64 {
+	if m != nil {
+		return m.Counter
+	}
+	return 0
+}
+
+func (m *ProjectPostLock) GetUser() uint64 {
+	if m != nil {
+		return m.User
+	}
+	return 0
+}
+
+func (m *ProjectPostLock) GetHpid() uint64 {
+	if m != nil {
+		return m.Hpid
+	}
+	return 0
+}
+
+func (m *ProjectPostLock) GetTime() *google_protobuf2.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+// ProjectPostUserLock disables the notifications of a ProjectPost caused by a single user
+type ProjectPostUserLock struct {
+	Counter uint64                      `protobuf:"varint,1,opt,name=counter" json:"counter,omitempty"`
+	From    uint64                      `protobuf:"varint,2,opt,name=from" json:"from,omitempty"`
+	To      uint64                      `protobuf:"varint,3,opt,name=to" json:"to,omitempty"`
+	Hpid    uint64                      `protobuf:"varint,4,opt,name=hpid" json:"hpid,omitempty"`
+	Time    *google_protobuf2.Timestamp `protobuf:"bytes,5,opt,name=time" json:"time,omitempty"`
+}
+
+func (m *ProjectPostUserLock) Reset()                    { *m = ProjectPostUserLock{} }
+func (m *ProjectPostUserLock) String() string            { return proto1.CompactTextString(m) }
+func (*ProjectPostUserLock) ProtoMessage()               {}
+func (*ProjectPostUserLock) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *ProjectPostUserLock) GetCounter() uint64 {
+	if m != nil {
+		return m.Counter
+	}
+	return 0
+}
+
+func (m *ProjectPostUserLock) GetFrom() uint64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *ProjectPostUserLock) GetTo() uint64 {
+	if m != nil {
+		return m.To
+	}
+	return 0
+}
+
+func (m *ProjectPostUserLock) GetHpid() uint64 {
+	if m != nil {
+		return m.Hpid
+	}
+	return 0
+}
+
+func (m *ProjectPostUserLock) GetTime() *google_protobuf2.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+// Application is the transfer object of an OAuth2 client. The secret is never transferred
+type Application struct {
+	Id          uint64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	Name        string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	RedirectUri string `protobuf:"bytes,3,opt,name=redirect_uri,json=redirectUri" json:"redirect_uri,omitempty"`
+	UserId      uint64 `protobuf:"varint,4,opt,name=user_id,json=userId" json:"user_id,omitempty"`
+	// public clients authenticate without secret and must use PKCE
+	Public bool `protobuf:"varint,5,opt,name=public" json:"public,omitempty"`
+}
+
+func (m *Application) Reset()                    { *m = Application{} }
+func (m *Application) String() string            { return proto1.CompactTextString(m) }
+func (*Application) ProtoMessage()               {}
+func (*Application) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+func (m *Application) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Application) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Application) GetRedirectUri() string {
+	if m != nil {
+		return m.RedirectUri
+	}
+	return ""
+}
+
+func (m *Application) GetUserId() uint64 {
+	if m != nil {
+		return m.UserId
+	}
+	return 0
+}
+
+func (m *Application) GetPublic() bool {
+	if m != nil {
+		return m.Public
+	}
+	return false
+}
+
+// Message is an element of the home: a post on a user or on a project board
+type Message struct {
+	// Types that are valid to be assigned to Post:
+	//	*Message_UserPost
+	//	*Message_ProjectPost
+	Post isMessage_Post `protobuf_oneof:"post"`
+}
+
+func (m *Message) Reset()                    { *m = Message{} }
+func (m *Message) String() string            { return proto1.CompactTextString(m) }
+func (*Message) ProtoMessage()               {}
+func (*Message) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
+type isMessage_Post interface{ isMessage_Post() }
+
+type Message_UserPost struct {
+	UserPost *UserPost `protobuf:"bytes,1,opt,name=user_post,json=userPost,oneof"`
+}
+type Message_ProjectPost struct {
+	ProjectPost *ProjectPost `protobuf:"bytes,2,opt,name=project_post,json=projectPost,oneof"`
+}
+
+func (*Message_UserPost) isMessage_Post()    {}
+func (*Message_ProjectPost) isMessage_Post() {}
+
+func (m *Message) GetPost() isMessage_Post {
+	if m != nil {
+		return m.Post
+	}
+	return nil
+}
+
+func (m *Message) GetUserPost() *UserPost {
+	if x, ok := m.GetPost().(*Message_UserPost); ok {
+		return x.UserPost
+	}
+	return nil
+}
+
+func (m *Message) GetProjectPost() *ProjectPost {
+	if x, ok := m.GetPost().(*Message_ProjectPost); ok {
+		return x.ProjectPost
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*Message) XXX_OneofFuncs() (func(msg proto1.Message, b *proto1.Buffer) error, func(msg proto1.Message, tag, wire int, b *proto1.Buffer) (bool, error), func(msg proto1.Message) (n int), []interface{}) {
+	return _Message_OneofMarshaler, _Message_OneofUnmarshaler, _Message_OneofSizer, []interface{}{
+		(*Message_UserPost)(nil),
+		(*Message_ProjectPost)(nil),
+	}
+}
+
+func _Message_OneofMarshaler(msg proto1.Message, b *proto1.Buffer) error {
+	m := msg.(*Message)
+	// post
+	switch x := m.Post.(type) {
+	case *Message_UserPost:
+		b.EncodeVarint(1<<3 | proto1.WireBytes)
+		if err := b.EncodeMessage(x.UserPost); err != nil {
+			return err
+		}
+	case *Message_ProjectPost:
+		b.EncodeVarint(2<<3 | proto1.WireBytes)
+		if err := b.EncodeMessage(x.ProjectPost); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("Message.Post has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _Message_OneofUnmarshaler(msg proto1.Message, tag, wire int, b *proto1.Buffer) (bool, error) {
+	m := msg.(*Message)
+	switch tag {
+	case 1: // post.user_post
+		if wire != proto1.WireBytes {
+			return true, proto1.ErrInternalBadWireType
+		}
+		msg := new(UserPost)
+		err := b.DecodeMessage(msg)
+		m.Post = &Message_UserPost{msg}
+		return true, err
+	case 2: // post.project_post
+		if wire != proto1.WireBytes {
+			return true, proto1.ErrInternalBadWireType
+		}
+		msg := new(ProjectPost)
+		err := b.DecodeMessage(msg)
+		m.Post = &Message_ProjectPost{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _Message_OneofSizer(msg proto1.Message) (n int) {
+	m := msg.(*Message)
+	// post
+	switch x := m.Post.(type) {
+	case *Message_UserPost:
+		s := proto1.Size(x.UserPost)
+		n += proto1.SizeVarint(1<<3 | proto1.WireBytes)
+		n += proto1.SizeVarint(uint64(s))
+		n += s
+	case *Message_ProjectPost:
+		s := proto1.Size(x.ProjectPost)
+		n += proto1.SizeVarint(2<<3 | proto1.WireBytes)
+		n += proto1.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+// Content is a generic message used by Nerdz
+type Content struct {
+	// Types that are valid to be assigned to Content:
+	//	*Content_UserPost
+	//	*Content_ProjectPost
+	//	*Content_UserPostComment
+	//	*Content_ProjectPostComment
+	//	*Content_Pm
+	Content isContent_Content `protobuf_oneof:"content"`
+}
+
+func (m *Content) Reset()                    { *m = Content{} }
+func (m *Content) String() string            { return proto1.CompactTextString(m) }
+func (*Content) ProtoMessage()               {}
+func (*Content) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+
+type isContent_Content interface{ isContent_Content() }
+
+type Content_UserPost struct {
+	UserPost *UserPost `protobuf:"bytes,1,opt,name=user_post,json=userPost,oneof"`
+}
+type Content_ProjectPost struct {
+	ProjectPost *ProjectPost `protobuf:"bytes,2,opt,name=project_post,json=projectPost,oneof"`
+}
+type Content_UserPostComment struct {
+	UserPostComment *UserPostComment `protobuf:"bytes,3,opt,name=user_post_comment,json=userPostComment,oneof"`
+}
+type Content_ProjectPostComment struct {
+	ProjectPostComment *ProjectPostComment `protobuf:"bytes,4,opt,name=project_post_comment,json=projectPostComment,oneof"`
+}
+type Content_Pm struct {
+	Pm *PM `protobuf:"bytes,5,opt,name=pm,oneof"`
+}
+
+func (*Content_UserPost) isContent_Content()           {}
+func (*Content_ProjectPost) isContent_Content()        {}
+func (*Content_UserPostComment) isContent_Content()    {}
+func (*Content_ProjectPostComment) isContent_Content() {}
+func (*Content_Pm) isContent_Content()                 {}
+
+func (m *Content) GetContent() isContent_Content {
+	if m != nil {
+		return m.Content
+	}
+	return nil
+}
+
+func (m *Content) GetUserPost() *UserPost {
+	if x, ok := m.GetContent().(*Content_UserPost); ok {
+		return x.UserPost
+	}
+	return nil
+}
+
+func (m *Content) GetProjectPost() *ProjectPost {
+	if x, ok := m.GetContent().(*Content_ProjectPost); ok {
+		return x.ProjectPost
+	}
+	return nil
+}
+
+func (m *Content) GetUserPostComment() *UserPostComment {
+	if x, ok := m.GetContent().(*Content_UserPostComment); ok {
+		return x.UserPostComment
+	}
+	return nil
+}
+
+func (m *Content) GetProjectPostComment() *ProjectPostComment {
+	if x, ok := m.GetContent().(*Content_ProjectPostComment); ok {
+		return x.ProjectPostComment
+	}
+	return nil
+}
+
+func (m *Content) GetPm() *PM {
+	if x, ok := m.GetContent().(*Content_Pm); ok {
+		return x.Pm
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*Content) XXX_OneofFuncs() (func(msg proto1.Message, b *proto1.Buffer) error, func(msg proto1.Message, tag, wire int, b *proto1.Buffer) (bool, error), func(msg proto1.Message) (n int), []interface{}) {
+	return _Content_OneofMarshaler, _Content_OneofUnmarshaler, _Content_OneofSizer, []interface{}{
+		(*Content_UserPost)(nil),
+		(*Content_ProjectPost)(nil),
+		(*Content_UserPostComment)(nil),
+		(*Content_ProjectPostComment)(nil),
+		(*Content_Pm)(nil),
+	}
+}
+
+func _Content_OneofMarshaler(msg proto1.Message, b *proto1.Buffer) error {
+	m := msg.(*Content)
+	// content
+	switch x := m.Content.(type) {
+	case *Content_UserPost:
+		b.EncodeVarint(1<<3 | proto1.WireBytes)
+		if err := b.EncodeMessage(x.UserPost); err != nil {
+			return err
+		}
+	case *Content_ProjectPost:
+		b.EncodeVarint(2<<3 | proto1.WireBytes)
+		if err := b.EncodeMessage(x.ProjectPost); err != nil {
+			return err
+		}
+	case *Content_UserPostComment:
+		b.EncodeVarint(3<<3 | proto1.WireBytes)
+		if err := b.EncodeMessage(x.UserPostComment); err != nil {
+			return err
+		}
+	case *Content_ProjectPostComment:
+		b.EncodeVarint(4<<3 | proto1.WireBytes)
+		if err := b.EncodeMessage(x.ProjectPostComment); err != nil {
+			return err
+		}
+	case *Content_Pm:
+		b.EncodeVarint(5<<3 | proto1.WireBytes)
+		if err := b.EncodeMessage(x.Pm); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("Content.Content has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _Content_OneofUnmarshaler(msg proto1.Message, tag, wire int, b *proto1.Buffer) (bool, error) {
+	m := msg.(*Content)
+	switch tag {
+	case 1: // content.user_post
+		if wire != proto1.WireBytes {
+			return true, proto1.ErrInternalBadWireType
+		}
+		msg := new(UserPost)
+		err := b.DecodeMessage(msg)
+		m.Content = &Content_UserPost{msg}
+		return true, err
+	case 2: // content.project_post
+		if wire != proto1.WireBytes {
+			return true, proto1.ErrInternalBadWireType
+		}
+		msg := new(ProjectPost)
+		err := b.DecodeMessage(msg)
+		m.Content = &Content_ProjectPost{msg}
+		return true, err
+	case 3: // content.user_post_comment
+		if wire != proto1.WireBytes {
+			return true, proto1.ErrInternalBadWireType
+		}
+		msg := new(UserPostComment)
+		err := b.DecodeMessage(msg)
+		m.Content = &Content_UserPostComment{msg}
+		return true, err
+	case 4: // content.project_post_comment
+		if wire != proto1.WireBytes {
+			return true, proto1.ErrInternalBadWireType
+		}
+		msg := new(ProjectPostComment)
+		err := b.DecodeMessage(msg)
+		m.Content = &Content_ProjectPostComment{msg}
+		return true, err
+	case 5: // content.pm
+		if wire != proto1.WireBytes {
+			return true, proto1.ErrInternalBadWireType
+		}
+		msg := new(PM)
+		err := b.DecodeMessage(msg)
+		m.Content = &Content_Pm{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _Content_OneofSizer(msg proto1.Message) (n int) {
+	m := msg.(*Content)
+	// content
+	switch x := m.Content.(type) {
+	case *Content_UserPost:
+		s := proto1.Size(x.UserPost)
+		n += proto1.SizeVarint(1<<3 | proto1.WireBytes)
+		n += proto1.SizeVarint(uint64(s))
+		n += s
+	case *Content_ProjectPost:
+		s := proto1.Size(x.ProjectPost)
+		n += proto1.SizeVarint(2<<3 | proto1.WireBytes)
+		n += proto1.SizeVarint(uint64(s))
+		n += s
+	case *Content_UserPostComment:
+		s := proto1.Size(x.UserPostComment)
+		n += proto1.SizeVarint(3<<3 | proto1.WireBytes)
+		n += proto1.SizeVarint(uint64(s))
+		n += s
+	case *Content_ProjectPostComment:
+		s := proto1.Size(x.ProjectPostComment)
+		n += proto1.SizeVarint(4<<3 | proto1.WireBytes)
+		n += proto1.SizeVarint(uint64(s))
+		n += s
+	case *Content_Pm:
+		s := proto1.Size(x.Pm)
+		n += proto1.SizeVarint(5<<3 | proto1.WireBytes)
+		n += proto1.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+// ContentID references an existing Content
+type ContentID struct {
+	Type ContentType `protobuf:"varint,1,opt,name=type,enum=nerdz.ContentType" json:"type,omitempty"`
+	Id   uint64      `protobuf:"varint,2,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *ContentID) Reset()                    { *m = ContentID{} }
+func (m *ContentID) String() string            { return proto1.CompactTextString(m) }
+func (*ContentID) ProtoMessage()               {}
+func (*ContentID) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+
+func (m *ContentID) GetType() ContentType {
+	if m != nil {
+		return m.Type
+	}
+	return ContentType_USER_POST
+}
+
+func (m *ContentID) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+// Event is something that happened on NERDZ, pushed to the users it concerns
+type Event struct {
+	Type EventType `protobuf:"varint,1,opt,name=type,enum=nerdz.EventType" json:"type,omitempty"`
+	// from is the user that caused the event
+	From uint64 `protobuf:"varint,2,opt,name=from" json:"from,omitempty"`
+	// content is the content created, voted or that mentions the user. It's missing for NEW_FOLLOWER
+	Content *ContentID `protobuf:"bytes,3,opt,name=content" json:"content,omitempty"`
+	// board is the board of the post or of the comment, or the followed board. It's missing for NEW_PM
+	BoardType BoardType `protobuf:"varint,4,opt,name=board_type,json=boardType,enum=nerdz.BoardType" json:"board_type,omitempty"`
+	Board     uint64    `protobuf:"varint,5,opt,name=board" json:"board,omitempty"`
+	// vote is the value of a NEW_VOTE
+	Vote int32                       `protobuf:"varint,6,opt,name=vote" json:"vote,omitempty"`
+	Time *google_protobuf2.Timestamp `protobuf:"bytes,7,opt,name=time" json:"time,omitempty"`
+}
+
+func (m *Event) Reset()                    { *m = Event{} }
+func (m *Event) String() string            { return proto1.CompactTextString(m) }
+func (*Event) ProtoMessage()               {}
+func (*Event) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+
+func (m *Event) GetType() EventType {
+	if m != nil {
+		return m.Type
+	}
+	return EventType_NEW_POST
+}
+
+func (m *Event) GetFrom() uint64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *Event) GetContent() *ContentID {
+	if m != nil {
+		return m.Content
+	}
+	return nil
+}
+
+func (m *Event) GetBoardType() BoardType {
+	if m != nil {
+		return m.BoardType
+	}
+	return BoardType_USER
+}
+
+func (m *Event) GetBoard() uint64 {
+	if m != nil {
+		return m.Board
+	}
+	return 0
+}
+
+func (m *Event) GetVote() int32 {
+	if m != nil {
+		return m.Vote
+	}
+	return 0
+}
+
+func (m *Event) GetTime() *google_protobuf2.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+// Notification tells the user something that happened on NERDZ
+type Notification struct {
+	Type NotificationType `protobuf:"varint,1,opt,name=type,enum=nerdz.NotificationType" json:"type,omitempty"`
+	// from is the user that caused the notification. It's missing for NOTIFICATION_MEMBER
+	From uint64 `protobuf:"varint,2,opt,name=from" json:"from,omitempty"`
+	// board is the board of the post, the followed board or the project the user is a member of
+	BoardType BoardType `protobuf:"varint,3,opt,name=board_type,json=boardType,enum=nerdz.BoardType" json:"board_type,omitempty"`
+	Board     uint64    `protobuf:"varint,4,opt,name=board" json:"board,omitempty"`
+	// post is the post commented, created or that mentions the user, if any
+	Post *ContentID                  `protobuf:"bytes,5,opt,name=post" json:"post,omitempty"`
+	Time *google_protobuf2.Timestamp `protobuf:"bytes,6,opt,name=time" json:"time,omitempty"`
+}
+
+func (m *Notification) Reset()                    { *m = Notification{} }
+func (m *Notification) String() string            { return proto1.CompactTextString(m) }
+func (*Notification) ProtoMessage()               {}
+func (*Notification) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+
+func (m *Notification) GetType() NotificationType {
+	if m != nil {
+		return m.Type
+	}
+	return NotificationType_NOTIFICATION_COMMENT
+}
+
+func (m *Notification) GetFrom() uint64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *Notification) GetBoardType() BoardType {
+	if m != nil {
+		return m.BoardType
+	}
+	return BoardType_USER
+}
+
+func (m *Notification) GetBoard() uint64 {
+	if m != nil {
+		return m.Board
+	}
+	return 0
+}
+
+func (m *Notification) GetPost() *ContentID {
+	if m != nil {
+		return m.Post
+	}
+	return nil
+}
+
+func (m *Notification) GetTime() *google_protobuf2.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+// Mention is a reference to a user, with @username or [user]username[/user], in a post or in one of its comments
+type Mention struct {
+	Id uint64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	// from is the author of the content that mentions the user to
+	From uint64 `protobuf:"varint,2,opt,name=from" json:"from,omitempty"`
+	To   uint64 `protobuf:"varint,3,opt,name=to" json:"to,omitempty"`
+	// post is the post of the content that mentions the user
+	Post *ContentID                  `protobuf:"bytes,4,opt,name=post" json:"post,omitempty"`
+	Time *google_protobuf2.Timestamp `protobuf:"bytes,5,opt,name=time" json:"time,omitempty"`
+}
+
+func (m *Mention) Reset()                    { *m = Mention{} }
+func (m *Mention) String() string            { return proto1.CompactTextString(m) }
+func (*Mention) ProtoMessage()               {}
+func (*Mention) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+
+func (m *Mention) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Mention) GetFrom() uint64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *Mention) GetTo() uint64 {
+	if m != nil {
+		return m.To
+	}
+	return 0
+}
+
+func (m *Mention) GetPost() *ContentID {
+	if m != nil {
+		return m.Post
+	}
+	return nil
+}
+
+func (m *Mention) GetTime() *google_protobuf2.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+// Trend is a tag, with the number of posts it classifies
+type Trend struct {
+	Tag   string `protobuf:"bytes,1,opt,name=tag" json:"tag,omitempty"`
+	Count uint64 `protobuf:"varint,2,opt,name=count" json:"count,omitempty"`
+}
+
+func (m *Trend) Reset()                    { *m = Trend{} }
+func (m *Trend) String() string            { return proto1.CompactTextString(m) }
+func (*Trend) ProtoMessage()               {}
+func (*Trend) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+
+func (m *Trend) GetTag() string {
+	if m != nil {
+		return m.Tag
+	}
+	return ""
+}
+
+func (m *Trend) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type UserList struct {
+	Users []*User `protobuf:"bytes,1,rep,name=users" json:"users,omitempty"`
+}
+
+func (m *UserList) Reset()                    { *m = UserList{} }
+func (m *UserList) String() string            { return proto1.CompactTextString(m) }
+func (*UserList) ProtoMessage()               {}
+func (*UserList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+
+func (m *UserList) GetUsers() []*User {
+	if m != nil {
+		return m.Users
+	}
+	return nil
+}
+
+type ProjectList struct {
+	Projects []*Project `protobuf:"bytes,1,rep,name=projects" json:"projects,omitempty"`
+}
+
+func (m *ProjectList) Reset()                    { *m = ProjectList{} }
+func (m *ProjectList) String() string            { return proto1.CompactTextString(m) }
+func (*ProjectList) ProtoMessage()               {}
+func (*ProjectList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+
+func (m *ProjectList) GetProjects() []*Project {
+	if m != nil {
+		return m.Projects
+	}
+	return nil
+}
+
+type MessageList struct {
+	Messages []*Message `protobuf:"bytes,1,rep,name=messages" json:"messages,omitempty"`
+	Next     string     `protobuf:"bytes,2,opt,name=next" json:"next,omitempty"`
+	Prev     string     `protobuf:"bytes,3,opt,name=prev" json:"prev,omitempty"`
+}
+
+func (m *MessageList) Reset()                    { *m = MessageList{} }
+func (m *MessageList) String() string            { return proto1.CompactTextString(m) }
+func (*MessageList) ProtoMessage()               {}
+func (*MessageList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+
+func (m *MessageList) GetMessages() []*Message {
+	if m != nil {
+		return m.Messages
+	}
+	return nil
+}
+
+func (m *MessageList) GetNext() string {
+	if m != nil {
+		return m.Next
+	}
+	return ""
+}
+
+func (m *MessageList) GetPrev() string {
+	if m != nil {
+		return m.Prev
+	}
+	return ""
+}
+
+type ContentList struct {
+	Contents []*Content `protobuf:"bytes,1,rep,name=contents" json:"contents,omitempty"`
+	Next     string     `protobuf:"bytes,2,opt,name=next" json:"next,omitempty"`
+	Prev     string     `protobuf:"bytes,3,opt,name=prev" json:"prev,omitempty"`
+}
+
+func (m *ContentList) Reset()                    { *m = ContentList{} }
+func (m *ContentList) String() string            { return proto1.CompactTextString(m) }
+func (*ContentList) ProtoMessage()               {}
+func (*ContentList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+
+func (m *ContentList) GetContents() []*Content {
+	if m != nil {
+		return m.Contents
+	}
+	return nil
+}
+
+func (m *ContentList) GetNext() string {
+	if m != nil {
+		return m.Next
+	}
+	return ""
+}
+
+func (m *ContentList) GetPrev() string {
+	if m != nil {
+		return m.Prev
+	}
+	return ""
+}
+
+type ConversationList struct {
+	Conversations []*Conversation `protobuf:"bytes,1,rep,name=conversations" json:"conversations,omitempty"`
+}
+
+func (m *ConversationList) Reset()                    { *m = ConversationList{} }
+func (m *ConversationList) String() string            { return proto1.CompactTextString(m) }
+func (*ConversationList) ProtoMessage()               {}
+func (*ConversationList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+
+func (m *ConversationList) GetConversations() []*Conversation {
+	if m != nil {
+		return m.Conversations
+	}
+	return nil
+}
+
+type NotificationList struct {
+	Notifications []*Notification `protobuf:"bytes,1,rep,name=notifications" json:"notifications,omitempty"`
+	Next          string          `protobuf:"bytes,2,opt,name=next" json:"next,omitempty"`
+	Prev          string          `protobuf:"bytes,3,opt,name=prev" json:"prev,omitempty"`
+}
+
+func (m *NotificationList) Reset()                    { *m = NotificationList{} }
+func (m *NotificationList) String() string            { return proto1.CompactTextString(m) }
+func (*NotificationList) ProtoMessage()               {}
+func (*NotificationList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+
+func (m *NotificationList) GetNotifications() []*Notification {
+	if m != nil {
+		return m.Notifications
+	}
+	return nil
+}
+
+func (m *NotificationList) GetNext() string {
+	if m != nil {
+		return m.Next
+	}
+	return ""
+}
+
+func (m *NotificationList) GetPrev() string {
+	if m != nil {
+		return m.Prev
+	}
+	return ""
+}
+
+type MentionList struct {
+	Mentions []*Mention `protobuf:"bytes,1,rep,name=mentions" json:"mentions,omitempty"`
+	Next     string     `protobuf:"bytes,2,opt,name=next" json:"next,omitempty"`
+	Prev     string     `protobuf:"bytes,3,opt,name=prev" json:"prev,omitempty"`
+}
+
+func (m *MentionList) Reset()                    { *m = MentionList{} }
+func (m *MentionList) String() string            { return proto1.CompactTextString(m) }
+func (*MentionList) ProtoMessage()               {}
+func (*MentionList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+
+func (m *MentionList) GetMentions() []*Mention {
+	if m != nil {
+		return m.Mentions
+	}
+	return nil
+}
+
+func (m *MentionList) GetNext() string {
+	if m != nil {
+		return m.Next
+	}
+	return ""
+}
+
+func (m *MentionList) GetPrev() string {
+	if m != nil {
+		return m.Prev
+	}
+	return ""
+}
+
+type TrendList struct {
+	Trends []*Trend `protobuf:"bytes,1,rep,name=trends" json:"trends,omitempty"`
+}
+
+func (m *TrendList) Reset()                    { *m = TrendList{} }
+func (m *TrendList) String() string            { return proto1.CompactTextString(m) }
+func (*TrendList) ProtoMessage()               {}
+func (*TrendList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+
+func (m *TrendList) GetTrends() []*Trend {
+	if m != nil {
+		return m.Trends
+	}
+	return nil
+}
+
+type ApplicationList struct {
+	Applications []*Application `protobuf:"bytes,1,rep,name=applications" json:"applications,omitempty"`
+}
+
+func (m *ApplicationList) Reset()                    { *m = ApplicationList{} }
+func (m *ApplicationList) String() string            { return proto1.CompactTextString(m) }
+func (*ApplicationList) ProtoMessage()               {}
+func (*ApplicationList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+
+func (m *ApplicationList) GetApplications() []*Application {
+	if m != nil {
+		return m.Applications
+	}
+	return nil
+}
+
+// PostlistOptions is used to specify the options for a list of posts.
+// older and newer are the next and prev cursors of a page of the same list
+type PostlistOptions struct {
+	Following bool     `protobuf:"varint,1,opt,name=following" json:"following,omitempty"`
+	Followers bool     `protobuf:"varint,2,opt,name=followers" json:"followers,omitempty"`
+	Language  Language `protobuf:"varint,3,opt,name=language,enum=nerdz.Language" json:"language,omitempty"`
+	N         uint32   `protobuf:"varint,4,opt,name=n" json:"n,omitempty"`
+	Older     string   `protobuf:"bytes,9,opt,name=older" json:"older,omitempty"`
+	Newer     string   `protobuf:"bytes,10,opt,name=newer" json:"newer,omitempty"`
+}
+
+func (m *PostlistOptions) Reset()                    { *m = PostlistOptions{} }
+func (m *PostlistOptions) String() string            { return proto1.CompactTextString(m) }
+func (*PostlistOptions) ProtoMessage()               {}
+func (*PostlistOptions) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+
+func (m *PostlistOptions) GetFollowing() bool {
+	if m != nil {
+		return m.Following
+	}
+	return false
+}
+
+func (m *PostlistOptions) GetFollowers() bool {
+	if m != nil {
+		return m.Followers
+	}
+	return false
+}
+
+func (m *PostlistOptions) GetLanguage() Language {
+	if m != nil {
+		return m.Language
+	}
+	return Language_INVALID
+}
+
+func (m *PostlistOptions) GetN() uint32 {
+	if m != nil {
+		return m.N
+	}
+	return 0
+}
+
+func (m *PostlistOptions) GetOlder() string {
+	if m != nil {
+		return m.Older
+	}
+	return ""
+}
+
+func (m *PostlistOptions) GetNewer() string {
+	if m != nil {
+		return m.Newer
+	}
+	return ""
+}
+
+// CommentlistOptions is used to specify the options for a list of comments
+type CommentlistOptions struct {
+	N     uint32 `protobuf:"varint,1,opt,name=n" json:"n,omitempty"`
+	Older string `protobuf:"bytes,4,opt,name=older" json:"older,omitempty"`
+	Newer string `protobuf:"bytes,5,opt,name=newer" json:"newer,omitempty"`
+}
+
+func (m *CommentlistOptions) Reset()                    { *m = CommentlistOptions{} }
+func (m *CommentlistOptions) String() string            { return proto1.CompactTextString(m) }
+func (*CommentlistOptions) ProtoMessage()               {}
+func (*CommentlistOptions) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
+
+func (m *CommentlistOptions) GetN() uint32 {
+	if m != nil {
+		return m.N
+	}
+	return 0
+}
+
+func (m *CommentlistOptions) GetOlder() string {
+	if m != nil {
+		return m.Older
+	}
+	return ""
+}
+
+func (m *CommentlistOptions) GetNewer() string {
+	if m != nil {
+		return m.Newer
+	}
+	return ""
+}
+
+// PmsOptions is used to specify the options for a list of pms
+type PmsOptions struct {
+	N     uint32 `protobuf:"varint,1,opt,name=n" json:"n,omitempty"`
+	Older string `protobuf:"bytes,4,opt,name=older" json:"older,omitempty"`
+	Newer string `protobuf:"bytes,5,opt,name=newer" json:"newer,omitempty"`
+}
+
+func (m *PmsOptions) Reset()                    { *m = PmsOptions{} }
+func (m *PmsOptions) String() string            { return proto1.CompactTextString(m) }
+func (*PmsOptions) ProtoMessage()               {}
+func (*PmsOptions) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
+
+func (m *PmsOptions) GetN() uint32 {
+	if m != nil {
+		return m.N
+	}
+	return 0
+}
+
+func (m *PmsOptions) GetOlder() string {
+	if m != nil {
+		return m.Older
+	}
+	return ""
+}
+
+func (m *PmsOptions) GetNewer() string {
+	if m != nil {
+		return m.Newer
+	}
+	return ""
+}
+
+// NotificationsOptions is used to specify the options for a list of notifications
+type NotificationsOptions struct {
+	N     uint32 `protobuf:"varint,1,opt,name=n" json:"n,omitempty"`
+	Older string `protobuf:"bytes,2,opt,name=older" json:"older,omitempty"`
+	Newer string `protobuf:"bytes,3,opt,name=newer" json:"newer,omitempty"`
+	// types selects the notifications of these types. Every type, if empty
+	Types []NotificationType `protobuf:"varint,4,rep,packed,name=types,enum=nerdz.NotificationType" json:"types,omitempty"`
+	// read selects the read notifications, instead of the unread ones
+	Read bool `protobuf:"varint,5,opt,name=read" json:"read,omitempty"`
+}
+
+func (m *NotificationsOptions) Reset()                    { *m = NotificationsOptions{} }
+func (m *NotificationsOptions) String() string            { return proto1.CompactTextString(m) }
+func (*NotificationsOptions) ProtoMessage()               {}
+func (*NotificationsOptions) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
+
+func (m *NotificationsOptions) GetN() uint32 {
+	if m != nil {
+		return m.N
+	}
+	return 0
+}
+
+func (m *NotificationsOptions) GetOlder() string {
+	if m != nil {
+		return m.Older
+	}
+	return ""
+}
+
+func (m *NotificationsOptions) GetNewer() string {
+	if m != nil {
+		return m.Newer
+	}
+	return ""
+}
+
+func (m *NotificationsOptions) GetTypes() []NotificationType {
+	if m != nil {
+		return m.Types
+	}
+	return nil
+}
+
+func (m *NotificationsOptions) GetRead() bool {
+	if m != nil {
+		return m.Read
+	}
+	return false
+}
+
+// MentionsOptions is used to specify the options for a list of mentions
+type MentionsOptions struct {
+	N     uint32 `protobuf:"varint,1,opt,name=n" json:"n,omitempty"`
+	Older string `protobuf:"bytes,2,opt,name=older" json:"older,omitempty"`
+	Newer string `protobuf:"bytes,3,opt,name=newer" json:"newer,omitempty"`
+}
+
+func (m *MentionsOptions) Reset()                    { *m = MentionsOptions{} }
+func (m *MentionsOptions) String() string            { return proto1.CompactTextString(m) }
+func (*MentionsOptions) ProtoMessage()               {}
+func (*MentionsOptions) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
+
+func (m *MentionsOptions) GetN() uint32 {
+	if m != nil {
+		return m.N
+	}
+	return 0
+}
+
+func (m *MentionsOptions) GetOlder() string {
+	if m != nil {
+		return m.Older
+	}
+	return ""
+}
+
+func (m *MentionsOptions) GetNewer() string {
+	if m != nil {
+		return m.Newer
+	}
+	return ""
+}
+
+type UserRequest struct {
+	Id uint64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *UserRequest) Reset()                    { *m = UserRequest{} }
+func (m *UserRequest) String() string            { return proto1.CompactTextString(m) }
+func (*UserRequest) ProtoMessage()               {}
+func (*UserRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
+
+func (m *UserRequest) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type ProjectRequest struct {
+	Id uint64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *ProjectRequest) Reset()                    { *m = ProjectRequest{} }
+func (m *ProjectRequest) String() string            { return proto1.CompactTextString(m) }
+func (*ProjectRequest) ProtoMessage()               {}
+func (*ProjectRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
+
+func (m *ProjectRequest) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type PostlistRequest struct {
+	Id      uint64           `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	Options *PostlistOptions `protobuf:"bytes,2,opt,name=options" json:"options,omitempty"`
+}
+
+func (m *PostlistRequest) Reset()                    { *m = PostlistRequest{} }
+func (m *PostlistRequest) String() string            { return proto1.CompactTextString(m) }
+func (*PostlistRequest) ProtoMessage()               {}
+func (*PostlistRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
+
+func (m *PostlistRequest) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *PostlistRequest) GetOptions() *PostlistOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+type HomeRequest struct {
+	Options *PostlistOptions `protobuf:"bytes,2,opt,name=options" json:"options,omitempty"`
+}
+
+func (m *HomeRequest) Reset()                    { *m = HomeRequest{} }
+func (m *HomeRequest) String() string            { return proto1.CompactTextString(m) }
+func (*HomeRequest) ProtoMessage()               {}
+func (*HomeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
+
+func (m *HomeRequest) GetOptions() *PostlistOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+type CommentsRequest struct {
+	Post    *ContentID          `protobuf:"bytes,1,opt,name=post" json:"post,omitempty"`
+	Options *CommentlistOptions `protobuf:"bytes,2,opt,name=options" json:"options,omitempty"`
+}
+
+func (m *CommentsRequest) Reset()                    { *m = CommentsRequest{} }
+func (m *CommentsRequest) String() string            { return proto1.CompactTextString(m) }
+func (*CommentsRequest) ProtoMessage()               {}
+func (*CommentsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{52} }
+
+func (m *CommentsRequest) GetPost() *ContentID {
+	if m != nil {
+		return m.Post
+	}
+	return nil
+}
+
+func (m *CommentsRequest) GetOptions() *CommentlistOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+type MentionsRequest struct {
+	Post    *ContentID       `protobuf:"bytes,1,opt,name=post" json:"post,omitempty"`
+	Options *MentionsOptions `protobuf:"bytes,2,opt,name=options" json:"options,omitempty"`
+}
+
+func (m *MentionsRequest) Reset()                    { *m = MentionsRequest{} }
+func (m *MentionsRequest) String() string            { return proto1.CompactTextString(m) }
+func (*MentionsRequest) ProtoMessage()               {}
+func (*MentionsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{53} }
+
+func (m *MentionsRequest) GetPost() *ContentID {
+	if m != nil {
+		return m.Post
+	}
+	return nil
+}
+
+func (m *MentionsRequest) GetOptions() *MentionsOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+// TaggedRequest selects the posts classified with tag, with or without the leading #
+type TaggedRequest struct {
+	Tag     string           `protobuf:"bytes,1,opt,name=tag" json:"tag,omitempty"`
+	Options *PostlistOptions `protobuf:"bytes,2,opt,name=options" json:"options,omitempty"`
+}
+
+func (m *TaggedRequest) Reset()                    { *m = TaggedRequest{} }
+func (m *TaggedRequest) String() string            { return proto1.CompactTextString(m) }
+func (*TaggedRequest) ProtoMessage()               {}
+func (*TaggedRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{54} }
+
+func (m *TaggedRequest) GetTag() string {
+	if m != nil {
+		return m.Tag
+	}
+	return ""
+}
+
+func (m *TaggedRequest) GetOptions() *PostlistOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+// TrendingRequest selects at most n tags of the posts created in the last window.
+// The window must be positive: it defaults to the trending_window of the server,
+// and is narrowed to its max_trending_window
+type TrendingRequest struct {
+	N      uint32                    `protobuf:"varint,1,opt,name=n" json:"n,omitempty"`
+	Window *google_protobuf.Duration `protobuf:"bytes,2,opt,name=window" json:"window,omitempty"`
+}
+
+func (m *TrendingRequest) Reset()                    { *m = TrendingRequest{} }
+func (m *TrendingRequest) String() string            { return proto1.CompactTextString(m) }
+func (*TrendingRequest) ProtoMessage()               {}
+func (*TrendingRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{55} }
+
+func (m *TrendingRequest) GetN() uint32 {
+	if m != nil {
+		return m.N
+	}
+	return 0
+}
+
+func (m *TrendingRequest) GetWindow() *google_protobuf.Duration {
+	if m != nil {
+		return m.Window
+	}
+	return nil
+}
+
+// BoardRequest references the board of an user or of a project
+type BoardRequest struct {
+	Type BoardType `protobuf:"varint,2,opt,name=type,enum=nerdz.BoardType" json:"type,omitempty"`
+	Id   uint64    `protobuf:"varint,3,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *BoardRequest) Reset()                    { *m = BoardRequest{} }
+func (m *BoardRequest) String() string            { return proto1.CompactTextString(m) }
+func (*BoardRequest) ProtoMessage()               {}
+func (*BoardRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{56} }
+
+func (m *BoardRequest) GetType() BoardType {
+	if m != nil {
+		return m.Type
+	}
+	return BoardType_USER
+}
+
+func (m *BoardRequest) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+// UserActionRequest is the request of an action of the user on another user
+type UserActionRequest struct {
+	Other uint64 `protobuf:"varint,2,opt,name=other" json:"other,omitempty"`
+	// motivation is used only when blacklisting
+	Motivation string `protobuf:"bytes,3,opt,name=motivation" json:"motivation,omitempty"`
+}
+
+func (m *UserActionRequest) Reset()                    { *m = UserActionRequest{} }
+func (m *UserActionRequest) String() string            { return proto1.CompactTextString(m) }
+func (*UserActionRequest) ProtoMessage()               {}
+func (*UserActionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{57} }
+
+func (m *UserActionRequest) GetOther() uint64 {
+	if m != nil {
+		return m.Other
+	}
+	return 0
+}
+
+func (m *UserActionRequest) GetMotivation() string {
+	if m != nil {
+		return m.Motivation
+	}
+	return ""
+}
+
+type SubmitRequest struct {
+	Content *Content `protobuf:"bytes,2,opt,name=content" json:"content,omitempty"`
+}
+
+func (m *SubmitRequest) Reset()                    { *m = SubmitRequest{} }
+func (m *SubmitRequest) String() string            { return proto1.CompactTextString(m) }
+func (*SubmitRequest) ProtoMessage()               {}
+func (*SubmitRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{58} }
+
+func (m *SubmitRequest) GetContent() *Content {
+	if m != nil {
+		return m.Content
+	}
+	return nil
+}
+
+type EditRequest struct {
+	Content *ContentID `protobuf:"bytes,2,opt,name=content" json:"content,omitempty"`
+	Message string     `protobuf:"bytes,3,opt,name=message" json:"message,omitempty"`
+}
+
+func (m *EditRequest) Reset()                    { *m = EditRequest{} }
+func (m *EditRequest) String() string            { return proto1.CompactTextString(m) }
+func (*EditRequest) ProtoMessage()               {}
+func (*EditRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{59} }
+
+func (m *EditRequest) GetContent() *ContentID {
+	if m != nil {
+		return m.Content
+	}
+	return nil
+}
+
+func (m *EditRequest) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+type ContentRequest struct {
+	Content *ContentID `protobuf:"bytes,2,opt,name=content" json:"content,omitempty"`
+}
+
+func (m *ContentRequest) Reset()                    { *m = ContentRequest{} }
+func (m *ContentRequest) String() string            { return proto1.CompactTextString(m) }
+func (*ContentRequest) ProtoMessage()               {}
+func (*ContentRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{60} }
+
+func (m *ContentRequest) GetContent() *ContentID {
+	if m != nil {
+		return m.Content
+	}
+	return nil
+}
+
+type VoteRequest struct {
+	Content *ContentID `protobuf:"bytes,2,opt,name=content" json:"content,omitempty"`
+	Vote    int32      `protobuf:"varint,3,opt,name=vote" json:"vote,omitempty"`
+}
+
+func (m *VoteRequest) Reset()                    { *m = VoteRequest{} }
+func (m *VoteRequest) String() string            { return proto1.CompactTextString(m) }
+func (*VoteRequest) ProtoMessage()               {}
+func (*VoteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{61} }
+
+func (m *VoteRequest) GetContent() *ContentID {
+	if m != nil {
+		return m.Content
+	}
+	return nil
+}
+
+func (m *VoteRequest) GetVote() int32 {
+	if m != nil {
+		return m.Vote
+	}
+	return 0
+}
+
+// LockRequest locks a post. If users is not empty, only the notifications
+// caused by these users are disabled
+type LockRequest struct {
+	Post  *ContentID `protobuf:"bytes,2,opt,name=post" json:"post,omitempty"`
+	Users []uint64   `protobuf:"varint,3,rep,packed,name=users" json:"users,omitempty"`
+}
+
+func (m *LockRequest) Reset()                    { *m = LockRequest{} }
+func (m *LockRequest) String() string            { return proto1.CompactTextString(m) }
+func (*LockRequest) ProtoMessage()               {}
+func (*LockRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{62} }
+
+func (m *LockRequest) GetPost() *ContentID {
+	if m != nil {
+		return m.Post
+	}
+	return nil
+}
+
+func (m *LockRequest) GetUsers() []uint64 {
+	if m != nil {
+		return m.Users
+	}
+	return nil
+}
+
+type PmsRequest struct {
+	Other   uint64      `protobuf:"varint,2,opt,name=other" json:"other,omitempty"`
+	Options *PmsOptions `protobuf:"bytes,3,opt,name=options" json:"options,omitempty"`
+}
+
+func (m *PmsRequest) Reset()                    { *m = PmsRequest{} }
+func (m *PmsRequest) String() string            { return proto1.CompactTextString(m) }
+func (*PmsRequest) ProtoMessage()               {}
+func (*PmsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{63} }
+
+func (m *PmsRequest) GetOther() uint64 {
+	if m != nil {
+		return m.Other
+	}
+	return 0
+}
+
+func (m *PmsRequest) GetOptions() *PmsOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+type ConversationRequest struct {
+	Other uint64 `protobuf:"varint,2,opt,name=other" json:"other,omitempty"`
+}
+
+func (m *ConversationRequest) Reset()                    { *m = ConversationRequest{} }
+func (m *ConversationRequest) String() string            { return proto1.CompactTextString(m) }
+func (*ConversationRequest) ProtoMessage()               {}
+func (*ConversationRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{64} }
+
+func (m *ConversationRequest) GetOther() uint64 {
+	if m != nil {
+		return m.Other
+	}
+	return 0
+}
+
+// ChatRequest is an action of the user on a Pms.Chat stream
+type ChatRequest struct {
+	// id is chosen by the client, and returned in the ChatEvent that answers the request
+	Id uint64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	// Types that are valid to be assigned to Action:
+	//	*ChatRequest_Send
+	//	*ChatRequest_Typing
+	//	*ChatRequest_Read
+	Action isChatRequest_Action `protobuf_oneof:"action"`
+}
+
+func (m *ChatRequest) Reset()                    { *m = ChatRequest{} }
+func (m *ChatRequest) String() string            { return proto1.CompactTextString(m) }
+func (*ChatRequest) ProtoMessage()               {}
+func (*ChatRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{65} }
+
+type isChatRequest_Action interface{ isChatRequest_Action() }
+
+type ChatRequest_Send struct {
+	Send *PM `protobuf:"bytes,2,opt,name=send,oneof"`
+}
+type ChatRequest_Typing struct {
+	Typing uint64 `protobuf:"varint,3,opt,name=typing,oneof"`
+}
+type ChatRequest_Read struct {
+	Read uint64 `protobuf:"varint,4,opt,name=read,oneof"`
+}
+
+func (*ChatRequest_Send) isChatRequest_Action()   {}
+func (*ChatRequest_Typing) isChatRequest_Action() {}
+func (*ChatRequest_Read) isChatRequest_Action()   {}
+
+func (m *ChatRequest) GetAction() isChatRequest_Action {
+	if m != nil {
+		return m.Action
+	}
+	return nil
+}
+
+func (m *ChatRequest) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *ChatRequest) GetSend() *PM {
+	if x, ok := m.GetAction().(*ChatRequest_Send); ok {
+		return x.Send
+	}
+	return nil
+}
+
+func (m *ChatRequest) GetTyping() uint64 {
+	if x, ok := m.GetAction().(*ChatRequest_Typing); ok {
+		return x.Typing
+	}
+	return 0
+}
+
+func (m *ChatRequest) GetRead() uint64 {
+	if x, ok := m.GetAction().(*ChatRequest_Read); ok {
+		return x.Read
+	}
+	return 0
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*ChatRequest) XXX_OneofFuncs() (func(msg proto1.Message, b *proto1.Buffer) error, func(msg proto1.Message, tag, wire int, b *proto1.Buffer) (bool, error), func(msg proto1.Message) (n int), []interface{}) {
+	return _ChatRequest_OneofMarshaler, _ChatRequest_OneofUnmarshaler, _ChatRequest_OneofSizer, []interface{}{
+		(*ChatRequest_Send)(nil),
+		(*ChatRequest_Typing)(nil),
+		(*ChatRequest_Read)(nil),
+	}
+}
+
+func _ChatRequest_OneofMarshaler(msg proto1.Message, b *proto1.Buffer) error {
+	m := msg.(*ChatRequest)
+	// action
+	switch x := m.Action.(type) {
+	case *ChatRequest_Send:
+		b.EncodeVarint(2<<3 | proto1.WireBytes)
+		if err := b.EncodeMessage(x.Send); err != nil {
+			return err
+		}
+	case *ChatRequest_Typing:
+		b.EncodeVarint(3<<3 | proto1.WireVarint)
+		b.EncodeVarint(uint64(x.Typing))
+	case *ChatRequest_Read:
+		b.EncodeVarint(4<<3 | proto1.WireVarint)
+		b.EncodeVarint(uint64(x.Read))
+	case nil:
+	default:
+		return fmt.Errorf("ChatRequest.Action has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _ChatRequest_OneofUnmarshaler(msg proto1.Message, tag, wire int, b *proto1.Buffer) (bool, error) {
+	m := msg.(*ChatRequest)
+	switch tag {
+	case 2: // action.send
+		if wire != proto1.WireBytes {
+			return true, proto1.ErrInternalBadWireType
+		}
+		msg := new(PM)
+		err := b.DecodeMessage(msg)
+		m.Action = &ChatRequest_Send{msg}
+		return true, err
+	case 3: // action.typing
+		if wire != proto1.WireVarint {
+			return true, proto1.ErrInternalBadWireType
+		}
+		x, err := b.DecodeVarint()
+		m.Action = &ChatRequest_Typing{x}
+		return true, err
+	case 4: // action.read
+		if wire != proto1.WireVarint {
+			return true, proto1.ErrInternalBadWireType
+		}
+		x, err := b.DecodeVarint()
+		m.Action = &ChatRequest_Read{x}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _ChatRequest_OneofSizer(msg proto1.Message) (n int) {
+	m := msg.(*ChatRequest)
+	// action
+	switch x := m.Action.(type) {
+	case *ChatRequest_Send:
+		s := proto1.Size(x.Send)
+		n += proto1.SizeVarint(2<<3 | proto1.WireBytes)
+		n += proto1.SizeVarint(uint64(s))
+		n += s
+	case *ChatRequest_Typing:
+		n += proto1.SizeVarint(3<<3 | proto1.WireVarint)
+		n += proto1.SizeVarint(uint64(x.Typing))
+	case *ChatRequest_Read:
+		n += proto1.SizeVarint(4<<3 | proto1.WireVarint)
+		n += proto1.SizeVarint(uint64(x.Read))
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+// ChatError is the failure of a ChatRequest. code is a gRPC status code
+type ChatError struct {
+	Code    int32  `protobuf:"varint,1,opt,name=code" json:"code,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message" json:"message,omitempty"`
+}
+
+func (m *ChatError) Reset()                    { *m = ChatError{} }
+func (m *ChatError) String() string            { return proto1.CompactTextString(m) }
+func (*ChatError) ProtoMessage()               {}
+func (*ChatError) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{66} }
+
+func (m *ChatError) GetCode() int32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *ChatError) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+// ChatEvent is sent on a Pms.Chat stream, in answer to a ChatRequest or when another user
+// sends a pm to the user, is typing a pm to them or read their pms
+type ChatEvent struct {
+	// request is the id of the ChatRequest answered, 0 if the event is caused by another user.
+	// A ChatRequest is answered with the pm sent, with an error, or with no event if it's not a send
+	Request uint64 `protobuf:"varint,1,opt,name=request" json:"request,omitempty"`
+	// Types that are valid to be assigned to Event:
+	//	*ChatEvent_Pm
+	//	*ChatEvent_Typing
+	//	*ChatEvent_Read
+	//	*ChatEvent_Error
+	Event isChatEvent_Event `protobuf_oneof:"event"`
+}
+
+func (m *ChatEvent) Reset()                    { *m = ChatEvent{} }
+func (m *ChatEvent) String() string            { return proto1.CompactTextString(m) }
+func (*ChatEvent) ProtoMessage()               {}
+func (*ChatEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{67} }
+
+type isChatEvent_Event interface{ isChatEvent_Event() }
+
+type ChatEvent_Pm struct {
+	Pm *PM `protobuf:"bytes,2,opt,name=pm,oneof"`
+}
+type ChatEvent_Typing struct {
+	Typing uint64 `protobuf:"varint,3,opt,name=typing,oneof"`
+}
+type ChatEvent_Read struct {
+	Read uint64 `protobuf:"varint,4,opt,name=read,oneof"`
+}
+type ChatEvent_Error struct {
+	Error *ChatError `protobuf:"bytes,5,opt,name=error,oneof"`
+}
+
+func (*ChatEvent_Pm) isChatEvent_Event()     {}
+func (*ChatEvent_Typing) isChatEvent_Event() {}
+func (*ChatEvent_Read) isChatEvent_Event()   {}
+func (*ChatEvent_Error) isChatEvent_Event()  {}
+
+func (m *ChatEvent) GetEvent() isChatEvent_Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (m *ChatEvent) GetRequest() uint64 {
+	if m != nil {
+		return m.Request
+	}
+	return 0
+}
+
+func (m *ChatEvent) GetPm() *PM {
+	if x, ok := m.GetEvent().(*ChatEvent_Pm); ok {
+		return x.Pm
+	}
+	return nil
+}
+
+func (m *ChatEvent) GetTyping() uint64 {
+	if x, ok := m.GetEvent().(*ChatEvent_Typing); ok {
+		return x.Typing
+	}
+	return 0
+}
+
+func (m *ChatEvent) GetRead() uint64 {
+	if x, ok := m.GetEvent().(*ChatEvent_Read); ok {
+		return x.Read
+	}
+	return 0
+}
+
+func (m *ChatEvent) GetError() *ChatError {
+	if x, ok := m.GetEvent().(*ChatEvent_Error); ok {
+		return x.Error
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*ChatEvent) XXX_OneofFuncs() (func(msg proto1.Message, b *proto1.Buffer) error, func(msg proto1.Message, tag, wire int, b *proto1.Buffer) (bool, error), func(msg proto1.Message) (n int), []interface{}) {
+	return _ChatEvent_OneofMarshaler, _ChatEvent_OneofUnmarshaler, _ChatEvent_OneofSizer, []interface{}{
+		(*ChatEvent_Pm)(nil),
+		(*ChatEvent_Typing)(nil),
+		(*ChatEvent_Read)(nil),
+		(*ChatEvent_Error)(nil),
+	}
+}
+
+func _ChatEvent_OneofMarshaler(msg proto1.Message, b *proto1.Buffer) error {
+	m := msg.(*ChatEvent)
+	// event
+	switch x := m.Event.(type) {
+	case *ChatEvent_Pm:
+		b.EncodeVarint(2<<3 | proto1.WireBytes)
+		if err := b.EncodeMessage(x.Pm); err != nil {
+			return err
+		}
+	case *ChatEvent_Typing:
+		b.EncodeVarint(3<<3 | proto1.WireVarint)
+		b.EncodeVarint(uint64(x.Typing))
+	case *ChatEvent_Read:
+		b.EncodeVarint(4<<3 | proto1.WireVarint)
+		b.EncodeVarint(uint64(x.Read))
+	case *ChatEvent_Error:
+		b.EncodeVarint(5<<3 | proto1.WireBytes)
+		if err := b.EncodeMessage(x.Error); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("ChatEvent.Event has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _ChatEvent_OneofUnmarshaler(msg proto1.Message, tag, wire int, b *proto1.Buffer) (bool, error) {
+	m := msg.(*ChatEvent)
+	switch tag {
+	case 2: // event.pm
+		if wire != proto1.WireBytes {
+			return true, proto1.ErrInternalBadWireType
+		}
+		msg := new(PM)
+		err := b.DecodeMessage(msg)
+		m.Event = &ChatEvent_Pm{msg}
+		return true, err
+	case 3: // event.typing
+		if wire != proto1.WireVarint {
+			return true, proto1.ErrInternalBadWireType
+		}
+		x, err := b.DecodeVarint()
+		m.Event = &ChatEvent_Typing{x}
+		return true, err
+	case 4: // event.read
+		if wire != proto1.WireVarint {
+			return true, proto1.ErrInternalBadWireType
+		}
+		x, err := b.DecodeVarint()
+		m.Event = &ChatEvent_Read{x}
+		return true, err
+	case 5: // event.error
+		if wire != proto1.WireBytes {
+			return true, proto1.ErrInternalBadWireType
+		}
+		msg := new(ChatError)
+		err := b.DecodeMessage(msg)
+		m.Event = &ChatEvent_Error{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _ChatEvent_OneofSizer(msg proto1.Message) (n int) {
+	m := msg.(*ChatEvent)
+	// event
+	switch x := m.Event.(type) {
+	case *ChatEvent_Pm:
+		s := proto1.Size(x.Pm)
+		n += proto1.SizeVarint(2<<3 | proto1.WireBytes)
+		n += proto1.SizeVarint(uint64(s))
+		n += s
+	case *ChatEvent_Typing:
+		n += proto1.SizeVarint(3<<3 | proto1.WireVarint)
+		n += proto1.SizeVarint(uint64(x.Typing))
+	case *ChatEvent_Read:
+		n += proto1.SizeVarint(4<<3 | proto1.WireVarint)
+		n += proto1.SizeVarint(uint64(x.Read))
+	case *ChatEvent_Error:
+		s := proto1.Size(x.Error)
+		n += proto1.SizeVarint(5<<3 | proto1.WireBytes)
+		n += proto1.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+// NotificationsRequest selects the notifications of types. Every type, if empty
+type NotificationsRequest struct {
+	Types []NotificationType `protobuf:"varint,1,rep,packed,name=types,enum=nerdz.NotificationType" json:"types,omitempty"`
+}
+
+func (m *NotificationsRequest) Reset()                    { *m = NotificationsRequest{} }
+func (m *NotificationsRequest) String() string            { return proto1.CompactTextString(m) }
+func (*NotificationsRequest) ProtoMessage()               {}
+func (*NotificationsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{68} }
+
+func (m *NotificationsRequest) GetTypes() []NotificationType {
+	if m != nil {
+		return m.Types
+	}
+	return nil
+}
+
+type NotificationsCount struct {
+	Count uint64 `protobuf:"varint,1,opt,name=count" json:"count,omitempty"`
+}
+
+func (m *NotificationsCount) Reset()                    { *m = NotificationsCount{} }
+func (m *NotificationsCount) String() string            { return proto1.CompactTextString(m) }
+func (*NotificationsCount) ProtoMessage()               {}
+func (*NotificationsCount) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{69} }
+
+func (m *NotificationsCount) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type RevokeTokenRequest struct {
+	// token is either an access or a refresh token
+	Token string `protobuf:"bytes,1,opt,name=token" json:"token,omitempty"`
+}
+
+func (m *RevokeTokenRequest) Reset()                    { *m = RevokeTokenRequest{} }
+func (m *RevokeTokenRequest) String() string            { return proto1.CompactTextString(m) }
+func (*RevokeTokenRequest) ProtoMessage()               {}
+func (*RevokeTokenRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{70} }
+
+func (m *RevokeTokenRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+type CreateClientRequest struct {
+	Name        string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	RedirectUri string `protobuf:"bytes,2,opt,name=redirect_uri,json=redirectUri" json:"redirect_uri,omitempty"`
+	Public      bool   `protobuf:"varint,3,opt,name=public" json:"public,omitempty"`
+}
+
+func (m *CreateClientRequest) Reset()                    { *m = CreateClientRequest{} }
+func (m *CreateClientRequest) String() string            { return proto1.CompactTextString(m) }
+func (*CreateClientRequest) ProtoMessage()               {}
+func (*CreateClientRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{71} }
+
+func (m *CreateClientRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CreateClientRequest) GetRedirectUri() string {
+	if m != nil {
+		return m.RedirectUri
+	}
+	return ""
+}
+
+func (m *CreateClientRequest) GetPublic() bool {
+	if m != nil {
+		return m.Public
+	}
+	return false
+}
+
+type UpdateClientRequest struct {
+	Id          uint64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	RedirectUri string `protobuf:"bytes,2,opt,name=redirect_uri,json=redirectUri" json:"redirect_uri,omitempty"`
+}
+
+func (m *UpdateClientRequest) Reset()                    { *m = UpdateClientRequest{} }
+func (m *UpdateClientRequest) String() string            { return proto1.CompactTextString(m) }
+func (*UpdateClientRequest) ProtoMessage()               {}
+func (*UpdateClientRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{72} }
+
+func (m *UpdateClientRequest) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *UpdateClientRequest) GetRedirectUri() string {
+	if m != nil {
+		return m.RedirectUri
+	}
+	return ""
+}
+
+type ClientRequest struct {
+	Id uint64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *ClientRequest) Reset()                    { *m = ClientRequest{} }
+func (m *ClientRequest) String() string            { return proto1.CompactTextString(m) }
+func (*ClientRequest) ProtoMessage()               {}
+func (*ClientRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{73} }
+
+func (m *ClientRequest) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+// ClientSecret is returned when a client is created or its secret is rotated.
+// The secret is shown only once. Public clients have no secret
+type ClientSecret struct {
+	Application *Application `protobuf:"bytes,1,opt,name=application" json:"application,omitempty"`
+	Secret      string       `protobuf:"bytes,2,opt,name=secret" json:"secret,omitempty"`
+}
+
+func (m *ClientSecret) Reset()                    { *m = ClientSecret{} }
+func (m *ClientSecret) String() string            { return proto1.CompactTextString(m) }
+func (*ClientSecret) ProtoMessage()               {}
+func (*ClientSecret) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{74} }
+
+func (m *ClientSecret) GetApplication() *Application {
+	if m != nil {
+		return m.Application
+	}
+	return nil
+}
+
+func (m *ClientSecret) GetSecret() string {
+	if m != nil {
+		return m.Secret
+	}
+	return ""
+}
+
+func init() {
+	proto1.RegisterType((*Profile)(nil), "nerdz.Profile")
+	proto1.RegisterType((*User)(nil), "nerdz.User")
+	proto1.RegisterType((*PersonalInfo)(nil), "nerdz.PersonalInfo")
+	proto1.RegisterType((*ContactInfo)(nil), "nerdz.ContactInfo")
+	proto1.RegisterType((*BoardInfo)(nil), "nerdz.BoardInfo")
+	proto1.RegisterType((*Info)(nil), "nerdz.Info")
+	proto1.RegisterType((*Project)(nil), "nerdz.Project")
+	proto1.RegisterType((*ProjectInfo)(nil), "nerdz.ProjectInfo")
+	proto1.RegisterType((*UserPost)(nil), "nerdz.UserPost")
+	proto1.RegisterType((*ProjectPost)(nil), "nerdz.ProjectPost")
+	proto1.RegisterType((*UserPostComment)(nil), "nerdz.UserPostComment")
+	proto1.RegisterType((*ProjectPostComment)(nil), "nerdz.ProjectPostComment")
+	proto1.RegisterType((*PM)(nil), "nerdz.PM")
+	proto1.RegisterType((*Conversation)(nil), "nerdz.Conversation")
+	proto1.RegisterType((*UserPostVote)(nil), "nerdz.UserPostVote")
+	proto1.RegisterType((*ProjectPostVote)(nil), "nerdz.ProjectPostVote")
+	proto1.RegisterType((*UserPostCommentVote)(nil), "nerdz.UserPostCommentVote")
+	proto1.RegisterType((*ProjectPostCommentVote)(nil), "nerdz.ProjectPostCommentVote")
+	proto1.RegisterType((*UserPostBookmark)(nil), "nerdz.UserPostBookmark")
+	proto1.RegisterType((*ProjectPostBookmark)(nil), "nerdz.ProjectPostBookmark")
+	proto1.RegisterType((*UserPostLurk)(nil), "nerdz.UserPostLurk")
+	proto1.RegisterType((*ProjectPostLurk)(nil), "nerdz.ProjectPostLurk")
+	proto1.RegisterType((*UserPostLock)(nil), "nerdz.UserPostLock")
+	proto1.RegisterType((*UserPostUserLock)(nil), "nerdz.UserPostUserLock")
+	proto1.RegisterType((*ProjectPostLock)(nil), "nerdz.ProjectPostLock")
+	proto1.RegisterType((*ProjectPostUserLock)(nil), "nerdz.ProjectPostUserLock")
+	proto1.RegisterType((*Application)(nil), "nerdz.Application")
+	proto1.RegisterType((*Message)(nil), "nerdz.Message")
+	proto1.RegisterType((*Content)(nil), "nerdz.Content")
+	proto1.RegisterType((*ContentID)(nil), "nerdz.ContentID")
+	proto1.RegisterType((*Event)(nil), "nerdz.Event")
+	proto1.RegisterType((*Notification)(nil), "nerdz.Notification")
+	proto1.RegisterType((*Mention)(nil), "nerdz.Mention")
+	proto1.RegisterType((*Trend)(nil), "nerdz.Trend")
+	proto1.RegisterType((*UserList)(nil), "nerdz.UserList")
+	proto1.RegisterType((*ProjectList)(nil), "nerdz.ProjectList")
+	proto1.RegisterType((*MessageList)(nil), "nerdz.MessageList")
+	proto1.RegisterType((*ContentList)(nil), "nerdz.ContentList")
+	proto1.RegisterType((*ConversationList)(nil), "nerdz.ConversationList")
+	proto1.RegisterType((*NotificationList)(nil), "nerdz.NotificationList")
+	proto1.RegisterType((*MentionList)(nil), "nerdz.MentionList")
+	proto1.RegisterType((*TrendList)(nil), "nerdz.TrendList")
+	proto1.RegisterType((*ApplicationList)(nil), "nerdz.ApplicationList")
+	proto1.RegisterType((*PostlistOptions)(nil), "nerdz.PostlistOptions")
+	proto1.RegisterType((*CommentlistOptions)(nil), "nerdz.CommentlistOptions")
+	proto1.RegisterType((*PmsOptions)(nil), "nerdz.PmsOptions")
+	proto1.RegisterType((*NotificationsOptions)(nil), "nerdz.NotificationsOptions")
+	proto1.RegisterType((*MentionsOptions)(nil), "nerdz.MentionsOptions")
+	proto1.RegisterType((*UserRequest)(nil), "nerdz.UserRequest")
+	proto1.RegisterType((*ProjectRequest)(nil), "nerdz.ProjectRequest")
+	proto1.RegisterType((*PostlistRequest)(nil), "nerdz.PostlistRequest")
+	proto1.RegisterType((*HomeRequest)(nil), "nerdz.HomeRequest")
+	proto1.RegisterType((*CommentsRequest)(nil), "nerdz.CommentsRequest")
+	proto1.RegisterType((*MentionsRequest)(nil), "nerdz.MentionsRequest")
+	proto1.RegisterType((*TaggedRequest)(nil), "nerdz.TaggedRequest")
+	proto1.RegisterType((*TrendingRequest)(nil), "nerdz.TrendingRequest")
+	proto1.RegisterType((*BoardRequest)(nil), "nerdz.BoardRequest")
+	proto1.RegisterType((*UserActionRequest)(nil), "nerdz.UserActionRequest")
+	proto1.RegisterType((*SubmitRequest)(nil), "nerdz.SubmitRequest")
+	proto1.RegisterType((*EditRequest)(nil), "nerdz.EditRequest")
+	proto1.RegisterType((*ContentRequest)(nil), "nerdz.ContentRequest")
+	proto1.RegisterType((*VoteRequest)(nil), "nerdz.VoteRequest")
+	proto1.RegisterType((*LockRequest)(nil), "nerdz.LockRequest")
+	proto1.RegisterType((*PmsRequest)(nil), "nerdz.PmsRequest")
+	proto1.RegisterType((*ConversationRequest)(nil), "nerdz.ConversationRequest")
+	proto1.RegisterType((*ChatRequest)(nil), "nerdz.ChatRequest")
+	proto1.RegisterType((*ChatError)(nil), "nerdz.ChatError")
+	proto1.RegisterType((*ChatEvent)(nil), "nerdz.ChatEvent")
+	proto1.RegisterType((*NotificationsRequest)(nil), "nerdz.NotificationsRequest")
+	proto1.RegisterType((*NotificationsCount)(nil), "nerdz.NotificationsCount")
+	proto1.RegisterType((*RevokeTokenRequest)(nil), "nerdz.RevokeTokenRequest")
+	proto1.RegisterType((*CreateClientRequest)(nil), "nerdz.CreateClientRequest")
+	proto1.RegisterType((*UpdateClientRequest)(nil), "nerdz.UpdateClientRequest")
+	proto1.RegisterType((*ClientRequest)(nil), "nerdz.ClientRequest")
+	proto1.RegisterType((*ClientSecret)(nil), "nerdz.ClientSecret")
+	proto1.RegisterEnum("nerdz.Language", Language_name, Language_value)
+	proto1.RegisterEnum("nerdz.ContentType", ContentType_name, ContentType_value)
+	proto1.RegisterEnum("nerdz.BoardType", BoardType_name, BoardType_value)
+	proto1.RegisterEnum("nerdz.EventType", EventType_name, EventType_value)
+	proto1.RegisterEnum("nerdz.NotificationType", NotificationType_name, NotificationType_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Users service
+
+type UsersClient interface {
+	Get(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*User, error)
+	Info(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*Info, error)
+	PersonalInfo(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*PersonalInfo, error)
+	ContactInfo(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*ContactInfo, error)
+	BoardInfo(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*BoardInfo, error)
+	Followers(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*UserList, error)
+	UserFollowing(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*UserList, error)
+	ProjectFollowing(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*ProjectList, error)
+	Friends(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*UserList, error)
+	Whitelist(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*UserList, error)
+	Blacklist(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*UserList, error)
+	Postlist(ctx context.Context, in *PostlistRequest, opts ...grpc.CallOption) (*ContentList, error)
+	Home(ctx context.Context, in *HomeRequest, opts ...grpc.CallOption) (*MessageList, error)
+	Follow(ctx context.Context, in *BoardRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error)
+	Unfollow(ctx context.Context, in *BoardRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error)
+	WhitelistUser(ctx context.Context, in *UserActionRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error)
+	UnwhitelistUser(ctx context.Context, in *UserActionRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error)
+	BlacklistUser(ctx context.Context, in *UserActionRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error)
+	UnblacklistUser(ctx context.Context, in *UserActionRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error)
+}
+
+type usersClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewUsersClient(cc *grpc.ClientConn) UsersClient {
+	return &usersClient{cc}
+}
+
+func (c *usersClient) Get(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := grpc.Invoke(ctx, "/nerdz.Users/Get", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) Info(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*Info, error) {
+	out := new(Info)
+	err := grpc.Invoke(ctx, "/nerdz.Users/Info", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) PersonalInfo(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*PersonalInfo, error) {
+	out := new(PersonalInfo)
+	err := grpc.Invoke(ctx, "/nerdz.Users/PersonalInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) ContactInfo(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*ContactInfo, error) {
+	out := new(ContactInfo)
+	err := grpc.Invoke(ctx, "/nerdz.Users/ContactInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) BoardInfo(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*BoardInfo, error) {
+	out := new(BoardInfo)
+	err := grpc.Invoke(ctx, "/nerdz.Users/BoardInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) Followers(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*UserList, error) {
+	out := new(UserList)
+	err := grpc.Invoke(ctx, "/nerdz.Users/Followers", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) UserFollowing(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*UserList, error) {
+	out := new(UserList)
+	err := grpc.Invoke(ctx, "/nerdz.Users/UserFollowing", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) ProjectFollowing(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*ProjectList, error) {
+	out := new(ProjectList)
+	err := grpc.Invoke(ctx, "/nerdz.Users/ProjectFollowing", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) Friends(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*UserList, error) {
+	out := new(UserList)
+	err := grpc.Invoke(ctx, "/nerdz.Users/Friends", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) Whitelist(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*UserList, error) {
+	out := new(UserList)
+	err := grpc.Invoke(ctx, "/nerdz.Users/Whitelist", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) Blacklist(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*UserList, error) {
+	out := new(UserList)
+	err := grpc.Invoke(ctx, "/nerdz.Users/Blacklist", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) Postlist(ctx context.Context, in *PostlistRequest, opts ...grpc.CallOption) (*ContentList, error) {
+	out := new(ContentList)
+	err := grpc.Invoke(ctx, "/nerdz.Users/Postlist", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) Home(ctx context.Context, in *HomeRequest, opts ...grpc.CallOption) (*MessageList, error) {
+	out := new(MessageList)
+	err := grpc.Invoke(ctx, "/nerdz.Users/Home", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) Follow(ctx context.Context, in *BoardRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error) {
+	out := new(google_protobuf1.Empty)
+	err := grpc.Invoke(ctx, "/nerdz.Users/Follow", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) Unfollow(ctx context.Context, in *BoardRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error) {
+	out := new(google_protobuf1.Empty)
+	err := grpc.Invoke(ctx, "/nerdz.Users/Unfollow", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) WhitelistUser(ctx context.Context, in *UserActionRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error) {
+	out := new(google_protobuf1.Empty)
+	err := grpc.Invoke(ctx, "/nerdz.Users/WhitelistUser", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) UnwhitelistUser(ctx context.Context, in *UserActionRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error) {
+	out := new(google_protobuf1.Empty)
+	err := grpc.Invoke(ctx, "/nerdz.Users/UnwhitelistUser", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) BlacklistUser(ctx context.Context, in *UserActionRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error) {
+	out := new(google_protobuf1.Empty)
+	err := grpc.Invoke(ctx, "/nerdz.Users/BlacklistUser", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) UnblacklistUser(ctx context.Context, in *UserActionRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error) {
+	out := new(google_protobuf1.Empty)
+	err := grpc.Invoke(ctx, "/nerdz.Users/UnblacklistUser", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Users service
+
+type UsersServer interface {
+	Get(context.Context, *UserRequest) (*User, error)
+	Info(context.Context, *UserRequest) (*Info, error)
+	PersonalInfo(context.Context, *UserRequest) (*PersonalInfo, error)
+	ContactInfo(context.Context, *UserRequest) (*ContactInfo, error)
+	BoardInfo(context.Context, *UserRequest) (*BoardInfo, error)
+	Followers(context.Context, *UserRequest) (*UserList, error)
+	UserFollowing(context.Context, *UserRequest) (*UserList, error)
+	ProjectFollowing(context.Context, *UserRequest) (*ProjectList, error)
+	Friends(context.Context, *UserRequest) (*UserList, error)
+	Whitelist(context.Context, *UserRequest) (*UserList, error)
+	Blacklist(context.Context, *UserRequest) (*UserList, error)
+	Postlist(context.Context, *PostlistRequest) (*ContentList, error)
+	Home(context.Context, *HomeRequest) (*MessageList, error)
+	Follow(context.Context, *BoardRequest) (*google_protobuf1.Empty, error)
+	Unfollow(context.Context, *BoardRequest) (*google_protobuf1.Empty, error)
+	WhitelistUser(context.Context, *UserActionRequest) (*google_protobuf1.Empty, error)
+	UnwhitelistUser(context.Context, *UserActionRequest) (*google_protobuf1.Empty, error)
+	BlacklistUser(context.Context, *UserActionRequest) (*google_protobuf1.Empty, error)
+	UnblacklistUser(context.Context, *UserActionRequest) (*google_protobuf1.Empty, error)
+}
+
+func RegisterUsersServer(s *grpc.Server, srv UsersServer) {
+	s.RegisterService(&_Users_serviceDesc, srv)
+}
+
+func _Users_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Users/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).Get(ctx, req.(*UserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_Info_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).Info(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Users/Info",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).Info(ctx, req.(*UserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_PersonalInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).PersonalInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Users/PersonalInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).PersonalInfo(ctx, req.(*UserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_ContactInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).ContactInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Users/ContactInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).ContactInfo(ctx, req.(*UserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_BoardInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).BoardInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Users/BoardInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).BoardInfo(ctx, req.(*UserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_Followers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).Followers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Users/Followers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).Followers(ctx, req.(*UserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_UserFollowing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).UserFollowing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Users/UserFollowing",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).UserFollowing(ctx, req.(*UserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_ProjectFollowing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).ProjectFollowing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Users/ProjectFollowing",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).ProjectFollowing(ctx, req.(*UserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_Friends_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).Friends(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Users/Friends",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).Friends(ctx, req.(*UserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_Whitelist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).Whitelist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Users/Whitelist",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).Whitelist(ctx, req.(*UserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_Blacklist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).Blacklist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Users/Blacklist",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).Blacklist(ctx, req.(*UserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_Postlist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PostlistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).Postlist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Users/Postlist",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).Postlist(ctx, req.(*PostlistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_Home_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HomeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).Home(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Users/Home",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).Home(ctx, req.(*HomeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_Follow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BoardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).Follow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Users/Follow",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).Follow(ctx, req.(*BoardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_Unfollow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BoardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).Unfollow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Users/Unfollow",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).Unfollow(ctx, req.(*BoardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_WhitelistUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).WhitelistUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Users/WhitelistUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).WhitelistUser(ctx, req.(*UserActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_UnwhitelistUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).UnwhitelistUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Users/UnwhitelistUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).UnwhitelistUser(ctx, req.(*UserActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_BlacklistUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).BlacklistUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Users/BlacklistUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).BlacklistUser(ctx, req.(*UserActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_UnblacklistUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).UnblacklistUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Users/UnblacklistUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).UnblacklistUser(ctx, req.(*UserActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Users_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "nerdz.Users",
+	HandlerType: (*UsersServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Get",
+			Handler:    _Users_Get_Handler,
+		},
+		{
+			MethodName: "Info",
+			Handler:    _Users_Info_Handler,
+		},
+		{
+			MethodName: "PersonalInfo",
+			Handler:    _Users_PersonalInfo_Handler,
+		},
+		{
+			MethodName: "ContactInfo",
+			Handler:    _Users_ContactInfo_Handler,
+		},
+		{
+			MethodName: "BoardInfo",
+			Handler:    _Users_BoardInfo_Handler,
+		},
+		{
+			MethodName: "Followers",
+			Handler:    _Users_Followers_Handler,
+		},
+		{
+			MethodName: "UserFollowing",
+			Handler:    _Users_UserFollowing_Handler,
+		},
+		{
+			MethodName: "ProjectFollowing",
+			Handler:    _Users_ProjectFollowing_Handler,
+		},
+		{
+			MethodName: "Friends",
+			Handler:    _Users_Friends_Handler,
+		},
+		{
+			MethodName: "Whitelist",
+			Handler:    _Users_Whitelist_Handler,
+		},
+		{
+			MethodName: "Blacklist",
+			Handler:    _Users_Blacklist_Handler,
+		},
+		{
+			MethodName: "Postlist",
+			Handler:    _Users_Postlist_Handler,
+		},
+		{
+			MethodName: "Home",
+			Handler:    _Users_Home_Handler,
+		},
+		{
+			MethodName: "Follow",
+			Handler:    _Users_Follow_Handler,
+		},
+		{
+			MethodName: "Unfollow",
+			Handler:    _Users_Unfollow_Handler,
+		},
+		{
+			MethodName: "WhitelistUser",
+			Handler:    _Users_WhitelistUser_Handler,
+		},
+		{
+			MethodName: "UnwhitelistUser",
+			Handler:    _Users_UnwhitelistUser_Handler,
+		},
+		{
+			MethodName: "BlacklistUser",
+			Handler:    _Users_BlacklistUser_Handler,
+		},
+		{
+			MethodName: "UnblacklistUser",
+			Handler:    _Users_UnblacklistUser_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "nerdz.proto",
+}
+
+// Client API for Projects service
+
+type ProjectsClient interface {
+	Get(ctx context.Context, in *ProjectRequest, opts ...grpc.CallOption) (*Project, error)
+	Info(ctx context.Context, in *ProjectRequest, opts ...grpc.CallOption) (*Info, error)
+	ProjectInfo(ctx context.Context, in *ProjectRequest, opts ...grpc.CallOption) (*ProjectInfo, error)
+	Members(ctx context.Context, in *ProjectRequest, opts ...grpc.CallOption) (*UserList, error)
+	Followers(ctx context.Context, in *ProjectRequest, opts ...grpc.CallOption) (*UserList, error)
+	Postlist(ctx context.Context, in *PostlistRequest, opts ...grpc.CallOption) (*ContentList, error)
+}
+
+type projectsClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewProjectsClient(cc *grpc.ClientConn) ProjectsClient {
+	return &projectsClient{cc}
+}
+
+func (c *projectsClient) Get(ctx context.Context, in *ProjectRequest, opts ...grpc.CallOption) (*Project, error) {
+	out := new(Project)
+	err := grpc.Invoke(ctx, "/nerdz.Projects/Get", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectsClient) Info(ctx context.Context, in *ProjectRequest, opts ...grpc.CallOption) (*Info, error) {
+	out := new(Info)
+	err := grpc.Invoke(ctx, "/nerdz.Projects/Info", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectsClient) ProjectInfo(ctx context.Context, in *ProjectRequest, opts ...grpc.CallOption) (*ProjectInfo, error) {
+	out := new(ProjectInfo)
+	err := grpc.Invoke(ctx, "/nerdz.Projects/ProjectInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectsClient) Members(ctx context.Context, in *ProjectRequest, opts ...grpc.CallOption) (*UserList, error) {
+	out := new(UserList)
+	err := grpc.Invoke(ctx, "/nerdz.Projects/Members", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectsClient) Followers(ctx context.Context, in *ProjectRequest, opts ...grpc.CallOption) (*UserList, error) {
+	out := new(UserList)
+	err := grpc.Invoke(ctx, "/nerdz.Projects/Followers", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectsClient) Postlist(ctx context.Context, in *PostlistRequest, opts ...grpc.CallOption) (*ContentList, error) {
+	out := new(ContentList)
+	err := grpc.Invoke(ctx, "/nerdz.Projects/Postlist", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Projects service
+
+type ProjectsServer interface {
+	Get(context.Context, *ProjectRequest) (*Project, error)
+	Info(context.Context, *ProjectRequest) (*Info, error)
+	ProjectInfo(context.Context, *ProjectRequest) (*ProjectInfo, error)
+	Members(context.Context, *ProjectRequest) (*UserList, error)
+	Followers(context.Context, *ProjectRequest) (*UserList, error)
+	Postlist(context.Context, *PostlistRequest) (*ContentList, error)
+}
+
+func RegisterProjectsServer(s *grpc.Server, srv ProjectsServer) {
+	s.RegisterService(&_Projects_serviceDesc, srv)
+}
+
+func _Projects_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectsServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Projects/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectsServer).Get(ctx, req.(*ProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Projects_Info_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectsServer).Info(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Projects/Info",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectsServer).Info(ctx, req.(*ProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Projects_ProjectInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectsServer).ProjectInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Projects/ProjectInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectsServer).ProjectInfo(ctx, req.(*ProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Projects_Members_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectsServer).Members(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Projects/Members",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectsServer).Members(ctx, req.(*ProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Projects_Followers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectsServer).Followers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Projects/Followers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectsServer).Followers(ctx, req.(*ProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Projects_Postlist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PostlistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectsServer).Postlist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Projects/Postlist",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectsServer).Postlist(ctx, req.(*PostlistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Projects_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "nerdz.Projects",
+	HandlerType: (*ProjectsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Get",
+			Handler:    _Projects_Get_Handler,
+		},
+		{
+			MethodName: "Info",
+			Handler:    _Projects_Info_Handler,
+		},
+		{
+			MethodName: "ProjectInfo",
+			Handler:    _Projects_ProjectInfo_Handler,
+		},
+		{
+			MethodName: "Members",
+			Handler:    _Projects_Members_Handler,
+		},
+		{
+			MethodName: "Followers",
+			Handler:    _Projects_Followers_Handler,
+		},
+		{
+			MethodName: "Postlist",
+			Handler:    _Projects_Postlist_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "nerdz.proto",
+}
+
+// Client API for Contents service
+
+type ContentsClient interface {
+	Get(ctx context.Context, in *ContentID, opts ...grpc.CallOption) (*Content, error)
+	Comments(ctx context.Context, in *CommentsRequest, opts ...grpc.CallOption) (*ContentList, error)
+	// Mentions lists the mentions in the post and in its comments
+	Mentions(ctx context.Context, in *MentionsRequest, opts ...grpc.CallOption) (*MentionList, error)
+	Submit(ctx context.Context, in *SubmitRequest, opts ...grpc.CallOption) (*Content, error)
+	Edit(ctx context.Context, in *EditRequest, opts ...grpc.CallOption) (*Content, error)
+	Delete(ctx context.Context, in *ContentRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error)
+	Vote(ctx context.Context, in *VoteRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error)
+	Bookmark(ctx context.Context, in *ContentRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error)
+	Unbookmark(ctx context.Context, in *ContentRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error)
+	Lurk(ctx context.Context, in *ContentRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error)
+	Unlurk(ctx context.Context, in *ContentRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error)
+	Lock(ctx context.Context, in *LockRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error)
+	Unlock(ctx context.Context, in *LockRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error)
+}
+
+type contentsClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewContentsClient(cc *grpc.ClientConn) ContentsClient {
+	return &contentsClient{cc}
+}
+
+func (c *contentsClient) Get(ctx context.Context, in *ContentID, opts ...grpc.CallOption) (*Content, error) {
+	out := new(Content)
+	err := grpc.Invoke(ctx, "/nerdz.Contents/Get", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentsClient) Comments(ctx context.Context, in *CommentsRequest, opts ...grpc.CallOption) (*ContentList, error) {
+	out := new(ContentList)
+	err := grpc.Invoke(ctx, "/nerdz.Contents/Comments", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentsClient) Mentions(ctx context.Context, in *MentionsRequest, opts ...grpc.CallOption) (*MentionList, error) {
+	out := new(MentionList)
+	err := grpc.Invoke(ctx, "/nerdz.Contents/Mentions", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentsClient) Submit(ctx context.Context, in *SubmitRequest, opts ...grpc.CallOption) (*Content, error) {
+	out := new(Content)
+	err := grpc.Invoke(ctx, "/nerdz.Contents/Submit", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentsClient) Edit(ctx context.Context, in *EditRequest, opts ...grpc.CallOption) (*Content, error) {
+	out := new(Content)
+	err := grpc.Invoke(ctx, "/nerdz.Contents/Edit", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentsClient) Delete(ctx context.Context, in *ContentRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error) {
+	out := new(google_protobuf1.Empty)
+	err := grpc.Invoke(ctx, "/nerdz.Contents/Delete", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentsClient) Vote(ctx context.Context, in *VoteRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error) {
+	out := new(google_protobuf1.Empty)
+	err := grpc.Invoke(ctx, "/nerdz.Contents/Vote", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentsClient) Bookmark(ctx context.Context, in *ContentRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error) {
+	out := new(google_protobuf1.Empty)
+	err := grpc.Invoke(ctx, "/nerdz.Contents/Bookmark", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentsClient) Unbookmark(ctx context.Context, in *ContentRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error) {
+	out := new(google_protobuf1.Empty)
+	err := grpc.Invoke(ctx, "/nerdz.Contents/Unbookmark", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentsClient) Lurk(ctx context.Context, in *ContentRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error) {
+	out := new(google_protobuf1.Empty)
+	err := grpc.Invoke(ctx, "/nerdz.Contents/Lurk", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentsClient) Unlurk(ctx context.Context, in *ContentRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error) {
+	out := new(google_protobuf1.Empty)
+	err := grpc.Invoke(ctx, "/nerdz.Contents/Unlurk", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentsClient) Lock(ctx context.Context, in *LockRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error) {
+	out := new(google_protobuf1.Empty)
+	err := grpc.Invoke(ctx, "/nerdz.Contents/Lock", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contentsClient) Unlock(ctx context.Context, in *LockRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error) {
+	out := new(google_protobuf1.Empty)
+	err := grpc.Invoke(ctx, "/nerdz.Contents/Unlock", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Contents service
+
+type ContentsServer interface {
+	Get(context.Context, *ContentID) (*Content, error)
+	Comments(context.Context, *CommentsRequest) (*ContentList, error)
+	// Mentions lists the mentions in the post and in its comments
+	Mentions(context.Context, *MentionsRequest) (*MentionList, error)
+	Submit(context.Context, *SubmitRequest) (*Content, error)
+	Edit(context.Context, *EditRequest) (*Content, error)
+	Delete(context.Context, *ContentRequest) (*google_protobuf1.Empty, error)
+	Vote(context.Context, *VoteRequest) (*google_protobuf1.Empty, error)
+	Bookmark(context.Context, *ContentRequest) (*google_protobuf1.Empty, error)
+	Unbookmark(context.Context, *ContentRequest) (*google_protobuf1.Empty, error)
+	Lurk(context.Context, *ContentRequest) (*google_protobuf1.Empty, error)
+	Unlurk(context.Context, *ContentRequest) (*google_protobuf1.Empty, error)
+	Lock(context.Context, *LockRequest) (*google_protobuf1.Empty, error)
+	Unlock(context.Context, *LockRequest) (*google_protobuf1.Empty, error)
+}
+
+func RegisterContentsServer(s *grpc.Server, srv ContentsServer) {
+	s.RegisterService(&_Contents_serviceDesc, srv)
+}
+
+func _Contents_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContentID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentsServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Contents/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentsServer).Get(ctx, req.(*ContentID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Contents_Comments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentsServer).Comments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Contents/Comments",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentsServer).Comments(ctx, req.(*CommentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Contents_Mentions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MentionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentsServer).Mentions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Contents/Mentions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentsServer).Mentions(ctx, req.(*MentionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Contents_Submit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentsServer).Submit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Contents/Submit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentsServer).Submit(ctx, req.(*SubmitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Contents_Edit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EditRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentsServer).Edit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Contents/Edit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentsServer).Edit(ctx, req.(*EditRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Contents_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentsServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Contents/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentsServer).Delete(ctx, req.(*ContentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Contents_Vote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentsServer).Vote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Contents/Vote",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentsServer).Vote(ctx, req.(*VoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Contents_Bookmark_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentsServer).Bookmark(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Contents/Bookmark",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentsServer).Bookmark(ctx, req.(*ContentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Contents_Unbookmark_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentsServer).Unbookmark(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Contents/Unbookmark",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentsServer).Unbookmark(ctx, req.(*ContentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Contents_Lurk_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentsServer).Lurk(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Contents/Lurk",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentsServer).Lurk(ctx, req.(*ContentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Contents_Unlurk_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentsServer).Unlurk(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Contents/Unlurk",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentsServer).Unlurk(ctx, req.(*ContentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Contents_Lock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentsServer).Lock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Contents/Lock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentsServer).Lock(ctx, req.(*LockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Contents_Unlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentsServer).Unlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Contents/Unlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentsServer).Unlock(ctx, req.(*LockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Contents_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "nerdz.Contents",
+	HandlerType: (*ContentsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Get",
+			Handler:    _Contents_Get_Handler,
+		},
+		{
+			MethodName: "Comments",
+			Handler:    _Contents_Comments_Handler,
+		},
+		{
+			MethodName: "Mentions",
+			Handler:    _Contents_Mentions_Handler,
+		},
+		{
+			MethodName: "Submit",
+			Handler:    _Contents_Submit_Handler,
+		},
+		{
+			MethodName: "Edit",
+			Handler:    _Contents_Edit_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _Contents_Delete_Handler,
+		},
+		{
+			MethodName: "Vote",
+			Handler:    _Contents_Vote_Handler,
+		},
+		{
+			MethodName: "Bookmark",
+			Handler:    _Contents_Bookmark_Handler,
+		},
+		{
+			MethodName: "Unbookmark",
+			Handler:    _Contents_Unbookmark_Handler,
+		},
+		{
+			MethodName: "Lurk",
+			Handler:    _Contents_Lurk_Handler,
+		},
+		{
+			MethodName: "Unlurk",
+			Handler:    _Contents_Unlurk_Handler,
+		},
+		{
+			MethodName: "Lock",
+			Handler:    _Contents_Lock_Handler,
+		},
+		{
+			MethodName: "Unlock",
+			Handler:    _Contents_Unlock_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "nerdz.proto",
+}
+
+// Client API for Pms service
+
+type PmsClient interface {
+	Conversations(ctx context.Context, in *google_protobuf1.Empty, opts ...grpc.CallOption) (*ConversationList, error)
+	Pms(ctx context.Context, in *PmsRequest, opts ...grpc.CallOption) (*ContentList, error)
+	DeleteConversation(ctx context.Context, in *ConversationRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error)
+	// Chat sends and receives pms, typing indicators and read receipts live. It requires both pms:read
+	// and pms:write. The users that blacklisted each other can't chat. If the client doesn't keep up,
+	// the stream is aborted
+	Chat(ctx context.Context, opts ...grpc.CallOption) (Pms_ChatClient, error)
+}
+
+type pmsClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewPmsClient(cc *grpc.ClientConn) PmsClient {
+	return &pmsClient{cc}
+}
+
+func (c *pmsClient) Conversations(ctx context.Context, in *google_protobuf1.Empty, opts ...grpc.CallOption) (*ConversationList, error) {
+	out := new(ConversationList)
+	err := grpc.Invoke(ctx, "/nerdz.Pms/Conversations", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pmsClient) Pms(ctx context.Context, in *PmsRequest, opts ...grpc.CallOption) (*ContentList, error) {
+	out := new(ContentList)
+	err := grpc.Invoke(ctx, "/nerdz.Pms/Pms", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pmsClient) DeleteConversation(ctx context.Context, in *ConversationRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error) {
+	out := new(google_protobuf1.Empty)
+	err := grpc.Invoke(ctx, "/nerdz.Pms/DeleteConversation", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pmsClient) Chat(ctx context.Context, opts ...grpc.CallOption) (Pms_ChatClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Pms_serviceDesc.Streams[0], c.cc, "/nerdz.Pms/Chat", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &pmsChatClient{stream}
+	return x, nil
+}
+
+type Pms_ChatClient interface {
+	Send(*ChatRequest) error
+	Recv() (*ChatEvent, error)
+	grpc.ClientStream
+}
+
+type pmsChatClient struct {
+	grpc.ClientStream
+}
+
+func (x *pmsChatClient) Send(m *ChatRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *pmsChatClient) Recv() (*ChatEvent, error) {
+	m := new(ChatEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Server API for Pms service
+
+type PmsServer interface {
+	Conversations(context.Context, *google_protobuf1.Empty) (*ConversationList, error)
+	Pms(context.Context, *PmsRequest) (*ContentList, error)
+	DeleteConversation(context.Context, *ConversationRequest) (*google_protobuf1.Empty, error)
+	// Chat sends and receives pms, typing indicators and read receipts live. It requires both pms:read
+	// and pms:write. The users that blacklisted each other can't chat. If the client doesn't keep up,
+	// the stream is aborted
+	Chat(Pms_ChatServer) error
+}
+
+func RegisterPmsServer(s *grpc.Server, srv PmsServer) {
+	s.RegisterService(&_Pms_serviceDesc, srv)
+}
+
+func _Pms_Conversations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(google_protobuf1.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PmsServer).Conversations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Pms/Conversations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PmsServer).Conversations(ctx, req.(*google_protobuf1.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Pms_Pms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PmsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PmsServer).Pms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Pms/Pms",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PmsServer).Pms(ctx, req.(*PmsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Pms_DeleteConversation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConversationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PmsServer).DeleteConversation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Pms/DeleteConversation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PmsServer).DeleteConversation(ctx, req.(*ConversationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Pms_Chat_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(PmsServer).Chat(&pmsChatServer{stream})
+}
+
+type Pms_ChatServer interface {
+	Send(*ChatEvent) error
+	Recv() (*ChatRequest, error)
+	grpc.ServerStream
+}
+
+type pmsChatServer struct {
+	grpc.ServerStream
+}
+
+func (x *pmsChatServer) Send(m *ChatEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *pmsChatServer) Recv() (*ChatRequest, error) {
+	m := new(ChatRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var _Pms_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "nerdz.Pms",
+	HandlerType: (*PmsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Conversations",
+			Handler:    _Pms_Conversations_Handler,
+		},
+		{
+			MethodName: "Pms",
+			Handler:    _Pms_Pms_Handler,
+		},
+		{
+			MethodName: "DeleteConversation",
+			Handler:    _Pms_DeleteConversation_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Chat",
+			Handler:       _Pms_Chat_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "nerdz.proto",
+}
+
+// Client API for Notifications service
+
+type NotificationsClient interface {
+	List(ctx context.Context, in *NotificationsOptions, opts ...grpc.CallOption) (*NotificationList, error)
+	// Count counts the unread notifications
+	Count(ctx context.Context, in *NotificationsRequest, opts ...grpc.CallOption) (*NotificationsCount, error)
+	// Read marks the unread notifications as read
+	Read(ctx context.Context, in *NotificationsRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error)
+	// Clear deletes the notifications, read and unread
+	Clear(ctx context.Context, in *NotificationsRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error)
+	// Mentions lists the mentions of the user, read and unread
+	Mentions(ctx context.Context, in *MentionsOptions, opts ...grpc.CallOption) (*MentionList, error)
+}
+
+type notificationsClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewNotificationsClient(cc *grpc.ClientConn) NotificationsClient {
+	return &notificationsClient{cc}
+}
+
+func (c *notificationsClient) List(ctx context.Context, in *NotificationsOptions, opts ...grpc.CallOption) (*NotificationList, error) {
+	out := new(NotificationList)
+	err := grpc.Invoke(ctx, "/nerdz.Notifications/List", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationsClient) Count(ctx context.Context, in *NotificationsRequest, opts ...grpc.CallOption) (*NotificationsCount, error) {
+	out := new(NotificationsCount)
+	err := grpc.Invoke(ctx, "/nerdz.Notifications/Count", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationsClient) Read(ctx context.Context, in *NotificationsRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error) {
+	out := new(google_protobuf1.Empty)
+	err := grpc.Invoke(ctx, "/nerdz.Notifications/Read", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationsClient) Clear(ctx context.Context, in *NotificationsRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error) {
+	out := new(google_protobuf1.Empty)
+	err := grpc.Invoke(ctx, "/nerdz.Notifications/Clear", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationsClient) Mentions(ctx context.Context, in *MentionsOptions, opts ...grpc.CallOption) (*MentionList, error) {
+	out := new(MentionList)
+	err := grpc.Invoke(ctx, "/nerdz.Notifications/Mentions", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Notifications service
+
+type NotificationsServer interface {
+	List(context.Context, *NotificationsOptions) (*NotificationList, error)
+	// Count counts the unread notifications
+	Count(context.Context, *NotificationsRequest) (*NotificationsCount, error)
+	// Read marks the unread notifications as read
+	Read(context.Context, *NotificationsRequest) (*google_protobuf1.Empty, error)
+	// Clear deletes the notifications, read and unread
+	Clear(context.Context, *NotificationsRequest) (*google_protobuf1.Empty, error)
+	// Mentions lists the mentions of the user, read and unread
+	Mentions(context.Context, *MentionsOptions) (*MentionList, error)
+}
+
+func RegisterNotificationsServer(s *grpc.Server, srv NotificationsServer) {
+	s.RegisterService(&_Notifications_serviceDesc, srv)
+}
+
+func _Notifications_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NotificationsOptions)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationsServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Notifications/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationsServer).List(ctx, req.(*NotificationsOptions))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notifications_Count_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NotificationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationsServer).Count(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Notifications/Count",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationsServer).Count(ctx, req.(*NotificationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notifications_Read_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NotificationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationsServer).Read(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Notifications/Read",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationsServer).Read(ctx, req.(*NotificationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notifications_Clear_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NotificationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationsServer).Clear(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Notifications/Clear",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationsServer).Clear(ctx, req.(*NotificationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notifications_Mentions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MentionsOptions)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationsServer).Mentions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Notifications/Mentions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationsServer).Mentions(ctx, req.(*MentionsOptions))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Notifications_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "nerdz.Notifications",
+	HandlerType: (*NotificationsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "List",
+			Handler:    _Notifications_List_Handler,
+		},
+		{
+			MethodName: "Count",
+			Handler:    _Notifications_Count_Handler,
+		},
+		{
+			MethodName: "Read",
+			Handler:    _Notifications_Read_Handler,
+		},
+		{
+			MethodName: "Clear",
+			Handler:    _Notifications_Clear_Handler,
+		},
+		{
+			MethodName: "Mentions",
+			Handler:    _Notifications_Mentions_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "nerdz.proto",
+}
+
+// Client API for Tags service
+
+type TagsClient interface {
+	Posts(ctx context.Context, in *TaggedRequest, opts ...grpc.CallOption) (*MessageList, error)
+	// Trending lists the most used tags, most used first
+	Trending(ctx context.Context, in *TrendingRequest, opts ...grpc.CallOption) (*TrendList, error)
+}
+
+type tagsClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewTagsClient(cc *grpc.ClientConn) TagsClient {
+	return &tagsClient{cc}
+}
+
+func (c *tagsClient) Posts(ctx context.Context, in *TaggedRequest, opts ...grpc.CallOption) (*MessageList, error) {
+	out := new(MessageList)
+	err := grpc.Invoke(ctx, "/nerdz.Tags/Posts", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tagsClient) Trending(ctx context.Context, in *TrendingRequest, opts ...grpc.CallOption) (*TrendList, error) {
+	out := new(TrendList)
+	err := grpc.Invoke(ctx, "/nerdz.Tags/Trending", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Tags service
+
+type TagsServer interface {
+	Posts(context.Context, *TaggedRequest) (*MessageList, error)
+	// Trending lists the most used tags, most used first
+	Trending(context.Context, *TrendingRequest) (*TrendList, error)
+}
+
+func RegisterTagsServer(s *grpc.Server, srv TagsServer) {
+	s.RegisterService(&_Tags_serviceDesc, srv)
+}
+
+func _Tags_Posts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaggedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TagsServer).Posts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Tags/Posts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TagsServer).Posts(ctx, req.(*TaggedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tags_Trending_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TrendingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TagsServer).Trending(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.Tags/Trending",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TagsServer).Trending(ctx, req.(*TrendingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Tags_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "nerdz.Tags",
+	HandlerType: (*TagsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Posts",
+			Handler:    _Tags_Posts_Handler,
+		},
+		{
+			MethodName: "Trending",
+			Handler:    _Tags_Trending_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "nerdz.proto",
+}
+
+// Client API for Events service
+
+type EventsClient interface {
+	// Subscribe streams the events that concern the authenticated user: the posts on the followed boards
+	// and on the user board, the comments on the lurked posts, the pms, the typing indicators and the read receipts
+	// (if the token has been granted pms:read), the votes on the contents of the user, the mentions and the new followers.
+	// The events are filtered as the home. If the client doesn't keep up, the stream is aborted
+	Subscribe(ctx context.Context, in *google_protobuf1.Empty, opts ...grpc.CallOption) (Events_SubscribeClient, error)
+}
+
+type eventsClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewEventsClient(cc *grpc.ClientConn) EventsClient {
+	return &eventsClient{cc}
+}
+
+func (c *eventsClient) Subscribe(ctx context.Context, in *google_protobuf1.Empty, opts ...grpc.CallOption) (Events_SubscribeClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Events_serviceDesc.Streams[0], c.cc, "/nerdz.Events/Subscribe", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &eventsSubscribeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Events_SubscribeClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
+}
+
+type eventsSubscribeClient struct {
+	grpc.ClientStream
+}
+
+func (x *eventsSubscribeClient) Recv() (*Event, error) {
+	m := new(Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Server API for Events service
+
+type EventsServer interface {
+	// Subscribe streams the events that concern the authenticated user: the posts on the followed boards
+	// and on the user board, the comments on the lurked posts, the pms, the typing indicators and the read receipts
+	// (if the token has been granted pms:read), the votes on the contents of the user, the mentions and the new followers.
+	// The events are filtered as the home. If the client doesn't keep up, the stream is aborted
+	Subscribe(*google_protobuf1.Empty, Events_SubscribeServer) error
+}
+
+func RegisterEventsServer(s *grpc.Server, srv EventsServer) {
+	s.RegisterService(&_Events_serviceDesc, srv)
+}
+
+func _Events_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(google_protobuf1.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EventsServer).Subscribe(m, &eventsSubscribeServer{stream})
+}
+
+type Events_SubscribeServer interface {
+	Send(*Event) error
+	grpc.ServerStream
+}
+
+type eventsSubscribeServer struct {
+	grpc.ServerStream
+}
+
+func (x *eventsSubscribeServer) Send(m *Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _Events_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "nerdz.Events",
+	HandlerType: (*EventsServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Subscribe",
+			Handler:       _Events_Subscribe_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "nerdz.proto",
+}
+
+// Client API for OAuth2 service
+
+type OAuth2Client interface {
+	// RevokeToken revokes the token family of a token issued to the calling client
+	RevokeToken(ctx context.Context, in *RevokeTokenRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error)
+	// RevokeClientTokens revokes every token issued to the calling client
+	RevokeClientTokens(ctx context.Context, in *google_protobuf1.Empty, opts ...grpc.CallOption) (*google_protobuf1.Empty, error)
+	// RevokeUserTokens revokes every token granted by the authenticated user, to any client
+	RevokeUserTokens(ctx context.Context, in *google_protobuf1.Empty, opts ...grpc.CallOption) (*google_protobuf1.Empty, error)
+	// Clients lists the clients owned by the authenticated user
+	Clients(ctx context.Context, in *google_protobuf1.Empty, opts ...grpc.CallOption) (*ApplicationList, error)
+	// CreateClient creates a client owned by the authenticated user
+	CreateClient(ctx context.Context, in *CreateClientRequest, opts ...grpc.CallOption) (*ClientSecret, error)
+	// UpdateClient changes the redirect uri of a client owned by the authenticated user
+	UpdateClient(ctx context.Context, in *UpdateClientRequest, opts ...grpc.CallOption) (*Application, error)
+	// RotateClientSecret replaces the secret of a client owned by the authenticated user
+	RotateClientSecret(ctx context.Context, in *ClientRequest, opts ...grpc.CallOption) (*ClientSecret, error)
+	// DeleteClient deletes a client owned by the authenticated user, revoking its tokens
+	DeleteClient(ctx context.Context, in *ClientRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error)
+}
+
+type oAuth2Client struct {
+	cc *grpc.ClientConn
+}
+
+func NewOAuth2Client(cc *grpc.ClientConn) OAuth2Client {
+	return &oAuth2Client{cc}
+}
+
+func (c *oAuth2Client) RevokeToken(ctx context.Context, in *RevokeTokenRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error) {
+	out := new(google_protobuf1.Empty)
+	err := grpc.Invoke(ctx, "/nerdz.OAuth2/RevokeToken", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oAuth2Client) RevokeClientTokens(ctx context.Context, in *google_protobuf1.Empty, opts ...grpc.CallOption) (*google_protobuf1.Empty, error) {
+	out := new(google_protobuf1.Empty)
+	err := grpc.Invoke(ctx, "/nerdz.OAuth2/RevokeClientTokens", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oAuth2Client) RevokeUserTokens(ctx context.Context, in *google_protobuf1.Empty, opts ...grpc.CallOption) (*google_protobuf1.Empty, error) {
+	out := new(google_protobuf1.Empty)
+	err := grpc.Invoke(ctx, "/nerdz.OAuth2/RevokeUserTokens", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oAuth2Client) Clients(ctx context.Context, in *google_protobuf1.Empty, opts ...grpc.CallOption) (*ApplicationList, error) {
+	out := new(ApplicationList)
+	err := grpc.Invoke(ctx, "/nerdz.OAuth2/Clients", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oAuth2Client) CreateClient(ctx context.Context, in *CreateClientRequest, opts ...grpc.CallOption) (*ClientSecret, error) {
+	out := new(ClientSecret)
+	err := grpc.Invoke(ctx, "/nerdz.OAuth2/CreateClient", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oAuth2Client) UpdateClient(ctx context.Context, in *UpdateClientRequest, opts ...grpc.CallOption) (*Application, error) {
+	out := new(Application)
+	err := grpc.Invoke(ctx, "/nerdz.OAuth2/UpdateClient", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oAuth2Client) RotateClientSecret(ctx context.Context, in *ClientRequest, opts ...grpc.CallOption) (*ClientSecret, error) {
+	out := new(ClientSecret)
+	err := grpc.Invoke(ctx, "/nerdz.OAuth2/RotateClientSecret", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oAuth2Client) DeleteClient(ctx context.Context, in *ClientRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error) {
+	out := new(google_protobuf1.Empty)
+	err := grpc.Invoke(ctx, "/nerdz.OAuth2/DeleteClient", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for OAuth2 service
+
+type OAuth2Server interface {
+	// RevokeToken revokes the token family of a token issued to the calling client
+	RevokeToken(context.Context, *RevokeTokenRequest) (*google_protobuf1.Empty, error)
+	// RevokeClientTokens revokes every token issued to the calling client
+	RevokeClientTokens(context.Context, *google_protobuf1.Empty) (*google_protobuf1.Empty, error)
+	// RevokeUserTokens revokes every token granted by the authenticated user, to any client
+	RevokeUserTokens(context.Context, *google_protobuf1.Empty) (*google_protobuf1.Empty, error)
+	// Clients lists the clients owned by the authenticated user
+	Clients(context.Context, *google_protobuf1.Empty) (*ApplicationList, error)
+	// CreateClient creates a client owned by the authenticated user
+	CreateClient(context.Context, *CreateClientRequest) (*ClientSecret, error)
+	// UpdateClient changes the redirect uri of a client owned by the authenticated user
+	UpdateClient(context.Context, *UpdateClientRequest) (*Application, error)
+	// RotateClientSecret replaces the secret of a client owned by the authenticated user
+	RotateClientSecret(context.Context, *ClientRequest) (*ClientSecret, error)
+	// DeleteClient deletes a client owned by the authenticated user, revoking its tokens
+	DeleteClient(context.Context, *ClientRequest) (*google_protobuf1.Empty, error)
+}
+
+func RegisterOAuth2Server(s *grpc.Server, srv OAuth2Server) {
+	s.RegisterService(&_OAuth2_serviceDesc, srv)
+}
+
+func _OAuth2_RevokeToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OAuth2Server).RevokeToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.OAuth2/RevokeToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OAuth2Server).RevokeToken(ctx, req.(*RevokeTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OAuth2_RevokeClientTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(google_protobuf1.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OAuth2Server).RevokeClientTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.OAuth2/RevokeClientTokens",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OAuth2Server).RevokeClientTokens(ctx, req.(*google_protobuf1.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OAuth2_RevokeUserTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(google_protobuf1.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OAuth2Server).RevokeUserTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.OAuth2/RevokeUserTokens",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OAuth2Server).RevokeUserTokens(ctx, req.(*google_protobuf1.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OAuth2_Clients_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(google_protobuf1.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OAuth2Server).Clients(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.OAuth2/Clients",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OAuth2Server).Clients(ctx, req.(*google_protobuf1.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OAuth2_CreateClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateClientRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OAuth2Server).CreateClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.OAuth2/CreateClient",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OAuth2Server).CreateClient(ctx, req.(*CreateClientRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OAuth2_UpdateClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateClientRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OAuth2Server).UpdateClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.OAuth2/UpdateClient",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OAuth2Server).UpdateClient(ctx, req.(*UpdateClientRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OAuth2_RotateClientSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClientRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OAuth2Server).RotateClientSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.OAuth2/RotateClientSecret",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OAuth2Server).RotateClientSecret(ctx, req.(*ClientRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OAuth2_DeleteClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClientRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OAuth2Server).DeleteClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nerdz.OAuth2/DeleteClient",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OAuth2Server).DeleteClient(ctx, req.(*ClientRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _OAuth2_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "nerdz.OAuth2",
+	HandlerType: (*OAuth2Server)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RevokeToken",
+			Handler:    _OAuth2_RevokeToken_Handler,
+		},
+		{
+			MethodName: "RevokeClientTokens",
+			Handler:    _OAuth2_RevokeClientTokens_Handler,
+		},
+		{
+			MethodName: "RevokeUserTokens",
+			Handler:    _OAuth2_RevokeUserTokens_Handler,
+		},
+		{
+			MethodName: "Clients",
+			Handler:    _OAuth2_Clients_Handler,
+		},
+		{
+			MethodName: "CreateClient",
+			Handler:    _OAuth2_CreateClient_Handler,
+		},
+		{
+			MethodName: "UpdateClient",
+			Handler:    _OAuth2_UpdateClient_Handler,
+		},
+		{
+			MethodName: "RotateClientSecret",
+			Handler:    _OAuth2_RotateClientSecret_Handler,
+		},
+		{
+			MethodName: "DeleteClient",
+			Handler:    _OAuth2_DeleteClient_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "nerdz.proto",
 }
 
 func init() { proto1.RegisterFile("nerdz.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 147 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0xce, 0x4b, 0x2d, 0x4a,
-	0xa9, 0xd2, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x05, 0x73, 0xb4, 0x32, 0xb9, 0x38, 0x7c,
-	0x12, 0xf3, 0xd2, 0x4b, 0x13, 0xd3, 0x53, 0x85, 0xb8, 0xb9, 0xd8, 0x3d, 0xfd, 0xc2, 0x1c, 0x7d,
-	0x3c, 0x5d, 0x04, 0x18, 0x40, 0x1c, 0x57, 0x3f, 0x77, 0x1f, 0xcf, 0x60, 0x0f, 0x01, 0x46, 0xb0,
-	0x4c, 0x88, 0xa3, 0x8f, 0xa7, 0xa3, 0x9f, 0x00, 0x93, 0x10, 0x0f, 0x17, 0x87, 0x73, 0x90, 0xbf,
-	0x63, 0x08, 0x88, 0xc7, 0x2c, 0xc4, 0xc5, 0xc5, 0xe6, 0xee, 0x1a, 0xe4, 0xeb, 0xe8, 0x27, 0xc0,
-	0x22, 0xc4, 0xc7, 0xc5, 0x15, 0xe0, 0x1f, 0x14, 0x12, 0xea, 0x1e, 0xea, 0x1a, 0xec, 0x2a, 0xc0,
-	0x0a, 0x52, 0x19, 0xe4, 0xef, 0xeb, 0xe8, 0x07, 0x52, 0xc9, 0xe6, 0xc4, 0x1e, 0xc5, 0x0a, 0xb6,
-	0x3a, 0x89, 0x0d, 0x4c, 0x19, 0x03, 0x02, 0x00, 0x00, 0xff, 0xff, 0x54, 0x86, 0x91, 0x8a, 0x90,
-	0x00, 0x00, 0x00,
+	// 3859 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x3a, 0x4b, 0x6c, 0xe3, 0x48,
+	0x76, 0x26, 0x45, 0x49, 0xd4, 0x93, 0x6c, 0xb3, 0xcb, 0x9e, 0x1e, 0xb5, 0xe7, 0xe7, 0x66, 0x26,
+	0x3b, 0x5e, 0xcf, 0xae, 0xa7, 0xdb, 0x33, 0xd3, 0x9d, 0xce, 0x76, 0xd2, 0x6b, 0xcb, 0xea, 0xb6,
+	0x3b, 0xfe, 0x81, 0x96, 0x7b, 0xb0, 0x8b, 0x00, 0x02, 0x25, 0x95, 0x65, 0xae, 0x25, 0x52, 0x4b,
+	0x52, 0xf6, 0x78, 0x4e, 0x09, 0x92, 0x20, 0x40, 0x2e, 0x09, 0x30, 0x01, 0x72, 0x08, 0x90, 0x53,
+	0x3e, 0x40, 0x0e, 0xc9, 0x3d, 0x01, 0x16, 0x08, 0xb0, 0xf7, 0x1c, 0x73, 0xcb, 0x39, 0x41, 0x02,
+	0x04, 0xc9, 0x29, 0xc7, 0xa0, 0x7e, 0x64, 0x91, 0xa2, 0x64, 0xc9, 0xdd, 0xc9, 0x0c, 0xb2, 0x27,
+	0xf2, 0x55, 0xbd, 0x5f, 0xbd, 0xf7, 0xea, 0x55, 0xbd, 0x47, 0x42, 0xd9, 0xc5, 0x7e, 0xe7, 0xab,
+	0x8d, 0x81, 0xef, 0x85, 0x1e, 0xca, 0x53, 0x60, 0xe5, 0xfd, 0xae, 0xe7, 0x75, 0x7b, 0xf8, 0x13,
+	0x3a, 0xd8, 0x1a, 0x9e, 0x7d, 0xd2, 0x19, 0xfa, 0x76, 0xe8, 0x78, 0x2e, 0x43, 0x5b, 0x79, 0x27,
+	0x3d, 0x8f, 0xfb, 0x83, 0xf0, 0x9a, 0x4f, 0x7e, 0x90, 0x9e, 0x0c, 0x9d, 0x3e, 0x0e, 0x42, 0xbb,
+	0x3f, 0x60, 0x08, 0xe6, 0x1f, 0x68, 0x50, 0x3c, 0xf6, 0xbd, 0x33, 0xa7, 0x87, 0x51, 0x15, 0x8a,
+	0x6d, 0x6f, 0xe8, 0x86, 0xd8, 0xaf, 0x2a, 0xab, 0xca, 0x9a, 0x66, 0x09, 0x90, 0xcc, 0x5c, 0xe1,
+	0x56, 0xe0, 0x84, 0xb8, 0xaa, 0xae, 0x2a, 0x6b, 0x25, 0x4b, 0x80, 0xe8, 0x2e, 0x14, 0x7e, 0x3a,
+	0xf4, 0x42, 0x1c, 0x54, 0x73, 0x74, 0x82, 0x43, 0xe8, 0x5d, 0x28, 0xb5, 0x1c, 0xaf, 0xeb, 0xdb,
+	0x83, 0xf3, 0xeb, 0xaa, 0x46, 0xa7, 0xe2, 0x01, 0x42, 0xd5, 0x75, 0xc2, 0xf3, 0x61, 0xab, 0x9a,
+	0x67, 0x54, 0x0c, 0x42, 0xcb, 0x90, 0x0f, 0x2e, 0xae, 0x07, 0xb8, 0x5a, 0xa0, 0xc3, 0x0c, 0x20,
+	0xd8, 0x3f, 0xb1, 0x5b, 0x2d, 0xec, 0x57, 0x8b, 0x0c, 0x9b, 0x41, 0x04, 0xfb, 0xda, 0x3e, 0xf7,
+	0xbc, 0xaa, 0xce, 0xb0, 0x29, 0x80, 0xde, 0x07, 0x18, 0x06, 0xd8, 0x0f, 0xda, 0xbe, 0x33, 0x08,
+	0xab, 0x25, 0x3a, 0x25, 0x8d, 0xa0, 0x15, 0xd0, 0x43, 0xdc, 0x1f, 0xf4, 0xec, 0x10, 0x57, 0x61,
+	0x55, 0x59, 0x9b, 0xb7, 0x22, 0x18, 0x7d, 0x04, 0x8b, 0x7d, 0xaf, 0xe5, 0xf4, 0x70, 0x33, 0x42,
+	0x29, 0x53, 0x94, 0x05, 0x36, 0xdc, 0x10, 0x88, 0xef, 0x03, 0x74, 0xec, 0x10, 0x9f, 0x79, 0x7e,
+	0xdf, 0x0e, 0xab, 0x15, 0x26, 0x24, 0x1e, 0x21, 0x42, 0xce, 0xec, 0x36, 0x6e, 0x79, 0xde, 0x45,
+	0x75, 0x9e, 0xce, 0x46, 0x30, 0x31, 0x66, 0x78, 0xe5, 0x84, 0xc4, 0xcc, 0x0b, 0xcc, 0x98, 0x1c,
+	0xa4, 0xcb, 0x0f, 0xb1, 0xdd, 0xaf, 0x2e, 0xf2, 0xe5, 0x13, 0x00, 0x21, 0xd0, 0x06, 0xc3, 0xe0,
+	0xbc, 0x6a, 0xac, 0x2a, 0x6b, 0xba, 0x45, 0xdf, 0xd1, 0x53, 0x28, 0x93, 0xa7, 0x8f, 0xbb, 0xc4,
+	0xa1, 0xd5, 0x3b, 0xab, 0xca, 0x5a, 0x79, 0x73, 0x65, 0x83, 0x79, 0x7b, 0x43, 0x78, 0x7b, 0xa3,
+	0x21, 0xbc, 0x6d, 0xc9, 0xe8, 0xc4, 0xa0, 0xed, 0x9e, 0x17, 0xe0, 0x4e, 0x15, 0x51, 0x9e, 0x1c,
+	0x32, 0x7f, 0xae, 0x81, 0x76, 0x1a, 0x30, 0x7f, 0x8f, 0x89, 0x84, 0x0d, 0xd0, 0x7a, 0x76, 0x10,
+	0x56, 0xd5, 0x1b, 0x25, 0x52, 0x3c, 0x74, 0x1f, 0x2a, 0xae, 0x17, 0x3a, 0x67, 0xd7, 0xcd, 0x20,
+	0xf4, 0xfc, 0x6b, 0x1e, 0x25, 0x65, 0x36, 0x76, 0x42, 0x86, 0x88, 0xb0, 0x81, 0xef, 0x5c, 0x12,
+	0x63, 0x6b, 0x54, 0x1d, 0x01, 0xa2, 0x5f, 0x22, 0xc2, 0xdc, 0x2e, 0x0d, 0x92, 0x85, 0xcd, 0xc5,
+	0x0d, 0xb6, 0x3b, 0xf6, 0x6d, 0xb7, 0x3b, 0xb4, 0xbb, 0xd8, 0xa2, 0x93, 0xc4, 0xd4, 0xc4, 0xbb,
+	0xae, 0xdd, 0x17, 0x61, 0x13, 0xc1, 0xc4, 0xa0, 0xb8, 0x6f, 0x3b, 0x3d, 0x1e, 0x38, 0x0c, 0x20,
+	0x06, 0xa5, 0xd8, 0x2c, 0x6c, 0xe8, 0x3b, 0x51, 0x22, 0x18, 0x32, 0x26, 0x2c, 0x64, 0x04, 0x48,
+	0x63, 0x15, 0xbb, 0x1d, 0xec, 0xd3, 0x68, 0xd1, 0x2d, 0x0e, 0xa1, 0x27, 0x00, 0x2d, 0xc7, 0x0f,
+	0xcf, 0x9b, 0x1d, 0x11, 0x26, 0x93, 0xed, 0x51, 0xa2, 0xd8, 0x3b, 0x64, 0x5d, 0x1b, 0x00, 0x2d,
+	0xcf, 0xf6, 0x3b, 0x4d, 0xba, 0xba, 0x4a, 0xf6, 0xea, 0x4a, 0x14, 0x65, 0x9f, 0x2f, 0x91, 0xf8,
+	0xed, 0x2b, 0xcf, 0xc5, 0x22, 0x9a, 0x04, 0x4c, 0x22, 0xf1, 0xd2, 0xc1, 0x57, 0x9e, 0xdb, 0x73,
+	0x5c, 0x4c, 0x03, 0x4a, 0xb7, 0xa4, 0x11, 0xf4, 0x02, 0xee, 0xf8, 0xb8, 0xeb, 0x04, 0x21, 0x4b,
+	0x1a, 0x4d, 0x1a, 0x2f, 0x8b, 0x37, 0x6a, 0x6b, 0xc8, 0x44, 0x64, 0x18, 0xad, 0x11, 0x37, 0xd1,
+	0x44, 0x41, 0x23, 0xb1, 0xbc, 0xb9, 0xc0, 0x35, 0xe6, 0xe9, 0xc3, 0x12, 0xd3, 0xe6, 0xbf, 0xaa,
+	0x50, 0x39, 0xc6, 0x7e, 0xe0, 0xb9, 0x76, 0x6f, 0xcf, 0x3d, 0xf3, 0xd0, 0x3b, 0x50, 0x72, 0x82,
+	0x26, 0x57, 0x51, 0xa1, 0x2a, 0xea, 0x4e, 0x70, 0xc4, 0x14, 0xbc, 0x0b, 0x05, 0x97, 0x4a, 0xe1,
+	0xa9, 0x85, 0x43, 0x89, 0x45, 0xe7, 0x52, 0x8b, 0x96, 0x7d, 0xae, 0xa5, 0x7c, 0x2e, 0xbc, 0x9b,
+	0xcf, 0xf6, 0x6e, 0x61, 0x9c, 0x77, 0x8b, 0x09, 0xef, 0x3e, 0x02, 0x9d, 0xfa, 0xab, 0x63, 0x5f,
+	0x57, 0xf5, 0x1b, 0xad, 0x15, 0xe1, 0x12, 0xcd, 0xba, 0xbe, 0x7d, 0x69, 0x87, 0xb6, 0xcf, 0x03,
+	0x29, 0x82, 0x49, 0x4e, 0x74, 0xc8, 0x26, 0xc2, 0x41, 0x18, 0x54, 0x61, 0x35, 0x47, 0x72, 0x62,
+	0x34, 0x20, 0x65, 0xd2, 0x32, 0x9d, 0xca, 0xcc, 0xa4, 0x95, 0x54, 0x26, 0x35, 0xff, 0x51, 0x81,
+	0x72, 0xcd, 0x73, 0x43, 0xbb, 0x1d, 0x52, 0x53, 0x4b, 0x99, 0x5a, 0x19, 0xc9, 0xd4, 0x3c, 0xe7,
+	0xaa, 0xd9, 0x39, 0x37, 0x97, 0x9d, 0x73, 0xb5, 0xec, 0x9c, 0x9b, 0x97, 0x73, 0xae, 0x9c, 0xee,
+	0x0a, 0xe3, 0xd3, 0x5d, 0x71, 0x4c, 0xba, 0xd3, 0xa5, 0x74, 0x67, 0xfe, 0xbd, 0x02, 0xa5, 0x6d,
+	0x12, 0xfa, 0x74, 0x3d, 0x1f, 0x83, 0xde, 0xe3, 0x3b, 0xa2, 0xaa, 0x64, 0x6f, 0x94, 0x08, 0x81,
+	0xc7, 0x19, 0x4f, 0x6d, 0xaa, 0x88, 0xb3, 0x1a, 0x85, 0xe5, 0x34, 0x93, 0x4b, 0xa6, 0x99, 0xef,
+	0x42, 0xe9, 0xea, 0xdc, 0x09, 0x71, 0xcf, 0x09, 0xc2, 0xaa, 0xb6, 0x9a, 0x5b, 0x2b, 0x6f, 0x96,
+	0xb9, 0x10, 0x92, 0x0d, 0xad, 0x78, 0x16, 0x7d, 0x00, 0x65, 0x12, 0x68, 0x4d, 0x7e, 0xba, 0xe4,
+	0xe3, 0xd3, 0xe5, 0x84, 0x8e, 0x98, 0xff, 0xa4, 0x80, 0x46, 0x15, 0x5f, 0x00, 0xd5, 0xe9, 0xf0,
+	0xec, 0xa9, 0x3a, 0x1d, 0x74, 0x1f, 0xf2, 0xde, 0x95, 0x8b, 0x7d, 0x9e, 0x39, 0x85, 0x00, 0x82,
+	0x6b, 0xb1, 0x99, 0x28, 0x72, 0x73, 0x52, 0xe4, 0x4e, 0x8a, 0x74, 0xc9, 0xd7, 0xf9, 0xa4, 0xaf,
+	0x97, 0x21, 0xef, 0xf4, 0x89, 0xc9, 0xf8, 0x39, 0x4a, 0x01, 0x29, 0xed, 0x17, 0xe5, 0xb4, 0x8f,
+	0x3e, 0x04, 0x2d, 0x24, 0x01, 0xa0, 0x53, 0xfb, 0x1a, 0x5c, 0x33, 0xea, 0x83, 0xc6, 0xf5, 0x00,
+	0x5b, 0x74, 0xd6, 0xfc, 0x4b, 0x95, 0xde, 0x14, 0x7e, 0x82, 0xdb, 0xe1, 0x84, 0xf3, 0x61, 0x15,
+	0xca, 0x1d, 0xcc, 0xac, 0x13, 0x6f, 0x69, 0x79, 0x28, 0x73, 0x95, 0xe3, 0x8f, 0x80, 0x65, 0xc8,
+	0x0f, 0xce, 0xbd, 0x30, 0x8a, 0x37, 0x0a, 0xc8, 0x2b, 0x2f, 0x24, 0x57, 0x8e, 0x40, 0xeb, 0x7a,
+	0xb6, 0x48, 0xf8, 0xf4, 0x9d, 0x60, 0x5f, 0x3a, 0x81, 0xd3, 0xea, 0xb1, 0x25, 0xea, 0x96, 0x00,
+	0x09, 0xb6, 0x37, 0xc0, 0x2e, 0xdd, 0xa9, 0xba, 0x45, 0xdf, 0xd1, 0x33, 0x98, 0x6f, 0xfb, 0x58,
+	0x4a, 0x96, 0x70, 0xe3, 0xf6, 0xaf, 0x08, 0x02, 0x32, 0x64, 0xfe, 0x75, 0x0e, 0xca, 0xdc, 0x50,
+	0xb3, 0x44, 0x02, 0x0d, 0x35, 0x36, 0x83, 0x7e, 0x19, 0x8a, 0x7d, 0xdc, 0x6f, 0x61, 0x9f, 0x5c,
+	0xab, 0x46, 0xe2, 0x51, 0xcc, 0x91, 0xeb, 0x8a, 0x3b, 0xec, 0x63, 0xdf, 0x69, 0x37, 0x05, 0x3a,
+	0x09, 0x5f, 0xcd, 0x5a, 0xe0, 0xc3, 0x07, 0x1c, 0xf1, 0xbb, 0x50, 0x3a, 0xf3, 0x7a, 0x3d, 0xef,
+	0x8a, 0xa0, 0xe4, 0x33, 0x22, 0x3c, 0x9a, 0x45, 0x1f, 0xc3, 0x1d, 0xc1, 0x33, 0x26, 0x29, 0x50,
+	0xae, 0x06, 0x9f, 0x78, 0x1e, 0x21, 0xa7, 0xbc, 0x5d, 0x1c, 0xef, 0x6d, 0xf9, 0xac, 0x8d, 0x7c,
+	0x5a, 0x1a, 0xe3, 0x53, 0xc8, 0xf6, 0x69, 0x39, 0xdb, 0xa7, 0x95, 0xa4, 0x4f, 0xa5, 0x58, 0x9a,
+	0x4f, 0xc6, 0x92, 0xf0, 0xf6, 0x42, 0xec, 0x6d, 0xf3, 0x3f, 0x14, 0xd0, 0x89, 0x09, 0x8e, 0xbd,
+	0x20, 0x24, 0x08, 0xe7, 0x83, 0xc8, 0x57, 0xf4, 0x9d, 0x8c, 0x9d, 0xf9, 0x5e, 0x9f, 0x3a, 0x4b,
+	0xb3, 0xe8, 0x3b, 0xf1, 0x68, 0xe8, 0xd1, 0x00, 0xd6, 0x2c, 0x35, 0xf4, 0x90, 0x01, 0x39, 0x42,
+	0xa6, 0xd1, 0x01, 0xf2, 0x4a, 0x94, 0xe8, 0xe3, 0x20, 0x20, 0x5b, 0x90, 0x6f, 0x4d, 0x0e, 0x92,
+	0x0b, 0x14, 0x8d, 0xaa, 0xc2, 0xcd, 0x17, 0x28, 0x82, 0x17, 0xdd, 0x81, 0x8a, 0x93, 0xee, 0x40,
+	0xc4, 0xca, 0xf8, 0x2a, 0xe0, 0xe1, 0x4d, 0xdf, 0xa5, 0xdd, 0x5e, 0x4a, 0x5c, 0xf2, 0xfe, 0x4b,
+	0x89, 0xc2, 0xf3, 0x17, 0x67, 0xd1, 0xff, 0xa2, 0xc0, 0xa2, 0x70, 0x73, 0xcd, 0xeb, 0xf7, 0xb1,
+	0xcb, 0x16, 0xde, 0x96, 0x16, 0xde, 0x66, 0x0b, 0xa7, 0xc6, 0x50, 0x33, 0x8c, 0x91, 0x1b, 0x31,
+	0x86, 0x16, 0x19, 0x63, 0xfc, 0xd2, 0xc5, 0x52, 0x0a, 0x93, 0x96, 0x22, 0xec, 0x53, 0x9c, 0xd2,
+	0x3e, 0x2b, 0xa0, 0xe3, 0x8e, 0x13, 0xda, 0x71, 0x4a, 0x8b, 0x60, 0xf3, 0xdf, 0x14, 0x40, 0x92,
+	0x7f, 0xff, 0x9f, 0xaf, 0xf6, 0xe7, 0x0a, 0xa8, 0xc7, 0x07, 0xb4, 0x46, 0xea, 0xc7, 0xab, 0x23,
+	0xef, 0x53, 0x05, 0xb1, 0xb4, 0x12, 0x2d, 0x7b, 0x25, 0x13, 0x6b, 0x8f, 0xb7, 0xa1, 0x18, 0x7a,
+	0x4d, 0x1f, 0xdb, 0x1d, 0xba, 0x62, 0xdd, 0x2a, 0x84, 0x9e, 0x85, 0xed, 0xce, 0xac, 0x4b, 0x34,
+	0xff, 0x4c, 0x81, 0x4a, 0xcd, 0x73, 0x2f, 0xb1, 0x1f, 0xd8, 0x22, 0x6f, 0x52, 0xe5, 0x95, 0x11,
+	0xe5, 0xd5, 0x48, 0xf9, 0xfb, 0x50, 0x21, 0x35, 0x56, 0x53, 0xac, 0x80, 0xd7, 0x56, 0x64, 0xec,
+	0x20, 0xb5, 0xf1, 0xb4, 0x29, 0x4d, 0x2d, 0x2d, 0x28, 0x2f, 0x2f, 0xc8, 0xfc, 0x73, 0x05, 0x2a,
+	0x62, 0x03, 0xbd, 0xf2, 0xc2, 0x49, 0xcd, 0x82, 0xdb, 0x46, 0x15, 0x02, 0xed, 0xd2, 0xe3, 0x77,
+	0x99, 0xbc, 0x45, 0xdf, 0x67, 0x4d, 0x1c, 0xe6, 0x5f, 0x29, 0xb0, 0x28, 0x05, 0xff, 0xb7, 0x58,
+	0xd3, 0x0b, 0x58, 0x4a, 0x25, 0xa4, 0x29, 0x94, 0x6d, 0x4b, 0xca, 0xb6, 0xc7, 0x28, 0x2b, 0x94,
+	0xd3, 0x62, 0xe5, 0xcc, 0xbf, 0x51, 0xe0, 0xee, 0x68, 0x4e, 0x78, 0x43, 0x02, 0xff, 0x37, 0xac,
+	0xf3, 0xbb, 0x0a, 0x18, 0xc2, 0x3c, 0xdb, 0x9e, 0x77, 0xd1, 0xb7, 0xfd, 0x8b, 0x37, 0xe0, 0xc8,
+	0x19, 0xb7, 0x83, 0xf9, 0xfb, 0x0a, 0x2c, 0x49, 0x76, 0xfb, 0x06, 0x35, 0xf9, 0x23, 0x69, 0xff,
+	0xed, 0x0f, 0xdf, 0x88, 0x0a, 0x69, 0xbf, 0x09, 0x95, 0xf2, 0x53, 0xaa, 0xf4, 0x75, 0x72, 0xaf,
+	0x7d, 0x4b, 0xb4, 0xfa, 0x2d, 0xd9, 0x50, 0x5e, 0xfb, 0x06, 0x95, 0x48, 0x2d, 0x25, 0x54, 0x1a,
+	0x06, 0x6c, 0x8c, 0xaa, 0x99, 0x93, 0xd4, 0x9c, 0xd5, 0x57, 0x7f, 0x2c, 0x05, 0x2f, 0x79, 0xde,
+	0xac, 0xc6, 0x8d, 0xe7, 0x94, 0x50, 0x4b, 0xcb, 0x50, 0x6b, 0x5a, 0xcb, 0xfc, 0x4e, 0xca, 0x5f,
+	0xdf, 0x8c, 0x71, 0xfe, 0x24, 0xb9, 0xa5, 0xbe, 0x45, 0xf6, 0xf9, 0x3d, 0x05, 0xca, 0x5b, 0x83,
+	0x41, 0xcf, 0x69, 0xb3, 0x23, 0x38, 0x5d, 0xb7, 0x89, 0x52, 0x46, 0x95, 0x4a, 0x99, 0xfb, 0x50,
+	0xf1, 0x71, 0xc7, 0xf1, 0x71, 0x3b, 0x6c, 0x0e, 0x7d, 0x47, 0x1c, 0xc1, 0x62, 0xec, 0xd4, 0x77,
+	0xc8, 0x91, 0x4a, 0x5b, 0x06, 0x91, 0x76, 0x05, 0x02, 0xee, 0x75, 0xc8, 0x5d, 0x75, 0x30, 0x6c,
+	0xf5, 0x9c, 0xb6, 0x38, 0x6a, 0x19, 0x64, 0x7e, 0x05, 0xc5, 0xf8, 0xf8, 0x2e, 0x51, 0xda, 0x81,
+	0x17, 0x84, 0x54, 0x93, 0x72, 0x74, 0x13, 0x11, 0x01, 0xb6, 0x3b, 0xc7, 0x3a, 0x02, 0xe4, 0x1d,
+	0x3d, 0x86, 0xca, 0x80, 0xd9, 0x96, 0x91, 0xb0, 0x0a, 0x13, 0xc5, 0x8d, 0x3a, 0x61, 0xf6, 0xdd,
+	0x39, 0xab, 0x3c, 0x90, 0x12, 0x5b, 0x01, 0x34, 0x42, 0x60, 0xfe, 0xad, 0x0a, 0x45, 0xd2, 0x4e,
+	0x22, 0x37, 0xc6, 0xff, 0x2b, 0xe1, 0x68, 0x07, 0xee, 0x44, 0x82, 0x9a, 0x6d, 0x76, 0x36, 0x51,
+	0x4b, 0x96, 0x37, 0xef, 0xa6, 0x04, 0xf2, 0x93, 0x6b, 0x77, 0xce, 0x5a, 0x1c, 0x26, 0x87, 0xd0,
+	0x01, 0x2c, 0xcb, 0xe2, 0x23, 0x46, 0x2c, 0x30, 0xef, 0x8d, 0xaa, 0x11, 0xf3, 0x42, 0x83, 0x91,
+	0x51, 0xf4, 0x0e, 0xa8, 0x83, 0x3e, 0x8f, 0x9d, 0x92, 0x20, 0x3e, 0xd8, 0x9d, 0xb3, 0xd4, 0x41,
+	0x7f, 0xbb, 0x44, 0x82, 0x95, 0x5a, 0xc9, 0xac, 0x41, 0x89, 0x1b, 0x6c, 0x6f, 0x07, 0x7d, 0x87,
+	0x77, 0x52, 0x58, 0xa7, 0x4a, 0x2c, 0x9d, 0xcf, 0xc7, 0xbd, 0x14, 0x1e, 0x5a, 0xaa, 0x08, 0x2d,
+	0xf3, 0xbf, 0x15, 0xc8, 0xd7, 0x2f, 0x89, 0xd8, 0x0f, 0x13, 0x1c, 0x44, 0x2f, 0xa6, 0x7e, 0x99,
+	0xa4, 0xcf, 0xda, 0x12, 0xeb, 0x91, 0x4e, 0xdc, 0x76, 0x46, 0x52, 0xfc, 0xde, 0x8e, 0x25, 0x10,
+	0xd0, 0x27, 0xa2, 0x01, 0x4d, 0x65, 0x69, 0x63, 0xfa, 0x3e, 0xa5, 0x96, 0x78, 0x25, 0x25, 0x3b,
+	0x05, 0xa8, 0x41, 0x34, 0x8b, 0x01, 0xd1, 0x49, 0x5f, 0xc8, 0x38, 0xe9, 0xa7, 0xbd, 0xf9, 0xfe,
+	0xbb, 0x02, 0x95, 0x43, 0xf2, 0x35, 0x40, 0x6c, 0xbb, 0x8f, 0x13, 0x16, 0x78, 0x9b, 0x6b, 0x25,
+	0xa3, 0xdc, 0x60, 0x88, 0xe4, 0xe2, 0x72, 0x33, 0x2c, 0x4e, 0x93, 0x17, 0xf7, 0x21, 0xdb, 0x12,
+	0x3c, 0x04, 0x46, 0x8d, 0x49, 0x67, 0x67, 0xbe, 0xd8, 0x7c, 0xad, 0x90, 0xdd, 0xed, 0x8e, 0x4b,
+	0x30, 0x37, 0x26, 0x3a, 0xa1, 0x95, 0x36, 0x95, 0x56, 0xd3, 0xa6, 0xbe, 0x4f, 0x20, 0xdf, 0xf0,
+	0xb1, 0xdb, 0x21, 0x45, 0x7d, 0x68, 0x77, 0x79, 0xeb, 0x98, 0xbc, 0x12, 0xe3, 0xd0, 0x44, 0xcc,
+	0xb5, 0x62, 0x80, 0xf9, 0x7d, 0xd6, 0x35, 0xd9, 0x77, 0xe8, 0x27, 0x9e, 0x3c, 0xfd, 0xbc, 0x56,
+	0x55, 0x46, 0x1b, 0x4b, 0x6c, 0xc6, 0x7c, 0x12, 0xb5, 0x1c, 0x28, 0xc5, 0x3a, 0xe8, 0x7c, 0xc7,
+	0x09, 0xa2, 0x85, 0xe4, 0xf6, 0xb4, 0xa2, 0x79, 0xd3, 0x86, 0x32, 0xcf, 0x86, 0x82, 0x94, 0x97,
+	0x3b, 0x69, 0x52, 0x8e, 0x65, 0x45, 0xf3, 0xac, 0x41, 0xf0, 0x65, 0x18, 0x25, 0x6c, 0xfc, 0x25,
+	0x2d, 0x83, 0x07, 0x3e, 0xbe, 0x14, 0xdd, 0x47, 0xf2, 0x4e, 0x44, 0x70, 0x03, 0x0a, 0x11, 0x7c,
+	0x9f, 0xa4, 0x45, 0x70, 0x2c, 0x2b, 0x9a, 0x9f, 0x5a, 0xc4, 0x01, 0x18, 0x72, 0x79, 0x47, 0xe5,
+	0x3c, 0x81, 0xf9, 0xb6, 0x34, 0x26, 0x84, 0x2d, 0xc5, 0xc2, 0xa2, 0x39, 0x2b, 0x89, 0x69, 0x0e,
+	0xc1, 0x90, 0x37, 0x84, 0x60, 0xe7, 0x4a, 0x63, 0x69, 0x76, 0x32, 0xbe, 0x95, 0xc4, 0x9c, 0xc5,
+	0x50, 0x3c, 0x76, 0x63, 0x5f, 0xb8, 0xb2, 0xb0, 0xd8, 0x17, 0x74, 0xd8, 0x8a, 0xe6, 0xa7, 0x16,
+	0xf1, 0x10, 0x4a, 0x34, 0x12, 0xa9, 0x80, 0x0f, 0xa1, 0x10, 0x12, 0x40, 0xb0, 0xaf, 0x70, 0xf6,
+	0x14, 0xc3, 0xe2, 0x73, 0xe6, 0x1e, 0x2c, 0x4a, 0xc7, 0x36, 0x25, 0x7c, 0x04, 0x15, 0x3b, 0x1e,
+	0x12, 0xe4, 0x22, 0x1f, 0x4b, 0xd8, 0x56, 0x02, 0xcf, 0xfc, 0x3b, 0x72, 0x45, 0xf2, 0x82, 0x90,
+	0xf4, 0xfa, 0x8f, 0x06, 0x4c, 0xf3, 0x77, 0x45, 0xef, 0xd4, 0x71, 0xbb, 0xfc, 0xe3, 0x55, 0x3c,
+	0x10, 0xcf, 0x92, 0x0d, 0xa0, 0xca, 0xb3, 0xac, 0x99, 0x1a, 0x7f, 0xbd, 0xc8, 0xdd, 0xf4, 0xf5,
+	0xa2, 0x02, 0x8a, 0x4b, 0xf7, 0xf5, 0xbc, 0xa5, 0xb8, 0x64, 0xdf, 0x79, 0x3d, 0xf2, 0x5d, 0x8a,
+	0x37, 0x49, 0x29, 0x40, 0x46, 0x5d, 0x7c, 0xc5, 0xbf, 0x45, 0x96, 0x2c, 0x06, 0xbc, 0xd4, 0xf4,
+	0xbc, 0x51, 0x32, 0x7f, 0x0c, 0x88, 0x1f, 0x5e, 0xb2, 0xfa, 0x94, 0xab, 0x32, 0xc2, 0x55, 0xcb,
+	0xe4, 0x9a, 0x4f, 0x72, 0x55, 0x8d, 0xdc, 0x4b, 0x4d, 0xcf, 0x19, 0x9a, 0x69, 0x01, 0x1c, 0xf7,
+	0x83, 0x37, 0xcb, 0xf3, 0x6b, 0x05, 0x96, 0xe5, 0xa8, 0xbc, 0x89, 0xbd, 0x9a, 0xc9, 0x3e, 0x27,
+	0xb1, 0x47, 0xdf, 0x87, 0x3c, 0x49, 0xfa, 0xac, 0x0d, 0x3e, 0xe1, 0xf0, 0x60, 0x58, 0x24, 0x00,
+	0xa5, 0x56, 0x07, 0x7d, 0x37, 0x7f, 0x03, 0x16, 0x79, 0xf4, 0xbe, 0xbe, 0x3e, 0xe6, 0x7b, 0x50,
+	0xa6, 0x69, 0x10, 0xff, 0x74, 0x88, 0x83, 0x30, 0x9d, 0xf0, 0xcd, 0x55, 0x58, 0x10, 0x09, 0x6f,
+	0x0c, 0xc6, 0x49, 0x1c, 0x8f, 0x63, 0x50, 0xd0, 0x03, 0x28, 0x7a, 0x4c, 0xd1, 0xaa, 0x9a, 0xb8,
+	0x33, 0xa5, 0x02, 0xd9, 0x12, 0x68, 0x66, 0x1d, 0xca, 0xbb, 0x5e, 0x1f, 0x0b, 0x86, 0x33, 0x33,
+	0x78, 0xa9, 0xe9, 0x8a, 0xa1, 0x9a, 0x3d, 0x58, 0xe4, 0xf1, 0x16, 0x08, 0x56, 0xe2, 0x74, 0x52,
+	0x26, 0x9e, 0x4e, 0x9f, 0xa6, 0x05, 0xde, 0x8b, 0x10, 0xd3, 0xe1, 0x1b, 0x2b, 0xed, 0xc4, 0x7e,
+	0x99, 0x4d, 0xda, 0xd8, 0xe5, 0xa5, 0xdc, 0x1c, 0x8b, 0x3a, 0x81, 0xf9, 0x86, 0xdd, 0xed, 0xe2,
+	0x8e, 0x10, 0x34, 0x7a, 0x2a, 0xce, 0x6e, 0x74, 0x0b, 0x16, 0x69, 0xda, 0x72, 0xdc, 0xae, 0x60,
+	0x9b, 0x8c, 0xab, 0x87, 0x50, 0xb8, 0x72, 0xdc, 0x8e, 0x77, 0x15, 0x19, 0x25, 0x7d, 0x6a, 0xef,
+	0xf0, 0x1f, 0x7f, 0x2c, 0x8e, 0x68, 0xbe, 0x84, 0x0a, 0xbd, 0xd0, 0x48, 0x06, 0xa1, 0x77, 0x1e,
+	0x75, 0xd2, 0x87, 0x3c, 0x1e, 0x40, 0x39, 0x11, 0x40, 0xdc, 0x9b, 0x47, 0x70, 0x87, 0x84, 0xea,
+	0x56, 0x9b, 0x4a, 0xe0, 0x0c, 0x49, 0xac, 0x87, 0xe7, 0x51, 0x15, 0xc8, 0x00, 0xf2, 0xcb, 0x41,
+	0xdf, 0x0b, 0xc9, 0x37, 0x15, 0xf2, 0xd1, 0x87, 0x05, 0xbc, 0x34, 0xc2, 0x19, 0x3e, 0x83, 0xf9,
+	0x93, 0x61, 0xab, 0xef, 0x44, 0x81, 0xbb, 0x16, 0x5f, 0x50, 0xd5, 0xc4, 0x0f, 0x04, 0xe2, 0x58,
+	0x15, 0xd3, 0x9c, 0xc1, 0x8f, 0xa0, 0x5c, 0xef, 0xc4, 0xe4, 0xeb, 0x69, 0xf2, 0x09, 0xf7, 0x5b,
+	0xa9, 0xad, 0x9b, 0x4b, 0xb4, 0x75, 0x39, 0xeb, 0x6d, 0x58, 0x10, 0x42, 0x67, 0xe7, 0xce, 0x79,
+	0x9c, 0x40, 0x99, 0x34, 0xd0, 0x6e, 0xa3, 0x9e, 0xb8, 0x37, 0xe7, 0xe2, 0x7b, 0x73, 0xe4, 0x85,
+	0x32, 0xa9, 0x86, 0xd3, 0x11, 0xae, 0x4e, 0x8c, 0xf0, 0x65, 0x71, 0x01, 0xcb, 0xd1, 0xcf, 0x74,
+	0x0c, 0xe0, 0x0c, 0x4f, 0x69, 0xe2, 0x9e, 0xec, 0xcf, 0x8f, 0xe3, 0x60, 0x66, 0x95, 0xc3, 0x1d,
+	0x11, 0xcc, 0xfd, 0x60, 0xcc, 0xde, 0x7f, 0x08, 0x4b, 0x89, 0xfb, 0xc9, 0x24, 0xfe, 0x9c, 0xe4,
+	0x4b, 0x28, 0xd7, 0xce, 0xed, 0xb1, 0x69, 0xec, 0x03, 0xd0, 0x02, 0xec, 0x76, 0xaa, 0xea, 0x68,
+	0xc5, 0x45, 0x27, 0x50, 0x15, 0x0a, 0xe1, 0xf5, 0x80, 0x1c, 0xc2, 0x34, 0x74, 0x77, 0xe7, 0x2c,
+	0x0e, 0xa3, 0x65, 0x9e, 0xc6, 0x35, 0x3e, 0x4e, 0xa1, 0x6d, 0x1d, 0x0a, 0x36, 0x0d, 0x66, 0xf3,
+	0x09, 0x94, 0x88, 0xe4, 0xba, 0xef, 0x7b, 0xb4, 0x9b, 0xd0, 0xf6, 0x3a, 0xac, 0xbc, 0xc8, 0x5b,
+	0xf4, 0x5d, 0x0e, 0x17, 0x35, 0x11, 0x2e, 0xe6, 0x5f, 0x28, 0x9c, 0xf6, 0x92, 0x87, 0x95, 0xcf,
+	0xd4, 0x17, 0x3d, 0x0a, 0x0e, 0xf2, 0x6a, 0x51, 0xcd, 0xac, 0x16, 0x67, 0xd5, 0x1c, 0xad, 0x41,
+	0x1e, 0x13, 0x5d, 0xd3, 0xa5, 0x87, 0x58, 0xc3, 0xee, 0x9c, 0xc5, 0x10, 0xb6, 0x8b, 0x90, 0xc7,
+	0x44, 0x33, 0xb3, 0x9e, 0x3a, 0x4a, 0x85, 0x95, 0xa3, 0x03, 0x51, 0x99, 0xe6, 0x40, 0x34, 0xd7,
+	0x01, 0x25, 0xd8, 0xd4, 0xc8, 0x65, 0x3f, 0x2e, 0x01, 0x14, 0xb9, 0x04, 0x58, 0x07, 0x64, 0xe1,
+	0x4b, 0xef, 0x02, 0x37, 0xbc, 0x0b, 0x2c, 0x47, 0x40, 0x48, 0x60, 0x9e, 0x2c, 0x19, 0x60, 0x76,
+	0x60, 0xa9, 0x46, 0x3e, 0x91, 0xe3, 0x5a, 0xcf, 0x91, 0x36, 0x9d, 0xe8, 0xa8, 0x28, 0x13, 0x3a,
+	0x2a, 0xea, 0x68, 0x47, 0x25, 0x6e, 0x9c, 0xe4, 0x12, 0x8d, 0x93, 0x5d, 0x58, 0x3a, 0x1d, 0x74,
+	0x46, 0xa4, 0x8c, 0x7e, 0x7f, 0xbf, 0x49, 0x82, 0xf9, 0x01, 0xcc, 0x4f, 0xe4, 0x61, 0xfe, 0x26,
+	0x54, 0x18, 0xc2, 0x09, 0x6e, 0xfb, 0x38, 0x44, 0x9f, 0x41, 0x59, 0xba, 0x48, 0xf2, 0x13, 0x29,
+	0xeb, 0xbe, 0x29, 0xa3, 0x91, 0x85, 0x04, 0x94, 0x5e, 0xfc, 0x92, 0xc3, 0xa0, 0x75, 0x07, 0x74,
+	0x71, 0x3f, 0x44, 0x65, 0x28, 0xee, 0x1d, 0xbe, 0xda, 0xda, 0xdf, 0xdb, 0x31, 0xe6, 0x08, 0x50,
+	0x3f, 0x7c, 0xb1, 0xbf, 0x77, 0xb2, 0x6b, 0x28, 0x74, 0xa6, 0xb1, 0xb5, 0xbf, 0xb7, 0x75, 0x68,
+	0xa8, 0xa8, 0x02, 0x7a, 0xcd, 0x3a, 0xda, 0x6a, 0x10, 0x28, 0x87, 0x00, 0x0a, 0x2f, 0xea, 0xd6,
+	0xc1, 0xd6, 0xa1, 0xa1, 0xa1, 0x05, 0x80, 0xe3, 0x23, 0xab, 0x71, 0xfa, 0xe2, 0xb4, 0x7e, 0x52,
+	0x37, 0xf2, 0x04, 0xd3, 0x3a, 0x3a, 0xd8, 0x3a, 0x24, 0x98, 0x85, 0xf5, 0x30, 0xaa, 0x7d, 0x68,
+	0x29, 0x3c, 0x0f, 0xa5, 0xd3, 0x93, 0xba, 0xd5, 0x3c, 0x3e, 0x3a, 0x69, 0x18, 0x73, 0xc8, 0x80,
+	0xca, 0xb1, 0x75, 0xf4, 0xb2, 0x5e, 0x6b, 0xb0, 0x11, 0x05, 0xbd, 0x05, 0x77, 0x22, 0x84, 0x66,
+	0xed, 0xe8, 0xe0, 0xa0, 0x7e, 0xd8, 0x30, 0x54, 0x54, 0x85, 0x65, 0x19, 0x31, 0x9a, 0xc9, 0xa1,
+	0x25, 0x58, 0x3c, 0xb6, 0xf6, 0x5e, 0x6d, 0x35, 0xea, 0xcd, 0x83, 0xfa, 0xc9, 0xc9, 0xd6, 0x8b,
+	0xba, 0xa1, 0xad, 0x9b, 0xfc, 0x17, 0x1f, 0x2a, 0x53, 0x07, 0x8d, 0xb0, 0x64, 0xcb, 0xe3, 0x5c,
+	0x0c, 0x65, 0xfd, 0xb7, 0x15, 0x28, 0x45, 0x7d, 0x0f, 0xa2, 0xf5, 0x61, 0xfd, 0x0b, 0xa1, 0xd7,
+	0x22, 0x94, 0x09, 0x24, 0xa4, 0x28, 0x64, 0xc1, 0x74, 0xfa, 0x80, 0x99, 0x82, 0xbc, 0xbf, 0x3a,
+	0x6a, 0xd4, 0x8d, 0x9c, 0x40, 0x25, 0x78, 0x7b, 0x47, 0xc4, 0x1e, 0x06, 0x54, 0xc8, 0xc0, 0xf3,
+	0xa3, 0xfd, 0xfd, 0xa3, 0x2f, 0xea, 0x96, 0x91, 0x27, 0xc4, 0x8d, 0x1f, 0x1d, 0xef, 0x1d, 0xbe,
+	0x30, 0x0a, 0x84, 0xf8, 0xf8, 0xe0, 0xa4, 0x69, 0xd5, 0xb7, 0x76, 0x8c, 0xe2, 0xfa, 0x3f, 0x2b,
+	0xc9, 0x42, 0x8b, 0xaa, 0x52, 0x85, 0xe5, 0xc3, 0xa3, 0xc6, 0xde, 0xf3, 0xbd, 0xda, 0x16, 0x61,
+	0x19, 0x69, 0x31, 0x87, 0x56, 0xe1, 0xdd, 0xc4, 0x8c, 0x30, 0x49, 0xac, 0xe7, 0x7b, 0x70, 0x2f,
+	0x13, 0x83, 0xae, 0x4b, 0x1d, 0x61, 0x2d, 0xb4, 0xce, 0xa1, 0x7b, 0xf0, 0x56, 0x62, 0x26, 0x52,
+	0x5f, 0x43, 0xf7, 0xe1, 0xbd, 0x4c, 0x9e, 0xd2, 0x0a, 0xdf, 0x86, 0xa5, 0x14, 0xdf, 0x83, 0xed,
+	0xba, 0x65, 0x14, 0x36, 0xff, 0x54, 0x87, 0x3c, 0x39, 0xf6, 0x03, 0xf4, 0x1d, 0xc8, 0xbd, 0xc0,
+	0x21, 0x42, 0x72, 0xf5, 0xce, 0x82, 0x7f, 0x45, 0xae, 0xe8, 0xd1, 0x47, 0xfc, 0xff, 0xa6, 0x49,
+	0x88, 0x14, 0xe1, 0x71, 0xea, 0x27, 0xc0, 0x2c, 0x02, 0x51, 0x9c, 0x26, 0x10, 0x3f, 0x4f, 0xfe,
+	0xd1, 0x96, 0x45, 0x27, 0x77, 0xd6, 0x04, 0xde, 0x43, 0xf9, 0xb7, 0xb1, 0x2c, 0xa2, 0xc4, 0x7d,
+	0x88, 0x62, 0x3d, 0x80, 0x52, 0xfc, 0x2f, 0x4b, 0x16, 0x89, 0xdc, 0xef, 0xa4, 0x85, 0xe5, 0x67,
+	0x30, 0x4f, 0xde, 0x9f, 0x47, 0xf5, 0xdf, 0x54, 0x54, 0xbf, 0x0a, 0x06, 0xbf, 0xe7, 0x4f, 0x26,
+	0x4c, 0xf5, 0x4a, 0x29, 0xed, 0x06, 0x14, 0x9f, 0xfb, 0x0e, 0x29, 0x74, 0xa7, 0x93, 0xf5, 0x00,
+	0x4a, 0x5f, 0x44, 0xbf, 0xab, 0x4d, 0x4b, 0xb1, 0xdd, 0xb3, 0xdb, 0x17, 0xd3, 0x53, 0x3c, 0x02,
+	0x5d, 0xdc, 0x73, 0x51, 0xfa, 0xe2, 0x9b, 0xe5, 0x22, 0xd1, 0x59, 0xd9, 0x00, 0x8d, 0x14, 0x1e,
+	0x91, 0x10, 0xa9, 0x0a, 0x59, 0x41, 0xc9, 0x36, 0x0e, 0xc5, 0xff, 0x1c, 0x0a, 0xcc, 0x60, 0x68,
+	0x49, 0xf6, 0x9d, 0x20, 0xb9, 0x3b, 0x72, 0x43, 0xae, 0x93, 0x5f, 0xdf, 0xd1, 0x63, 0xd0, 0x4f,
+	0xdd, 0xb3, 0x5b, 0x10, 0x6e, 0xc1, 0x7c, 0x64, 0x3b, 0xf6, 0x1f, 0xb4, 0xb4, 0xf2, 0xc4, 0xcd,
+	0x78, 0x2c, 0x8b, 0x1a, 0x2c, 0x9e, 0xba, 0x57, 0xaf, 0xc9, 0x64, 0x0b, 0xe6, 0x23, 0x8f, 0xbc,
+	0x8e, 0x1e, 0xad, 0xd7, 0x63, 0xb2, 0xf9, 0x33, 0x15, 0x74, 0x1e, 0x8b, 0x01, 0xfa, 0x1e, 0x4b,
+	0x10, 0x6f, 0xa5, 0x3a, 0x75, 0x9c, 0x45, 0xaa, 0x81, 0x87, 0xd6, 0x79, 0x9a, 0x18, 0x83, 0x9e,
+	0xc8, 0x14, 0xbf, 0x92, 0xfc, 0x5f, 0x6e, 0x0c, 0x49, 0x6a, 0x73, 0xf0, 0x3d, 0x5f, 0x14, 0xbf,
+	0xb8, 0x8d, 0xa1, 0x1a, 0x89, 0xdd, 0x4f, 0xe5, 0x3d, 0x3f, 0x2d, 0xd1, 0x2d, 0x03, 0x7e, 0xf3,
+	0x67, 0x79, 0xd0, 0x6b, 0xa2, 0x57, 0xf8, 0x11, 0x33, 0xe0, 0xc8, 0x2d, 0x7e, 0x25, 0x55, 0x07,
+	0x11, 0x69, 0xa2, 0xb0, 0x8e, 0xa4, 0xa5, 0x2a, 0xed, 0xcc, 0xed, 0xf5, 0x08, 0x74, 0x51, 0xd3,
+	0xa2, 0x74, 0x91, 0x3b, 0xba, 0xcd, 0xe2, 0x3e, 0xde, 0x06, 0x14, 0x58, 0xa5, 0x86, 0x96, 0xf9,
+	0x6c, 0xa2, 0x70, 0x1b, 0xd1, 0x6f, 0x1d, 0x34, 0x52, 0x98, 0x45, 0xdb, 0xb8, 0xde, 0x19, 0x8f,
+	0xfb, 0x18, 0x0a, 0x3b, 0xb8, 0x87, 0x43, 0x8c, 0xde, 0x4a, 0xce, 0xdc, 0x14, 0xc0, 0x9b, 0xa0,
+	0xd1, 0xff, 0x13, 0x84, 0x10, 0xa9, 0xd6, 0x1a, 0x4b, 0xf3, 0x04, 0xf4, 0xe8, 0x13, 0xfd, 0x8c,
+	0xe2, 0x7e, 0x00, 0x70, 0xea, 0xb6, 0x6e, 0x49, 0xfc, 0x39, 0x68, 0xfb, 0xc3, 0xd9, 0xc9, 0x1e,
+	0x43, 0xe1, 0xd4, 0xed, 0xdd, 0x82, 0x70, 0x13, 0x34, 0xfa, 0xcd, 0x54, 0xd8, 0x46, 0x2a, 0x19,
+	0xc7, 0xd2, 0x7c, 0x46, 0x85, 0xcd, 0x48, 0xb5, 0xf9, 0x9f, 0x0a, 0xe4, 0x8e, 0xfb, 0x01, 0xfa,
+	0x21, 0xcc, 0xcb, 0xf5, 0x5e, 0x80, 0xc6, 0x10, 0xac, 0xbc, 0x9d, 0xd1, 0xbd, 0xa6, 0x41, 0xf6,
+	0x3d, 0xc6, 0x48, 0x2a, 0x2d, 0x27, 0x85, 0xf2, 0x2e, 0x20, 0x16, 0x36, 0x32, 0x1f, 0xb4, 0x92,
+	0xc1, 0xfc, 0xa6, 0x75, 0x3f, 0x00, 0x8d, 0x14, 0x4e, 0xd1, 0xaa, 0xa5, 0x1a, 0x74, 0x25, 0x51,
+	0x59, 0x91, 0xab, 0xe6, 0x9a, 0xf2, 0x40, 0xd9, 0xfc, 0x07, 0x15, 0xe6, 0x13, 0x55, 0x10, 0x7a,
+	0x0a, 0x1a, 0xd5, 0xea, 0x9d, 0x8c, 0xf2, 0x49, 0x54, 0xc8, 0x2b, 0x59, 0xb5, 0x15, 0xa5, 0x7a,
+	0x06, 0x79, 0x56, 0x47, 0x65, 0x92, 0x0b, 0x5d, 0xee, 0x65, 0x4d, 0x32, 0xba, 0x1f, 0x80, 0x46,
+	0x7f, 0x2a, 0x9b, 0x48, 0x3f, 0x6e, 0xfd, 0x4f, 0x21, 0x5f, 0xeb, 0x61, 0xdb, 0xbf, 0x1d, 0xf5,
+	0xa4, 0x94, 0x22, 0x16, 0x9e, 0x91, 0x52, 0x36, 0x3d, 0xd0, 0x1a, 0x76, 0x37, 0x40, 0x0f, 0x21,
+	0x4f, 0xf2, 0x64, 0x10, 0x65, 0x96, 0x44, 0x63, 0x2d, 0xf3, 0xd0, 0xff, 0x0c, 0x74, 0xd1, 0x28,
+	0x8b, 0x44, 0xa6, 0x3a, 0x67, 0x2b, 0x86, 0x3c, 0x4e, 0x05, 0xfe, 0x1a, 0x14, 0xa8, 0x07, 0x03,
+	0x92, 0xe0, 0x4f, 0x86, 0x2d, 0xf2, 0x07, 0x72, 0x0b, 0x8f, 0x0d, 0xd3, 0x8a, 0xfc, 0x61, 0xf5,
+	0x81, 0xb2, 0xf9, 0x87, 0x1a, 0x14, 0x8e, 0xb6, 0x86, 0xe1, 0xf9, 0x26, 0xfa, 0x21, 0x94, 0xa5,
+	0xba, 0x16, 0x09, 0xbf, 0x8c, 0xd6, 0xba, 0x63, 0x8d, 0xb6, 0x23, 0x2a, 0x63, 0x56, 0x22, 0x52,
+	0x9a, 0xf1, 0x3b, 0x66, 0x1c, 0x97, 0x6d, 0x30, 0x18, 0x17, 0x72, 0x0a, 0xdd, 0x92, 0xc7, 0x13,
+	0x28, 0x32, 0x1d, 0x26, 0x91, 0x8e, 0x14, 0xa9, 0x3c, 0x6a, 0x2b, 0x72, 0xc9, 0x1e, 0xef, 0xbd,
+	0xd1, 0x3a, 0x3e, 0xba, 0xc6, 0x27, 0x4a, 0xe2, 0x5f, 0x87, 0x8a, 0x5c, 0x8d, 0x47, 0x0c, 0x32,
+	0x4a, 0xf4, 0x95, 0x8c, 0x4a, 0x19, 0x3d, 0x03, 0x64, 0x79, 0x61, 0x84, 0xca, 0xb9, 0x2e, 0x27,
+	0x44, 0x4d, 0x54, 0xe0, 0x29, 0x54, 0x78, 0x0e, 0x61, 0x0a, 0x64, 0x93, 0x8e, 0xb1, 0xcb, 0x76,
+	0xf1, 0xc7, 0x79, 0x36, 0x52, 0xa0, 0x8f, 0x4f, 0xff, 0x67, 0x00, 0x4b, 0x56, 0xcf, 0xf5, 0x9c,
+	0x39, 0x00, 0x00,
 }
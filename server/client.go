@@ -0,0 +1,82 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package server
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+
+	"github.com/nerdzeu/nerdz-core/db"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// fingerprint returns the hex encoded SHA-256 fingerprint of cert
+func fingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// certificateClient returns the OAuth2 client authenticated by cert, looked up by its fingerprint in the storage.
+// Returns an Unavailable error if the client can't be loaded from the storage
+func certificateClient(ctx context.Context, cert *x509.Certificate) (*db.OAuth2Client, error) {
+	client, err := db.OAuth2ClientByCertificate(ctx, fingerprint(cert))
+	switch {
+	case errors.Is(err, db.ErrPermissionDenied):
+		return nil, grpc.Errorf(codes.Unauthenticated, "revoked client certificate")
+	case errors.Is(err, db.ErrNotFound):
+		return nil, grpc.Errorf(codes.Unauthenticated, "unknown client certificate")
+	case err != nil:
+		return nil, grpc.Errorf(codes.Unavailable, "can't load the OAuth2 client: %s", err.Error())
+	}
+	return client, nil
+}
+
+// currentClient returns the OAuth2 client that performed the request,
+// or an Unauthenticated error if the client is not authenticated
+func currentClient(ctx context.Context) (*db.OAuth2Client, error) {
+	if client := ClientFromContext(ctx); client != nil {
+		return client, nil
+	}
+	return nil, grpc.Errorf(codes.Unauthenticated, "missing client certificate")
+}
+
+// authenticate is an authenticator that stores into the context the OAuth2 client
+// that presented the verified TLS certificate of the connection
+func authenticateClient(ctx context.Context) (context.Context, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, grpc.Errorf(codes.Unauthenticated, "missing client certificate")
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return nil, grpc.Errorf(codes.Unauthenticated, "missing client certificate")
+	}
+
+	client, err := certificateClient(ctx, tlsInfo.State.VerifiedChains[0][0])
+	if err != nil {
+		return nil, err
+	}
+	return context.WithValue(ctx, clientKey, client), nil
+}
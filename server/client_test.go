@@ -0,0 +1,67 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package server_test
+
+import (
+	"crypto/tls"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/nerdzeu/nerdz-core/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+)
+
+func TestClientCertificates(t *testing.T) {
+	for name, cert := range map[string]tls.Certificate{"web": webCert, "mobile": mobileCert} {
+		conn := dial(t, cert)
+		if _, err := proto.NewOAuth2Client(conn).RevokeClientTokens(context.Background(), &empty.Empty{}); err != nil {
+			t.Errorf("The certificate of %s, mapped by its fingerprint, should authenticate the client, but got: %s", name, err)
+		}
+		conn.Close()
+	}
+
+	for name, cert := range map[string]tls.Certificate{"unknown": unknownCert, "revoked": revokedCert, "missing": missingCert} {
+		conn := dial(t, cert)
+		_, err := proto.NewUsersClient(conn).Get(context.Background(), &proto.UserRequest{Id: me.ID()})
+		expectCode(t, err, codes.Unauthenticated, "Connecting with the "+name+" certificate")
+		conn.Close()
+	}
+}
+
+func TestClientCertificateRevocation(t *testing.T) {
+	conn := dial(t, rotatedCert)
+	defer conn.Close()
+	client := proto.NewOAuth2Client(conn)
+
+	_, err := client.RevokeClientTokens(context.Background(), &empty.Empty{})
+	expectCode(t, err, codes.Unauthenticated, "Connecting with a certificate not yet added")
+
+	if _, err = me.AddOAuth2ClientCertificate(ctx, mobile, fingerprint(rotatedCert)); err != nil {
+		t.Fatalf("AddOAuth2ClientCertificate: %s", err)
+	}
+	if _, err = client.RevokeClientTokens(context.Background(), &empty.Empty{}); err != nil {
+		t.Errorf("The added certificate should authenticate the client on the same connection, but got: %s", err)
+	}
+
+	if err = me.RevokeOAuth2ClientCertificate(ctx, mobile, fingerprint(rotatedCert)); err != nil {
+		t.Fatalf("RevokeOAuth2ClientCertificate: %s", err)
+	}
+	_, err = client.RevokeClientTokens(context.Background(), &empty.Empty{})
+	expectCode(t, err, codes.Unauthenticated, "Connecting with a certificate revoked while the server is running")
+}
@@ -0,0 +1,79 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package server
+
+import (
+	"strings"
+	"time"
+
+	"github.com/nerdzeu/nerdz-core/db"
+	"github.com/spf13/viper"
+)
+
+const (
+	viperScope = "server."
+
+	addressKey           = viperScope + "address"
+	certKey              = viperScope + "cert"
+	keyKey               = viperScope + "key"
+	clientCAKey          = viperScope + "client_ca"
+	shutdownTimeoutKey   = viperScope + "shutdown_timeout"
+	metricsAddressKey    = viperScope + "metrics_address"
+	trendingWindowKey    = viperScope + "trending_window"
+	maxTrendingWindowKey = viperScope + "max_trending_window"
+
+	sweeperAuthorizeIntervalKey  = viperScope + "sweeper.authorize_interval"
+	sweeperAuthorizeBatchSizeKey = viperScope + "sweeper.authorize_batch_size"
+	sweeperAccessIntervalKey     = viperScope + "sweeper.access_interval"
+	sweeperAccessBatchSizeKey    = viperScope + "sweeper.access_batch_size"
+	sweeperRefreshTTLKey         = viperScope + "sweeper.refresh_ttl"
+)
+
+// bindEnv parses and loads into viper config env variables, if present
+func bindEnv() {
+	viper.SetEnvPrefix("nerdz")
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+
+	viper.BindEnv(addressKey)
+	viper.BindEnv(certKey)
+	viper.BindEnv(keyKey)
+	viper.BindEnv(clientCAKey)
+	viper.BindEnv(shutdownTimeoutKey)
+	viper.BindEnv(metricsAddressKey)
+	viper.BindEnv(trendingWindowKey)
+	viper.BindEnv(maxTrendingWindowKey)
+	viper.BindEnv(sweeperAuthorizeIntervalKey)
+	viper.BindEnv(sweeperAuthorizeBatchSizeKey)
+	viper.BindEnv(sweeperAccessIntervalKey)
+	viper.BindEnv(sweeperAccessBatchSizeKey)
+	viper.BindEnv(sweeperRefreshTTLKey)
+}
+
+// setDefaults sets into viper the default values used by the server.
+// This packages namespaces each one of its keys with 'server.'.
+func setDefaults() {
+	viper.SetDefault(addressKey, ":9000")
+	viper.SetDefault(shutdownTimeoutKey, 30*time.Second)
+	viper.SetDefault(trendingWindowKey, 24*time.Hour)
+	viper.SetDefault(maxTrendingWindowKey, 7*24*time.Hour)
+	viper.SetDefault(sweeperAuthorizeIntervalKey, 10*time.Minute)
+	viper.SetDefault(sweeperAuthorizeBatchSizeKey, 1000)
+	viper.SetDefault(sweeperAccessIntervalKey, time.Hour)
+	viper.SetDefault(sweeperAccessBatchSizeKey, 1000)
+	viper.SetDefault(sweeperRefreshTTLKey, db.DefaultOAuth2RefreshTTL)
+}
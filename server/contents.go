@@ -0,0 +1,269 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package server
+
+import (
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/nerdzeu/nerdz-core/convert"
+	"github.com/nerdzeu/nerdz-core/db"
+	"github.com/nerdzeu/nerdz-core/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// contentsServer implements proto.ContentsServer
+type contentsServer struct{}
+
+func (contentsServer) Get(ctx context.Context, req *proto.ContentID) (*proto.Content, error) {
+	content, err := getContent(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = canRead(ctx, content); err != nil {
+		return nil, err
+	}
+
+	ret, err := convert.ContentToProto(content)
+	return ret, statusError(err)
+}
+
+func (contentsServer) Comments(ctx context.Context, req *proto.CommentsRequest) (*proto.ContentList, error) {
+	post, err := getPost(ctx, req.Post)
+	if err != nil {
+		return nil, err
+	}
+	if err = canRead(ctx, post); err != nil {
+		return nil, err
+	}
+
+	options, err := convert.CommentlistOptionsFromProto(ctx, req.Options, post)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	comments := *post.Comments(ctx, options)
+	ret := new(proto.ContentList)
+	for _, comment := range comments {
+		content, err := convert.ContentToProto(comment)
+		if err != nil {
+			return nil, statusError(err)
+		}
+		ret.Contents = append(ret.Contents, content)
+	}
+
+	// the comments are sorted from the oldest to the newest
+	if len(comments) > 0 {
+		newest, oldest := comments[len(comments)-1], comments[0]
+		if ret.Next, ret.Prev, err = pageCursors(ctx, newest, oldest, len(comments), int(db.AtMostComments(uint64(options.N))), options.Older, options.Newer); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+func (contentsServer) Mentions(ctx context.Context, req *proto.MentionsRequest) (*proto.MentionList, error) {
+	post, err := getPost(ctx, req.Post)
+	if err != nil {
+		return nil, err
+	}
+	if err = canRead(ctx, post); err != nil {
+		return nil, err
+	}
+
+	options, err := convert.MentionsOptionsFromProto(ctx, req.Options)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	mentions, err := post.Mentions(ctx, options)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return mentionList(ctx, *mentions, options)
+}
+
+func (contentsServer) Submit(ctx context.Context, req *proto.SubmitRequest) (*proto.Content, error) {
+	content, err := convert.ContentFromProto(req.Content)
+	if err != nil || content == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "missing content")
+	}
+
+	user, err := currentUser(ctx, writeScope(content))
+	if err != nil {
+		return nil, err
+	}
+
+	if err = user.Submit(ctx, content); err != nil {
+		return nil, statusError(err)
+	}
+
+	ret, err := convert.ContentToProto(content)
+	return ret, statusError(err)
+}
+
+func (contentsServer) Edit(ctx context.Context, req *proto.EditRequest) (*proto.Content, error) {
+	content, err := getContent(ctx, req.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := currentUser(ctx, writeScope(content))
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.CanEdit(ctx, content) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "editing of this message is not allowed")
+	}
+
+	content.SetText(req.Message)
+	if err = user.Edit(ctx, content); err != nil {
+		return nil, statusError(err)
+	}
+
+	ret, err := convert.ContentToProto(content)
+	return ret, statusError(err)
+}
+
+func (contentsServer) Delete(ctx context.Context, req *proto.ContentRequest) (*empty.Empty, error) {
+	content, err := getContent(ctx, req.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := currentUser(ctx, writeScope(content))
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.CanDelete(ctx, content) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "you can't delete this message")
+	}
+	return &empty.Empty{}, statusError(user.Delete(ctx, content))
+}
+
+func (contentsServer) Vote(ctx context.Context, req *proto.VoteRequest) (*empty.Empty, error) {
+	user, err := currentUser(ctx, db.ScopePostsWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := getContent(ctx, req.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = user.Vote(ctx, content, int8(req.Vote))
+	return &empty.Empty{}, statusError(err)
+}
+
+// postAction returns the current user and the post referenced by req
+func postAction(ctx context.Context, req *proto.ContentRequest) (*db.User, db.ExistingPost, error) {
+	user, err := currentUser(ctx, db.ScopePostsWrite)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	post, err := getPost(ctx, req.Content)
+	if err != nil {
+		return nil, nil, err
+	}
+	return user, post, nil
+}
+
+func (contentsServer) Bookmark(ctx context.Context, req *proto.ContentRequest) (*empty.Empty, error) {
+	user, post, err := postAction(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.CanBookmark(ctx, post) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "you can't bookmark this post")
+	}
+
+	_, err = user.Bookmark(ctx, post)
+	return &empty.Empty{}, statusError(err)
+}
+
+func (contentsServer) Unbookmark(ctx context.Context, req *proto.ContentRequest) (*empty.Empty, error) {
+	user, post, err := postAction(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, statusError(user.Unbookmark(ctx, post))
+}
+
+func (contentsServer) Lurk(ctx context.Context, req *proto.ContentRequest) (*empty.Empty, error) {
+	user, post, err := postAction(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.CanLurk(ctx, post) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "you can't lurk this post")
+	}
+
+	_, err = user.Lurk(ctx, post)
+	return &empty.Empty{}, statusError(err)
+}
+
+func (contentsServer) Unlurk(ctx context.Context, req *proto.ContentRequest) (*empty.Empty, error) {
+	user, post, err := postAction(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, statusError(user.Unlurk(ctx, post))
+}
+
+// lockAction returns the current user, the post and the users referenced by req
+func lockAction(ctx context.Context, req *proto.LockRequest) (*db.User, db.ExistingPost, []*db.User, error) {
+	user, post, err := postAction(ctx, &proto.ContentRequest{Content: req.Post})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var users []*db.User
+	for _, id := range req.Users {
+		other, err := getUser(ctx, id)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		users = append(users, other)
+	}
+	return user, post, users, nil
+}
+
+func (contentsServer) Lock(ctx context.Context, req *proto.LockRequest) (*empty.Empty, error) {
+	user, post, users, err := lockAction(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = user.LockPost(ctx, post, users...)
+	return &empty.Empty{}, statusError(err)
+}
+
+func (contentsServer) Unlock(ctx context.Context, req *proto.LockRequest) (*empty.Empty, error) {
+	user, post, users, err := lockAction(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, statusError(user.Unlock(ctx, post, users...))
+}
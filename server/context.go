@@ -0,0 +1,114 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package server
+
+import (
+	"github.com/nerdzeu/nerdz-core/db"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// contextKey is the type of the keys of the values stored by this package in a request context
+type contextKey int
+
+const (
+	clientKey contextKey = iota
+	userKey
+	accessKey
+)
+
+// ClientFromContext returns the OAuth2 client that performed the request, authenticated
+// using its TLS certificate. Returns nil if ctx doesn't come from an authenticated request
+func ClientFromContext(ctx context.Context) *db.OAuth2Client {
+	client, _ := ctx.Value(clientKey).(*db.OAuth2Client)
+	return client
+}
+
+// readYourWritesKey is the metadata key that requires the reads of a request to see the previous writes
+const readYourWritesKey = "read-your-writes"
+
+// readYourWrites routes the reads of the request to the primary database, instead of the read replicas,
+// if the request metadata contains read-your-writes: true
+func readYourWrites(ctx context.Context) (context.Context, error) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md[readYourWritesKey]) > 0 && md[readYourWritesKey][0] == "true" {
+		return db.ReadYourWrites(ctx), nil
+	}
+	return ctx, nil
+}
+
+// authenticator authenticates a request, returning the context enriched with the authenticated identity
+type authenticator func(context.Context) (context.Context, error)
+
+// chain returns an authenticator that authenticates the request using every authenticator, in order
+func chain(auths ...authenticator) authenticator {
+	return func(ctx context.Context) (context.Context, error) {
+		var err error
+		for _, auth := range auths {
+			if ctx, err = auth(ctx); err != nil {
+				return nil, err
+			}
+		}
+		return ctx, nil
+	}
+}
+
+// unaryInterceptor returns a grpc.UnaryServerInterceptor that authenticates every request using auth.
+// The context of the request carries store
+func unaryInterceptor(store *db.Store, auth authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := auth(store.Context(ctx))
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// streamInterceptor returns a grpc.StreamServerInterceptor that authenticates every stream using auth.
+// The context of the stream carries store, and it's cancelled when streams is done
+func streamInterceptor(store *db.Store, auth authenticator, streams context.Context) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := auth(store.Context(stream.Context()))
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go func() {
+			select {
+			case <-streams.Done():
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+		return handler(srv, &serverStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// serverStream is a grpc.ServerStream whose context can be replaced
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context of the stream
+func (stream *serverStream) Context() context.Context {
+	return stream.ctx
+}
@@ -0,0 +1,58 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package server
+
+import (
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/nerdzeu/nerdz-core/convert"
+	"github.com/nerdzeu/nerdz-core/db"
+	"github.com/nerdzeu/nerdz-core/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// eventsServer implements proto.EventsServer
+type eventsServer struct{}
+
+func (eventsServer) Subscribe(req *empty.Empty, stream proto.Events_SubscribeServer) error {
+	ctx := stream.Context()
+	user, err := currentUser(ctx, db.ScopePostsRead)
+	if err != nil {
+		return err
+	}
+	pms := hasScope(ctx, db.ScopePmsRead)
+
+	events, err := user.Events(ctx)
+	if err != nil {
+		return statusError(err)
+	}
+
+	for event := range events {
+		if (event.Type == db.EventPm || event.Type == db.EventTyping || event.Type == db.EventRead) && !pms {
+			continue
+		}
+		if err = stream.Send(convert.EventToProto(&event)); err != nil {
+			return err
+		}
+	}
+
+	if ctx.Err() != nil {
+		return grpc.Errorf(codes.Canceled, "%s", ctx.Err())
+	}
+	return grpc.Errorf(codes.Aborted, "the events are not received fast enough: subscribe again")
+}
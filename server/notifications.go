@@ -0,0 +1,106 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package server
+
+import (
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/nerdzeu/nerdz-core/convert"
+	"github.com/nerdzeu/nerdz-core/db"
+	"github.com/nerdzeu/nerdz-core/proto"
+	"golang.org/x/net/context"
+)
+
+// notificationsServer implements proto.NotificationsServer
+type notificationsServer struct{}
+
+func (notificationsServer) List(ctx context.Context, req *proto.NotificationsOptions) (*proto.NotificationList, error) {
+	user, err := currentUser(ctx, db.ScopeNotifications)
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := convert.NotificationsOptionsFromProto(ctx, req)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	notifications, err := user.Notifications(ctx, options)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	ret := new(proto.NotificationList)
+	for _, notification := range notifications {
+		ret.Notifications = append(ret.Notifications, convert.NotificationToProto(&notification))
+	}
+
+	if len(notifications) > 0 {
+		newest, oldest := notifications[0].Cursor(), notifications[len(notifications)-1].Cursor()
+		if ret.Next, ret.Prev, err = pageTokens(ctx, newest, oldest, len(notifications), int(db.AtMostNotifications(uint64(options.N))), options.Older, options.Newer); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+func (notificationsServer) Count(ctx context.Context, req *proto.NotificationsRequest) (*proto.NotificationsCount, error) {
+	user, err := currentUser(ctx, db.ScopeNotifications)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := user.CountNotifications(ctx, convert.NotificationTypesFromProto(req.Types)...)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &proto.NotificationsCount{Count: count}, nil
+}
+
+func (notificationsServer) Read(ctx context.Context, req *proto.NotificationsRequest) (*empty.Empty, error) {
+	user, err := currentUser(ctx, db.ScopeNotifications)
+	if err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, statusError(user.ReadNotifications(ctx, convert.NotificationTypesFromProto(req.Types)...))
+}
+
+func (notificationsServer) Clear(ctx context.Context, req *proto.NotificationsRequest) (*empty.Empty, error) {
+	user, err := currentUser(ctx, db.ScopeNotifications)
+	if err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, statusError(user.ClearNotifications(ctx, convert.NotificationTypesFromProto(req.Types)...))
+}
+
+func (notificationsServer) Mentions(ctx context.Context, req *proto.MentionsOptions) (*proto.MentionList, error) {
+	user, err := currentUser(ctx, db.ScopeNotifications)
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := convert.MentionsOptionsFromProto(ctx, req)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	mentions, err := user.Mentions(ctx, options)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return mentionList(ctx, *mentions, options)
+}
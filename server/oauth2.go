@@ -0,0 +1,139 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package server
+
+import (
+	"errors"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/nerdzeu/nerdz-core/convert"
+	"github.com/nerdzeu/nerdz-core/db"
+	"github.com/nerdzeu/nerdz-core/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// oauth2Server implements proto.OAuth2Server
+type oauth2Server struct {
+	storage *db.OAuth2Storage
+}
+
+func (srv oauth2Server) RevokeToken(ctx context.Context, req *proto.RevokeTokenRequest) (*empty.Empty, error) {
+	client, err := currentClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, statusError(srv.storage.WithContext(ctx).RevokeToken(client.ID, req.Token))
+}
+
+func (srv oauth2Server) RevokeClientTokens(ctx context.Context, req *empty.Empty) (*empty.Empty, error) {
+	client, err := currentClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, statusError(srv.storage.WithContext(ctx).RevokeClientTokens(client.ID))
+}
+
+func (srv oauth2Server) RevokeUserTokens(ctx context.Context, req *empty.Empty) (*empty.Empty, error) {
+	user, err := currentUser(ctx, db.ScopeProfileWrite)
+	if err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, statusError(srv.storage.WithContext(ctx).RevokeUserTokens(user.ID()))
+}
+
+// ownedClient returns the client with the specified id, owned by the authenticated user
+func ownedClient(ctx context.Context, id uint64) (*db.User, *db.OAuth2Client, error) {
+	user, err := currentUser(ctx, db.ScopeClients)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	client, err := db.NewOAuth2Client(ctx, id)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, nil, grpc.Errorf(codes.NotFound, "client %d does not exist", id)
+	}
+	if err != nil {
+		return nil, nil, statusError(err)
+	}
+
+	if !user.CanManage(client) {
+		return nil, nil, grpc.Errorf(codes.PermissionDenied, "you can't manage this client")
+	}
+	return user, client, nil
+}
+
+func (oauth2Server) Clients(ctx context.Context, req *empty.Empty) (*proto.ApplicationList, error) {
+	user, err := currentUser(ctx, db.ScopeClients)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.ApplicationList{Applications: convert.OAuth2ClientsToProto(user.OAuth2Clients(ctx))}, nil
+}
+
+func (oauth2Server) CreateClient(ctx context.Context, req *proto.CreateClientRequest) (*proto.ClientSecret, error) {
+	user, err := currentUser(ctx, db.ScopeClients)
+	if err != nil {
+		return nil, err
+	}
+
+	client, secret, err := user.CreateOAuth2Client(ctx, req.Name, req.RedirectUri, req.Public)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &proto.ClientSecret{Application: convert.OAuth2ClientToProto(client), Secret: secret}, nil
+}
+
+func (oauth2Server) UpdateClient(ctx context.Context, req *proto.UpdateClientRequest) (*proto.Application, error) {
+	user, client, err := ownedClient(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = user.UpdateOAuth2ClientRedirectURI(ctx, client, req.RedirectUri); err != nil {
+		return nil, statusError(err)
+	}
+	return convert.OAuth2ClientToProto(client), nil
+}
+
+func (oauth2Server) RotateClientSecret(ctx context.Context, req *proto.ClientRequest) (*proto.ClientSecret, error) {
+	user, client, err := ownedClient(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if client.Public {
+		return nil, grpc.Errorf(codes.FailedPrecondition, "public clients have no secret")
+	}
+
+	secret, err := user.RotateOAuth2ClientSecret(ctx, client)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &proto.ClientSecret{Application: convert.OAuth2ClientToProto(client), Secret: secret}, nil
+}
+
+func (oauth2Server) DeleteClient(ctx context.Context, req *proto.ClientRequest) (*empty.Empty, error) {
+	user, client, err := ownedClient(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, statusError(user.DeleteOAuth2Client(ctx, client))
+}
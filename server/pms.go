@@ -0,0 +1,207 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package server
+
+import (
+	"io"
+	"sync"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/nerdzeu/nerdz-core/convert"
+	"github.com/nerdzeu/nerdz-core/db"
+	"github.com/nerdzeu/nerdz-core/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// pmsServer implements proto.PmsServer
+type pmsServer struct{}
+
+func (pmsServer) Conversations(ctx context.Context, req *empty.Empty) (*proto.ConversationList, error) {
+	user, err := currentUser(ctx, db.ScopePmsRead)
+	if err != nil {
+		return nil, err
+	}
+
+	conversations, err := user.Conversations(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	ret := new(proto.ConversationList)
+	for _, conversation := range *conversations {
+		ret.Conversations = append(ret.Conversations, convert.ConversationToProto(&conversation))
+	}
+	return ret, nil
+}
+
+func (pmsServer) Pms(ctx context.Context, req *proto.PmsRequest) (*proto.ContentList, error) {
+	user, err := currentUser(ctx, db.ScopePmsRead)
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := convert.PmsOptionsFromProto(ctx, req.Options)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	pms, err := user.Pms(ctx, req.Other, options)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	ret := new(proto.ContentList)
+	for _, pm := range *pms {
+		ret.Contents = append(ret.Contents, &proto.Content{Content: &proto.Content_Pm{Pm: convert.PMToProto(&pm)}})
+	}
+
+	if len(*pms) > 0 {
+		newest, oldest := &(*pms)[0], &(*pms)[len(*pms)-1]
+		if ret.Next, ret.Prev, err = pageCursors(ctx, newest, oldest, len(*pms), int(db.AtMostPms(uint64(options.N))), options.Older, options.Newer); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+func (pmsServer) DeleteConversation(ctx context.Context, req *proto.ConversationRequest) (*empty.Empty, error) {
+	user, err := currentUser(ctx, db.ScopePmsWrite)
+	if err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, statusError(user.DeleteConversation(ctx, req.Other))
+}
+
+func (pmsServer) Chat(stream proto.Pms_ChatServer) error {
+	ctx := stream.Context()
+	user, err := currentUser(ctx, db.ScopePmsRead)
+	if err != nil {
+		return err
+	}
+	if _, err = currentUser(ctx, db.ScopePmsWrite); err != nil {
+		return err
+	}
+
+	events, err := user.Events(ctx)
+	if err != nil {
+		return statusError(err)
+	}
+
+	c := &chat{stream: stream, user: user}
+	done := make(chan error, 1)
+	go func() {
+		done <- c.serve(ctx)
+	}()
+
+	for {
+		select {
+		case err = <-done:
+			return err
+		case event, ok := <-events:
+			if !ok {
+				if ctx.Err() != nil {
+					return grpc.Errorf(codes.Canceled, "%s", ctx.Err())
+				}
+				return grpc.Errorf(codes.Aborted, "the events are not received fast enough: chat again")
+			}
+			reply := c.event(ctx, &event)
+			if reply == nil {
+				continue
+			}
+			if err = c.send(reply); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// chat is a Pms.Chat stream of user. The events are sent by the handler and by
+// the goroutine that serves the requests, so the sends are serialized
+type chat struct {
+	stream proto.Pms_ChatServer
+	user   *db.User
+
+	mu sync.Mutex
+}
+
+// send sends event on the stream
+func (c *chat) send(event *proto.ChatEvent) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stream.Send(event)
+}
+
+// serve serves the requests received on the stream, until the client closes it.
+// The failed requests are answered with an error, and don't close the stream
+func (c *chat) serve(ctx context.Context) error {
+	for {
+		req, err := c.stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		reply := &proto.ChatEvent{Request: req.Id}
+		switch action := req.Action.(type) {
+		case *proto.ChatRequest_Send:
+			pm := convert.PMFromProto(action.Send)
+			if pm == nil {
+				err = grpc.Errorf(codes.InvalidArgument, "missing pm")
+				break
+			}
+			if err = statusError(c.user.Submit(ctx, pm)); err == nil {
+				reply.Event = &proto.ChatEvent_Pm{Pm: convert.PMToProto(pm)}
+			}
+		case *proto.ChatRequest_Typing:
+			err = statusError(c.user.Typing(ctx, action.Typing))
+		case *proto.ChatRequest_Read:
+			err = statusError(c.user.ReadPms(ctx, action.Read))
+		default:
+			err = grpc.Errorf(codes.InvalidArgument, "missing action")
+		}
+
+		if err != nil {
+			reply.Event = &proto.ChatEvent_Error{Error: &proto.ChatError{Code: int32(grpc.Code(err)), Message: grpc.ErrorDesc(err)}}
+		}
+		if err = c.send(reply); err != nil {
+			return err
+		}
+	}
+}
+
+// event converts event into the *proto.ChatEvent to send, or returns nil if it's not about the pms
+func (c *chat) event(ctx context.Context, event *db.Event) *proto.ChatEvent {
+	switch event.Type {
+	case db.EventPm:
+		// the pm has been committed when the event is published: it must be read from the primary
+		pm, err := db.NewPm(db.ReadYourWrites(ctx), event.Pmid)
+		if err != nil {
+			return nil
+		}
+		return &proto.ChatEvent{Event: &proto.ChatEvent_Pm{Pm: convert.PMToProto(pm)}}
+	case db.EventTyping:
+		return &proto.ChatEvent{Event: &proto.ChatEvent_Typing{Typing: event.From}}
+	case db.EventRead:
+		return &proto.ChatEvent{Event: &proto.ChatEvent_Read{Read: event.From}}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package server_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nerdzeu/nerdz-core/db"
+	"github.com/nerdzeu/nerdz-core/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+)
+
+// chat opens a Pms.Chat stream of client, authenticated by token, that is closed after a few seconds
+func chat(t *testing.T, client proto.PmsClient, token string) (proto.Pms_ChatClient, context.CancelFunc) {
+	streamCtx, cancel := context.WithTimeout(bearer("Bearer "+token), 5*time.Second)
+	stream, err := client.Chat(streamCtx)
+	if err != nil {
+		cancel()
+		t.Fatalf("No error should happen when opening a chat, but got: %s", err)
+	}
+	return stream, cancel
+}
+
+// expectEvent receives the events of stream until one matches, failing the test if the stream fails
+func expectEvent(t *testing.T, stream proto.Pms_ChatClient, what string, match func(*proto.ChatEvent) bool) *proto.ChatEvent {
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("The chat should receive %s, but got: %s", what, err)
+		}
+		if match(event) {
+			return event
+		}
+	}
+}
+
+func TestChat(t *testing.T) {
+	webConn, mobileConn := dial(t, webCert), dial(t, mobileCert)
+	defer webConn.Close()
+	defer mobileConn.Close()
+
+	scope := db.ScopePmsRead + " " + db.ScopePmsWrite
+	mine, cancelMine := chat(t, proto.NewPmsClient(webConn), newToken(t, web, scope, time.Now().UTC()))
+	defer cancelMine()
+	others, cancelOthers := chat(t, proto.NewPmsClient(mobileConn), newUserToken(t, mobile, other, scope, time.Now().UTC()))
+	defer cancelOthers()
+
+	// a request is answered once the stream receives the events: wait for the answers before sending
+	if err := others.Send(&proto.ChatRequest{Id: 1, Action: &proto.ChatRequest_Read{Read: me.ID()}}); err != nil {
+		t.Fatalf("No error should happen when sending a request, but got: %s", err)
+	}
+	expectEvent(t, others, "the answer to the read", func(event *proto.ChatEvent) bool { return event.Request == 1 })
+
+	if err := mine.Send(&proto.ChatRequest{Id: 1, Action: &proto.ChatRequest_Typing{Typing: other.ID()}}); err != nil {
+		t.Fatalf("No error should happen when sending a request, but got: %s", err)
+	}
+	expectEvent(t, mine, "the answer to the typing", func(event *proto.ChatEvent) bool { return event.Request == 1 })
+	expectEvent(t, others, "the typing indicator", func(event *proto.ChatEvent) bool { return event.GetTyping() == me.ID() })
+
+	pm := &proto.PM{To: other.ID(), Message: "Hi", Lang: proto.Language_ENGLISH}
+	if err := mine.Send(&proto.ChatRequest{Id: 2, Action: &proto.ChatRequest_Send{Send: pm}}); err != nil {
+		t.Fatalf("No error should happen when sending a request, but got: %s", err)
+	}
+	sent := expectEvent(t, mine, "the answer to the send", func(event *proto.ChatEvent) bool { return event.Request == 2 })
+	if sent.GetPm() == nil || sent.GetPm().Pmid == 0 {
+		t.Fatalf("A send should be answered with the pm sent, but got: %+v", sent)
+	}
+	received := expectEvent(t, others, "the pm", func(event *proto.ChatEvent) bool { return event.GetPm() != nil })
+	if received.GetPm().Pmid != sent.GetPm().Pmid || received.GetPm().From != me.ID() || received.GetPm().Message != "Hi" {
+		t.Errorf("The recipient should receive the pm sent, but got: %+v", received.GetPm())
+	}
+
+	if err := mine.Send(&proto.ChatRequest{Id: 3, Action: &proto.ChatRequest_Send{Send: &proto.PM{To: 42, Message: "Nobody", Lang: proto.Language_ENGLISH}}}); err != nil {
+		t.Fatalf("No error should happen when sending a request, but got: %s", err)
+	}
+	failed := expectEvent(t, mine, "the answer to the failed send", func(event *proto.ChatEvent) bool { return event.Request == 3 })
+	if failed.GetError() == nil {
+		t.Errorf("A failed send should be answered with an error and keep the chat open, but got: %+v", failed)
+	}
+}
+
+func TestChatScopes(t *testing.T) {
+	conn := dial(t, webCert)
+	defer conn.Close()
+
+	stream, cancel := chat(t, proto.NewPmsClient(conn), newToken(t, web, db.ScopePmsRead, time.Now().UTC()))
+	defer cancel()
+
+	_, err := stream.Recv()
+	expectCode(t, err, codes.PermissionDenied, "Chatting without the pms:write scope")
+}
@@ -0,0 +1,99 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package server
+
+import (
+	"github.com/nerdzeu/nerdz-core/convert"
+	"github.com/nerdzeu/nerdz-core/db"
+	"github.com/nerdzeu/nerdz-core/proto"
+	"golang.org/x/net/context"
+)
+
+// projectsServer implements proto.ProjectsServer
+type projectsServer struct{}
+
+func (projectsServer) Get(ctx context.Context, req *proto.ProjectRequest) (*proto.Project, error) {
+	project, err := getProject(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return convert.ProjectToProto(project), nil
+}
+
+func (projectsServer) Info(ctx context.Context, req *proto.ProjectRequest) (*proto.Info, error) {
+	project, err := getVisibleProject(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return convert.InfoToProto(project.Info(ctx)), nil
+}
+
+func (projectsServer) ProjectInfo(ctx context.Context, req *proto.ProjectRequest) (*proto.ProjectInfo, error) {
+	project, err := getVisibleProject(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	info, err := project.ProjectInfo(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	ret := convert.ProjectInfoToProto(info)
+	ret.Owner, ret.Members, ret.Followers = userToProto(ctx, info.Owner), usersToProto(ctx, info.Members), usersToProto(ctx, info.Followers)
+	return ret, nil
+}
+
+func (projectsServer) Members(ctx context.Context, req *proto.ProjectRequest) (*proto.UserList, error) {
+	project, err := getVisibleProject(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	users, err := project.Members(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &proto.UserList{Users: usersToProto(ctx, users)}, nil
+}
+
+func (projectsServer) Followers(ctx context.Context, req *proto.ProjectRequest) (*proto.UserList, error) {
+	project, err := getVisibleProject(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	users, err := project.Followers(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &proto.UserList{Users: usersToProto(ctx, users)}, nil
+}
+
+func (projectsServer) Postlist(ctx context.Context, req *proto.PostlistRequest) (*proto.ContentList, error) {
+	project, err := getProject(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	options, err := convert.PostlistOptionsFromProto(ctx, req.Options, db.ProjectPost{})
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	posts, err := project.ReadablePostlist(ctx, viewer(ctx), options)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return postList(ctx, posts, options)
+}
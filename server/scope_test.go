@@ -0,0 +1,64 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package server_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/nerdzeu/nerdz-core/db"
+	"github.com/nerdzeu/nerdz-core/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+)
+
+func TestScopes(t *testing.T) {
+	conn := dial(t, webCert)
+	defer conn.Close()
+	users, pms := proto.NewUsersClient(conn), proto.NewPmsClient(conn)
+
+	readOnly := bearer("Bearer " + newToken(t, web, db.ScopePostsRead, time.Now().UTC()))
+	follow := bearer("Bearer " + newToken(t, web, db.ScopeFollow+" "+db.ScopePmsRead, time.Now().UTC()))
+	board := &proto.BoardRequest{Type: proto.BoardType_USER, Id: other.ID()}
+
+	_, err := users.Follow(context.Background(), board)
+	expectCode(t, err, codes.Unauthenticated, "Following without a bearer token")
+
+	_, err = users.Follow(readOnly, board)
+	expectCode(t, err, codes.PermissionDenied, "Following without the follow scope")
+
+	if _, err = users.Follow(follow, board); err != nil {
+		t.Errorf("Following with the follow scope should work, but got: %s", err)
+	}
+
+	_, err = pms.Conversations(readOnly, &empty.Empty{})
+	expectCode(t, err, codes.PermissionDenied, "Reading the conversations without the pms:read scope")
+
+	if _, err = pms.Conversations(follow, &empty.Empty{}); err != nil {
+		t.Errorf("Reading the conversations with the pms:read scope should work, but got: %s", err)
+	}
+
+	user, err := users.Get(readOnly, &proto.UserRequest{Id: me.ID()})
+	if err != nil {
+		t.Fatalf("Reading a user should work, but got: %s", err)
+	}
+	if user.Email != "" {
+		t.Errorf("The private fields of the user should not be returned without the profile:read scope, but got: %s", user.Email)
+	}
+}
@@ -0,0 +1,208 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package server implements the gRPC services described in the proto package.
+//
+// The server is configured using viper: every key is namespaced with 'server.'
+// and can be overridden by an environment variable prefixed with NERDZ_
+// (e.g. NERDZ_SERVER_ADDRESS for server.address).
+// Every request is served using the db.Store the server has been created with.
+//
+// Every client must authenticate itself using a TLS certificate signed by the configured
+// client CA. The certificate is mapped to an OAuth2 client by its SHA-256 fingerprint, stored
+// with the client (see db.OAuth2ClientCertificate) and looked up on every request: the revoked
+// certificates are rejected as soon as they are revoked, as the certificates of no OAuth2 client.
+//
+// The actions are performed by the user authenticated by the OAuth2 access token sent
+// as bearer token in the 'authorization' metadata of the request. The token must have been
+// issued to the client that performs the request.
+// Every action requires the token to have been granted the scope of the action
+// (see the Scope* constants of the db package); reading public data requires no token.
+//
+// The expired OAuth2 authorization codes and access tokens are periodically deleted, every
+// 'server.sweeper.authorize_interval' and 'server.sweeper.access_interval', in batches of
+// 'server.sweeper.authorize_batch_size' codes and 'server.sweeper.access_batch_size' token families.
+// The token families whose refresh token is older than 'server.sweeper.refresh_ttl' are deleted as well.
+// The number of rows deleted is published by expvar, under oauth2_sweeper, and served
+// on 'server.metrics_address' at /debug/vars, if configured.
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"expvar"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/nerdzeu/nerdz-core/db"
+	"github.com/nerdzeu/nerdz-core/proto"
+	"github.com/spf13/viper"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// Server is the TLS secured gRPC server of Nerdz Core
+type Server struct {
+	server          *grpc.Server
+	address         string
+	shutdownTimeout time.Duration
+	sweeper         *sweeper
+	metrics         *http.Server
+	// cancelStreams cancels the context of the open streams, that would never complete otherwise
+	cancelStreams context.CancelFunc
+}
+
+// New creates a new Server that serves the data of store, using the configuration loaded in viper.
+// The TLS certificate and its private key are required
+func New(store *db.Store) (*Server, error) {
+	setDefaults()
+	bindEnv()
+
+	config, err := tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	sweeper, err := newSweeper(store)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := tagsServer{trendingWindow: viper.GetDuration(trendingWindowKey), maxTrendingWindow: viper.GetDuration(maxTrendingWindowKey)}
+	if tags.trendingWindow <= 0 || tags.trendingWindow > tags.maxTrendingWindow {
+		return nil, errors.New("the trending window must be positive and not wider than the max trending window")
+	}
+
+	auth := chain(readYourWrites, authenticateClient, tokenAuthenticate)
+	streams, cancelStreams := context.WithCancel(context.Background())
+
+	srv := &Server{
+		server: grpc.NewServer(
+			grpc.Creds(credentials.NewTLS(config)),
+			grpc.UnaryInterceptor(unaryInterceptor(store, auth)),
+			grpc.StreamInterceptor(streamInterceptor(store, auth, streams))),
+		address:         viper.GetString(addressKey),
+		shutdownTimeout: viper.GetDuration(shutdownTimeoutKey),
+		sweeper:         sweeper,
+		cancelStreams:   cancelStreams}
+
+	if address := viper.GetString(metricsAddressKey); address != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/debug/vars", expvar.Handler())
+		srv.metrics = &http.Server{Addr: address, Handler: mux}
+	}
+
+	proto.RegisterUsersServer(srv.server, usersServer{})
+	proto.RegisterProjectsServer(srv.server, projectsServer{})
+	proto.RegisterContentsServer(srv.server, contentsServer{})
+	proto.RegisterPmsServer(srv.server, pmsServer{})
+	proto.RegisterNotificationsServer(srv.server, notificationsServer{})
+	proto.RegisterTagsServer(srv.server, tags)
+	proto.RegisterEventsServer(srv.server, eventsServer{})
+	proto.RegisterOAuth2Server(srv.server, oauth2Server{storage: db.NewOAuth2Storage()})
+
+	return srv, nil
+}
+
+// tlsConfig returns the TLS configuration of the server, that requires and verifies
+// the certificates of the clients
+func tlsConfig() (*tls.Config, error) {
+	cert, key := viper.GetString(certKey), viper.GetString(keyKey)
+	if cert == "" || key == "" {
+		return nil, errors.New("empty TLS certificate or key")
+	}
+
+	certificate, err := tls.LoadX509KeyPair(cert, key)
+	if err != nil {
+		return nil, err
+	}
+
+	clientCA := viper.GetString(clientCAKey)
+	if clientCA == "" {
+		return nil, errors.New("empty client CA")
+	}
+
+	pem, err := ioutil.ReadFile(clientCA)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, errors.New("no valid certificate in the client CA " + clientCA)
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12}, nil
+}
+
+// Address returns the address the server listens on
+func (srv *Server) Address() string {
+	return srv.address
+}
+
+// Serve accepts incoming connections on the configured address, starts the sweeper of the
+// expired OAuth2 data and, if configured, serves the metrics.
+// Serve blocks until Shutdown is called, or until an error occurs
+func (srv *Server) Serve() error {
+	listener, err := net.Listen("tcp", srv.address)
+	if err != nil {
+		return err
+	}
+
+	if srv.metrics != nil {
+		go func() {
+			if err := srv.metrics.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Printf("unable to serve the metrics: %s", err)
+			}
+		}()
+	}
+
+	srv.sweeper.start()
+	return srv.server.Serve(listener)
+}
+
+// Shutdown stops the server from accepting new connections, cancels the open streams (the
+// events and the chats) and waits for the pending requests to complete. If they don't complete
+// in the configured shutdown timeout, the server is stopped and the pending requests are cancelled
+func (srv *Server) Shutdown() {
+	srv.sweeper.stop()
+	if srv.metrics != nil {
+		srv.metrics.Close()
+	}
+	srv.cancelStreams()
+
+	done := make(chan struct{})
+	go func() {
+		srv.server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(srv.shutdownTimeout):
+		srv.server.Stop()
+	}
+}
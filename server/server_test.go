@@ -0,0 +1,299 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package server_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RangelReale/osin"
+	"github.com/nerdzeu/nerdz-core/db"
+	"github.com/nerdzeu/nerdz-core/db/memory"
+	"github.com/nerdzeu/nerdz-core/server"
+	"github.com/spf13/viper"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+)
+
+var (
+	// ctx carries the in-memory store served by the server
+	ctx     context.Context
+	storage *db.OAuth2Storage
+
+	me, other *db.User
+	// web and mobile are the OAuth2 clients of the certificates with the same name
+	web, mobile *db.OAuth2Client
+
+	// the certificates of the clients, signed by the client CA. unknown is mapped to no client,
+	// revoked is mapped to web but revoked, missing was mapped to a client that has been deleted,
+	// rotated is mapped to mobile while the server is running
+	webCert, mobileCert, unknownCert, revokedCert, missingCert, rotatedCert tls.Certificate
+
+	address string
+	roots   *x509.CertPool
+)
+
+// TestMain serves an in-memory store on a free local address, for the whole test run
+func TestMain(m *testing.M) {
+	dir, err := ioutil.TempDir("", "nerdz-server")
+	if err != nil {
+		panic(err)
+	}
+
+	srv := newServer(dir)
+	go srv.Serve()
+
+	code := m.Run()
+	srv.Shutdown()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// newServer populates the store, writes the TLS material into dir and creates the server
+func newServer(dir string) *server.Server {
+	store := db.NewStore(memory.New())
+	ctx = store.Context(context.Background())
+	storage = store.OAuth2Storage()
+
+	me, other = newUser("me"), newUser("other")
+	web, mobile = newClient("web"), newClient("mobile")
+
+	ca, caKey := newCertificate("client CA", nil, nil)
+	webCert, _ = newCertificate("web", &ca, caKey)
+	mobileCert, _ = newCertificate("mobile", &ca, caKey)
+	unknownCert, _ = newCertificate("unknown", &ca, caKey)
+	revokedCert, _ = newCertificate("revoked", &ca, caKey)
+	missingCert, _ = newCertificate("missing", &ca, caKey)
+	rotatedCert, _ = newCertificate("rotated", &ca, caKey)
+
+	serverCA, serverCAKey := newCertificate("server CA", nil, nil)
+	serverCert, _ := newCertificate("localhost", &serverCA, serverCAKey)
+	roots = x509.NewCertPool()
+	roots.AddCert(serverCA.Leaf)
+
+	writePEM(filepath.Join(dir, "client-ca.pem"), "CERTIFICATE", ca.Certificate[0])
+	writePEM(filepath.Join(dir, "cert.pem"), "CERTIFICATE", serverCert.Certificate[0])
+	key, err := x509.MarshalECPrivateKey(serverCert.PrivateKey.(*ecdsa.PrivateKey))
+	if err != nil {
+		panic(err)
+	}
+	writePEM(filepath.Join(dir, "key.pem"), "EC PRIVATE KEY", key)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic(err)
+	}
+	address = listener.Addr().String()
+	listener.Close()
+
+	viper.Set("server.address", address)
+	viper.Set("server.cert", filepath.Join(dir, "cert.pem"))
+	viper.Set("server.key", filepath.Join(dir, "key.pem"))
+	viper.Set("server.client_ca", filepath.Join(dir, "client-ca.pem"))
+	// the fingerprint of web is formatted as printed by openssl
+	addCertificate(web, colons(fingerprint(webCert)))
+	addCertificate(mobile, fingerprint(mobileCert))
+	addCertificate(web, fingerprint(revokedCert))
+	if err := me.RevokeOAuth2ClientCertificate(ctx, web, fingerprint(revokedCert)); err != nil {
+		panic(err)
+	}
+	deleted := newClient("deleted")
+	addCertificate(deleted, fingerprint(missingCert))
+	if err := me.DeleteOAuth2Client(ctx, deleted); err != nil {
+		panic(err)
+	}
+
+	srv, err := server.New(store)
+	if err != nil {
+		panic(err)
+	}
+	return srv
+}
+
+// newUser creates the user username
+func newUser(username string) *db.User {
+	user := db.User{
+		Username:  username,
+		Password:  "password",
+		Email:     username + "@nerdz.eu",
+		Lang:      "en",
+		BoardLang: "en",
+		BirthDate: time.Date(1990, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+	if err := db.StoreFromContext(ctx).Storage().Create(ctx, &user); err != nil {
+		panic(err)
+	}
+
+	created, err := db.NewUser(ctx, user.Counter)
+	if err != nil {
+		panic(err)
+	}
+	return created
+}
+
+// newClient creates the confidential OAuth2 client name, owned by me
+func newClient(name string) *db.OAuth2Client {
+	client, err := storage.CreateClient(&osin.DefaultClient{
+		Secret:      "secret",
+		RedirectUri: "https://example.com/" + name,
+		UserData:    me.ID()}, name)
+	if err != nil {
+		panic(err)
+	}
+	return client
+}
+
+// addCertificate allows client to authenticate with the certificate whose fingerprint is fp
+func addCertificate(client *db.OAuth2Client, fp string) {
+	if _, err := me.AddOAuth2ClientCertificate(ctx, client, fp); err != nil {
+		panic(err)
+	}
+}
+
+// newCertificate creates a certificate for cn, signed by parent. If parent is nil, the certificate is a self-signed CA
+func newCertificate(cn string, parent *tls.Certificate, parentKey *ecdsa.PrivateKey) (tls.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	if err != nil {
+		panic(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")}}
+
+	issuer, signer := template, key
+	if parent == nil {
+		template.IsCA, template.BasicConstraintsValid = true, true
+		template.KeyUsage |= x509.KeyUsageCertSign
+	} else {
+		issuer, signer = parent.Leaf, parentKey
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, issuer, &key.PublicKey, signer)
+	if err != nil {
+		panic(err)
+	}
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		panic(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}, key
+}
+
+// writePEM writes into path the PEM block of type kind that contains der
+func writePEM(path, kind string, der []byte) {
+	if err := ioutil.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: kind, Bytes: der}), 0600); err != nil {
+		panic(err)
+	}
+}
+
+// fingerprint returns the hex encoded SHA-256 fingerprint of cert
+func fingerprint(cert tls.Certificate) string {
+	sum := sha256.Sum256(cert.Certificate[0])
+	return hex.EncodeToString(sum[:])
+}
+
+// colons returns the fingerprint fp upper cased, with its bytes separated by colons
+func colons(fp string) string {
+	var bytes []string
+	for i := 0; i < len(fp); i += 2 {
+		bytes = append(bytes, strings.ToUpper(fp[i:i+2]))
+	}
+	return strings.Join(bytes, ":")
+}
+
+// dial connects to the server presenting cert
+func dial(t *testing.T, cert tls.Certificate) *grpc.ClientConn {
+	dialCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, err := grpc.DialContext(dialCtx, address, grpc.WithBlock(), grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      roots,
+		ServerName:   "localhost"})))
+	if err != nil {
+		t.Fatalf("No error should happen when connecting to the server, but got: %s", err)
+	}
+	return conn
+}
+
+// tokens counts the access tokens issued, to make them unique
+var tokens int
+
+// newToken issues to client an access token of me, granted scope, created at createdAt
+func newToken(t *testing.T, client *db.OAuth2Client, scope string, createdAt time.Time) string {
+	return newUserToken(t, client, me, scope, createdAt)
+}
+
+// newUserToken issues to client an access token of user, granted scope, created at createdAt
+func newUserToken(t *testing.T, client *db.OAuth2Client, user *db.User, scope string, createdAt time.Time) string {
+	tokens++
+	token := fmt.Sprintf("token%d", tokens)
+	if err := storage.SaveAccess(&osin.AccessData{
+		Client:      client,
+		AccessToken: token,
+		ExpiresIn:   3600,
+		Scope:       scope,
+		CreatedAt:   createdAt,
+		UserData:    user.ID()}); err != nil {
+		t.Fatalf("No error should happen when issuing an access token, but got: %s", err)
+	}
+	return token
+}
+
+// bearer returns a context whose requests send authorization as authorization metadata
+func bearer(authorization string) context.Context {
+	return metadata.NewOutgoingContext(context.Background(), metadata.Pairs("authorization", authorization))
+}
+
+// expectCode fails the test if err hasn't the gRPC status code
+func expectCode(t *testing.T, err error, code codes.Code, action string) {
+	if grpc.Code(err) != code {
+		t.Errorf("%s should fail with %s, but got: %v", action, code, err)
+	}
+}
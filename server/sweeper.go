@@ -0,0 +1,141 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package server
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/nerdzeu/nerdz-core/db"
+	"github.com/spf13/viper"
+)
+
+// sweeperMetrics counts the rows removed by the sweeper, published by expvar as oauth2_sweeper
+var sweeperMetrics = expvar.NewMap("oauth2_sweeper")
+
+// sweep is a periodic deletion of expired rows, in batches limited by batchSize
+type sweep struct {
+	// name is the key of the metrics of the sweep
+	name      string
+	interval  time.Duration
+	batchSize int
+	remove    func(limit int) (int, error)
+}
+
+// sweeper periodically deletes the expired OAuth2 authorization codes and access tokens,
+// so that their relations don't grow without bound
+type sweeper struct {
+	sweeps []sweep
+	// ctx is cancelled by stop, aborting the running batches
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// newSweeper creates the sweeper of the data of store, configured in viper.
+// A sweep with a non positive interval is disabled
+func newSweeper(store *db.Store) (*sweeper, error) {
+	s := new(sweeper)
+	s.ctx, s.cancel = context.WithCancel(store.Context(context.Background()))
+	storage := db.NewOAuth2Storage().WithContext(s.ctx).WithRefreshTTL(viper.GetDuration(sweeperRefreshTTLKey))
+	for _, sw := range []sweep{
+		{
+			name:      "authorize",
+			interval:  viper.GetDuration(sweeperAuthorizeIntervalKey),
+			batchSize: viper.GetInt(sweeperAuthorizeBatchSizeKey),
+			remove:    storage.RemoveExpiredAuthorize},
+		{
+			name:      "access",
+			interval:  viper.GetDuration(sweeperAccessIntervalKey),
+			batchSize: viper.GetInt(sweeperAccessBatchSizeKey),
+			remove:    storage.RemoveExpiredAccess},
+	} {
+		if sw.interval <= 0 {
+			continue
+		}
+		if sw.batchSize <= 0 {
+			return nil, errors.New("the batch size of the " + sw.name + " sweeper must be positive")
+		}
+		s.sweeps = append(s.sweeps, sw)
+	}
+	return s, nil
+}
+
+// start runs every sweep in its own goroutine, until stop is called
+func (s *sweeper) start() {
+	for _, sw := range s.sweeps {
+		s.wg.Add(1)
+		go func(sw sweep) {
+			defer s.wg.Done()
+			ticker := time.NewTicker(sw.interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-s.ctx.Done():
+					return
+				case <-ticker.C:
+					s.run(sw)
+				}
+			}
+		}(sw)
+	}
+}
+
+// run deletes the expired rows of the sweep, one batch at a time, until there are none left
+// or the sweeper is stopped
+func (s *sweeper) run(sw sweep) {
+	// the db package panics on malformed queries: a failed sweep must not stop the server
+	defer func() {
+		if r := recover(); r != nil {
+			sweeperMetrics.Add(sw.name+"_errors", 1)
+			log.Printf("unable to sweep the expired OAuth2 %s data: %v", sw.name, r)
+		}
+	}()
+
+	sweeperMetrics.Add(sw.name+"_runs", 1)
+	for {
+		removed, err := sw.remove(sw.batchSize)
+		if err != nil && s.ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			sweeperMetrics.Add(sw.name+"_errors", 1)
+			log.Printf("unable to sweep the expired OAuth2 %s data: %s", sw.name, err)
+			return
+		}
+		sweeperMetrics.Add(sw.name+"_removed", int64(removed))
+
+		if removed == 0 {
+			return
+		}
+
+		if s.ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// stop stops the sweeper, cancelling the running batches and waiting for them to return
+func (s *sweeper) stop() {
+	s.cancel()
+	s.wg.Wait()
+}
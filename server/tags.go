@@ -0,0 +1,76 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package server
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/nerdzeu/nerdz-core/convert"
+	"github.com/nerdzeu/nerdz-core/db"
+	"github.com/nerdzeu/nerdz-core/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// tagsServer implements proto.TagsServer
+type tagsServer struct {
+	// trendingWindow is the default window of the trending tags, and maxTrendingWindow the widest
+	trendingWindow, maxTrendingWindow time.Duration
+}
+
+func (tagsServer) Posts(ctx context.Context, req *proto.TaggedRequest) (*proto.MessageList, error) {
+	options, err := convert.PostlistOptionsFromProto(ctx, req.Options, db.UserPost{}, db.ProjectPost{})
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	messages, err := db.Tagged(ctx, viewer(ctx), req.Tag, options)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return messageList(ctx, *messages, options)
+}
+
+func (s tagsServer) Trending(ctx context.Context, req *proto.TrendingRequest) (*proto.TrendList, error) {
+	window := s.trendingWindow
+	if req.Window != nil {
+		var err error
+		if window, err = ptypes.Duration(req.Window); err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, "invalid window: %s", err)
+		}
+		if window <= 0 {
+			return nil, grpc.Errorf(codes.InvalidArgument, "the window must be positive")
+		}
+	}
+	if window > s.maxTrendingWindow {
+		window = s.maxTrendingWindow
+	}
+
+	trends, err := db.Trending(ctx, viewer(ctx), window, db.AtMostTrending(uint64(req.N)))
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	ret := new(proto.TrendList)
+	for _, trend := range trends {
+		ret.Trends = append(ret.Trends, &proto.Trend{Tag: trend.Tag, Count: trend.Count})
+	}
+	return ret, nil
+}
@@ -0,0 +1,113 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package server
+
+import (
+	"strings"
+
+	"github.com/nerdzeu/nerdz-core/db"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+// authorizationKey is the metadata key of the bearer token
+const authorizationKey = "authorization"
+
+// UserFromContext returns the user authenticated by the bearer token of the request.
+// Returns nil if the request has no bearer token
+func UserFromContext(ctx context.Context) *db.User {
+	user, _ := ctx.Value(userKey).(*db.User)
+	return user
+}
+
+// hasScope returns true if the bearer token of the request has been granted scope
+func hasScope(ctx context.Context, scope string) bool {
+	access, _ := ctx.Value(accessKey).(*db.OAuth2AccessData)
+	return access != nil && access.HasScope(scope)
+}
+
+// viewer returns the user authenticated by the bearer token of the request, if the token has been granted
+// the posts:read scope, and nil otherwise: the public data are read anonymously
+func viewer(ctx context.Context) *db.User {
+	if !hasScope(ctx, db.ScopePostsRead) {
+		return nil
+	}
+	return UserFromContext(ctx)
+}
+
+// currentUser returns the user authenticated by the bearer token of the request.
+// Returns an Unauthenticated error if the request has no bearer token, and a
+// PermissionDenied error if the token has not been granted scope
+func currentUser(ctx context.Context, scope string) (*db.User, error) {
+	user := UserFromContext(ctx)
+	if user == nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "missing bearer token")
+	}
+
+	if !hasScope(ctx, scope) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "the access token has not been granted the %s scope", scope)
+	}
+	return user, nil
+}
+
+// bearerToken returns the bearer token contained in the metadata of the request, if any
+func bearerToken(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md[authorizationKey]) == 0 {
+		return "", nil
+	}
+
+	const prefix = "bearer "
+	value := md[authorizationKey][0]
+	if len(value) <= len(prefix) || strings.ToLower(value[:len(prefix)]) != prefix {
+		return "", grpc.Errorf(codes.Unauthenticated, "malformed authorization header")
+	}
+	return strings.TrimSpace(value[len(prefix):]), nil
+}
+
+// tokenAuthenticate is an authenticator that stores into the context the user
+// authenticated by the bearer token of the request.
+// The token must have been issued to the client that performs the request.
+// Requests without a bearer token are anonymous: their context is left untouched
+func tokenAuthenticate(ctx context.Context) (context.Context, error) {
+	token, err := bearerToken(ctx)
+	if err != nil || token == "" {
+		return ctx, err
+	}
+
+	access, err := db.NewOAuth2AccessDataWhere(ctx, &db.OAuth2AccessData{AccessToken: token})
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "invalid access token")
+	}
+
+	if access.IsExpired() {
+		return nil, grpc.Errorf(codes.Unauthenticated, "expired access token")
+	}
+
+	if client := ClientFromContext(ctx); client == nil || client.ID != access.ClientID {
+		return nil, grpc.Errorf(codes.Unauthenticated, "access token issued to another client")
+	}
+
+	user, err := db.NewUser(ctx, access.UserID)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "invalid access token")
+	}
+	return context.WithValue(context.WithValue(ctx, accessKey, access), userKey, user), nil
+}
@@ -0,0 +1,83 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package server_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nerdzeu/nerdz-core/db"
+	"github.com/nerdzeu/nerdz-core/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+)
+
+func TestBearerToken(t *testing.T) {
+	conn := dial(t, webCert)
+	defer conn.Close()
+	users := proto.NewUsersClient(conn)
+	token := newToken(t, web, db.ScopeProfileRead, time.Now().UTC())
+
+	user, err := users.Get(context.Background(), &proto.UserRequest{Id: me.ID()})
+	if err != nil {
+		t.Fatalf("Reading a user without a bearer token should work, but got: %s", err)
+	}
+	if user.Email != "" {
+		t.Errorf("The private fields of a user should not be returned without a bearer token, but got: %s", user.Email)
+	}
+
+	for _, authorization := range []string{"Token " + token, "Bearer", "Bearer ", token, "Bearer unknown"} {
+		_, err = users.Get(bearer(authorization), &proto.UserRequest{Id: me.ID()})
+		expectCode(t, err, codes.Unauthenticated, "Authenticating with "+authorization)
+	}
+
+	for _, authorization := range []string{"Bearer " + token, "bearer " + token, "BEARER  " + token} {
+		if user, err = users.Get(bearer(authorization), &proto.UserRequest{Id: me.ID()}); err != nil {
+			t.Fatalf("Authenticating with %s should work, but got: %s", authorization, err)
+		}
+		if user.Email != me.Email {
+			t.Errorf("The private fields of the user should be returned to the user, but got: %s", user.Email)
+		}
+	}
+}
+
+func TestExpiredToken(t *testing.T) {
+	conn := dial(t, webCert)
+	defer conn.Close()
+	users := proto.NewUsersClient(conn)
+
+	expired := newToken(t, web, db.ScopeProfileRead, time.Now().UTC().Add(-2*time.Hour))
+	_, err := users.Get(bearer("Bearer "+expired), &proto.UserRequest{Id: me.ID()})
+	expectCode(t, err, codes.Unauthenticated, "Authenticating with an expired token")
+}
+
+func TestTokenClient(t *testing.T) {
+	webToken := newToken(t, web, db.ScopeProfileRead, time.Now().UTC())
+	mobileToken := newToken(t, mobile, db.ScopeProfileRead, time.Now().UTC())
+
+	conn := dial(t, mobileCert)
+	defer conn.Close()
+	users := proto.NewUsersClient(conn)
+
+	_, err := users.Get(bearer("Bearer "+webToken), &proto.UserRequest{Id: me.ID()})
+	expectCode(t, err, codes.Unauthenticated, "Authenticating with a token issued to another client")
+
+	if _, err = users.Get(bearer("Bearer "+mobileToken), &proto.UserRequest{Id: me.ID()}); err != nil {
+		t.Errorf("Authenticating with a token issued to the client should work, but got: %s", err)
+	}
+}
@@ -0,0 +1,239 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package server
+
+import (
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/nerdzeu/nerdz-core/convert"
+	"github.com/nerdzeu/nerdz-core/db"
+	"github.com/nerdzeu/nerdz-core/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// usersServer implements proto.UsersServer
+type usersServer struct{}
+
+func (usersServer) Get(ctx context.Context, req *proto.UserRequest) (*proto.User, error) {
+	user, err := getUser(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return userToProto(ctx, user), nil
+}
+
+func (usersServer) Info(ctx context.Context, req *proto.UserRequest) (*proto.Info, error) {
+	user, err := getUser(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return convert.InfoToProto(user.Info(ctx)), nil
+}
+
+func (usersServer) PersonalInfo(ctx context.Context, req *proto.UserRequest) (*proto.PersonalInfo, error) {
+	user, err := getUser(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	ret := convert.PersonalInfoToProto(user.PersonalInfo(ctx))
+	if ret != nil && !isSelf(ctx, user, db.ScopeProfileRead) {
+		ret.Birthday = nil
+	}
+	return ret, nil
+}
+
+func (usersServer) ContactInfo(ctx context.Context, req *proto.UserRequest) (*proto.ContactInfo, error) {
+	user, err := getUser(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return convert.ContactInfoToProto(user.ContactInfo()), nil
+}
+
+func (usersServer) BoardInfo(ctx context.Context, req *proto.UserRequest) (*proto.BoardInfo, error) {
+	user, err := getUser(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	info, err := user.BoardInfo(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return convert.BoardInfoToProto(info), nil
+}
+
+func (usersServer) Followers(ctx context.Context, req *proto.UserRequest) (*proto.UserList, error) {
+	user, err := getUser(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	users, err := user.Followers(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &proto.UserList{Users: usersToProto(ctx, users)}, nil
+}
+
+func (usersServer) UserFollowing(ctx context.Context, req *proto.UserRequest) (*proto.UserList, error) {
+	user, err := getUser(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	users, err := user.UserFollowing(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &proto.UserList{Users: usersToProto(ctx, users)}, nil
+}
+
+func (usersServer) ProjectFollowing(ctx context.Context, req *proto.UserRequest) (*proto.ProjectList, error) {
+	user, err := getUser(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	projects, err := user.ProjectFollowing(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &proto.ProjectList{Projects: convert.ProjectsToProto(projects)}, nil
+}
+
+func (usersServer) Friends(ctx context.Context, req *proto.UserRequest) (*proto.UserList, error) {
+	user, err := getUser(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	users, err := user.Friends(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &proto.UserList{Users: usersToProto(ctx, users)}, nil
+}
+
+func (usersServer) Whitelist(ctx context.Context, req *proto.UserRequest) (*proto.UserList, error) {
+	user, err := getSelf(ctx, req.Id, db.ScopeProfileRead)
+	if err != nil {
+		return nil, err
+	}
+	users, err := user.Whitelist(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &proto.UserList{Users: usersToProto(ctx, users)}, nil
+}
+
+func (usersServer) Blacklist(ctx context.Context, req *proto.UserRequest) (*proto.UserList, error) {
+	user, err := getSelf(ctx, req.Id, db.ScopeProfileRead)
+	if err != nil {
+		return nil, err
+	}
+	users, err := user.Blacklist(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &proto.UserList{Users: usersToProto(ctx, users)}, nil
+}
+
+func (usersServer) Postlist(ctx context.Context, req *proto.PostlistRequest) (*proto.ContentList, error) {
+	user, err := getUser(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if me := viewer(ctx); me != nil && !me.CanSee(ctx, user) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "you are in the blacklist of user %d", user.ID())
+	}
+
+	options, err := convert.PostlistOptionsFromProto(ctx, req.Options, db.UserPost{})
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return postList(ctx, user.Postlist(ctx, options), options)
+}
+
+func (usersServer) Home(ctx context.Context, req *proto.HomeRequest) (*proto.MessageList, error) {
+	user, err := currentUser(ctx, db.ScopePostsRead)
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := convert.PostlistOptionsFromProto(ctx, req.Options, db.UserPost{}, db.ProjectPost{})
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return messageList(ctx, *user.Home(ctx, options), options)
+}
+
+func (usersServer) Follow(ctx context.Context, req *proto.BoardRequest) (*empty.Empty, error) {
+	user, err := currentUser(ctx, db.ScopeFollow)
+	if err != nil {
+		return nil, err
+	}
+
+	board, err := getBoard(ctx, req.Type, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, statusError(user.Follow(ctx, board))
+}
+
+func (usersServer) Unfollow(ctx context.Context, req *proto.BoardRequest) (*empty.Empty, error) {
+	user, err := currentUser(ctx, db.ScopeFollow)
+	if err != nil {
+		return nil, err
+	}
+
+	board, err := getBoard(ctx, req.Type, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, statusError(user.Unfollow(ctx, board))
+}
+
+func (usersServer) WhitelistUser(ctx context.Context, req *proto.UserActionRequest) (*empty.Empty, error) {
+	user, other, err := getUsers(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, statusError(user.WhitelistUser(ctx, other))
+}
+
+func (usersServer) UnwhitelistUser(ctx context.Context, req *proto.UserActionRequest) (*empty.Empty, error) {
+	user, other, err := getUsers(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, statusError(user.UnwhitelistUser(ctx, other))
+}
+
+func (usersServer) BlacklistUser(ctx context.Context, req *proto.UserActionRequest) (*empty.Empty, error) {
+	user, other, err := getUsers(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, statusError(user.BlacklistUser(ctx, other, req.Motivation))
+}
+
+func (usersServer) UnblacklistUser(ctx context.Context, req *proto.UserActionRequest) (*empty.Empty, error) {
+	user, other, err := getUsers(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, statusError(user.UnblacklistUser(ctx, other))
+}
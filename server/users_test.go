@@ -0,0 +1,95 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package server_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nerdzeu/nerdz-core/db"
+	"github.com/nerdzeu/nerdz-core/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+func TestPrivateInfo(t *testing.T) {
+	conn := dial(t, webCert)
+	defer conn.Close()
+	users := proto.NewUsersClient(conn)
+
+	self := bearer("Bearer " + newToken(t, web, db.ScopeProfileRead, time.Now().UTC()))
+	others := bearer("Bearer " + newUserToken(t, web, other, db.ScopeProfileRead, time.Now().UTC()))
+	request := &proto.UserRequest{Id: me.ID()}
+
+	for _, caller := range []context.Context{context.Background(), others} {
+		info, err := users.PersonalInfo(caller, request)
+		if err != nil {
+			t.Fatalf("Reading the personal info of a user should work, but got: %s", err)
+		}
+		if info.Birthday != nil {
+			t.Errorf("The birthday should be returned only to the user, but got: %v", info.Birthday)
+		}
+	}
+	if info, err := users.PersonalInfo(self, request); err != nil || info.Birthday == nil {
+		t.Errorf("The birthday should be returned to the user, but got %v (%v)", info, err)
+	}
+
+	_, err := users.Blacklist(context.Background(), request)
+	expectCode(t, err, codes.Unauthenticated, "Reading a blacklist without a bearer token")
+	_, err = users.Whitelist(others, request)
+	expectCode(t, err, codes.PermissionDenied, "Reading the whitelist of another user")
+	for _, list := range []func(context.Context, *proto.UserRequest, ...grpc.CallOption) (*proto.UserList, error){users.Whitelist, users.Blacklist} {
+		if _, err = list(self, request); err != nil {
+			t.Errorf("The user should read their own lists, but got: %s", err)
+		}
+	}
+}
+
+func TestInvisibleProject(t *testing.T) {
+	conn := dial(t, webCert)
+	defer conn.Close()
+	projects := proto.NewProjectsClient(conn)
+
+	project := db.Project{Name: "Invisible"}
+	if err := db.StoreFromContext(ctx).Storage().Create(ctx, &project); err != nil {
+		t.Fatalf("No error should happen when creating a project, but got: %s", err)
+	}
+	if err := db.StoreFromContext(ctx).Storage().Create(ctx, &db.ProjectOwner{From: other.ID(), To: project.Counter}); err != nil {
+		t.Fatalf("No error should happen when setting the owner of a project, but got: %s", err)
+	}
+
+	owner := bearer("Bearer " + newUserToken(t, web, other, db.ScopePostsRead, time.Now().UTC()))
+	stranger := bearer("Bearer " + newToken(t, web, db.ScopePostsRead, time.Now().UTC()))
+	request := &proto.ProjectRequest{Id: project.Counter}
+
+	for _, caller := range []context.Context{context.Background(), stranger} {
+		_, err := projects.Info(caller, request)
+		expectCode(t, err, codes.NotFound, "Reading the info of an invisible project")
+		_, err = projects.ProjectInfo(caller, request)
+		expectCode(t, err, codes.NotFound, "Reading the project info of an invisible project")
+		_, err = projects.Members(caller, request)
+		expectCode(t, err, codes.NotFound, "Reading the members of an invisible project")
+		_, err = projects.Followers(caller, request)
+		expectCode(t, err, codes.NotFound, "Reading the followers of an invisible project")
+	}
+
+	if _, err := projects.ProjectInfo(owner, request); err != nil {
+		t.Errorf("The owner should read the info of an invisible project, but got: %s", err)
+	}
+}
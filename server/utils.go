@@ -0,0 +1,291 @@
+/*
+Copyright (C) 2016 Paolo Galeone <nessuno@nerdz.eu>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package server
+
+import (
+	"errors"
+
+	"github.com/nerdzeu/nerdz-core/convert"
+	"github.com/nerdzeu/nerdz-core/db"
+	"github.com/nerdzeu/nerdz-core/db/igor"
+	"github.com/nerdzeu/nerdz-core/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// getUser returns the user with the specified id, or a NotFound error
+func getUser(ctx context.Context, id uint64) (*db.User, error) {
+	user, err := db.NewUser(ctx, id)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, grpc.Errorf(codes.NotFound, "user %d does not exist", id)
+	}
+	return user, statusError(err)
+}
+
+// userToProto converts user into a *proto.User that contains only its public fields.
+// The private fields are returned only to user: the email, the birth date and the last access
+// if the token has been granted the profile:read scope, the notification story if it has been
+// granted the notifications scope
+func userToProto(ctx context.Context, user *db.User) *proto.User {
+	ret := convert.UserToProto(user)
+	if ret == nil {
+		return nil
+	}
+
+	if !isSelf(ctx, user, db.ScopeProfileRead) {
+		ret.Email, ret.BirthDate, ret.Last = "", nil, nil
+	}
+	if !isSelf(ctx, user, db.ScopeNotifications) {
+		ret.NotifyStory = ""
+	}
+	return ret
+}
+
+// isSelf returns true if user is the one authenticated by the bearer token of the request,
+// and the token has been granted scope
+func isSelf(ctx context.Context, user *db.User, scope string) bool {
+	me := UserFromContext(ctx)
+	return me != nil && me.Counter == user.Counter && hasScope(ctx, scope)
+}
+
+// getSelf returns the user with the specified id, if it's the one authenticated by the bearer token
+// of the request and the token has been granted scope. Returns a PermissionDenied error otherwise
+func getSelf(ctx context.Context, id uint64, scope string) (*db.User, error) {
+	user, err := currentUser(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+	if user.ID() != id {
+		return nil, grpc.Errorf(codes.PermissionDenied, "only user %d can read it", id)
+	}
+	return user, nil
+}
+
+// usersToProto converts users into a slice of *proto.User, using userToProto
+func usersToProto(ctx context.Context, users []*db.User) []*proto.User {
+	var ret []*proto.User
+	for _, user := range users {
+		ret = append(ret, userToProto(ctx, user))
+	}
+	return ret
+}
+
+// getUsers returns the user that requested the action and the other user involved
+func getUsers(ctx context.Context, req *proto.UserActionRequest) (user, other *db.User, err error) {
+	if user, err = currentUser(ctx, db.ScopeProfileWrite); err != nil {
+		return
+	}
+	other, err = getUser(ctx, req.Other)
+	return
+}
+
+// getProject returns the project with the specified id, or a NotFound error
+func getProject(ctx context.Context, id uint64) (*db.Project, error) {
+	project, err := db.NewProject(ctx, id)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, grpc.Errorf(codes.NotFound, "project %d does not exist", id)
+	}
+	return project, statusError(err)
+}
+
+// getVisibleProject returns the project with the specified id, or a NotFound error if it doesn't exist
+// or if it's not visible and the viewer is neither its owner nor a member
+func getVisibleProject(ctx context.Context, id uint64) (*db.Project, error) {
+	project, err := getProject(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if me := viewer(ctx); !project.Visible && (me == nil || !me.CanSee(ctx, project)) {
+		return nil, grpc.Errorf(codes.NotFound, "project %d does not exist", id)
+	}
+	return project, nil
+}
+
+// getBoard returns the board of type boardType with the specified id
+func getBoard(ctx context.Context, boardType proto.BoardType, id uint64) (db.Board, error) {
+	if boardType == proto.BoardType_PROJECT {
+		return getProject(ctx, id)
+	}
+	return getUser(ctx, id)
+}
+
+// getContent returns the content referenced by id, or a NotFound error
+func getContent(ctx context.Context, id *proto.ContentID) (content db.Content, err error) {
+	if id == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "missing content")
+	}
+
+	switch id.Type {
+	case proto.ContentType_USER_POST:
+		content, err = db.NewUserPost(ctx, id.Id)
+	case proto.ContentType_PROJECT_POST:
+		content, err = db.NewProjectPost(ctx, id.Id)
+	case proto.ContentType_USER_POST_COMMENT:
+		content, err = db.NewUserPostComment(ctx, id.Id)
+	case proto.ContentType_PROJECT_POST_COMMENT:
+		content, err = db.NewProjectPostComment(ctx, id.Id)
+	case proto.ContentType_PRIVATE_MESSAGE:
+		content, err = db.NewPm(ctx, id.Id)
+	default:
+		return nil, grpc.Errorf(codes.InvalidArgument, "invalid content type %s", id.Type)
+	}
+
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, grpc.Errorf(codes.NotFound, "%s %d does not exist", id.Type, id.Id)
+	}
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return content, nil
+}
+
+// getPost returns the post referenced by id, or an error if id doesn't reference an existing post
+func getPost(ctx context.Context, id *proto.ContentID) (db.ExistingPost, error) {
+	content, err := getContent(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	post, ok := content.(db.ExistingPost)
+	if !ok {
+		return nil, grpc.Errorf(codes.InvalidArgument, "%s is not a post", id.Type)
+	}
+	return post, nil
+}
+
+// canRead returns an error if the user that sent the request can't read content.
+// Private messages are read with the pms:read scope, every other content with the posts:read scope
+func canRead(ctx context.Context, content db.Content) error {
+	user := viewer(ctx)
+	if _, ok := content.(*db.PM); ok {
+		user, _ = currentUser(ctx, db.ScopePmsRead)
+	}
+	return statusError(db.CanRead(ctx, user, content))
+}
+
+// writeScope returns the scope required to write content
+func writeScope(content db.Content) string {
+	if _, ok := content.(*db.PM); ok {
+		return db.ScopePmsWrite
+	}
+	return db.ScopePostsWrite
+}
+
+// statusError converts err, returned by the db package, into a gRPC error
+// whose code depends on the kind of err. Errors without a kind are Internal errors
+func statusError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	code := codes.Internal
+	switch {
+	case errors.Is(err, db.ErrNotFound):
+		code = codes.NotFound
+	case errors.Is(err, db.ErrPermissionDenied):
+		code = codes.PermissionDenied
+	case errors.Is(err, db.ErrInvalidArgument):
+		code = codes.InvalidArgument
+	case errors.Is(err, db.ErrConflict):
+		code = codes.AlreadyExists
+	case errors.Is(err, db.ErrAuthFailed):
+		code = codes.Unauthenticated
+	case errors.Is(err, db.ErrUnavailable):
+		code = codes.Unavailable
+	}
+	return grpc.Errorf(code, "%s", err.Error())
+}
+
+// postList converts a page of posts, selected by options, into a *proto.ContentList
+func postList(ctx context.Context, posts *[]db.ExistingPost, options db.PostlistOptions) (*proto.ContentList, error) {
+	ret := new(proto.ContentList)
+	for _, post := range *posts {
+		content, err := convert.ContentToProto(post)
+		if err != nil {
+			return nil, statusError(err)
+		}
+		ret.Contents = append(ret.Contents, content)
+	}
+
+	if len(*posts) > 0 {
+		newest, oldest := (*posts)[0], (*posts)[len(*posts)-1]
+		var err error
+		if ret.Next, ret.Prev, err = pageCursors(ctx, newest, oldest, len(*posts), int(db.AtMostPosts(uint64(options.N))), options.Older, options.Newer); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+// messageList returns the page of messages of the home, sorted from the newest to the oldest, selected by options
+func messageList(ctx context.Context, messages []db.Message, options db.PostlistOptions) (*proto.MessageList, error) {
+	ret := new(proto.MessageList)
+	for _, message := range messages {
+		ret.Messages = append(ret.Messages, convert.MessageToProto(&message))
+	}
+
+	if len(messages) > 0 {
+		newest, oldest := &messages[0], &messages[len(messages)-1]
+		var err error
+		if ret.Next, ret.Prev, err = pageCursors(ctx, newest, oldest, len(messages), int(db.AtMostPosts(uint64(options.N))), options.Older, options.Newer); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+// mentionList returns the page of mentions, sorted from the newest to the oldest, selected by options
+func mentionList(ctx context.Context, mentions []db.Mention, options db.MentionsOptions) (*proto.MentionList, error) {
+	ret := new(proto.MentionList)
+	for _, mention := range mentions {
+		ret.Mentions = append(ret.Mentions, convert.MentionToProto(&mention))
+	}
+
+	if len(mentions) > 0 {
+		newest, oldest := &mentions[0], &mentions[len(mentions)-1]
+		var err error
+		if ret.Next, ret.Prev, err = pageCursors(ctx, newest, oldest, len(mentions), int(db.AtMostMentions(uint64(options.N))), options.Older, options.Newer); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+// pageCursors returns the next and prev tokens of a page of length elements, at most n, selected by older and newer.
+// newest and oldest are the first and the last elements of the page.
+// next is omitted if the page is the last one, prev if there are no newer elements
+func pageCursors(ctx context.Context, newest, oldest igor.DBModel, length, n int, older, newer *db.Cursor) (next, prev string, err error) {
+	return pageTokens(ctx, db.NewCursor(newest), db.NewCursor(oldest), length, n, older, newer)
+}
+
+// pageTokens is pageCursors, given the cursors of the newest and of the oldest element of the page
+func pageTokens(ctx context.Context, newest, oldest *db.Cursor, length, n int, older, newer *db.Cursor) (next, prev string, err error) {
+	newerOnly := older == nil && newer != nil
+	if length == n || newerOnly {
+		if next, err = oldest.Token(ctx); err != nil {
+			return "", "", statusError(err)
+		}
+	}
+	if older != nil || (newerOnly && length == n) {
+		if prev, err = newest.Token(ctx); err != nil {
+			return "", "", statusError(err)
+		}
+	}
+	return
+}